@@ -1,9 +1,11 @@
 package gotezos
 
 import (
+	"crypto/rand"
 	"crypto/sha512"
 	"encoding/json"
 	"fmt"
+	"sync"
 
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/blake2b"
@@ -12,6 +14,9 @@ import (
 	"golang.org/x/crypto/pbkdf2"
 )
 
+// balancesParallelism is the maximum number of balance requests BalancesAt makes at once.
+const balancesParallelism = 10
+
 /*
 Wallet Respresentation
 Description: A Tezos wallet.
@@ -34,16 +39,17 @@ type keyPair struct {
 Balance RPC
 Path: ../<block_id>/context/contracts/<contract_id>/balance (GET)
 Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-contracts-contract-id-balance
-Description: Access the balance of a contract.
+Description: Access the balance of a contract. The balance at a past block needs its context, which full and
+rolling nodes prune below their savepoint, see NearestAvailableBlock.
 
 Parameters:
-	blockhash:
-		The hash of block (height) of which you want to make the query.
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
 	address:
 		Any tezos public address.
 */
-func (t *GoTezos) Balance(blockhash, address string) (*string, error) {
-	query := fmt.Sprintf("/chains/main/blocks/%s/context/contracts/%s/balance", blockhash, address)
+func (t *GoTezos) Balance(blockID BlockID, address string) (*string, error) {
+	query := fmt.Sprintf("/chains/main/blocks/%s/context/contracts/%s/balance", blockID.ID(), address)
 	resp, err := t.get(query)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get balance")
@@ -58,6 +64,64 @@ func (t *GoTezos) Balance(blockhash, address string) (*string, error) {
 	return &balance, nil
 }
 
+/*
+BalancesAt Function
+Description: Fetches the balances of many contracts at a block concurrently, with at most 10 requests in
+flight at once. Returns a map of address to balance. Fails on the first balance that could not be fetched.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+	addresses:
+		Any tezos public addresses.
+*/
+func (t *GoTezos) BalancesAt(blockID BlockID, addresses ...string) (map[string]string, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	balances := make(map[string]string, len(addresses))
+	sem := make(chan struct{}, balancesParallelism)
+
+	for _, address := range addresses {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(address string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			balance, err := t.Balance(blockID, address)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = errors.Wrapf(err, "failed to get balances at '%s'", address)
+				}
+				return
+			}
+			balances[address] = *balance
+		}(address)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return balances, nil
+}
+
 /*
 CreateWallet Function
 Description: Creates a new wallet.
@@ -131,7 +195,7 @@ func ImportWallet(hash, pk, sk string) (*Wallet, error) {
 		publicKey := decodedSecretKey[32:]
 
 		signKP.PubKey = []byte(publicKey)
-		signKP.PrivKey = []byte(sk)
+		signKP.PrivKey = []byte(decodedSecretKey)
 
 		wallet.Sk = sk
 
@@ -248,6 +312,64 @@ func ImportEncryptedWallet(password, esk string) (*Wallet, error) {
 	return &wallet, nil
 }
 
+/*
+ExportEncryptedWallet Function
+Description: Encrypts the secret key of a wallet with a password into the edesk format
+understood by tezos-client (encrypted:edesk).
+
+Parameters:
+	password:
+		The password to encrypt the secret key with.
+	wallet:
+		The wallet whose secret key will be encrypted.
+*/
+func ExportEncryptedWallet(password string, wallet *Wallet) (string, error) {
+	if wallet == nil || len(wallet.Kp.PrivKey) != ed25519.PrivateKeySize {
+		return "", errors.New("wallet does not contain a valid ed25519 secret key")
+	}
+
+	seed := ed25519.PrivateKey(wallet.Kp.PrivKey).Seed()
+
+	salt := make([]byte, 8)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", errors.Wrap(err, "could not generate salt")
+	}
+
+	// Derive a key from password, salt and number of iterations
+	key := pbkdf2.Key([]byte(password), salt, 32768, 32, sha512.New)
+	var byteKey [32]byte
+	for i := range key {
+		byteKey[i] = key[i]
+	}
+
+	var emptyNonceBytes [24]byte
+	esm := secretbox.Seal(nil, seed, &emptyNonceBytes, &byteKey)
+
+	return b58cencode(append(salt, esm...), prefix_edesk), nil
+}
+
+/*
+Sign Function
+Description: Signs a message with the wallet's secret key. Following the Tezos signature scheme the
+blake2b-256 digest of the message is signed. Returns the edsig encoded signature. Operations and blocks
+must be signed with a watermark, see SignOperation, SignEndorsement and SignBlock.
+
+Parameters:
+	message:
+		The bytes to sign (e.g. a packed Michelson value).
+*/
+func (w *Wallet) Sign(message []byte) (string, error) {
+	if len(w.Kp.PrivKey) != ed25519.PrivateKeySize {
+		return "", errors.New("wallet does not contain a valid ed25519 secret key")
+	}
+
+	digest := blake2b.Sum256(message)
+	signature := ed25519.Sign(ed25519.PrivateKey(w.Kp.PrivKey), digest[:])
+
+	return b58cencode(signature, prefix_edsig), nil
+}
+
 func generatePublicHash(publicKey []byte) (string, error) {
 	hash, err := blake2b.New(20, []byte{})
 	if err != nil {
@@ -2,9 +2,13 @@ package gotezos
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -273,6 +277,77 @@ func Test_ImportEncryptedSecret(t *testing.T) {
 	}
 }
 
+func Test_ExportEncryptedWallet(t *testing.T) {
+	type input struct {
+		pw     string
+		wallet *Wallet
+	}
+
+	type want struct {
+		err         bool
+		errContains string
+		wallet      *Wallet
+	}
+
+	imported, err := ImportWallet(
+		"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK",
+		"edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G",
+		"edskSA4oADtx6DTT6eXdBc6Pv5MoVBGXUzy8bBryi6D96RQNQYcRfVEXd2nuE2ZZPxs4YLZeM7KazUULFT1SfMDNyKFCUgk6vR",
+	)
+	assert.Nil(t, err)
+
+	var cases = []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			"is successful",
+			input{
+				"password12345##",
+				imported,
+			},
+			want{
+				false,
+				"",
+				imported,
+			},
+		},
+		{
+			"is missing secret key",
+			input{
+				"password12345##",
+				&Wallet{},
+			},
+			want{
+				true,
+				"wallet does not contain a valid ed25519 secret key",
+				nil,
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			esk, err := ExportEncryptedWallet(tt.input.pw, tt.input.wallet)
+			if tt.want.err {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), tt.want.errContains)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Len(t, esk, 88)
+			assert.Equal(t, "edesk", esk[:5])
+
+			wallet, err := ImportEncryptedWallet(tt.input.pw, esk)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want.wallet.Address, wallet.Address)
+			assert.Equal(t, tt.want.wallet.Pk, wallet.Pk)
+			assert.Equal(t, tt.want.wallet.Sk, wallet.Sk)
+		})
+	}
+}
+
 func Test_Balance(t *testing.T) {
 	var goldenBalance string
 	json.Unmarshal(mockStakingBalanceResp, &goldenBalance)
@@ -343,7 +418,7 @@ func Test_Balance(t *testing.T) {
 			gt, err := New(server.URL)
 			assert.Nil(t, err)
 
-			balance, err := gt.Balance(tt.input.hash, tt.input.address)
+			balance, err := gt.Balance(BlockIDHash(tt.input.hash), tt.input.address)
 			if tt.want.wantErr {
 				assert.NotNil(t, err)
 				assert.Contains(t, err.Error(), tt.want.containsErr)
@@ -355,3 +430,88 @@ func Test_Balance(t *testing.T) {
 		})
 	}
 }
+
+func Test_BalancesAt(t *testing.T) {
+	var addresses []string
+	want := map[string]string{}
+	for i := 0; i < 25; i++ {
+		address := fmt.Sprintf("tz1Address%d", i)
+		addresses = append(addresses, address)
+		want[address] = fmt.Sprintf("%d", i*1000)
+	}
+
+	var (
+		mu       sync.Mutex
+		inFlight int
+		maxSeen  int
+	)
+	regAddress := regexp.MustCompile(`contracts\/([A-z0-9]+)\/balance`)
+	balances := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxSeen {
+			maxSeen = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		address := regAddress.FindStringSubmatch(r.URL.String())[1]
+		if address == "tz1Bad" {
+			w.Write(mockRPCErrorResp)
+			return
+		}
+		balance, _ := json.Marshal(want[address])
+		w.Write(balance)
+	})
+
+	cases := []struct {
+		name        string
+		input       []string
+		wantErr     bool
+		containsErr string
+		want        map[string]string
+	}{
+		{
+			"is successful",
+			addresses,
+			false,
+			"",
+			want,
+		},
+		{
+			"is empty",
+			nil,
+			false,
+			"",
+			map[string]string{},
+		},
+		{
+			"returns rpc error",
+			append([]string{"tz1Bad"}, addresses...),
+			true,
+			"failed to get balances at 'tz1Bad'",
+			nil,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(gtGoldenHTTPMock(balances))
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			got, err := gt.BalancesAt(BlockIDHash(mockBlockHash), tt.input...)
+			checkErr(t, tt.wantErr, tt.containsErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.True(t, maxSeen <= balancesParallelism)
+}
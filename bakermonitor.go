@@ -0,0 +1,254 @@
+package gotezos
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+const (
+	// BakerEventMissedBake is a block the delegate had the right to bake before the baker of the block.
+	BakerEventMissedBake = "missed_bake"
+	// BakerEventMissedEndorsement is a level the delegate had endorsing slots for but did not endorse.
+	BakerEventMissedEndorsement = "missed_endorsement"
+	// BakerEventStolenBlock is a block the delegate baked at a priority (or round) above zero, in place of another
+	// baker.
+	BakerEventStolenBlock = "stolen_block"
+)
+
+/*
+BakerMonitorInput -
+Description: The input for monitoring the performance of a baker.
+Function: func (t *GoTezos) MonitorBaker(ctx context.Context, input *BakerMonitorInput) (<-chan BakerEvent, <-chan error, error) {}
+*/
+type BakerMonitorInput struct {
+	// The delegate to monitor.
+	// Required.
+	Delegate string `validate:"required"`
+
+	// How often the head is polled. Defaults to 10 seconds.
+	Interval time.Duration
+}
+
+/*
+BakerEvent -
+Description: A missed bake, missed endorsement or stolen block of a delegate.
+*/
+type BakerEvent struct {
+	// The kind of the event, see the BakerEvent constants.
+	Kind     string
+	Delegate string
+	// The level baked, or endorsed for missed endorsements.
+	Level int
+	// The block baked at Level, or the block the endorsements of Level were included in for missed endorsements.
+	BlockHash string
+	// The baker of the block, and its priority before Tenderbake or its payload round on Tenderbake (bakes only).
+	Baker         string
+	BlockPriority int
+	BlockRound    int
+	// The priority or round of the right of the delegate (missed bakes only).
+	Priority int
+	Round    int
+	// The endorsing slots, or endorsing power on Tenderbake, of the delegate (missed endorsements only).
+	Slots          []int
+	EndorsingPower int
+}
+
+/*
+MonitorBaker Function
+Description: Watches the heads of the chain for the missed bakes, missed endorsements and stolen blocks of a
+delegate, using its baking and endorsing rights, by priority before Tenderbake and by round on Tenderbake. Every
+block from the head at the time of the call is checked once, in order, including blocks skipped between two polls.
+The head is polled rather than streamed: each block needs requests for its rights anyway, and blocks skipped
+between two polls are fetched by level, so none is missed. Endorsements of a level are checked in the block
+of the next level, which includes them. Both channels are closed when monitoring stops: once an RPC fails or
+the context is done. The error channel receives the reason monitoring stopped.
+
+Parameters:
+	ctx:
+		Cancels monitoring.
+	input:
+		The delegate to monitor and the polling interval. Delegate is required.
+*/
+func (t *GoTezos) MonitorBaker(ctx context.Context, input *BakerMonitorInput) (<-chan BakerEvent, <-chan error, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid input")
+	}
+
+	interval := input.Interval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	events := make(chan BakerEvent)
+	errs := make(chan error, 1)
+
+	ctx, cancel := t.untilClosed(ctx)
+	go func() {
+		defer cancel()
+		defer close(errs)
+		defer close(events)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		last := -1
+		for {
+			head, err := t.Head()
+			if err != nil {
+				errs <- errors.Wrap(err, "failed to monitor baker")
+				return
+			}
+
+			if last < 0 {
+				last = head.Header.Level - 1
+			}
+
+			for level := last + 1; level <= head.Header.Level; level++ {
+				block := head
+				if level != head.Header.Level {
+					block, err = t.Block(BlockIDLevel(level))
+					if err != nil {
+						errs <- errors.Wrap(err, "failed to monitor baker")
+						return
+					}
+				}
+
+				blockEvents, err := t.bakerEvents(block, input.Delegate)
+				if err != nil {
+					errs <- errors.Wrap(err, "failed to monitor baker")
+					return
+				}
+
+				for _, event := range blockEvents {
+					select {
+					case events <- event:
+					case <-ctx.Done():
+						errs <- ctx.Err()
+						return
+					}
+				}
+				last = level
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return events, errs, nil
+}
+
+// bakerEvents returns the events of the delegate in a block: the bake of the block and the endorsements of the
+// previous level it includes.
+func (t *GoTezos) bakerEvents(block *Block, delegate string) ([]BakerEvent, error) {
+	events := []BakerEvent{}
+	tenderbake := t.Protocol().Tenderbake
+	level, priority := block.Header.Level, block.Header.Priority
+	if tenderbake {
+		priority = block.Header.PayloadRound
+	}
+
+	bake := BakerEvent{
+		Delegate:  delegate,
+		Level:     level,
+		BlockHash: block.Hash,
+		Baker:     block.Metadata.Baker,
+	}
+	if tenderbake {
+		bake.BlockRound = priority
+	} else {
+		bake.BlockPriority = priority
+	}
+
+	switch {
+	case block.Metadata.Baker == delegate && priority > 0:
+		bake.Kind = BakerEventStolenBlock
+		events = append(events, bake)
+	case block.Metadata.Baker != delegate && priority > 0:
+		input := &BakingRightsInput{
+			BlockID:  BlockIDHash(block.Hash),
+			Level:    &level,
+			Delegate: &delegate,
+		}
+		maxPriority := priority - 1
+		if tenderbake {
+			input.Options = []RPCOption{WithMaxRound(maxPriority)}
+		} else {
+			input.MaxPriority = &maxPriority
+		}
+
+		rights, err := t.BakingRights(input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, right := range *rights {
+			if tenderbake && right.Delegate == delegate && right.Round < priority {
+				bake.Kind, bake.Round = BakerEventMissedBake, right.Round
+				events = append(events, bake)
+				break
+			}
+			if !tenderbake && right.Delegate == delegate && right.Priority < priority {
+				bake.Kind, bake.Priority = BakerEventMissedBake, right.Priority
+				events = append(events, bake)
+				break
+			}
+		}
+	}
+
+	endorsed := level - 1
+	if endorsed < 1 {
+		return events, nil
+	}
+
+	rights, err := t.EndorsingRights(&EndorsingRightsInput{
+		BlockID:  BlockIDHash(block.Hash),
+		Level:    &endorsed,
+		Delegate: &delegate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, right := range *rights {
+		if right.Delegate != delegate || blockEndorsedBy(block, delegate) {
+			continue
+		}
+
+		events = append(events, BakerEvent{
+			Kind:           BakerEventMissedEndorsement,
+			Delegate:       delegate,
+			Level:          endorsed,
+			BlockHash:      block.Hash,
+			Slots:          right.Slots,
+			EndorsingPower: right.EndorsingPower,
+		})
+	}
+
+	return events, nil
+}
+
+func blockEndorsedBy(block *Block, delegate string) bool {
+	for _, operations := range block.Operations {
+		for _, operation := range operations {
+			for _, contents := range operation.Contents {
+				switch contents.Kind {
+				case ENDORSEMENTOP, ENDORSEMENTWITHSLOTOP, ATTESTATIONOP:
+					if contents.Metadata != nil && contents.Metadata.Delegate == delegate {
+						return true
+					}
+				}
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,207 @@
+package gotezos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mockBakedBlock(level int, hash, baker string, priority int, endorsers ...string) Block {
+	block := mockChainBlock(level, hash)
+	block.Header.Priority = priority
+	block.Metadata.Baker = baker
+
+	var endorsements []Operations
+	for _, endorser := range endorsers {
+		endorsements = append(endorsements, Operations{
+			Contents: []Contents{{Kind: ENDORSEMENTOP, Level: level - 1, Metadata: &ContentsMetadata{Delegate: endorser}}},
+		})
+	}
+	block.Operations = [][]Operations{endorsements}
+
+	return block
+}
+
+// rightsHandlerMock serves the baking and endorsing rights of the level or cycle given by the query parameter
+// key. A nil map of rights fails the request.
+func rightsHandlerMock(key string, baking, endorsing map[string]string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var rights map[string]string
+		switch {
+		case regBakingRights.MatchString(r.URL.Path):
+			rights = baking
+		case regEndorsingRights.MatchString(r.URL.Path):
+			rights = endorsing
+		default:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if rights == nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		resp, ok := rights[r.URL.Query().Get(key)]
+		if !ok {
+			resp = "[]"
+		}
+		fmt.Fprint(w, resp)
+	})
+}
+
+// mockTenderbakeBlockResp is a block baked at round 1, including the endorsement of the previous level by
+// tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx.
+var mockTenderbakeBlockResp = []byte(`{"protocol":"Psithaca2MLRFYargivpo7YvUr7wUDqyxrdhC5CQq78mRvimz6A","chain_id":"NetXdQprcVkpaWU","hash":"BLwKksYwrxt39exDei7yi47h7aMcVY2kZMZhTwEEJSGWmmxk1Nb","header":{"level":2001,"proto":12,"predecessor":"BMSvXr6d3EpXNmtYHRBt2ELkx3A6AMKwJWRRqYAnhrxgi7sNkUT","timestamp":"2022-04-01T10:00:45Z","validation_pass":4,"operations_hash":"LLoZqBDX1E2ADRXbmwYo8VtMNeHG6Ygzmm4Zqv97i91UPBQHy9Vq3","fitness":["02","000007d1","","ffffffff","00000001"],"context":"CoVmAcMV64uAQo8XvfLr9VDuz7HVZLk2Mh9mrmgmVKZbMPi2hy5A","payload_hash":"vh2cHpQ3mXsBS5Ymdq48YMBv7phMGqrZoYQjRCnaS1vU7Sdu6Ue7","payload_round":1,"proof_of_work_nonce":"e0d6fe4100000000","liquidity_baking_escape_vote":false,"signature":"sigNE1ib8yqQqaTpKbhTUNupP1A3FuBdH2Bw1Yi3ryGbPTdpKBJ7mpvHDz2ELyr5Ye3qFwzovbZVhTY4pRBBhwBuBLwCaZVC"},"metadata":{"protocol":"Psithaca2MLRFYargivpo7YvUr7wUDqyxrdhC5CQq78mRvimz6A","next_protocol":"Psithaca2MLRFYargivpo7YvUr7wUDqyxrdhC5CQq78mRvimz6A","baker":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","level_info":{"level":2001,"level_position":2000,"cycle":0,"cycle_position":2000,"expected_commitment":false}},"operations":[[{"protocol":"Psithaca2MLRFYargivpo7YvUr7wUDqyxrdhC5CQq78mRvimz6A","chain_id":"NetXdQprcVkpaWU","hash":"ooH2D5Lv8uDgqdfnsFWrx1VjzEdEHXmzWnKpBZENmjFc3hnfrZo","branch":"BMSvXr6d3EpXNmtYHRBt2ELkx3A6AMKwJWRRqYAnhrxgi7sNkUT","contents":[{"kind":"endorsement","slot":0,"level":2000,"round":0,"block_payload_hash":"vh2cHpQ3mXsBS5Ymdq48YMBv7phMGqrZoYQjRCnaS1vU7Sdu6Ue7","metadata":{"delegate":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","endorsement_power":5}}],"signature":"sigNE1ib8yqQqaTpKbhTUNupP1A3FuBdH2Bw1Yi3ryGbPTdpKBJ7mpvHDz2ELyr5Ye3qFwzovbZVhTY4pRBBhwBuBLwCaZVC"}],[],[],[]]}`)
+
+func Test_MonitorBakerTenderbake(t *testing.T) {
+	delegate := "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"
+	other := "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+
+	var stolen Block
+	assert.Nil(t, json.Unmarshal(mockTenderbakeBlockResp, &stolen))
+	chain := map[int]Block{
+		2000: mockBakedBlock(2000, "BL2000", delegate, 0),
+		2001: stolen,
+		2002: mockBakedBlock(2002, "BL2002", delegate, 0, other),
+	}
+	baked := chain[2002]
+	baked.Header.PayloadRound = 2
+	chain[2002] = baked
+
+	baking := map[string]string{
+		"2001": fmt.Sprintf(`[{"level":2001,"delegate":"%s","round":0}]`, delegate),
+	}
+	endorsing := map[string]string{
+		"2000": fmt.Sprintf(`[{"level":2000,"delegates":[{"delegate":"%s","first_slot":4,"endorsing_power":12},{"delegate":"%s","first_slot":0,"endorsing_power":5}]}]`, delegate, other),
+	}
+
+	var queries []string
+	rights := rightsHandlerMock("level", baking, endorsing, blankHandler)
+	heads := &chainHandlerMock{heads: []int{2000, 2002}, chains: []map[int]Block{chain, chain}}
+	server := httptest.NewServer(gtGoldenHTTPMock(heads.handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if regBakingRights.MatchString(r.URL.Path) {
+			queries = append(queries, r.URL.RawQuery)
+		}
+		rights.ServeHTTP(w, r)
+	}))))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+	gt.SetProtocol("Psithaca2MLRFYargivpo7YvUr7wUDqyxrdhC5CQq78mRvimz6A")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, errs, err := gt.MonitorBaker(ctx, &BakerMonitorInput{Delegate: delegate, Interval: time.Millisecond})
+	assert.Nil(t, err)
+
+	want := []BakerEvent{
+		{Kind: BakerEventMissedBake, Delegate: delegate, Level: 2001, BlockHash: "BLwKksYwrxt39exDei7yi47h7aMcVY2kZMZhTwEEJSGWmmxk1Nb", Baker: other, BlockRound: 1, Round: 0},
+		{Kind: BakerEventMissedEndorsement, Delegate: delegate, Level: 2000, BlockHash: "BLwKksYwrxt39exDei7yi47h7aMcVY2kZMZhTwEEJSGWmmxk1Nb", EndorsingPower: 12},
+		{Kind: BakerEventStolenBlock, Delegate: delegate, Level: 2002, BlockHash: "BL2002", Baker: delegate, BlockRound: 2},
+	}
+
+	var got []BakerEvent
+	for event := range events {
+		got = append(got, event)
+		if len(got) == len(want) {
+			cancel()
+		}
+	}
+	checkErr(t, true, "context canceled", <-errs)
+	assert.Equal(t, want, got)
+	assert.Equal(t, []string{"delegate=tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK&level=2001&max_round=0"}, queries)
+}
+
+func Test_MonitorBaker(t *testing.T) {
+	delegate := "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"
+	other := "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+	chain := map[int]Block{
+		100: mockBakedBlock(100, "BL100", delegate, 0, other, delegate),
+		101: mockBakedBlock(101, "BL101", other, 1, other),
+		102: mockBakedBlock(102, "BL102", delegate, 2),
+	}
+	baking := map[string]string{
+		"101": fmt.Sprintf(`[{"level":101,"delegate":"%s","priority":0}]`, delegate),
+	}
+	endorsing := map[string]string{
+		"99":  fmt.Sprintf(`[{"level":99,"delegate":"%s","slots":[1]}]`, delegate),
+		"100": fmt.Sprintf(`[{"level":100,"delegate":"%s","slots":[3,7]}]`, delegate),
+	}
+
+	type want struct {
+		err         bool
+		containsErr string
+		events      []BakerEvent
+	}
+
+	cases := []struct {
+		name    string
+		handler http.Handler
+		want
+	}{
+		{
+			"is successful",
+			rightsHandlerMock("level", baking, endorsing, blankHandler),
+			want{
+				true,
+				"context canceled",
+				[]BakerEvent{
+					{Kind: BakerEventMissedBake, Delegate: delegate, Level: 101, BlockHash: "BL101", Baker: other, BlockPriority: 1, Priority: 0},
+					{Kind: BakerEventMissedEndorsement, Delegate: delegate, Level: 100, BlockHash: "BL101", Slots: []int{3, 7}},
+					{Kind: BakerEventStolenBlock, Delegate: delegate, Level: 102, BlockHash: "BL102", Baker: delegate, BlockPriority: 2},
+				},
+			},
+		},
+		{
+			"handles failure to get rights",
+			rightsHandlerMock("level", nil, nil, blankHandler),
+			want{
+				true,
+				"failed to monitor baker",
+				nil,
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			heads := &chainHandlerMock{
+				heads:  []int{100, 102},
+				chains: []map[int]Block{chain, chain},
+			}
+			server := httptest.NewServer(gtGoldenHTTPMock(heads.handler(tt.handler)))
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			events, errs, err := gt.MonitorBaker(ctx, &BakerMonitorInput{Delegate: delegate, Interval: time.Millisecond})
+			assert.Nil(t, err)
+
+			var got []BakerEvent
+			for event := range events {
+				got = append(got, event)
+				if len(got) == len(tt.want.events) {
+					cancel()
+				}
+			}
+			checkErr(t, tt.want.err, tt.want.containsErr, <-errs)
+			assert.Equal(t, tt.want.events, got)
+		})
+	}
+
+	_, _, err := (&GoTezos{}).MonitorBaker(context.Background(), &BakerMonitorInput{})
+	checkErr(t, true, "invalid input", err)
+}
@@ -0,0 +1,162 @@
+package gotezos
+
+const (
+	// BalanceUpdateKindContract is a balance update of a contract's spendable balance.
+	BalanceUpdateKindContract = "contract"
+	// BalanceUpdateKindFreezer is a balance update of frozen deposits, fees or rewards.
+	BalanceUpdateKindFreezer = "freezer"
+	// BalanceUpdateKindAccumulator is a balance update of a block's fee accumulator (Ithaca and later).
+	BalanceUpdateKindAccumulator = "accumulator"
+	// BalanceUpdateKindMinted is a balance update of tez created by the protocol (Ithaca and later).
+	BalanceUpdateKindMinted = "minted"
+	// BalanceUpdateKindBurned is a balance update of tez destroyed by the protocol (Ithaca and later).
+	BalanceUpdateKindBurned = "burned"
+	// BalanceUpdateKindCommitment is a balance update of a fundraiser commitment (Ithaca and later).
+	BalanceUpdateKindCommitment = "commitment"
+	// BalanceUpdateKindStaking is a balance update of staking pseudo-tokens (Oxford and later).
+	BalanceUpdateKindStaking = "staking"
+
+	// BalanceUpdateCategoryDeposits is a balance update of frozen deposits.
+	BalanceUpdateCategoryDeposits = "deposits"
+	// BalanceUpdateCategoryUnstakedDeposits is a balance update of unstaked, not yet finalized deposits.
+	BalanceUpdateCategoryUnstakedDeposits = "unstaked_deposits"
+	// BalanceUpdateCategoryRewards is a balance update of frozen rewards (protocols before Ithaca).
+	BalanceUpdateCategoryRewards = "rewards"
+	// BalanceUpdateCategoryFees is a balance update of frozen fees (protocols before Ithaca).
+	BalanceUpdateCategoryFees = "fees"
+	// BalanceUpdateCategoryBakingRewards is a balance update of minted baking rewards.
+	BalanceUpdateCategoryBakingRewards = "baking rewards"
+	// BalanceUpdateCategoryBakingBonuses is a balance update of minted baking bonuses.
+	BalanceUpdateCategoryBakingBonuses = "baking bonuses"
+	// BalanceUpdateCategoryEndorsingRewards is a balance update of minted endorsing rewards.
+	BalanceUpdateCategoryEndorsingRewards = "endorsing rewards"
+	// BalanceUpdateCategoryAttestingRewards is a balance update of minted attesting rewards (Oxford and later).
+	BalanceUpdateCategoryAttestingRewards = "attesting rewards"
+	// BalanceUpdateCategoryBlockFees is a balance update of the fees accumulated by a block.
+	BalanceUpdateCategoryBlockFees = "block fees"
+	// BalanceUpdateCategoryPunishments is a balance update of burned double signing deposits.
+	BalanceUpdateCategoryPunishments = "punishments"
+	// BalanceUpdateCategoryDoubleSigningPunishments is a balance update of burned double signing deposits
+	// (Oxford and later).
+	BalanceUpdateCategoryDoubleSigningPunishments = "double signing punishments"
+	// BalanceUpdateCategoryDoubleSigningEvidenceRewards is a balance update of minted rewards for denouncing
+	// a double signing.
+	BalanceUpdateCategoryDoubleSigningEvidenceRewards = "double signing evidence rewards"
+	// BalanceUpdateCategoryNonceRevelationRewards is a balance update of minted seed nonce revelation rewards.
+	BalanceUpdateCategoryNonceRevelationRewards = "nonce revelation rewards"
+	// BalanceUpdateCategoryVDFRevelationRewards is a balance update of minted VDF revelation rewards.
+	BalanceUpdateCategoryVDFRevelationRewards = "vdf revelation rewards"
+	// BalanceUpdateCategoryLostEndorsingRewards is a balance update of burned endorsing rewards, lost for
+	// missed slots or a missed nonce revelation.
+	BalanceUpdateCategoryLostEndorsingRewards = "lost endorsing rewards"
+	// BalanceUpdateCategoryLostAttestingRewards is a balance update of burned attesting rewards (Oxford and
+	// later), see BalanceUpdateCategoryLostEndorsingRewards.
+	BalanceUpdateCategoryLostAttestingRewards = "lost attesting rewards"
+	// BalanceUpdateCategoryStorageFees is a balance update of burned storage and allocation fees.
+	BalanceUpdateCategoryStorageFees = "storage fees"
+
+	// BalanceUpdateOriginBlock is a balance update caused by the application of a block or operation.
+	BalanceUpdateOriginBlock = "block"
+	// BalanceUpdateOriginMigration is a balance update caused by a protocol migration.
+	BalanceUpdateOriginMigration = "migration"
+	// BalanceUpdateOriginSubsidy is a balance update caused by the liquidity baking subsidy.
+	BalanceUpdateOriginSubsidy = "subsidy"
+	// BalanceUpdateOriginSimulation is a balance update of a simulated operation.
+	BalanceUpdateOriginSimulation = "simulation"
+	// BalanceUpdateOriginDelayedOperation is a balance update caused by a delayed operation (Oxford and later).
+	BalanceUpdateOriginDelayedOperation = "delayed_operation"
+)
+
+/*
+BalanceUpdates Function
+Description: Returns every balance update of the block, in the order they were applied: the balance
+updates of the block metadata followed by those of each operation, including the results of its
+internal operations.
+*/
+func (b *Block) BalanceUpdates() []BalanceUpdates {
+	balanceUpdates := append([]BalanceUpdates{}, b.Metadata.BalanceUpdates...)
+	for _, pass := range b.Operations {
+		for _, operation := range pass {
+			balanceUpdates = append(balanceUpdates, operation.BalanceUpdates()...)
+		}
+	}
+
+	return balanceUpdates
+}
+
+/*
+BalanceUpdates Function
+Description: Returns every balance update of the operation: the fees and deposits of each of its
+contents, the balance updates of its result and those of its internal operation results.
+*/
+func (o *Operations) BalanceUpdates() []BalanceUpdates {
+	balanceUpdates := []BalanceUpdates{}
+	for _, contents := range o.Contents {
+		if contents.Metadata == nil {
+			continue
+		}
+
+		balanceUpdates = append(balanceUpdates, contents.Metadata.BalanceUpdates...)
+		if contents.Metadata.OperationResult != nil {
+			balanceUpdates = append(balanceUpdates, contents.Metadata.OperationResult.BalanceUpdates...)
+		}
+
+		for _, internal := range contents.Metadata.InternalOperationResults {
+			balanceUpdates = append(balanceUpdates, internal.Result.BalanceUpdates...)
+		}
+	}
+
+	return balanceUpdates
+}
+
+/*
+BalanceChanges Function
+Description: Sums balance updates by contract or delegate, keyed by the address of the account
+affected. Freezer, deposits and staking updates are attributed to their delegate or staker; minted,
+burned and accumulator updates have no owner and are skipped.
+
+Parameters:
+	balanceUpdates:
+		The balance updates to sum, e.g. the result of Block.BalanceUpdates.
+*/
+func BalanceChanges(balanceUpdates []BalanceUpdates) map[string]*BigInt {
+	changes := map[string]*BigInt{}
+	for _, update := range balanceUpdates {
+		owner := update.owner()
+		if owner == "" {
+			continue
+		}
+
+		if _, ok := changes[owner]; !ok {
+			changes[owner] = &BigInt{}
+		}
+		changes[owner].Add(&changes[owner].Int, &update.Change.Int)
+	}
+
+	return changes
+}
+
+func (b BalanceUpdates) owner() string {
+	if b.Contract != "" {
+		return b.Contract
+	}
+	if b.Delegate != "" {
+		return b.Delegate
+	}
+	if b.Staker != nil {
+		if b.Staker.Contract != "" {
+			return b.Staker.Contract
+		}
+		if b.Staker.Delegate != "" {
+			return b.Staker.Delegate
+		}
+		if b.Staker.BakerOwnStake != "" {
+			return b.Staker.BakerOwnStake
+		}
+		if b.Staker.BakerEdge != "" {
+			return b.Staker.BakerEdge
+		}
+		return b.Staker.Baker
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var mockBalanceUpdatesBlockResp = []byte(`{
+	"protocol": "ProxfordYmVfjWnRcgjWH36fW6PArwqykTFzotUxRs6gmTcZDuH",
+	"hash": "BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1",
+	"header": {"level": 4587521},
+	"metadata": {
+		"baker": "tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q",
+		"proposer": "tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q",
+		"level_info": {"level": 4587521, "level_position": 4587520, "cycle": 655, "cycle_position": 0, "expected_commitment": false},
+		"voting_period_info": {"voting_period": {"index": 110, "kind": "proposal", "start_position": 4546560}, "position": 40960, "remaining": 40959},
+		"deactivated": ["tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"],
+		"balance_updates": [
+			{"kind": "minted", "category": "baking rewards", "change": "-5000000", "origin": "block"},
+			{"kind": "contract", "contract": "tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q", "change": "5000000", "origin": "block"},
+			{"kind": "freezer", "category": "deposits", "staker": {"baker": "tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q"}, "change": "1000000", "origin": "block"},
+			{"kind": "freezer", "category": "unstaked_deposits", "staker": {"contract": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "delegate": "tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q"}, "cycle": 654, "change": "-250", "origin": "block"}
+		]
+	},
+	"operations": [[{
+		"hash": "opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A",
+		"contents": [{
+			"kind": "transaction",
+			"source": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+			"metadata": {
+				"balance_updates": [
+					{"kind": "contract", "contract": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "change": "-1000", "origin": "block"},
+					{"kind": "accumulator", "category": "block fees", "change": "1000", "origin": "block"}
+				],
+				"operation_result": {
+					"status": "applied",
+					"balance_updates": [
+						{"kind": "contract", "contract": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "change": "-100", "origin": "block"},
+						{"kind": "contract", "contract": "KT1TxqZ8QtKvLu3V3JH7Gx58n7Co8pgtpQU5", "change": "100", "origin": "block"}
+					]
+				},
+				"internal_operation_results": [{
+					"kind": "transaction",
+					"source": "KT1TxqZ8QtKvLu3V3JH7Gx58n7Co8pgtpQU5",
+					"nonce": 0,
+					"result": {
+						"status": "applied",
+						"balance_updates": [
+							{"kind": "contract", "contract": "KT1TxqZ8QtKvLu3V3JH7Gx58n7Co8pgtpQU5", "change": "-10", "origin": "block"},
+							{"kind": "contract", "contract": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "change": "10", "origin": "block"}
+						]
+					}
+				}]
+			}
+		}]
+	}]]
+}`)
+
+func Test_BlockMetadata(t *testing.T) {
+	var block Block
+	err := json.Unmarshal(mockBalanceUpdatesBlockResp, &block)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q", block.Metadata.Baker)
+	assert.Equal(t, "tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q", block.Metadata.Proposer)
+	assert.Equal(t, &Level{Level: 4587521, LevelPosition: 4587520, Cycle: 655}, block.Metadata.LevelInfo)
+	assert.Equal(t, &VotingPeriodInfo{VotingPeriod: VotingPeriod{Index: 110, Kind: "proposal", StartPosition: 4546560}, Position: 40960, Remaining: 40959}, block.Metadata.VotingPeriodInfo)
+	assert.Equal(t, []string{"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}, block.Metadata.Deactivated)
+
+	unstaked := block.Metadata.BalanceUpdates[3]
+	assert.Equal(t, BalanceUpdateKindFreezer, unstaked.Kind)
+	assert.Equal(t, BalanceUpdateCategoryUnstakedDeposits, unstaked.Category)
+	assert.Equal(t, BalanceUpdateOriginBlock, unstaked.Origin)
+	assert.Equal(t, 654, unstaked.Cycle)
+	assert.Equal(t, "-250", unstaked.Change.String())
+	assert.Equal(t, &BalanceUpdateStaker{Contract: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", Delegate: "tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q"}, unstaked.Staker)
+}
+
+func Test_BalanceUpdates(t *testing.T) {
+	var block Block
+	err := json.Unmarshal(mockBalanceUpdatesBlockResp, &block)
+	assert.Nil(t, err)
+
+	balanceUpdates := block.BalanceUpdates()
+	assert.Len(t, balanceUpdates, 10)
+	assert.Len(t, block.Operations[0][0].BalanceUpdates(), 6)
+	assert.Equal(t, BalanceUpdateKindMinted, balanceUpdates[0].Kind)
+	assert.Equal(t, "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", balanceUpdates[9].Contract)
+
+	changes := BalanceChanges(balanceUpdates)
+	assert.Len(t, changes, 3)
+	assert.Equal(t, "6000000", changes["tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q"].String())
+	assert.Equal(t, "-1340", changes["tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"].String())
+	assert.Equal(t, "90", changes["KT1TxqZ8QtKvLu3V3JH7Gx58n7Co8pgtpQU5"].String())
+}
@@ -0,0 +1,136 @@
+package gotezos
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+/*
+Batch -
+Description: Collects GET requests and executes them concurrently, e.g. to fetch a block together with
+its votes, rights and constants in one shot.
+
+	batch := gt.NewBatch(4)
+	var block gotezos.Block
+	var constants gotezos.Constants
+	batch.Get("/chains/main/blocks/head", &block)
+	batch.Get("/chains/main/blocks/head/context/constants", &constants)
+	results, err := batch.Execute(ctx)
+*/
+type Batch struct {
+	gt          *GoTezos
+	concurrency int
+	requests    []batchRequest
+}
+
+type batchRequest struct {
+	path string
+	v    interface{}
+}
+
+/*
+BatchResult -
+Description: The result of a request of a Batch.
+*/
+type BatchResult struct {
+	// The path of the request.
+	Path string
+	// The raw response, if the request succeeded.
+	Body []byte
+	// The reason the request failed, nil if it succeeded.
+	Err error
+}
+
+/*
+NewBatch Function
+Description: Returns an empty Batch executing at most concurrency requests at a time.
+
+Parameters:
+	concurrency:
+		The maximum number of requests in flight. Values below 1 are treated as 1.
+*/
+func (t *GoTezos) NewBatch(concurrency int) *Batch {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return &Batch{gt: t, concurrency: concurrency}
+}
+
+/*
+Get Function
+Description: Adds a GET request to the batch and returns its index in the results of Execute.
+
+Parameters:
+	path:
+		The RPC path, e.g. /chains/main/blocks/head/votes/ballots.
+	v:
+		If not nil, the response is unmarshaled into v.
+*/
+func (b *Batch) Get(path string, v interface{}) int {
+	b.requests = append(b.requests, batchRequest{path: path, v: v})
+	return len(b.requests) - 1
+}
+
+/*
+Len Function
+Description: Returns the number of requests in the batch.
+*/
+func (b *Batch) Len() int {
+	return len(b.requests)
+}
+
+/*
+Execute Function
+Description: Executes the requests of the batch concurrently and returns their results in the order they
+were added. Every request is attempted unless the context is done; the returned error is that of the first
+request that failed, if any.
+
+Parameters:
+	ctx:
+		Cancels the requests still in flight or not yet started.
+*/
+func (b *Batch) Execute(ctx context.Context) ([]BatchResult, error) {
+	results := make([]BatchResult, len(b.requests))
+	sem := make(chan struct{}, b.concurrency)
+
+	var wg sync.WaitGroup
+	for i, request := range b.requests {
+		results[i].Path = request.path
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(result *BatchResult, request batchRequest) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result.Body, result.Err = b.gt.getContext(ctx, request.path)
+			if result.Err != nil || request.v == nil {
+				return
+			}
+
+			err := json.Unmarshal(result.Body, request.v)
+			if err != nil {
+				result.Err = errors.Wrapf(err, "could not unmarshal response of %s", request.path)
+			}
+		}(&results[i], request)
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		if result.Err != nil {
+			return results, errors.Wrapf(result.Err, "failed to execute batch request %s", result.Path)
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,102 @@
+package gotezos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Batch(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/chains/main/blocks/head/context/constants":
+			w.Write(mockConstantsResp)
+		case "/chains/main/blocks/head/votes/current_quorum":
+			w.Write([]byte(`5800`))
+		case "/fails":
+			w.Write(mockRPCErrorResp)
+		default:
+			w.Write([]byte(`"` + r.URL.Path + `"`))
+		}
+	})))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	t.Run("is successful", func(t *testing.T) {
+		maxInFlight = 0
+
+		var constants Constants
+		var quorum int
+		var paths [4]string
+
+		batch := gt.NewBatch(2)
+		assert.Equal(t, 0, batch.Get("/chains/main/blocks/head/context/constants", &constants))
+		assert.Equal(t, 1, batch.Get("/chains/main/blocks/head/votes/current_quorum", &quorum))
+		for i := range paths {
+			batch.Get("/path/"+string(rune('a'+i)), &paths[i])
+		}
+		assert.Equal(t, 6, batch.Len())
+
+		results, err := batch.Execute(context.Background())
+		assert.Nil(t, err)
+		assert.Len(t, results, 6)
+		assert.Equal(t, expectedConstants(t), &constants)
+		assert.Equal(t, 5800, quorum)
+		assert.Equal(t, [4]string{"/path/a", "/path/b", "/path/c", "/path/d"}, paths)
+		assert.Equal(t, "/path/c", results[4].Path)
+		assert.Equal(t, `"/path/c"`, string(results[4].Body))
+		assert.Equal(t, 2, maxInFlight)
+	})
+
+	t.Run("handles failed request", func(t *testing.T) {
+		var quorum int
+		batch := gt.NewBatch(0)
+		batch.Get("/chains/main/blocks/head/votes/current_quorum", &quorum)
+		batch.Get("/fails", nil)
+		batch.Get("/path/a", &quorum)
+
+		results, err := batch.Execute(context.Background())
+		checkErr(t, true, "failed to execute batch request /fails", err)
+		assert.Nil(t, results[0].Err)
+		assert.NotNil(t, results[1].Err)
+		checkErr(t, true, "could not unmarshal response of /path/a", results[2].Err)
+		assert.Equal(t, 5800, quorum)
+	})
+
+	t.Run("handles canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		batch := gt.NewBatch(1)
+		batch.Get("/path/a", nil)
+		batch.Get("/path/b", nil)
+
+		results, err := batch.Execute(ctx)
+		checkErr(t, true, "context canceled", err)
+		assert.Len(t, results, 2)
+		assert.NotNil(t, results[1].Err)
+	})
+}
@@ -0,0 +1,641 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+const (
+	// BigMapActionAlloc is the allocation of a new big map.
+	BigMapActionAlloc = "alloc"
+	// BigMapActionUpdate is the update of a key of a big map. The key was removed if the value is nil.
+	BigMapActionUpdate = "update"
+	// BigMapActionRemove is the removal of a whole big map.
+	BigMapActionRemove = "remove"
+	// BigMapActionCopy is the copy of a big map into a new big map.
+	BigMapActionCopy = "copy"
+
+	// LazyStorageKindBigMap is the kind of a big map lazy storage diff.
+	LazyStorageKindBigMap = "big_map"
+	// LazyStorageKindSaplingState is the kind of a sapling state lazy storage diff.
+	LazyStorageKindSaplingState = "sapling_state"
+)
+
+/*
+LazyStorageDiff <block>
+RPC: /chains/<chain_id>/blocks/<block_id> (<dyn>)
+Link: https://tezos.gitlab.io/api/rpc.html#get-block-id
+Description: The lazy storage (big map and sapling state) changes of an operation result, replacing
+big_map_diff since Edo.
+*/
+type LazyStorageDiff struct {
+	Kind string              `json:"kind"`
+	ID   string              `json:"id"`
+	Diff LazyStorageDiffDiff `json:"diff"`
+}
+
+/*
+LazyStorageDiffDiff <block>
+RPC: /chains/<chain_id>/blocks/<block_id> (<dyn>)
+Link: https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type LazyStorageDiffDiff struct {
+	Action    string                  `json:"action"`
+	Source    string                  `json:"source,omitempty"`
+	Updates   []LazyStorageDiffUpdate `json:"-"`
+	KeyType   *Micheline              `json:"key_type,omitempty"`
+	ValueType *Micheline              `json:"value_type,omitempty"`
+}
+
+/*
+LazyStorageDiffUpdate <block>
+RPC: /chains/<chain_id>/blocks/<block_id> (<dyn>)
+Link: https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type LazyStorageDiffUpdate struct {
+	KeyHash string     `json:"key_hash"`
+	Key     *Micheline `json:"key"`
+	Value   *Micheline `json:"value,omitempty"`
+}
+
+/*
+UnmarshalJSON Function
+Description: Implements the json.Unmarshaler interface for LazyStorageDiffDiff. The updates of a big map
+are a list of key updates, the updates of a sapling state an object; only big map updates are kept.
+
+Parameters:
+	v:
+		The JSON representation of a lazy storage diff.
+*/
+func (l *LazyStorageDiffDiff) UnmarshalJSON(v []byte) error {
+	type lazyStorageDiffDiff LazyStorageDiffDiff
+	var diff struct {
+		lazyStorageDiffDiff
+		Updates json.RawMessage `json:"updates"`
+	}
+
+	err := json.Unmarshal(v, &diff)
+	if err != nil {
+		return err
+	}
+
+	*l = LazyStorageDiffDiff(diff.lazyStorageDiffDiff)
+	if len(diff.Updates) > 0 && diff.Updates[0] == '[' {
+		return json.Unmarshal(diff.Updates, &l.Updates)
+	}
+
+	return nil
+}
+
+/*
+MarshalJSON Function
+Description: Implements the json.Marshaler interface for LazyStorageDiffDiff.
+*/
+func (l LazyStorageDiffDiff) MarshalJSON() ([]byte, error) {
+	type lazyStorageDiffDiff LazyStorageDiffDiff
+	return json.Marshal(struct {
+		lazyStorageDiffDiff
+		Updates []LazyStorageDiffUpdate `json:"updates,omitempty"`
+	}{lazyStorageDiffDiff(l), l.Updates})
+}
+
+/*
+BigMapUpdate -
+Description: A normalized big map change, decoded from either the big_map_diff or the lazy_storage_diff
+of an operation result.
+*/
+type BigMapUpdate struct {
+	// The hash of the operation that made the change.
+	OperationHash string
+	// The action, see the BigMapAction constants.
+	Action string
+	// The ID of the big map changed. Negative IDs are temporary big maps, only alive during the operation.
+	BigMap string
+	// The ID of the big map copied from (copy only).
+	SourceBigMap string
+	// The hash, key and new value of the key updated (update only). Value is nil if the key was removed.
+	KeyHash string
+	Key     *Micheline
+	Value   *Micheline
+	// The types of the keys and values of the big map (alloc only).
+	KeyType   *Micheline
+	ValueType *Micheline
+}
+
+/*
+BigMapUpdates Function
+Description: Returns the big map changes of the applied operations of the block, including internal
+operations, in order.
+*/
+func (b *Block) BigMapUpdates() []BigMapUpdate {
+	updates := []BigMapUpdate{}
+	for _, pass := range b.Operations {
+		for _, operation := range pass {
+			updates = append(updates, operation.BigMapUpdates()...)
+		}
+	}
+
+	return updates
+}
+
+/*
+BigMapUpdates Function
+Description: Returns the big map changes of the operation, including its internal operations, in order.
+Changes of results that were not applied (failed, backtracked or skipped) are not returned.
+*/
+func (o *Operations) BigMapUpdates() []BigMapUpdate {
+	updates := []BigMapUpdate{}
+	for _, contents := range o.Contents {
+		if contents.Metadata == nil {
+			continue
+		}
+
+		if contents.Metadata.OperationResult != nil {
+			updates = append(updates, contents.Metadata.OperationResult.bigMapUpdates(o.Hash)...)
+		}
+
+		for _, internal := range contents.Metadata.InternalOperationResults {
+			updates = append(updates, internal.Result.bigMapUpdates(o.Hash)...)
+		}
+	}
+
+	return updates
+}
+
+func (r *OperationResult) bigMapUpdates(hash string) []BigMapUpdate {
+	updates := []BigMapUpdate{}
+	if r.Status != APPLIEDSTATUS {
+		return updates
+	}
+
+	// Results of Edo and later protocols may carry both diffs; the lazy storage diff is the complete one.
+	if r.LazyStorageDiff != nil {
+		for _, diff := range r.LazyStorageDiff {
+			if diff.Kind != LazyStorageKindBigMap {
+				continue
+			}
+
+			// An update diff is only the container of its key updates.
+			if diff.Diff.Action != BigMapActionUpdate {
+				updates = append(updates, BigMapUpdate{
+					OperationHash: hash,
+					Action:        diff.Diff.Action,
+					BigMap:        diff.ID,
+					SourceBigMap:  diff.Diff.Source,
+					KeyType:       diff.Diff.KeyType,
+					ValueType:     diff.Diff.ValueType,
+				})
+			}
+
+			for _, u := range diff.Diff.Updates {
+				updates = append(updates, BigMapUpdate{
+					OperationHash: hash,
+					Action:        BigMapActionUpdate,
+					BigMap:        diff.ID,
+					KeyHash:       u.KeyHash,
+					Key:           u.Key,
+					Value:         u.Value,
+				})
+			}
+		}
+
+		return updates
+	}
+
+	for _, diff := range r.BigMapDiff {
+		update := BigMapUpdate{
+			OperationHash: hash,
+			Action:        diff.Action,
+			BigMap:        diff.BigMap,
+			KeyHash:       diff.KeyHash,
+			Key:           diff.Key,
+			Value:         diff.Value,
+			KeyType:       diff.KeyType,
+			ValueType:     diff.ValueType,
+		}
+		if diff.Action == BigMapActionCopy {
+			update.BigMap, update.SourceBigMap = diff.DestinationBigMap, diff.SourceBigMap
+		}
+
+		updates = append(updates, update)
+	}
+
+	return updates
+}
+
+/*
+BigMapUpdatesByID Function
+Description: Groups big map changes by the ID of the big map changed, keeping their order.
+
+Parameters:
+	updates:
+		The big map changes to group, e.g. the result of Block.BigMapUpdates.
+*/
+func BigMapUpdatesByID(updates []BigMapUpdate) map[string][]BigMapUpdate {
+	byID := map[string][]BigMapUpdate{}
+	for _, update := range updates {
+		byID[update.BigMap] = append(byID[update.BigMap], update)
+	}
+
+	return byID
+}
+
+/*
+BigMapUpdates Function
+Description: Returns the big map changes of every block from start to end (inclusive), in order.
+
+Parameters:
+	start:
+		The level of the first block.
+
+	end:
+		The level of the last block.
+*/
+func (t *GoTezos) BigMapUpdates(start, end int) ([]BigMapUpdate, error) {
+	if start > end {
+		return nil, errors.New("invalid block range: start is after end")
+	}
+
+	updates := []BigMapUpdate{}
+	for level := start; level <= end; level++ {
+		block, err := t.Block(BlockIDLevel(level))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get big map updates of block %d", level)
+		}
+
+		updates = append(updates, block.BigMapUpdates()...)
+	}
+
+	return updates, nil
+}
+
+/*
+BigMapEntry -
+Description: A key of a big map and its value. The node lists the values of big maps only, KeyHash and Key
+are empty for the entries it lists.
+*/
+type BigMapEntry struct {
+	KeyHash string
+	Key     *Micheline
+	Value   Micheline
+}
+
+/*
+BigMapLister -
+Description: A backend listing the entries of a big map page by page, which BigMapIterator falls back on when
+the node does not list big maps. indexer.TzKT is a BigMapLister.
+*/
+type BigMapLister interface {
+	BigMapEntries(bigMap string, offset, limit int) ([]BigMapEntry, error)
+}
+
+/*
+BigMapValuesInput -
+Description: The input for the BigMapValues function.
+Function: func (t *GoTezos) BigMapValues(input *BigMapValuesInput) ([]Micheline, error) {}
+*/
+type BigMapValuesInput struct {
+	// The block (hash, level, head or head~<n>) of which you want to make the query.
+	// Required.
+	BlockID BlockID `validate:"required"`
+
+	// The ID of the big map.
+	// Required.
+	BigMap string `validate:"required"`
+
+	// The number of values to skip.
+	Offset int
+
+	// The maximum number of values to return. If zero, all the values are returned.
+	Length int
+}
+
+/*
+BigMapValues RPC
+Path: ../<block_id>/context/big_maps/<big_map_id> (GET)
+Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-big-maps-big-map-id
+Description: Returns the values of a big map, optionally paginated. The order of the values is unspecified but
+consistent between calls. Older nodes do not support listing big maps.
+
+Parameters:
+	input:
+		Modifies the BigMapValues RPC query. BlockID and BigMap are required.
+*/
+func (t *GoTezos) BigMapValues(input *BigMapValuesInput) ([]Micheline, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	var opts []RPCOption
+	if input.Offset > 0 {
+		opts = append(opts, RPCOption{
+			Key:   "offset",
+			Value: strconv.Itoa(input.Offset),
+		})
+	}
+	if input.Length > 0 {
+		opts = append(opts, RPCOption{
+			Key:   "length",
+			Value: strconv.Itoa(input.Length),
+		})
+	}
+
+	// The values are decoded one at a time as they are read, so the response is never held whole.
+	values := []Micheline{}
+	decode := func(d *json.Decoder) error {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			return errors.Errorf("unexpected token %v", token)
+		}
+
+		for d.More() {
+			var value Micheline
+			err := d.Decode(&value)
+			if err != nil {
+				return err
+			}
+			values = append(values, value)
+		}
+
+		_, err = d.Token()
+		return err
+	}
+
+	err = t.getDecoded(fmt.Sprintf("/chains/main/blocks/%s/context/big_maps/%s", input.BlockID.ID(), input.BigMap), decode, opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get values of big map '%s'", input.BigMap)
+	}
+
+	return values, nil
+}
+
+/*
+BigMapIteratorInput -
+Description: The input for the BigMapIterator function.
+Function: func (t *GoTezos) BigMapIterator(input *BigMapIteratorInput) (*BigMapIterator, error) {}
+*/
+type BigMapIteratorInput struct {
+	// The block (hash, level, head or head~<n>) of which you want to list the big map.
+	// Required.
+	BlockID BlockID `validate:"required"`
+
+	// The ID of the big map.
+	// Required.
+	BigMap string `validate:"required"`
+
+	// The number of entries requested at a time. Defaults to DefaultBigMapPageSize.
+	PageSize int
+
+	// The backend listing the big map if the node does not, e.g. an indexer. Indexers list the big map at
+	// their own head rather than at BlockID.
+	Fallback BigMapLister
+}
+
+// DefaultBigMapPageSize is the number of big map entries BigMapIterator requests at a time by default.
+const DefaultBigMapPageSize = 100
+
+/*
+BigMapIterator -
+Description: Walks all the entries of a big map, requesting them page by page from the node, or from the
+fallback backend if the node does not list big maps.
+
+	it, err := gt.BigMapIterator(&gotezos.BigMapIteratorInput{BlockID: gotezos.BlockIDHead{}, BigMap: "511"})
+	if err != nil {
+		return err
+	}
+
+	for it.Next() {
+		fmt.Println(it.Value().Value.CompactMichelson())
+	}
+	return it.Err()
+*/
+type BigMapIterator struct {
+	list     func(offset, limit int) ([]BigMapEntry, error)
+	fallback BigMapLister
+	bigMap   string
+	pageSize int
+	offset   int
+	page     []BigMapEntry
+	value    BigMapEntry
+	err      error
+	last     bool
+	done     bool
+}
+
+/*
+BigMapIterator Function
+Description: Returns an iterator over the entries of a big map. No request is made until Next is called.
+
+Parameters:
+	input:
+		The big map to list. BlockID and BigMap are required.
+*/
+func (t *GoTezos) BigMapIterator(input *BigMapIteratorInput) (*BigMapIterator, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	pageSize := input.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultBigMapPageSize
+	}
+
+	blockID := input.BlockID
+	return &BigMapIterator{
+		list: func(offset, limit int) ([]BigMapEntry, error) {
+			values, err := t.BigMapValues(&BigMapValuesInput{BlockID: blockID, BigMap: input.BigMap, Offset: offset, Length: limit})
+			if err != nil {
+				return nil, err
+			}
+
+			entries := make([]BigMapEntry, len(values))
+			for i := range values {
+				entries[i].Value = values[i]
+			}
+			return entries, nil
+		},
+		fallback: input.Fallback,
+		bigMap:   input.BigMap,
+		pageSize: pageSize,
+	}, nil
+}
+
+/*
+Next Function
+Description: Advances the iterator to the next entry, requesting the next page when needed. Returns false
+once the big map is exhausted or an error occurred, see Err.
+*/
+func (i *BigMapIterator) Next() bool {
+	if i.done {
+		return false
+	}
+
+	if len(i.page) == 0 {
+		if i.last {
+			i.done = true
+			return false
+		}
+
+		page, err := i.list(i.offset, i.pageSize)
+		// The first page tells whether the node lists big maps at all.
+		if err != nil && i.offset == 0 && i.fallback != nil {
+			i.list = func(offset, limit int) ([]BigMapEntry, error) {
+				return i.fallback.BigMapEntries(i.bigMap, offset, limit)
+			}
+			page, err = i.list(i.offset, i.pageSize)
+		}
+		if err != nil {
+			i.err = errors.Wrapf(err, "failed to list big map '%s'", i.bigMap)
+			i.done = true
+			return false
+		}
+
+		i.offset += len(page)
+		i.last = len(page) < i.pageSize
+		i.page = page
+		if len(page) == 0 {
+			i.done = true
+			return false
+		}
+	}
+
+	i.value, i.page = i.page[0], i.page[1:]
+	return true
+}
+
+/*
+Value Function
+Description: Returns the entry the iterator is at.
+*/
+func (i *BigMapIterator) Value() BigMapEntry {
+	return i.value
+}
+
+/*
+Err Function
+Description: Returns the error that stopped the iterator, if any.
+*/
+func (i *BigMapIterator) Err() error {
+	return i.err
+}
+
+// bigMapKeysParallelism is the maximum number of big map requests BigMapKeys makes at once.
+const bigMapKeysParallelism = 10
+
+/*
+BigMapKey -
+Description: A key looked up by BigMapKeys.
+*/
+type BigMapKey struct {
+	// The key, as given.
+	Key Micheline
+	// The expr hash the key is stored under, see BigMapKeyHash.
+	KeyHash string
+	// Whether the big map has the key.
+	Found bool
+	// The value of the key, nil if not found.
+	Value *Micheline
+}
+
+/*
+BigMapKeys RPC
+Path: ../<block_id>/context/big_maps/<big_map_id>/<script_expr> (GET)
+Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-big-maps-big-map-id-script-expr
+Description: Looks up keys of a big map. The keys are hashed locally and their values requested at most
+bigMapKeysParallelism at a time. Returns the keys in the order given, along with whether the big map has them.
+Fails on the first request that failed for another reason than the key not being found.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+	bigMap:
+		The ID of the big map.
+	keyType:
+		The key type of the big map, needed to pack the keys.
+	keys:
+		The keys, in readable or optimized form.
+*/
+func (t *GoTezos) BigMapKeys(blockID BlockID, bigMap string, keyType Micheline, keys ...Micheline) ([]BigMapKey, error) {
+	results := make([]BigMapKey, len(keys))
+	for i := range keys {
+		hash, err := BigMapKeyHash(keyType, keys[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get keys of big map '%s'", bigMap)
+		}
+		results[i] = BigMapKey{Key: keys[i], KeyHash: hash}
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	sem := make(chan struct{}, bigMapKeysParallelism)
+
+	for i := range results {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(result *BigMapKey) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			value, err := t.bigMapValue(blockID, bigMap, result.KeyHash)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = errors.Wrapf(err, "failed to get key '%s' of big map '%s'", result.KeyHash, bigMap)
+				}
+				return
+			}
+			result.Found, result.Value = value != nil, value
+		}(&results[i])
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return results, nil
+}
+
+// bigMapValue returns the value of the key of a big map with the expr hash keyHash, or nil if there is none.
+func (t *GoTezos) bigMapValue(blockID BlockID, bigMap, keyHash string) (*Micheline, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/big_maps/%s/%s", blockID.ID(), bigMap, keyHash))
+	if requestErr, ok := AsRequestError(err); ok && requestErr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var value Micheline
+	err = json.Unmarshal(resp, &value)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal value")
+	}
+
+	return &value, nil
+}
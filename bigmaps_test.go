@@ -0,0 +1,399 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var mockBigMapUpdatesBlockResp = []byte(`{
+	"protocol": "PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA",
+	"hash": "BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1",
+	"header": {"level": 1300000},
+	"operations": [[], [], [], [{
+		"hash": "opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A",
+		"contents": [{
+			"kind": "transaction",
+			"metadata": {
+				"balance_updates": [],
+				"operation_result": {
+					"status": "applied",
+					"big_map_diff": [{"action": "update", "big_map": "17", "key_hash": "exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC", "key": {"int": "1"}}],
+					"lazy_storage_diff": [
+						{"kind": "big_map", "id": "17", "diff": {"action": "update", "updates": [{"key_hash": "exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC", "key": {"int": "1"}}]}},
+						{"kind": "sapling_state", "id": "3", "diff": {"action": "update", "updates": {"commitments_and_ciphertexts": [], "nullifiers": []}}}
+					]
+				},
+				"internal_operation_results": [
+					{
+						"kind": "origination",
+						"source": "KT1TxqZ8QtKvLu3V3JH7Gx58n7Co8pgtpQU5",
+						"nonce": 0,
+						"result": {
+							"status": "applied",
+							"big_map_diff": [
+								{"action": "alloc", "big_map": "18", "key_type": {"prim": "nat"}, "value_type": {"prim": "string"}},
+								{"action": "copy", "source_big_map": "17", "destination_big_map": "19"},
+								{"action": "update", "big_map": "18", "key_hash": "exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC", "key": {"int": "1"}, "value": {"string": "one"}},
+								{"action": "remove", "big_map": "16"}
+							]
+						}
+					},
+					{
+						"kind": "transaction",
+						"source": "KT1TxqZ8QtKvLu3V3JH7Gx58n7Co8pgtpQU5",
+						"nonce": 1,
+						"result": {
+							"status": "backtracked",
+							"lazy_storage_diff": [{"kind": "big_map", "id": "18", "diff": {"action": "remove"}}]
+						}
+					}
+				]
+			}
+		}]
+	}]]
+}`)
+
+func Test_BlockBigMapUpdates(t *testing.T) {
+	var block Block
+	err := json.Unmarshal(mockBigMapUpdatesBlockResp, &block)
+	assert.Nil(t, err)
+
+	updates := block.BigMapUpdates()
+	assert.Len(t, updates, 5)
+
+	assert.Equal(t, BigMapActionUpdate, updates[0].Action)
+	assert.Equal(t, "17", updates[0].BigMap)
+	assert.Equal(t, "1", updates[0].Key.Int.String())
+	assert.Nil(t, updates[0].Value)
+
+	assert.Equal(t, BigMapUpdate{OperationHash: "opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A", Action: BigMapActionAlloc, BigMap: "18", KeyType: &Micheline{Kind: MichelineKindPrim, Prim: "nat"}, ValueType: &Micheline{Kind: MichelineKindPrim, Prim: "string"}}, updates[1])
+	assert.Equal(t, BigMapUpdate{OperationHash: "opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A", Action: BigMapActionCopy, BigMap: "19", SourceBigMap: "17"}, updates[2])
+	assert.Equal(t, "one", updates[3].Value.String)
+	assert.Equal(t, BigMapActionRemove, updates[4].Action)
+
+	byID := BigMapUpdatesByID(updates)
+	assert.Len(t, byID, 4)
+	assert.Len(t, byID["17"], 1)
+	assert.Len(t, byID["18"], 2)
+}
+
+func Test_BigMapUpdates(t *testing.T) {
+	type want struct {
+		err         bool
+		containsErr string
+		updates     int
+	}
+
+	cases := []struct {
+		name        string
+		inputHanler http.Handler
+		start, end  int
+		want
+	}{
+		{
+			"handles failure to get block",
+			gtGoldenHTTPMock(newBlockMock().handler(mockRPCErrorResp, blankHandler)),
+			1300000,
+			1300001,
+			want{true, "failed to get big map updates of block 1300000", 0},
+		},
+		{
+			"handles invalid range",
+			gtGoldenHTTPMock(blankHandler),
+			1300001,
+			1300000,
+			want{true, "invalid block range", 0},
+		},
+		{
+			"is successful",
+			gtGoldenHTTPMock(newBlockMock().handler(mockBigMapUpdatesBlockResp, newBlockMock().handler(mockBigMapUpdatesBlockResp, blankHandler))),
+			1300000,
+			1300001,
+			want{false, "", 10},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.inputHanler)
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			updates, err := gt.BigMapUpdates(tt.start, tt.end)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			assert.Len(t, updates, tt.want.updates)
+		})
+	}
+}
+
+// bigMapHandlerMock serves the values of a big map of size values, paginated with offset and length.
+func bigMapHandlerMock(size int, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chains/main/blocks/head/context/big_maps/511" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		length, err := strconv.Atoi(r.URL.Query().Get("length"))
+		if err != nil {
+			length = size
+		}
+
+		values := []Micheline{}
+		for i := offset; i < size && i < offset+length; i++ {
+			values = append(values, NewMichelineInt(int64(i)))
+		}
+		json.NewEncoder(w).Encode(values)
+	})
+}
+
+type bigMapListerMock struct {
+	size     int
+	requests int
+}
+
+func (b *bigMapListerMock) BigMapEntries(bigMap string, offset, limit int) ([]BigMapEntry, error) {
+	b.requests++
+	entries := []BigMapEntry{}
+	for i := offset; i < b.size && i < offset+limit; i++ {
+		key := NewMichelineString(strconv.Itoa(i))
+		entries = append(entries, BigMapEntry{KeyHash: "expr" + bigMap, Key: &key, Value: NewMichelineInt(int64(i))})
+	}
+	return entries, nil
+}
+
+func Test_BigMapValues(t *testing.T) {
+	type want struct {
+		err         bool
+		containsErr string
+		values      []Micheline
+	}
+
+	cases := []struct {
+		name        string
+		inputHanler http.Handler
+		input       BigMapValuesInput
+		want
+	}{
+		{
+			"returns invalid input",
+			gtGoldenHTTPMock(blankHandler),
+			BigMapValuesInput{BlockID: BlockIDHead{}},
+			want{true, "invalid input", nil},
+		},
+		{
+			"handles rpc error",
+			gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write(mockRPCErrorResp)
+			})),
+			BigMapValuesInput{BlockID: BlockIDHead{}, BigMap: "511"},
+			want{true, "failed to get values of big map '511': GET /chains/main/blocks/head/context/big_maps/511: rpc error (somekind): someerror", nil},
+		},
+		{
+			"is successful",
+			gtGoldenHTTPMock(bigMapHandlerMock(5, blankHandler)),
+			BigMapValuesInput{BlockID: BlockIDHead{}, BigMap: "511", Offset: 3, Length: 10},
+			want{false, "", []Micheline{NewMichelineInt(3), NewMichelineInt(4)}},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.inputHanler)
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			values, err := gt.BigMapValues(&tt.input)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			assert.Equal(t, tt.want.values, values)
+		})
+	}
+}
+
+func Test_BigMapIterator(t *testing.T) {
+	type want struct {
+		err         bool
+		containsErr string
+		values      int
+		keys        bool
+	}
+
+	cases := []struct {
+		name        string
+		inputHanler http.Handler
+		fallback    *bigMapListerMock
+		want
+	}{
+		{
+			"lists the big map from the node",
+			gtGoldenHTTPMock(bigMapHandlerMock(7, blankHandler)),
+			&bigMapListerMock{size: 3},
+			want{false, "", 7, false},
+		},
+		{
+			"lists an empty big map",
+			gtGoldenHTTPMock(bigMapHandlerMock(0, blankHandler)),
+			nil,
+			want{false, "", 0, false},
+		},
+		{
+			"falls back when the node does not list big maps",
+			gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			})),
+			&bigMapListerMock{size: 6},
+			want{false, "", 6, true},
+		},
+		{
+			"handles failure without fallback",
+			gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			})),
+			nil,
+			want{true, "failed to list big map '511'", 0, false},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.inputHanler)
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			input := &BigMapIteratorInput{BlockID: BlockIDHead{}, BigMap: "511", PageSize: 3}
+			if tt.fallback != nil {
+				input.Fallback = tt.fallback
+			}
+
+			it, err := gt.BigMapIterator(input)
+			assert.Nil(t, err)
+
+			var values int
+			for it.Next() {
+				assert.Equal(t, int64(values), it.Value().Value.Int.Int64())
+				assert.Equal(t, tt.want.keys, it.Value().Key != nil)
+				values++
+			}
+			checkErr(t, tt.want.err, tt.want.containsErr, it.Err())
+			assert.Equal(t, tt.want.values, values)
+			assert.False(t, it.Next())
+		})
+	}
+
+	_, err := testGoTezos(t, gtGoldenHTTPMock(blankHandler)).BigMapIterator(&BigMapIteratorInput{BigMap: "511"})
+	checkErr(t, true, "invalid input", err)
+}
+
+// bigMapKeysHandlerMock serves the values of the keys of big map 511 by their hash, and fails for the hash fail.
+func bigMapKeysHandlerMock(values map[string]Micheline, fail string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const path = "/chains/main/blocks/head/context/big_maps/511/"
+		if !strings.HasPrefix(r.URL.Path, path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		hash := strings.TrimPrefix(r.URL.Path, path)
+		value, ok := values[hash]
+		switch {
+		case hash == fail:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write(mockRPCErrorResp)
+		case !ok:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			json.NewEncoder(w).Encode(value)
+		}
+	})
+}
+
+func Test_BigMapKeys(t *testing.T) {
+	keyType := NewMichelinePrim("address")
+	keys := []Micheline{
+		NewMichelineString("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"),
+		NewMichelineString("tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"),
+		NewMichelineString("KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"),
+	}
+
+	hashes := make([]string, len(keys))
+	for i := range keys {
+		hash, err := BigMapKeyHash(keyType, keys[i])
+		assert.Nil(t, err)
+		hashes[i] = hash
+	}
+	values := map[string]Micheline{
+		hashes[0]: NewMichelineInt(10),
+		hashes[2]: NewMichelineInt(3),
+	}
+
+	type want struct {
+		err         bool
+		containsErr string
+		found       []bool
+	}
+
+	cases := []struct {
+		name        string
+		inputHanler http.Handler
+		keyType     Micheline
+		want
+	}{
+		{
+			"is successful",
+			gtGoldenHTTPMock(bigMapKeysHandlerMock(values, "", blankHandler)),
+			keyType,
+			want{false, "", []bool{true, false, true}},
+		},
+		{
+			"handles failure",
+			gtGoldenHTTPMock(bigMapKeysHandlerMock(values, hashes[1], blankHandler)),
+			keyType,
+			want{true, "failed to get key '" + hashes[1] + "' of big map '511'", nil},
+		},
+		{
+			"handles key of another type",
+			gtGoldenHTTPMock(bigMapKeysHandlerMock(values, "", blankHandler)),
+			NewMichelinePrim("key_hash"),
+			want{true, "failed to hash big map key", nil},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.inputHanler)
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			results, err := gt.BigMapKeys(BlockIDHead{}, "511", tt.keyType, keys...)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			if tt.want.found == nil {
+				assert.Nil(t, results)
+				return
+			}
+
+			assert.Len(t, results, len(keys))
+			for i := range results {
+				assert.Equal(t, keys[i], results[i].Key)
+				assert.Equal(t, hashes[i], results[i].KeyHash)
+				assert.Equal(t, tt.want.found[i], results[i].Found)
+				assert.Equal(t, tt.want.found[i], results[i].Value != nil)
+			}
+			assert.Equal(t, "10", results[0].Value.Int.String())
+		})
+	}
+}
@@ -0,0 +1,1191 @@
+package gotezos
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+/*
+BinarySchema -
+Description: The binary layout of a data encoding of the node, as described by the describe RPC (the
+binary_schema of the input or output of an RPC) or by `octez-codec describe <encoding> binary schema`.
+It encodes and decodes values to and from their JSON form, so that operations and block headers of kinds
+and protocols without a hand written codec (see ForgeOperation) can still be forged and unforged.
+
+Keys, key hashes, addresses, Micheline expressions, entrypoints, numbers and timestamps are converted to
+and from their JSON form by the name of their encoding. Fixed size bytes are base58 encoded when the name
+of their field or encoding is a known hash (e.g. branch, block_payload_hash, context), other bytes are hex.
+*/
+type BinarySchema struct {
+	toplevel schemaDescription
+	fields   map[string]schemaDescription
+}
+
+// schemaDescription is an object, a union (Cases) or an enumeration (Int_enum) of a binary schema.
+type schemaDescription struct {
+	Kind    string           `json:"kind"`
+	Fields  []schemaField    `json:"fields"`
+	TagSize string           `json:"tag_size"`
+	Size    string           `json:"size"`
+	Cases   []schemaCase     `json:"-"`
+	Enum    map[int64]string `json:"-"`
+}
+
+type schemaCase struct {
+	Tag    int64         `json:"tag"`
+	Name   string        `json:"name"`
+	Fields []schemaField `json:"fields"`
+}
+
+// schemaField is a named or anonymous (anon) field, the length prefix of the next fields (dyn) or the
+// presence byte of the next field (option_indicator).
+type schemaField struct {
+	Kind      string         `json:"kind"`
+	Name      string         `json:"name"`
+	Layout    *schemaLayout  `json:"layout"`
+	DataKind  schemaDataKind `json:"data_kind"`
+	NumFields int            `json:"num_fields"`
+	Size      string         `json:"size"`
+}
+
+type schemaLayout struct {
+	Kind      string        `json:"kind"`
+	Size      string        `json:"size"`
+	Name      string        `json:"name"`
+	Reference string        `json:"reference"`
+	Layout    *schemaLayout `json:"layout"`
+	MaxLength *int          `json:"max_length"`
+	Min       json.Number   `json:"min"`
+	Max       json.Number   `json:"max"`
+}
+
+// schemaDataKind is the size of a field: Fixed, Dynamic (self delimited or length prefixed) or Variable (the
+// rest of the enclosing data).
+type schemaDataKind struct {
+	Kind string `json:"kind"`
+	Size int    `json:"size"`
+}
+
+var schemaInts = map[string]struct {
+	size     int
+	min, max int64
+}{
+	"Uint8":  {1, 0, math.MaxUint8},
+	"Int8":   {1, math.MinInt8, math.MaxInt8},
+	"Uint16": {2, 0, math.MaxUint16},
+	"Int16":  {2, math.MinInt16, math.MaxInt16},
+	"Uint30": {4, 0, 1<<30 - 1},
+	"Int31":  {4, -1 << 30, 1<<30 - 1},
+	"Int32":  {4, math.MinInt32, math.MaxInt32},
+	"Int64":  {8, math.MinInt64, math.MaxInt64},
+}
+
+// schemaBase58 are the prefixes of the hashes encoded as fixed size bytes, by the name of their field or
+// encoding.
+var schemaBase58 = map[string]prefix{
+	"block_hash":                   prefix_branch,
+	"branch":                       prefix_branch,
+	"predecessor":                  prefix_branch,
+	"value_hash":                   prefix_vh,
+	"block_payload_hash":           prefix_vh,
+	"payload_hash":                 prefix_vh,
+	"operation_list_list_hash":     prefix_llo,
+	"operations_hash":              prefix_llo,
+	"context_hash":                 prefix_co,
+	"context":                      prefix_co,
+	"chain_id":                     prefix_chain_id,
+	"operation_hash":               prefix_o,
+	"script_expr":                  prefix_expr,
+	"smart_rollup_address":         prefix_sr1,
+	"rollup":                       prefix_sr1,
+	"smart_rollup_commitment_hash": prefix_src1,
+	"smart_rollup_state_hash":      prefix_srs1,
+}
+
+// schemaMicheline are the fields holding a Micheline expression encoded as bytes (lazy expressions).
+var schemaMicheline = map[string]bool{
+	"value":         true,
+	"code":          true,
+	"storage":       true,
+	"parameters_ty": true,
+}
+
+// schemaCodec converts an encoding whose JSON form differs from its binary layout, e.g. a key hash that is
+// a union in binary and a base58 string in JSON.
+type schemaCodec struct {
+	encode func(v interface{}) ([]byte, error)
+	decode func(data []byte) (interface{}, int, error)
+}
+
+// schemaCodecs are keyed by the name of the encoding, without the protocol prefix (e.g. 020-PsParisC.).
+var schemaCodecs = map[string]schemaCodec{
+	"public_key_hash": {
+		encode: func(v interface{}) ([]byte, error) {
+			return keyHashToBytes(fmt.Sprint(v))
+		},
+		decode: func(data []byte) (interface{}, int, error) {
+			if len(data) < 21 {
+				return nil, 0, errors.New("unexpected end of data")
+			}
+			v, err := bytesToKeyHash(data[:21])
+			return v, 21, err
+		},
+	},
+	"public_key": {
+		encode: func(v interface{}) ([]byte, error) {
+			return publicKeyToBytes(fmt.Sprint(v))
+		},
+		decode: func(data []byte) (interface{}, int, error) {
+			length := 34
+			if len(data) > 0 && data[0] == 0 {
+				length = 33
+			}
+			if len(data) < length {
+				return nil, 0, errors.New("unexpected end of data")
+			}
+			v, err := bytesToPublicKey(data[:length])
+			return v, length, err
+		},
+	},
+	"contract_id": {
+		encode: func(v interface{}) ([]byte, error) {
+			return addressToBytes(fmt.Sprint(v))
+		},
+		decode: func(data []byte) (interface{}, int, error) {
+			if len(data) < 22 {
+				return nil, 0, errors.New("unexpected end of data")
+			}
+			v, err := bytesToAddress(data[:22])
+			return v, 22, err
+		},
+	},
+	"michelson_v1.expression": {
+		encode: encodeSchemaMicheline,
+		decode: func(data []byte) (interface{}, int, error) {
+			node, n, err := decodeMicheline(data)
+			if err != nil {
+				return nil, 0, err
+			}
+			v, err := toSchemaValue(node)
+			return v, n, err
+		},
+	},
+	"entrypoint": {
+		encode: func(v interface{}) ([]byte, error) {
+			entrypoint := fmt.Sprint(v)
+			if tag, ok := entrypointTags[entrypoint]; ok {
+				return hex.DecodeString(tag)
+			}
+			if len(entrypoint) > 31 {
+				return nil, errors.Errorf("entrypoint '%s' is too long", entrypoint)
+			}
+			return append([]byte{0xff, byte(len(entrypoint))}, entrypoint...), nil
+		},
+		decode: func(data []byte) (interface{}, int, error) {
+			if len(data) < 1 {
+				return nil, 0, errors.New("unexpected end of data")
+			}
+			if data[0] != 0xff {
+				for name, tag := range entrypointTags {
+					if tag == fmt.Sprintf("%02x", data[0]) {
+						return name, 1, nil
+					}
+				}
+				return nil, 0, errors.Errorf("unknown entrypoint tag %02x", data[0])
+			}
+			if len(data) < 2 || len(data) < 2+int(data[1]) {
+				return nil, 0, errors.New("unexpected end of data")
+			}
+			return string(data[2 : 2+int(data[1])]), 2 + int(data[1]), nil
+		},
+	},
+	"N.t": {
+		encode: func(v interface{}) ([]byte, error) {
+			i, err := schemaBigInt(v)
+			if err != nil {
+				return nil, err
+			}
+			if i.Sign() < 0 {
+				return nil, errors.Errorf("negative natural number %s", i)
+			}
+			return encodeSchemaZarith(i), nil
+		},
+		decode: func(data []byte) (interface{}, int, error) {
+			i, n, err := decodeSchemaZarith(data)
+			if err != nil {
+				return nil, 0, err
+			}
+			return i.String(), n, nil
+		},
+	},
+	"Z.t": {
+		encode: func(v interface{}) ([]byte, error) {
+			i, err := schemaBigInt(v)
+			if err != nil {
+				return nil, err
+			}
+			return encodeSignedZarith(i), nil
+		},
+		decode: func(data []byte) (interface{}, int, error) {
+			i, n, err := decodeSignedZarith(data)
+			if err != nil {
+				return nil, 0, err
+			}
+			return i.String(), n, nil
+		},
+	},
+	"timestamp.protocol": {
+		encode: encodeSchemaTimestamp,
+		decode: decodeSchemaTimestamp,
+	},
+	"timestamp.system": {
+		encode: encodeSchemaTimestamp,
+		decode: decodeSchemaTimestamp,
+	},
+}
+
+/*
+NewBinarySchema Function
+Description: Parses a binary schema, e.g. one embedded in an application, as output by
+`octez-codec describe <encoding> binary schema` or found in the binary_schema of the describe RPC.
+
+Parameters:
+	schema:
+		The binary schema, a JSON object with the toplevel description and the described fields.
+*/
+func NewBinarySchema(schema []byte) (*BinarySchema, error) {
+	var raw struct {
+		Toplevel *schemaDescription `json:"toplevel"`
+		Fields   []struct {
+			Description struct {
+				Title string `json:"title"`
+			} `json:"description"`
+			Encoding schemaDescription `json:"encoding"`
+		} `json:"fields"`
+	}
+	err := json.Unmarshal(schema, &raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal binary schema")
+	}
+	if raw.Toplevel == nil {
+		return nil, errors.New("could not parse binary schema: missing toplevel")
+	}
+
+	s := &BinarySchema{toplevel: *raw.Toplevel, fields: map[string]schemaDescription{}}
+	for _, field := range raw.Fields {
+		s.fields[field.Description.Title] = field.Encoding
+	}
+
+	return s, nil
+}
+
+// UnmarshalJSON parses the cases of a union or of an enumeration.
+func (d *schemaDescription) UnmarshalJSON(b []byte) error {
+	type description schemaDescription
+	var raw struct {
+		*description
+		Cases json.RawMessage `json:"cases"`
+	}
+	raw.description = (*description)(d)
+
+	err := json.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	}
+
+	switch d.Kind {
+	case "Cases":
+		return json.Unmarshal(raw.Cases, &d.Cases)
+	case "Int_enum":
+		var cases [][2]interface{}
+		err = json.Unmarshal(raw.Cases, &cases)
+		if err != nil {
+			return err
+		}
+		d.Enum = map[int64]string{}
+		for _, c := range cases {
+			tag, ok := c[0].(float64)
+			name, ok2 := c[1].(string)
+			if !ok || !ok2 {
+				return errors.Errorf("invalid enum case %v", c)
+			}
+			d.Enum[int64(tag)] = name
+		}
+	}
+
+	return nil
+}
+
+/*
+BinarySchema RPC
+Path: /describe/<path> (GET)
+Link: https://tezos.gitlab.io/api/rpc.html#get-describe-path
+Description: Returns the binary schema of the input or the output of an RPC.
+
+Parameters:
+	method:
+		The method of the RPC (e.g. GET or POST).
+	path:
+		The path of the RPC (e.g. /chains/main/blocks/head/helpers/forge/operations).
+	output:
+		Whether to return the schema of the output rather than of the input of the RPC.
+*/
+func (t *GoTezos) BinarySchema(method, path string, output bool) (*BinarySchema, error) {
+	resp, err := t.get(fmt.Sprintf("/describe%s", path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not describe '%s'", path)
+	}
+
+	type schema struct {
+		BinarySchema json.RawMessage `json:"binary_schema"`
+	}
+	var directory struct {
+		Static map[string]struct {
+			Input  *schema `json:"input"`
+			Output *schema `json:"output"`
+		} `json:"static"`
+	}
+	err = json.Unmarshal(resp, &directory)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal description of '%s'", path)
+	}
+
+	service, ok := directory.Static[fmt.Sprintf("%s_service", strings.ToLower(method))]
+	if !ok {
+		return nil, errors.Errorf("could not find %s service of '%s'", method, path)
+	}
+
+	s, direction := service.Input, "input"
+	if output {
+		s, direction = service.Output, "output"
+	}
+	if s == nil || len(s.BinarySchema) == 0 {
+		return nil, errors.Errorf("could not find binary schema of the %s of '%s'", direction, path)
+	}
+
+	binarySchema, err := NewBinarySchema(s.BinarySchema)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse binary schema of '%s'", path)
+	}
+
+	return binarySchema, nil
+}
+
+/*
+OperationSchema RPC
+Path: /describe/chains/main/blocks/head/helpers/forge/operations (GET)
+Link: https://tezos.gitlab.io/api/rpc.html#get-describe-path
+Description: Returns the binary schema of unsigned operations (branch and contents) of the protocol of the
+head, see BinarySchema.ForgeOperation and BinarySchema.UnforgeOperation.
+*/
+func (t *GoTezos) OperationSchema() (*BinarySchema, error) {
+	return t.BinarySchema(http.MethodPost, "/chains/main/blocks/head/helpers/forge/operations", false)
+}
+
+/*
+BlockHeaderSchema RPC
+Path: /describe/chains/main/blocks/head/helpers/forge_block_header (GET)
+Link: https://tezos.gitlab.io/api/rpc.html#get-describe-path
+Description: Returns the binary schema of block headers (the shell header followed by the protocol data).
+*/
+func (t *GoTezos) BlockHeaderSchema() (*BinarySchema, error) {
+	return t.BinarySchema(http.MethodPost, "/chains/main/blocks/head/helpers/forge_block_header", false)
+}
+
+/*
+Encode Function
+Description: Encodes a value to its binary form. The value is marshaled to JSON first, so it must marshal to
+the JSON the node expects for the encoding (e.g. Contents).
+
+Parameters:
+	v:
+		The value to encode.
+*/
+func (s *BinarySchema) Encode(v interface{}) ([]byte, error) {
+	value, err := toSchemaValue(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode")
+	}
+
+	var buf bytes.Buffer
+	err = s.encodeDescription(&buf, s.toplevel, value)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode")
+	}
+
+	return buf.Bytes(), nil
+}
+
+/*
+Decode Function
+Description: Decodes the binary form of a value into v, through the JSON form of the value.
+
+Parameters:
+	data:
+		The binary form of the value.
+	v:
+		A pointer to the value to unmarshal the JSON form into.
+*/
+func (s *BinarySchema) Decode(data []byte, v interface{}) error {
+	value, n, err := s.decodeDescription(s.toplevel, data)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode")
+	}
+	if n != len(data) {
+		return errors.Errorf("failed to decode: unexpected %d trailing bytes", len(data)-n)
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode")
+	}
+
+	err = json.Unmarshal(b, v)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode")
+	}
+
+	return nil
+}
+
+/*
+ForgeOperation Function
+Description: Forges an operation with the schema, see GoTezos.OperationSchema.
+
+Parameters:
+	branch:
+		The branch to forge the operation on.
+	contents:
+		The operation contents to be formed.
+*/
+func (s *BinarySchema) ForgeOperation(branch string, contents ...Contents) (*string, error) {
+	if contents == nil {
+		contents = []Contents{}
+	}
+
+	v, err := s.Encode(schemaOperation{Branch: branch, Contents: contents})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to forge operation")
+	}
+
+	operation := hex.EncodeToString(v)
+	return &operation, nil
+}
+
+/*
+UnforgeOperation Function
+Description: Unforges an operation with the schema, see GoTezos.OperationSchema.
+
+Parameters:
+	operation:
+		The hex string encoded operation.
+	signed:
+		The ?true Unforge will decode a signed operation.
+*/
+func (s *BinarySchema) UnforgeOperation(operation string, signed bool) (*string, *[]Contents, error) {
+	if signed && len(operation) <= 128 {
+		return nil, &[]Contents{}, errors.New("failed to unforge operation: not a valid signed transaction")
+	}
+
+	if signed {
+		operation = operation[:len(operation)-128]
+	}
+
+	v, err := hex.DecodeString(operation)
+	if err != nil {
+		return nil, &[]Contents{}, errors.Wrap(err, "failed to unforge operation")
+	}
+
+	var op schemaOperation
+	err = s.Decode(v, &op)
+	if err != nil {
+		return nil, &[]Contents{}, errors.Wrap(err, "failed to unforge operation")
+	}
+
+	return &op.Branch, &op.Contents, nil
+}
+
+type schemaOperation struct {
+	Branch   string     `json:"branch"`
+	Contents []Contents `json:"contents"`
+}
+
+func (s *BinarySchema) encodeDescription(buf *bytes.Buffer, d schemaDescription, v interface{}) error {
+	switch d.Kind {
+	case "Obj":
+		return s.encodeFields(buf, d.Fields, schemaFieldValues(d.Fields, v), -1)
+	case "Cases":
+		obj, _ := v.(map[string]interface{})
+		kind, _ := obj["kind"].(string)
+		for _, c := range d.Cases {
+			if strings.EqualFold(c.Name, kind) {
+				return s.encodeFields(buf, c.Fields, schemaFieldValues(c.Fields, v), c.Tag)
+			}
+		}
+		return errors.Errorf("unsupported kind '%s'", kind)
+	case "Int_enum":
+		for tag, name := range d.Enum {
+			if name == v {
+				return writeSchemaInt(buf, d.Size, big.NewInt(tag))
+			}
+		}
+		return errors.Errorf("unknown enum value '%v'", v)
+	}
+
+	return errors.Errorf("unsupported description '%s'", d.Kind)
+}
+
+// schemaFieldValues returns the value of each field of an object: the member of the same name, or for
+// anonymous fields the value itself (or its element of the same index for tuples).
+func schemaFieldValues(fields []schemaField, v interface{}) []interface{} {
+	obj, isObj := v.(map[string]interface{})
+	items, _ := v.([]interface{})
+
+	var anons, values int
+	for _, f := range fields {
+		if f.Kind == "anon" {
+			anons++
+		}
+		if (f.Kind == "anon" || f.Kind == "named") && f.Name != "Tag" {
+			values++
+		}
+	}
+
+	var anon int
+	fieldValues := make([]interface{}, len(fields))
+	for i, f := range fields {
+		switch {
+		case f.Kind == "anon" && anons > 1 && !isObj:
+			if anon < len(items) {
+				fieldValues[i] = items[anon]
+			}
+			anon++
+		case f.Kind == "anon", f.Kind == "named" && !isObj && values == 1:
+			fieldValues[i] = v
+		default:
+			fieldValues[i] = obj[f.Name]
+		}
+	}
+
+	return fieldValues
+}
+
+func (s *BinarySchema) encodeFields(buf *bytes.Buffer, fields []schemaField, values []interface{}, tag int64) error {
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		switch f.Kind {
+		case "dyn":
+			end := schemaDynEnd(fields, i)
+			var inner bytes.Buffer
+			err := s.encodeFields(&inner, fields[i+1:end], values[i+1:end], tag)
+			if err != nil {
+				return err
+			}
+			err = writeSchemaInt(buf, f.Size, big.NewInt(int64(inner.Len())))
+			if err != nil {
+				return errors.Wrapf(err, "field '%s'", f.Name)
+			}
+			buf.Write(inner.Bytes())
+			i = end - 1
+		case "option_indicator":
+			if values[i] == nil {
+				buf.WriteByte(0)
+				i = schemaOptionEnd(fields, i) - 1
+				continue
+			}
+			buf.WriteByte(0xff)
+		case "named", "anon":
+			if f.Name == "Tag" && tag >= 0 {
+				err := writeSchemaInt(buf, f.Layout.Size, big.NewInt(tag))
+				if err != nil {
+					return errors.Wrap(err, "tag")
+				}
+				continue
+			}
+			err := s.encodeLayout(buf, f.Layout, f.DataKind, f.Name, values[i])
+			if err != nil && f.Name != "" {
+				return errors.Wrapf(err, "field '%s'", f.Name)
+			}
+			if err != nil {
+				return err
+			}
+		default:
+			return errors.Errorf("unsupported field '%s'", f.Kind)
+		}
+	}
+
+	return nil
+}
+
+func (s *BinarySchema) encodeLayout(buf *bytes.Buffer, l *schemaLayout, dk schemaDataKind, name string, v interface{}) error {
+	if l == nil {
+		return errors.New("missing layout")
+	}
+
+	switch l.Kind {
+	case "Zero_width", "Padding":
+		return nil
+	case "Bool":
+		b, ok := v.(bool)
+		if !ok {
+			return errors.Errorf("expected a boolean, got %v", v)
+		}
+		if b {
+			buf.WriteByte(0xff)
+		} else {
+			buf.WriteByte(0)
+		}
+		return nil
+	case "Int":
+		i, err := schemaBigInt(v)
+		if err != nil {
+			return err
+		}
+		return writeSchemaInt(buf, l.Size, i)
+	case "RangedInt":
+		i, err := schemaBigInt(v)
+		if err != nil {
+			return err
+		}
+		min, max, size, err := schemaRange(l)
+		if err != nil {
+			return err
+		}
+		if !i.IsInt64() || i.Int64() < min || i.Int64() > max {
+			return errors.Errorf("%s out of range [%d, %d]", i, min, max)
+		}
+		if min > 0 {
+			i.Sub(i, big.NewInt(min))
+		}
+		return writeSchemaInt(buf, size, i)
+	case "Float", "RangedFloat":
+		f, err := strconv.ParseFloat(fmt.Sprint(v), 64)
+		if err != nil {
+			return errors.Errorf("expected a number, got %v", v)
+		}
+		return binary.Write(buf, binary.BigEndian, math.Float64bits(f))
+	case "String":
+		str, ok := v.(string)
+		if !ok {
+			return errors.Errorf("expected a string, got %v", v)
+		}
+		if dk.Kind == "Fixed" && len(str) != dk.Size {
+			return errors.Errorf("expected a string of %d bytes, got %d", dk.Size, len(str))
+		}
+		buf.WriteString(str)
+		return nil
+	case "Bytes":
+		b, err := encodeSchemaBytes(name, dk, v)
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+		return nil
+	case "Enum":
+		d, ok := s.fields[l.Reference]
+		if !ok {
+			return errors.Errorf("unknown enum '%s'", l.Reference)
+		}
+		return s.encodeDescription(buf, d, v)
+	case "Ref":
+		if codec, ok := findSchemaCodec(l.Name); ok {
+			b, err := codec.encode(v)
+			if err != nil {
+				return err
+			}
+			buf.Write(b)
+			return nil
+		}
+		d, ok := s.fields[l.Name]
+		if !ok {
+			return errors.Errorf("unknown encoding '%s'", l.Name)
+		}
+		return s.encodeDescription(buf, d, v)
+	case "Seq":
+		items, ok := v.([]interface{})
+		if !ok {
+			return errors.Errorf("expected a list, got %v", v)
+		}
+		if l.MaxLength != nil && len(items) > *l.MaxLength {
+			return errors.Errorf("list of %d elements is longer than %d", len(items), *l.MaxLength)
+		}
+		for _, item := range items {
+			err := s.encodeLayout(buf, l.Layout, schemaDataKind{Kind: "Dynamic"}, name, item)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return errors.Errorf("unsupported layout '%s'", l.Kind)
+}
+
+func (s *BinarySchema) decodeDescription(d schemaDescription, data []byte) (interface{}, int, error) {
+	switch d.Kind {
+	case "Obj":
+		return s.decodeFields(d.Fields, data, false)
+	case "Cases":
+		tag, _, err := readSchemaInt(data, d.TagSize)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "tag")
+		}
+		for _, c := range d.Cases {
+			if c.Tag != tag.Int64() {
+				continue
+			}
+			v, n, err := s.decodeFields(c.Fields, data, true)
+			if err != nil {
+				return nil, 0, errors.Wrapf(err, "%s", c.Name)
+			}
+			if obj, ok := v.(map[string]interface{}); ok {
+				obj["kind"] = strings.ToLower(c.Name)
+			}
+			return v, n, nil
+		}
+		return nil, 0, errors.Errorf("unknown tag %s", tag)
+	case "Int_enum":
+		tag, n, err := readSchemaInt(data, d.Size)
+		if err != nil {
+			return nil, 0, err
+		}
+		name, ok := d.Enum[tag.Int64()]
+		if !ok {
+			return nil, 0, errors.Errorf("unknown enum value %s", tag)
+		}
+		return name, n, nil
+	}
+
+	return nil, 0, errors.Errorf("unsupported description '%s'", d.Kind)
+}
+
+func (s *BinarySchema) decodeFields(fields []schemaField, data []byte, isCase bool) (interface{}, int, error) {
+	obj := map[string]interface{}{}
+	var anons []interface{}
+	n, err := s.decodeFieldRange(fields, data, isCase, obj, &anons)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Anonymous objects are merged into the object (merged objects), other anonymous values are the value
+	// itself (e.g. a wrapped number) or a tuple.
+	var values []interface{}
+	for _, anon := range anons {
+		if members, ok := anon.(map[string]interface{}); ok {
+			for k, v := range members {
+				obj[k] = v
+			}
+			continue
+		}
+		values = append(values, anon)
+	}
+
+	switch {
+	case len(obj) > 0 || len(values) == 0:
+		return obj, n, nil
+	case len(values) == 1:
+		return values[0], n, nil
+	default:
+		return values, n, nil
+	}
+}
+
+func (s *BinarySchema) decodeFieldRange(fields []schemaField, data []byte, isCase bool, obj map[string]interface{}, anons *[]interface{}) (int, error) {
+	var off int
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		switch f.Kind {
+		case "dyn":
+			length, n, err := readSchemaInt(data[off:], f.Size)
+			if err != nil {
+				return 0, errors.Wrapf(err, "field '%s'", f.Name)
+			}
+			off += n
+			if off+int(length.Int64()) > len(data) {
+				return 0, errors.Errorf("field '%s': unexpected end of data", f.Name)
+			}
+			end := schemaDynEnd(fields, i)
+			n, err = s.decodeFieldRange(fields[i+1:end], data[off:off+int(length.Int64())], isCase, obj, anons)
+			if err != nil {
+				return 0, err
+			}
+			if n != int(length.Int64()) {
+				return 0, errors.Errorf("field '%s': unexpected %d trailing bytes", f.Name, int(length.Int64())-n)
+			}
+			off += n
+			i = end - 1
+		case "option_indicator":
+			if off >= len(data) {
+				return 0, errors.Errorf("field '%s': unexpected end of data", f.Name)
+			}
+			off++
+			if data[off-1] == 0 {
+				i = schemaOptionEnd(fields, i) - 1
+			}
+		case "named", "anon":
+			region := data[off:]
+			if f.DataKind.Kind == "Fixed" {
+				if len(region) < f.DataKind.Size {
+					return 0, errors.Errorf("field '%s': unexpected end of data", f.Name)
+				}
+				region = region[:f.DataKind.Size]
+			}
+			v, n, err := s.decodeLayout(f.Layout, f.Name, region)
+			if err != nil && f.Name != "" {
+				return 0, errors.Wrapf(err, "field '%s'", f.Name)
+			}
+			if err != nil {
+				return 0, err
+			}
+			off += n
+
+			switch {
+			case f.Kind == "anon":
+				*anons = append(*anons, v)
+			case f.Name != "Tag" || !isCase:
+				obj[f.Name] = v
+			}
+		default:
+			return 0, errors.Errorf("unsupported field '%s'", f.Kind)
+		}
+	}
+
+	return off, nil
+}
+
+func (s *BinarySchema) decodeLayout(l *schemaLayout, name string, data []byte) (interface{}, int, error) {
+	if l == nil {
+		return nil, 0, errors.New("missing layout")
+	}
+
+	switch l.Kind {
+	case "Zero_width":
+		return nil, 0, nil
+	case "Padding":
+		return nil, len(data), nil
+	case "Bool":
+		if len(data) < 1 {
+			return nil, 0, errors.New("unexpected end of data")
+		}
+		return data[0] != 0, 1, nil
+	case "Int":
+		i, n, err := readSchemaInt(data, l.Size)
+		if err != nil {
+			return nil, 0, err
+		}
+		// The node encodes 64 bit integers as strings in JSON.
+		if l.Size == "Int64" {
+			return i.String(), n, nil
+		}
+		return json.Number(i.String()), n, nil
+	case "RangedInt":
+		min, _, size, err := schemaRange(l)
+		if err != nil {
+			return nil, 0, err
+		}
+		i, n, err := readSchemaInt(data, size)
+		if err != nil {
+			return nil, 0, err
+		}
+		if min > 0 {
+			i.Add(i, big.NewInt(min))
+		}
+		return json.Number(i.String()), n, nil
+	case "Float", "RangedFloat":
+		if len(data) < 8 {
+			return nil, 0, errors.New("unexpected end of data")
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(data[:8])), 8, nil
+	case "String":
+		return string(data), len(data), nil
+	case "Bytes":
+		return decodeSchemaBytes(name, data), len(data), nil
+	case "Enum":
+		d, ok := s.fields[l.Reference]
+		if !ok {
+			return nil, 0, errors.Errorf("unknown enum '%s'", l.Reference)
+		}
+		return s.decodeDescription(d, data)
+	case "Ref":
+		if codec, ok := findSchemaCodec(l.Name); ok {
+			return codec.decode(data)
+		}
+		d, ok := s.fields[l.Name]
+		if !ok {
+			return nil, 0, errors.Errorf("unknown encoding '%s'", l.Name)
+		}
+		return s.decodeDescription(d, data)
+	case "Seq":
+		items := []interface{}{}
+		var off int
+		for off < len(data) {
+			if l.MaxLength != nil && len(items) == *l.MaxLength {
+				break
+			}
+			item, n, err := s.decodeLayout(l.Layout, name, data[off:])
+			if err != nil {
+				return nil, 0, errors.Wrapf(err, "element %d", len(items))
+			}
+			if n == 0 {
+				return nil, 0, errors.Errorf("element %d: empty element", len(items))
+			}
+			items = append(items, item)
+			off += n
+		}
+		return items, off, nil
+	}
+
+	return nil, 0, errors.Errorf("unsupported layout '%s'", l.Kind)
+}
+
+// schemaDynEnd returns the index after the fields whose length a dyn field prefixes.
+func schemaDynEnd(fields []schemaField, i int) int {
+	end := i + 1 + fields[i].NumFields
+	if end > len(fields) {
+		return len(fields)
+	}
+	return end
+}
+
+// schemaOptionEnd returns the index after the field whose presence an option_indicator field encodes.
+func schemaOptionEnd(fields []schemaField, i int) int {
+	if i+1 < len(fields) && fields[i+1].Kind == "dyn" {
+		return schemaDynEnd(fields, i+1)
+	}
+	if i+2 > len(fields) {
+		return len(fields)
+	}
+	return i + 2
+}
+
+func findSchemaCodec(name string) (schemaCodec, bool) {
+	for k, codec := range schemaCodecs {
+		if name == k || strings.HasSuffix(name, "."+k) {
+			return codec, true
+		}
+	}
+	return schemaCodec{}, false
+}
+
+func findSchemaBase58(name string) (prefix, bool) {
+	for k, p := range schemaBase58 {
+		if name == k || strings.HasSuffix(name, "."+k) {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+func encodeSchemaBytes(name string, dk schemaDataKind, v interface{}) ([]byte, error) {
+	str, ok := v.(string)
+	if !ok {
+		if schemaMicheline[name] {
+			return encodeSchemaMicheline(v)
+		}
+		return nil, errors.Errorf("expected bytes, got %v", v)
+	}
+
+	if p, ok := findSchemaBase58(name); ok && dk.Kind == "Fixed" {
+		return b58cdecodeChecked(str, p, dk.Size)
+	}
+
+	b, err := hex.DecodeString(str)
+	if err != nil {
+		return nil, errors.Errorf("expected hex bytes, got '%s'", str)
+	}
+	if dk.Kind == "Fixed" && len(b) != dk.Size {
+		return nil, errors.Errorf("expected %d bytes, got %d", dk.Size, len(b))
+	}
+
+	return b, nil
+}
+
+func decodeSchemaBytes(name string, data []byte) interface{} {
+	if p, ok := findSchemaBase58(name); ok {
+		return b58cencode(data, p)
+	}
+
+	if schemaMicheline[name] {
+		if node, n, err := decodeMicheline(data); err == nil && n == len(data) {
+			if v, err := toSchemaValue(node); err == nil {
+				return v
+			}
+		}
+	}
+
+	return hex.EncodeToString(data)
+}
+
+func encodeSchemaMicheline(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var m Micheline
+	err = json.Unmarshal(b, &m)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid micheline expression")
+	}
+
+	return m.MarshalBinary()
+}
+
+func encodeSchemaTimestamp(v interface{}) ([]byte, error) {
+	seconds, err := schemaBigInt(v)
+	if err != nil {
+		ts, err := time.Parse(time.RFC3339, fmt.Sprint(v))
+		if err != nil {
+			return nil, errors.Errorf("invalid timestamp '%v'", v)
+		}
+		seconds = big.NewInt(ts.Unix())
+	}
+
+	var buf bytes.Buffer
+	err = writeSchemaInt(&buf, "Int64", seconds)
+	return buf.Bytes(), err
+}
+
+func decodeSchemaTimestamp(data []byte) (interface{}, int, error) {
+	seconds, n, err := readSchemaInt(data, "Int64")
+	if err != nil {
+		return nil, 0, err
+	}
+	return time.Unix(seconds.Int64(), 0).UTC().Format(time.RFC3339), n, nil
+}
+
+// schemaRange returns the bounds of a ranged integer and the size it is encoded with.
+func schemaRange(l *schemaLayout) (int64, int64, string, error) {
+	min, err := l.Min.Int64()
+	if err != nil {
+		return 0, 0, "", errors.Wrap(err, "invalid range")
+	}
+	max, err := l.Max.Int64()
+	if err != nil {
+		return 0, 0, "", errors.Wrap(err, "invalid range")
+	}
+
+	// Ranges with a positive minimum are encoded shifted by the minimum.
+	low, high := min, max
+	if min > 0 {
+		low, high = 0, max-min
+	}
+
+	for _, size := range []string{"Uint8", "Int8", "Uint16", "Int16"} {
+		if low >= schemaInts[size].min && high <= schemaInts[size].max {
+			return min, max, size, nil
+		}
+	}
+	return min, max, "Int31", nil
+}
+
+func schemaBigInt(v interface{}) (*big.Int, error) {
+	switch n := v.(type) {
+	case json.Number, string:
+		i, ok := new(big.Int).SetString(fmt.Sprint(n), 10)
+		if !ok {
+			return nil, errors.Errorf("expected an integer, got '%v'", v)
+		}
+		return i, nil
+	case float64:
+		return big.NewInt(int64(n)), nil
+	case int:
+		return big.NewInt(int64(n)), nil
+	}
+	return nil, errors.Errorf("expected an integer, got %v", v)
+}
+
+func writeSchemaInt(buf *bytes.Buffer, size string, i *big.Int) error {
+	bounds, ok := schemaInts[size]
+	if !ok {
+		return errors.Errorf("unsupported integer size '%s'", size)
+	}
+	if !i.IsInt64() || i.Int64() < bounds.min || i.Int64() > bounds.max {
+		return errors.Errorf("%s out of range for %s", i, size)
+	}
+
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(i.Int64()))
+	buf.Write(b[8-bounds.size:])
+	return nil
+}
+
+func readSchemaInt(data []byte, size string) (*big.Int, int, error) {
+	bounds, ok := schemaInts[size]
+	if !ok {
+		return nil, 0, errors.Errorf("unsupported integer size '%s'", size)
+	}
+	if len(data) < bounds.size {
+		return nil, 0, errors.New("unexpected end of data")
+	}
+
+	var u uint64
+	for _, b := range data[:bounds.size] {
+		u = u<<8 | uint64(b)
+	}
+
+	// Sign extends signed integers.
+	i := int64(u)
+	if bounds.min < 0 && bounds.size < 8 {
+		shift := uint(64 - 8*bounds.size)
+		i = int64(u<<shift) >> shift
+	}
+
+	return big.NewInt(i), bounds.size, nil
+}
+
+func encodeSchemaZarith(i *big.Int) []byte {
+	n := new(big.Int).Set(i)
+	out := []byte{byte(new(big.Int).And(n, big.NewInt(0x7f)).Uint64())}
+	n.Rsh(n, 7)
+	for n.Sign() > 0 {
+		out[len(out)-1] |= 0x80
+		out = append(out, byte(new(big.Int).And(n, big.NewInt(0x7f)).Uint64()))
+		n.Rsh(n, 7)
+	}
+	return out
+}
+
+func decodeSchemaZarith(data []byte) (*big.Int, int, error) {
+	i := new(big.Int)
+	var shift uint
+	for n, b := range data {
+		part := new(big.Int).SetUint64(uint64(b & 0x7f))
+		i.Or(i, part.Lsh(part, shift))
+		shift += 7
+		if b&0x80 == 0 {
+			return i, n + 1, nil
+		}
+	}
+	return nil, 0, errors.New("unexpected end of data")
+}
+
+// toSchemaValue returns the JSON form of a value as maps, slices, strings, json.Numbers and booleans.
+func toSchemaValue(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	var value interface{}
+	err = decoder.Decode(&value)
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
@@ -0,0 +1,304 @@
+package gotezos
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockOperationBinarySchema is the reveal, transaction and delegation subset of the binary schema of the
+// input of ../helpers/forge/operations, as described by a Paris node.
+var mockOperationBinarySchema = []byte(`{
+	"toplevel": {"kind": "Obj", "fields": [
+		{"name": "branch", "layout": {"kind": "Bytes"}, "data_kind": {"size": 32, "kind": "Fixed"}, "kind": "named"},
+		{"name": "contents", "layout": {"layout": {"name": "020-PsParisC.operation.alpha.contents", "kind": "Ref"}, "kind": "Seq"}, "data_kind": {"kind": "Variable"}, "kind": "named"}
+	]},
+	"fields": [
+		{"description": {"title": "020-PsParisC.mutez"}, "encoding": {"kind": "Obj", "fields": [
+			{"layout": {"name": "N.t", "kind": "Ref"}, "kind": "anon", "data_kind": {"kind": "Dynamic"}}
+		]}},
+		{"description": {"title": "020-PsParisC.operation.alpha.contents.transaction.parameters"}, "encoding": {"kind": "Obj", "fields": [
+			{"name": "entrypoint", "layout": {"name": "020-PsParisC.entrypoint", "kind": "Ref"}, "data_kind": {"kind": "Dynamic"}, "kind": "named"},
+			{"kind": "dyn", "num_fields": 1, "size": "Uint30"},
+			{"name": "value", "layout": {"kind": "Bytes"}, "data_kind": {"kind": "Variable"}, "kind": "named"}
+		]}},
+		{"description": {"title": "020-PsParisC.operation.alpha.contents"}, "encoding": {"tag_size": "Uint8", "kind": "Cases", "cases": [
+			{"tag": 107, "name": "Reveal", "fields": [
+				{"name": "Tag", "layout": {"size": "Uint8", "kind": "Int"}, "data_kind": {"size": 1, "kind": "Fixed"}, "kind": "named"},
+				{"name": "source", "layout": {"name": "public_key_hash", "kind": "Ref"}, "data_kind": {"size": 21, "kind": "Fixed"}, "kind": "named"},
+				{"name": "fee", "layout": {"name": "020-PsParisC.mutez", "kind": "Ref"}, "data_kind": {"kind": "Dynamic"}, "kind": "named"},
+				{"name": "counter", "layout": {"name": "N.t", "kind": "Ref"}, "data_kind": {"kind": "Dynamic"}, "kind": "named"},
+				{"name": "gas_limit", "layout": {"name": "N.t", "kind": "Ref"}, "data_kind": {"kind": "Dynamic"}, "kind": "named"},
+				{"name": "storage_limit", "layout": {"name": "N.t", "kind": "Ref"}, "data_kind": {"kind": "Dynamic"}, "kind": "named"},
+				{"name": "public_key", "layout": {"name": "public_key", "kind": "Ref"}, "data_kind": {"kind": "Dynamic"}, "kind": "named"}
+			]},
+			{"tag": 108, "name": "Transaction", "fields": [
+				{"name": "Tag", "layout": {"size": "Uint8", "kind": "Int"}, "data_kind": {"size": 1, "kind": "Fixed"}, "kind": "named"},
+				{"name": "source", "layout": {"name": "public_key_hash", "kind": "Ref"}, "data_kind": {"size": 21, "kind": "Fixed"}, "kind": "named"},
+				{"name": "fee", "layout": {"name": "020-PsParisC.mutez", "kind": "Ref"}, "data_kind": {"kind": "Dynamic"}, "kind": "named"},
+				{"name": "counter", "layout": {"name": "N.t", "kind": "Ref"}, "data_kind": {"kind": "Dynamic"}, "kind": "named"},
+				{"name": "gas_limit", "layout": {"name": "N.t", "kind": "Ref"}, "data_kind": {"kind": "Dynamic"}, "kind": "named"},
+				{"name": "storage_limit", "layout": {"name": "N.t", "kind": "Ref"}, "data_kind": {"kind": "Dynamic"}, "kind": "named"},
+				{"name": "amount", "layout": {"name": "020-PsParisC.mutez", "kind": "Ref"}, "data_kind": {"kind": "Dynamic"}, "kind": "named"},
+				{"name": "destination", "layout": {"name": "020-PsParisC.contract_id", "kind": "Ref"}, "data_kind": {"size": 22, "kind": "Fixed"}, "kind": "named"},
+				{"kind": "option_indicator", "name": "parameters"},
+				{"name": "parameters", "layout": {"name": "020-PsParisC.operation.alpha.contents.transaction.parameters", "kind": "Ref"}, "data_kind": {"kind": "Dynamic"}, "kind": "named"}
+			]},
+			{"tag": 110, "name": "Delegation", "fields": [
+				{"name": "Tag", "layout": {"size": "Uint8", "kind": "Int"}, "data_kind": {"size": 1, "kind": "Fixed"}, "kind": "named"},
+				{"name": "source", "layout": {"name": "public_key_hash", "kind": "Ref"}, "data_kind": {"size": 21, "kind": "Fixed"}, "kind": "named"},
+				{"name": "fee", "layout": {"name": "020-PsParisC.mutez", "kind": "Ref"}, "data_kind": {"kind": "Dynamic"}, "kind": "named"},
+				{"name": "counter", "layout": {"name": "N.t", "kind": "Ref"}, "data_kind": {"kind": "Dynamic"}, "kind": "named"},
+				{"name": "gas_limit", "layout": {"name": "N.t", "kind": "Ref"}, "data_kind": {"kind": "Dynamic"}, "kind": "named"},
+				{"name": "storage_limit", "layout": {"name": "N.t", "kind": "Ref"}, "data_kind": {"kind": "Dynamic"}, "kind": "named"},
+				{"kind": "option_indicator", "name": "delegate"},
+				{"name": "delegate", "layout": {"name": "public_key_hash", "kind": "Ref"}, "data_kind": {"size": 21, "kind": "Fixed"}, "kind": "named"}
+			]}
+		]}}
+	]
+}`)
+
+// mockHeaderBinarySchema exercises the layouts operations don't use.
+var mockHeaderBinarySchema = []byte(`{
+	"toplevel": {"kind": "Obj", "fields": [
+		{"name": "level", "layout": {"size": "Int32", "kind": "Int"}, "data_kind": {"size": 4, "kind": "Fixed"}, "kind": "named"},
+		{"name": "predecessor", "layout": {"kind": "Bytes"}, "data_kind": {"size": 32, "kind": "Fixed"}, "kind": "named"},
+		{"name": "timestamp", "layout": {"name": "timestamp.protocol", "kind": "Ref"}, "data_kind": {"size": 8, "kind": "Fixed"}, "kind": "named"},
+		{"name": "vote", "layout": {"size": "Uint8", "reference": "vote", "kind": "Enum"}, "data_kind": {"size": 1, "kind": "Fixed"}, "kind": "named"},
+		{"name": "round", "layout": {"min": 1, "max": 1000, "kind": "RangedInt"}, "data_kind": {"size": 2, "kind": "Fixed"}, "kind": "named"},
+		{"name": "adjust", "layout": {"size": "Int16", "kind": "Int"}, "data_kind": {"size": 2, "kind": "Fixed"}, "kind": "named"},
+		{"name": "liquidity", "layout": {"kind": "Bool"}, "data_kind": {"size": 1, "kind": "Fixed"}, "kind": "named"},
+		{"kind": "option_indicator", "name": "seed"},
+		{"name": "seed", "layout": {"kind": "Bytes"}, "data_kind": {"size": 32, "kind": "Fixed"}, "kind": "named"},
+		{"kind": "dyn", "name": "tags", "num_fields": 1, "size": "Uint30"},
+		{"name": "tags", "layout": {"layout": {"name": "tag", "kind": "Ref"}, "kind": "Seq"}, "data_kind": {"kind": "Variable"}, "kind": "named"}
+	]},
+	"fields": [
+		{"description": {"title": "vote"}, "encoding": {"size": "Uint8", "kind": "Int_enum", "cases": [[0, "on"], [1, "off"], [2, "pass"]]}},
+		{"description": {"title": "tag"}, "encoding": {"kind": "Obj", "fields": [
+			{"kind": "dyn", "num_fields": 1, "size": "Uint8"},
+			{"layout": {"kind": "String"}, "kind": "anon", "data_kind": {"kind": "Variable"}}
+		]}}
+	]
+}`)
+
+type mockHeader struct {
+	Level       int      `json:"level"`
+	Predecessor string   `json:"predecessor"`
+	Timestamp   string   `json:"timestamp"`
+	Vote        string   `json:"vote"`
+	Round       int      `json:"round"`
+	Adjust      int      `json:"adjust"`
+	Liquidity   bool     `json:"liquidity"`
+	Seed        string   `json:"seed,omitempty"`
+	Tags        []string `json:"tags"`
+}
+
+func describeHandlerMock(path string, schema []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/describe"+path {
+			w.Write([]byte(fmt.Sprintf(`{"static":{"post_service":{"meth":"POST","input":{"json_schema":{},"binary_schema":%s},"output":{"json_schema":{}}}}}`, schema)))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func Test_BinarySchemaOperations(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(describeHandlerMock("/chains/main/blocks/head/helpers/forge/operations", mockOperationBinarySchema, blankHandler)))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	schema, err := gt.OperationSchema()
+	assert.Nil(t, err)
+
+	branch := "BLyvCRkxuTXkx1KeGvrcEXiPYj4p1tFxzvFDhoHE7SFKtmP1rbk"
+	contents := []Contents{
+		{
+			Kind:         REVEALOP,
+			Source:       mockAddressTz1,
+			Fee:          BigInt{*big.NewInt(1300)},
+			Counter:      BigInt{*big.NewInt(1)},
+			GasLimit:     BigInt{*big.NewInt(1100)},
+			StorageLimit: BigInt{*big.NewInt(100)},
+			Phk:          "edpktnktxAzmXPD9XVNqAvdCFb76vxzQtkbVkSEtXcTz33QZQdb4JQ",
+		},
+		{
+			Kind:         TRANSACTIONOP,
+			Source:       mockAddressTz1,
+			Fee:          BigInt{*big.NewInt(34567123)},
+			Counter:      BigInt{*big.NewInt(2)},
+			GasLimit:     BigInt{*big.NewInt(56787)},
+			StorageLimit: BigInt{*big.NewInt(100)},
+			Amount:       BigInt{*big.NewInt(12345)},
+			Destination:  "KT1RJ6PbjHpwc3M5rw5s2Nbmefwbuwbdxton",
+			Parameters: &Parameters{
+				Entrypoint: "transfer",
+				Value:      NewMichelinePrim("Pair", NewMichelineString(mockAddressTz1), NewMichelineInt(10)),
+			},
+		},
+		{
+			Kind:         TRANSACTIONOP,
+			Source:       mockAddressTz1,
+			Fee:          BigInt{*big.NewInt(1000)},
+			Counter:      BigInt{*big.NewInt(3)},
+			GasLimit:     BigInt{*big.NewInt(1000)},
+			StorageLimit: BigInt{*big.NewInt(100)},
+			Amount:       BigInt{*big.NewInt(5000000)},
+			Destination:  mockAddressTz1,
+			Parameters:   &Parameters{Entrypoint: EntrypointStake, Value: NewMichelinePrim("Unit")},
+		},
+		{
+			Kind:         DELEGATIONOP,
+			Source:       mockAddressTz1,
+			Fee:          BigInt{*big.NewInt(1000)},
+			Counter:      BigInt{*big.NewInt(4)},
+			GasLimit:     BigInt{*big.NewInt(1000)},
+			StorageLimit: BigInt{*big.NewInt(100)},
+			Delegate:     mockAddressTz1,
+		},
+	}
+
+	// The schema forges the same bytes as the hand written codec.
+	want, err := gt.ForgeOperation(branch, contents...)
+	assert.Nil(t, err)
+	operation, err := schema.ForgeOperation(branch, contents...)
+	assert.Nil(t, err)
+	assert.Equal(t, *want, *operation)
+
+	unforgedBranch, unforged, err := schema.UnforgeOperation(*operation+strings.Repeat("00", 64), true)
+	assert.Nil(t, err)
+	assert.Equal(t, branch, *unforgedBranch)
+	assert.Len(t, *unforged, len(contents))
+	for i, c := range *unforged {
+		assert.Equal(t, contents[i].Kind, c.Kind)
+		assert.Equal(t, contents[i].Source, c.Source)
+		assert.Equal(t, 0, contents[i].Fee.Cmp(&c.Fee.Int))
+		assert.Equal(t, 0, contents[i].Counter.Cmp(&c.Counter.Int))
+		assert.Equal(t, 0, contents[i].Amount.Cmp(&c.Amount.Int))
+		assert.Equal(t, contents[i].Destination, c.Destination)
+		assert.Equal(t, contents[i].Delegate, c.Delegate)
+		assert.Equal(t, contents[i].Phk, c.Phk)
+		assert.Equal(t, contents[i].Parameters, c.Parameters)
+	}
+
+	_, err = schema.ForgeOperation(branch, Contents{Kind: DRAINDELEGATEOP})
+	checkErr(t, true, "failed to forge operation: failed to encode: field 'contents': unsupported kind 'drain_delegate'", err)
+
+	_, err = schema.ForgeOperation(branch, Contents{Kind: DELEGATIONOP, Source: "tz1invalid"})
+	checkErr(t, true, "field 'source'", err)
+
+	_, _, err = schema.UnforgeOperation(*operation+"ff", false)
+	checkErr(t, true, "unknown tag 255", err)
+
+	_, _, err = schema.UnforgeOperation("00", true)
+	checkErr(t, true, "not a valid signed transaction", err)
+}
+
+func Test_BinarySchemaLayouts(t *testing.T) {
+	schema, err := NewBinarySchema(mockHeaderBinarySchema)
+	assert.Nil(t, err)
+
+	header := mockHeader{
+		Level:       123,
+		Predecessor: "BLyvCRkxuTXkx1KeGvrcEXiPYj4p1tFxzvFDhoHE7SFKtmP1rbk",
+		Timestamp:   "2024-06-01T00:00:00Z",
+		Vote:        "pass",
+		Round:       2,
+		Adjust:      -3,
+		Liquidity:   true,
+		Tags:        []string{"a", "bc"},
+	}
+
+	v, err := schema.Encode(header)
+	assert.Nil(t, err)
+	assert.Equal(t, "0000007b"+
+		"a732d3520eeaa3de98d78e5e5cb6c85f72204fd46feb9f76853841d4a701add3"+
+		"00000000665a6480"+
+		"02"+
+		"0001"+
+		"fffd"+
+		"ff"+
+		"00"+
+		"00000005"+"0161"+"026263", hex.EncodeToString(v))
+
+	var decoded mockHeader
+	err = schema.Decode(v, &decoded)
+	assert.Nil(t, err)
+	assert.Equal(t, header, decoded)
+
+	header.Seed = strings.Repeat("ab", 32)
+	v, err = schema.Encode(header)
+	assert.Nil(t, err)
+	err = schema.Decode(v, &decoded)
+	assert.Nil(t, err)
+	assert.Equal(t, header, decoded)
+
+	cases := []struct {
+		name    string
+		header  func(h mockHeader) mockHeader
+		wantErr string
+	}{
+		{"handles unknown enum value", func(h mockHeader) mockHeader { h.Vote = "maybe"; return h }, "field 'vote': unknown enum value 'maybe'"},
+		{"handles out of range", func(h mockHeader) mockHeader { h.Round = 0; return h }, "field 'round': 0 out of range [1, 1000]"},
+		{"handles invalid bytes", func(h mockHeader) mockHeader { h.Seed = "abcd"; return h }, "field 'seed': expected 32 bytes, got 2"},
+		{"handles invalid timestamp", func(h mockHeader) mockHeader { h.Timestamp = "yesterday"; return h }, "field 'timestamp': invalid timestamp 'yesterday'"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := schema.Encode(tt.header(header))
+			checkErr(t, true, tt.wantErr, err)
+		})
+	}
+
+	err = schema.Decode(append(v, 0), &decoded)
+	checkErr(t, true, "failed to decode: unexpected 1 trailing bytes", err)
+
+	err = schema.Decode(v[:10], &decoded)
+	checkErr(t, true, "field 'predecessor': unexpected end of data", err)
+}
+
+func Test_BinarySchemaErrors(t *testing.T) {
+	_, err := NewBinarySchema([]byte(`[]`))
+	checkErr(t, true, "could not unmarshal binary schema", err)
+
+	_, err = NewBinarySchema([]byte(`{"fields":[]}`))
+	checkErr(t, true, "missing toplevel", err)
+
+	cases := []struct {
+		name    string
+		resp    []byte
+		wantErr string
+	}{
+		{"handles rpc error", mockRPCErrorResp, "could not describe '/chains/main/blocks/head/helpers/forge/operations'"},
+		{"handles failure to unmarshal", []byte(`[]`), "could not unmarshal description"},
+		{"handles missing service", []byte(`{"static":{"get_service":{}}}`), "could not find POST service"},
+		{"handles missing binary schema", []byte(`{"static":{"post_service":{"input":{"json_schema":{}}}}}`), "could not find binary schema of the input"},
+		{"handles invalid binary schema", []byte(`{"static":{"post_service":{"input":{"binary_schema":{}}}}}`), "could not parse binary schema"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(gtGoldenHTTPMock(routesHandlerMock(map[string][]byte{
+				"/describe/chains/main/blocks/head/helpers/forge/operations": tt.resp,
+			}, blankHandler)))
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			_, err = gt.OperationSchema()
+			checkErr(t, true, tt.wantErr, err)
+		})
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"math/big"
 	"strconv"
-	"time"
 
 	"github.com/pkg/errors"
 )
@@ -27,6 +26,11 @@ Parameters:
 		The byte representation of a BigInt.
 */
 func (i *BigInt) UnmarshalJSON(b []byte) error {
+	// The node quotes big numbers, MarshalJSON does not.
+	if len(b) > 0 && b[0] != '"' && b[0] != 'n' {
+		return i.UnmarshalText(b)
+	}
+
 	var val string
 	err := json.Unmarshal(b, &val)
 	if err != nil {
@@ -59,6 +63,9 @@ type Block struct {
 	Header     Header         `json:"header"`
 	Metadata   Metadata       `json:"metadata"`
 	Operations [][]Operations `json:"operations"`
+
+	// The block exactly as returned by the node, see Get.
+	Raw json.RawMessage `json:"-"`
 }
 
 /*
@@ -67,17 +74,21 @@ RPC: /chains/<chain_id>/blocks/<block_id> (<dyn>)
 Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-contracts-contract-id-balance
 */
 type Header struct {
-	Level            int       `json:"level"`
-	Proto            int       `json:"proto"`
-	Predecessor      string    `json:"Predecessor"`
-	Timestamp        time.Time `json:"timestamp"`
-	ValidationPass   int       `json:"validation_pass"`
-	OperationsHash   string    `json:"operations_hash"`
-	Fitness          []string  `json:"fitness"`
-	Context          string    `json:"context"`
-	Priority         int       `json:"priority"`
-	ProofOfWorkNonce string    `json:"proof_of_work_nonce"`
-	Signature        string    `json:"signature"`
+	Level                     int       `json:"level"`
+	Proto                     int       `json:"proto"`
+	Predecessor               string    `json:"Predecessor"`
+	Timestamp                 Timestamp `json:"timestamp"`
+	ValidationPass            int       `json:"validation_pass"`
+	OperationsHash            string    `json:"operations_hash"`
+	Fitness                   []string  `json:"fitness"`
+	Context                   string    `json:"context"`
+	Priority                  int       `json:"priority"`
+	PayloadHash               string    `json:"payload_hash,omitempty"`
+	PayloadRound              int       `json:"payload_round,omitempty"`
+	ProofOfWorkNonce          string    `json:"proof_of_work_nonce"`
+	LiquidityBakingEscapeVote bool      `json:"liquidity_baking_escape_vote,omitempty"`
+	LiquidityBakingToggleVote string    `json:"liquidity_baking_toggle_vote,omitempty"`
+	Signature                 string    `json:"signature"`
 }
 
 /*
@@ -86,20 +97,48 @@ RPC: /chains/<chain_id>/blocks/<block_id> (<dyn>)
 Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-contracts-contract-id-balance
 */
 type Metadata struct {
-	Protocol               string                   `json:"protocol"`
-	NextProtocol           string                   `json:"next_protocol"`
-	TestChainStatus        TestChainStatus          `json:"test_chain_status"`
-	MaxOperationsTTL       int                      `json:"max_operations_ttl"`
-	MaxOperationDataLength int                      `json:"max_operation_data_length"`
-	MaxBlockHeaderLength   int                      `json:"max_block_header_length"`
-	MaxOperationListLength []MaxOperationListLength `json:"max_operation_list_length"`
-	Baker                  string                   `json:"baker"`
-	Level                  Level                    `json:"level"`
-	VotingPeriodKind       string                   `json:"voting_period_kind"`
-	NonceHash              interface{}              `json:"nonce_hash"`
-	ConsumedGas            string                   `json:"consumed_gas"`
-	Deactivated            []string                 `json:"deactivated"`
-	BalanceUpdates         []BalanceUpdates         `json:"balance_updates"`
+	Protocol                 string                   `json:"protocol"`
+	NextProtocol             string                   `json:"next_protocol"`
+	TestChainStatus          TestChainStatus          `json:"test_chain_status"`
+	MaxOperationsTTL         int                      `json:"max_operations_ttl"`
+	MaxOperationDataLength   int                      `json:"max_operation_data_length"`
+	MaxBlockHeaderLength     int                      `json:"max_block_header_length"`
+	MaxOperationListLength   []MaxOperationListLength `json:"max_operation_list_length"`
+	Baker                    string                   `json:"baker"`
+	Proposer                 string                   `json:"proposer,omitempty"`
+	Level                    Level                    `json:"level"`
+	LevelInfo                *Level                   `json:"level_info,omitempty"`
+	VotingPeriodKind         string                   `json:"voting_period_kind"`
+	VotingPeriodInfo         *VotingPeriodInfo        `json:"voting_period_info,omitempty"`
+	NonceHash                interface{}              `json:"nonce_hash"`
+	ConsumedGas              string                   `json:"consumed_gas"`
+	Deactivated              []string                 `json:"deactivated"`
+	BalanceUpdates           []BalanceUpdates         `json:"balance_updates"`
+	LiquidityBakingEscapeEma int                      `json:"liquidity_baking_escape_ema,omitempty"`
+	LiquidityBakingToggleEma int                      `json:"liquidity_baking_toggle_ema,omitempty"`
+	DALAttestation           *BigInt                  `json:"dal_attestation,omitempty"`
+}
+
+/*
+VotingPeriodInfo <block>
+RPC: /chains/<chain_id>/blocks/<block_id> (<dyn>)
+Link: https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type VotingPeriodInfo struct {
+	VotingPeriod VotingPeriod `json:"voting_period"`
+	Position     int          `json:"position"`
+	Remaining    int          `json:"remaining"`
+}
+
+/*
+VotingPeriod <block>
+RPC: /chains/<chain_id>/blocks/<block_id> (<dyn>)
+Link: https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type VotingPeriod struct {
+	Index         int    `json:"index"`
+	Kind          string `json:"kind"`
+	StartPosition int    `json:"start_position"`
 }
 
 /*
@@ -142,13 +181,33 @@ RPC: /chains/<chain_id>/blocks/<block_id> (<dyn>)
 Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-contracts-contract-id-balance
 */
 type BalanceUpdates struct {
-	Kind     string `json:"kind"`
+	Kind          string               `json:"kind"`
+	Contract      string               `json:"contract,omitempty"`
+	Change        BigInt               `json:"change"`
+	Category      string               `json:"category,omitempty"`
+	Delegate      string               `json:"delegate,omitempty"`
+	Cycle         int                  `json:"cycle,omitempty"`
+	Level         int                  `json:"level,omitempty"`
+	Origin        string               `json:"origin,omitempty"`
+	Participation bool                 `json:"participation,omitempty"`
+	Revelation    bool                 `json:"revelation,omitempty"`
+	Committer     string               `json:"committer,omitempty"`
+	Staker        *BalanceUpdateStaker `json:"staker,omitempty"`
+	Delegator     string               `json:"delegator,omitempty"`
+}
+
+/*
+BalanceUpdateStaker <block>
+RPC: /chains/<chain_id>/blocks/<block_id> (<dyn>)
+Description: The staker of a deposits or unstaked deposits balance update (Oxford and later).
+*/
+type BalanceUpdateStaker struct {
 	Contract string `json:"contract,omitempty"`
-	Change   BigInt `json:"change"`
-	Category string `json:"category,omitempty"`
 	Delegate string `json:"delegate,omitempty"`
-	Cycle    int    `json:"cycle,omitempty"`
-	Level    int    `json:"level,omitempty"`
+	Baker    string `json:"baker,omitempty"`
+	// The delegate of its own stake or of its edge on the rewards of its stakers (Paris and later).
+	BakerOwnStake string `json:"baker_own_stake,omitempty"`
+	BakerEdge     string `json:"baker_edge,omitempty"`
 }
 
 /*
@@ -157,9 +216,19 @@ RPC: /chains/<chain_id>/blocks/<block_id> (<dyn>)
 Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-contracts-contract-id-balance
 */
 type OperationResult struct {
-	Status      string  `json:"status"`
-	ConsumedGas BigInt  `json:"consumed_gas,omitempty"`
-	Errors      []Error `json:"errors,omitempty"`
+	Status                       string            `json:"status"`
+	BalanceUpdates               []BalanceUpdates  `json:"balance_updates,omitempty"`
+	ConsumedGas                  BigInt            `json:"consumed_gas,omitempty"`
+	ConsumedMilligas             BigInt            `json:"consumed_milligas,omitempty"`
+	StorageSize                  BigInt            `json:"storage_size,omitempty"`
+	PaidStorageSizeDiff          BigInt            `json:"paid_storage_size_diff,omitempty"`
+	OriginatedContracts          []string          `json:"originated_contracts,omitempty"`
+	AllocatedDestinationContract bool              `json:"allocated_destination_contract,omitempty"`
+	GlobalAddress                string            `json:"global_address,omitempty"`
+	Storage                      *Micheline        `json:"storage,omitempty"`
+	BigMapDiff                   []BigMapDiff      `json:"big_map_diff,omitempty"`
+	LazyStorageDiff              []LazyStorageDiff `json:"lazy_storage_diff,omitempty"`
+	Errors                       []Error           `json:"errors,omitempty"`
 }
 
 /*
@@ -182,25 +251,248 @@ RPC: /chains/<chain_id>/blocks/<block_id> (<dyn>)
 Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-contracts-contract-id-balance
 */
 type Contents struct {
-	Kind             string            `json:"kind,omitempty"`
-	Source           string            `json:"source,omitempty"`
-	Fee              BigInt            `json:"fee,omitempty"`
-	Counter          BigInt            `json:"counter,omitempty"`
-	GasLimit         BigInt            `json:"gas_limit,omitempty"`
-	StorageLimit     BigInt            `json:"storage_limit,omitempty"`
-	Amount           BigInt            `json:"amount,omitempty"`
-	Destination      string            `json:"destination,omitempty"`
-	Delegate         string            `json:"delegate,omitempty"`
-	Phk              string            `json:"phk,omitempty"`
-	Secret           string            `json:"secret,omitempty"`
-	Level            int               `json:"level,omitempty"`
-	ManagerPublicKey string            `json:"managerPubkey,omitempty"`
-	Balance          BigInt            `json:"balance,omitempty"`
-	Period           int               `json:"period,omitempty"`
-	Proposal         string            `json:"proposal,omitempty"`
-	Proposals        []string          `json:"proposals,omitempty"`
-	Ballot           string            `json:"ballot,omitempty"`
-	Metadata         *ContentsMetadata `json:"metadata,omitempty"`
+	Kind             string                 `json:"kind,omitempty"`
+	Source           string                 `json:"source,omitempty"`
+	Fee              BigInt                 `json:"fee,omitempty"`
+	Counter          BigInt                 `json:"counter,omitempty"`
+	GasLimit         BigInt                 `json:"gas_limit,omitempty"`
+	StorageLimit     BigInt                 `json:"storage_limit,omitempty"`
+	Amount           BigInt                 `json:"amount,omitempty"`
+	Destination      string                 `json:"destination,omitempty"`
+	Parameters       *Parameters            `json:"parameters,omitempty"`
+	Value            *Micheline             `json:"value,omitempty"`
+	Rollup           string                 `json:"rollup,omitempty"`
+	Commitment       *SmartRollupCommitment `json:"commitment,omitempty"`
+	PvmKind          string                 `json:"pvm_kind,omitempty"`
+	Kernel           string                 `json:"kernel,omitempty"`
+	ParametersTy     *Micheline             `json:"parameters_ty,omitempty"`
+	Whitelist        []string               `json:"whitelist,omitempty"`
+	Message          []string               `json:"message,omitempty"`
+	Delegate         string                 `json:"delegate,omitempty"`
+	Phk              string                 `json:"phk,omitempty"`
+	Secret           string                 `json:"secret,omitempty"`
+	Level            int                    `json:"level,omitempty"`
+	Slot             int                    `json:"slot,omitempty"`
+	Round            int                    `json:"round,omitempty"`
+	BlockPayloadHash string                 `json:"block_payload_hash,omitempty"`
+	Endorsement      *InlinedEndorsement    `json:"endorsement,omitempty"`
+	ManagerPublicKey string                 `json:"managerPubkey,omitempty"`
+	Balance          BigInt                 `json:"balance,omitempty"`
+	Script           *Script                `json:"script,omitempty"`
+	Period           int                    `json:"period,omitempty"`
+	Proposal         string                 `json:"proposal,omitempty"`
+	Proposals        []string               `json:"proposals,omitempty"`
+	Ballot           string                 `json:"ballot,omitempty"`
+	Pk               string                 `json:"pk,omitempty"`
+	ConsensusKey     string                 `json:"consensus_key,omitempty"`
+	Attestation      BigInt                 `json:"attestation,omitempty"`
+	DALAttestation   BigInt                 `json:"dal_attestation,omitempty"`
+	SlotHeader       *DALSlotHeader         `json:"slot_header,omitempty"`
+	Metadata         *ContentsMetadata      `json:"metadata,omitempty"`
+
+	// The contents exactly as returned by the node, for kinds the library does not support only, so that they
+	// are marshaled as they were received.
+	Raw json.RawMessage `json:"-"`
+
+	// The contents decoded by the decoder registered for their kind, or *UnknownContents, if the library does
+	// not support their kind (see RegisterContentsDecoder).
+	Decoded interface{} `json:"-"`
+}
+
+/*
+InlinedEndorsement <block>
+RPC: /chains/<chain_id>/blocks/<block_id> (<dyn>)
+Description: The signed endorsement wrapped by an endorsement_with_slot (Edo to Hangzhou).
+*/
+type InlinedEndorsement struct {
+	Branch     string   `json:"branch"`
+	Operations Contents `json:"operations"`
+	Signature  string   `json:"signature"`
+}
+
+/*
+MarshalJSON Function
+Description: Implements the json.Marshaler interface for Contents. Manager operations (transaction,
+reveal, origination, delegation, register_global_constant, update_consensus_key, dal_publish_commitment and
+the smart rollup operations), drain_delegate and consensus operations (endorsement, endorsement_with_slot,
+attestation, attestation_with_dal, dal_attestation, preendorsement and preattestation) are marshaled with exactly the fields the
+node expects for their kind, so that they can be posted to the RPC (e.g. preapply, run_operation).
+*/
+func (c Contents) MarshalJSON() ([]byte, error) {
+	switch c.Kind {
+	case ENDORSEMENTOP, ATTESTATIONOP, ATTESTATIONWITHDALOP, DALATTESTATIONOP, PREENDORSEMENTOP, PREATTESTATIONOP:
+		op := map[string]interface{}{
+			"kind":  c.Kind,
+			"level": c.Level,
+		}
+		switch {
+		case c.Kind == DALATTESTATIONOP:
+			op["slot"] = c.Slot
+			op["round"] = c.Round
+			op["attestation"] = c.Attestation.String()
+		case c.Kind != ENDORSEMENTOP || c.BlockPayloadHash != "":
+			op["slot"] = c.Slot
+			op["round"] = c.Round
+			op["block_payload_hash"] = c.BlockPayloadHash
+		}
+		if c.Kind == ATTESTATIONWITHDALOP {
+			op["dal_attestation"] = c.DALAttestation.String()
+		}
+		if c.Metadata != nil {
+			op["metadata"] = c.Metadata
+		}
+		return json.Marshal(op)
+	case ENDORSEMENTWITHSLOTOP:
+		op := map[string]interface{}{
+			"kind":        c.Kind,
+			"endorsement": c.Endorsement,
+			"slot":        c.Slot,
+		}
+		if c.Metadata != nil {
+			op["metadata"] = c.Metadata
+		}
+		return json.Marshal(op)
+	case DRAINDELEGATEOP:
+		op := map[string]interface{}{
+			"kind":          c.Kind,
+			"consensus_key": c.ConsensusKey,
+			"delegate":      c.Delegate,
+			"destination":   c.Destination,
+		}
+		if c.Metadata != nil {
+			op["metadata"] = c.Metadata
+		}
+		return json.Marshal(op)
+	case TRANSACTIONOP, REVEALOP, ORIGINATIONOP, DELEGATIONOP, REGISTERGLOBALCONSTANTOP, UPDATECONSENSUSKEYOP,
+		SMARTROLLUPORIGINATEOP, SMARTROLLUPADDMESSAGESOP, SMARTROLLUPCEMENTOP, SMARTROLLUPPUBLISHOP,
+		DALPUBLISHCOMMITMENTOP:
+	default:
+		// Contents of unsupported kinds have fields Contents does not, they are marshaled as decoded.
+		if !builtinOperationKinds[c.Kind] && len(c.Raw) > 0 {
+			return c.Raw, nil
+		}
+		type contents Contents
+		return json.Marshal(contents(c))
+	}
+
+	op := map[string]interface{}{
+		"kind":          c.Kind,
+		"source":        c.Source,
+		"fee":           c.Fee.String(),
+		"counter":       c.Counter.String(),
+		"gas_limit":     c.GasLimit.String(),
+		"storage_limit": c.StorageLimit.String(),
+	}
+
+	switch c.Kind {
+	case TRANSACTIONOP:
+		op["amount"] = c.Amount.String()
+		op["destination"] = c.Destination
+		if c.Parameters != nil {
+			op["parameters"] = c.Parameters
+		}
+	case REVEALOP:
+		op["public_key"] = c.Phk
+	case ORIGINATIONOP:
+		op["balance"] = c.Balance.String()
+		if c.Delegate != "" {
+			op["delegate"] = c.Delegate
+		}
+		if c.Script != nil {
+			op["script"] = c.Script
+		}
+	case DELEGATIONOP:
+		if c.Delegate != "" {
+			op["delegate"] = c.Delegate
+		}
+	case REGISTERGLOBALCONSTANTOP:
+		op["value"] = c.Value
+	case UPDATECONSENSUSKEYOP:
+		op["pk"] = c.Pk
+	case SMARTROLLUPORIGINATEOP:
+		op["pvm_kind"] = c.PvmKind
+		op["kernel"] = c.Kernel
+		op["parameters_ty"] = c.ParametersTy
+		if c.Whitelist != nil {
+			op["whitelist"] = c.Whitelist
+		}
+	case SMARTROLLUPADDMESSAGESOP:
+		op["message"] = c.Message
+	case SMARTROLLUPCEMENTOP:
+		op["rollup"] = c.Rollup
+	case SMARTROLLUPPUBLISHOP:
+		op["rollup"] = c.Rollup
+		if c.Commitment != nil {
+			op["commitment"] = map[string]interface{}{
+				"compressed_state": c.Commitment.CompressedState,
+				"inbox_level":      c.Commitment.InboxLevel,
+				"predecessor":      c.Commitment.Predecessor,
+				"number_of_ticks":  c.Commitment.NumberOfTicks.String(),
+			}
+		}
+	case DALPUBLISHCOMMITMENTOP:
+		op["slot_header"] = c.SlotHeader
+	}
+
+	if c.Metadata != nil {
+		op["metadata"] = c.Metadata
+	}
+
+	return json.Marshal(op)
+}
+
+/*
+UnmarshalJSON Function
+Description: Implements the json.Unmarshaler interface for Contents. The public_key of a reveal is
+unmarshaled into Phk. Contents of kinds the library does not support are also decoded into Decoded; if their
+fields do not fit those of Contents, only their kind is.
+
+Parameters:
+	b:
+		The JSON representation of operation contents.
+*/
+func (c *Contents) UnmarshalJSON(b []byte) error {
+	type contents Contents
+	aux := struct {
+		*contents
+		PublicKey string `json:"public_key,omitempty"`
+	}{
+		contents: (*contents)(c),
+	}
+
+	err := json.Unmarshal(b, &aux)
+	if err != nil {
+		var kind struct {
+			Kind string `json:"kind"`
+		}
+		if json.Unmarshal(b, &kind) != nil || kind.Kind == "" || builtinOperationKinds[kind.Kind] {
+			return err
+		}
+		*c, aux.PublicKey = Contents{Kind: kind.Kind}, ""
+	}
+
+	if aux.PublicKey != "" {
+		c.Phk = aux.PublicKey
+	}
+	if c.Kind != "" && !builtinOperationKinds[c.Kind] {
+		c.Raw = append(json.RawMessage{}, b...)
+		c.Decoded, err = decodeUnsupported(c.Kind, b)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+Parameters <block>
+RPC: /chains/<chain_id>/blocks/<block_id> (<dyn>)
+Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-contracts-contract-id-balance
+*/
+type Parameters struct {
+	Entrypoint string    `json:"entrypoint"`
+	Value      Micheline `json:"value"`
 }
 
 /*
@@ -209,9 +501,78 @@ RPC: /chains/<chain_id>/blocks/<block_id> (<dyn>)
 Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-contracts-contract-id-balance
 */
 type ContentsMetadata struct {
-	BalanceUpdates  []BalanceUpdates `json:"balance_updates"`
-	OperationResult *OperationResult `json:"operation_result,omitempty"`
-	Slots           []int            `json:"slots"`
+	BalanceUpdates           []BalanceUpdates           `json:"balance_updates"`
+	OperationResult          *OperationResult           `json:"operation_result,omitempty"`
+	InternalOperationResults []InternalOperationResults `json:"internal_operation_results,omitempty"`
+	Slots                    []int                      `json:"slots"`
+	Delegate                 string                     `json:"delegate,omitempty"`
+	// Whether a drain_delegate allocated its destination.
+	AllocatedDestinationContract bool `json:"allocated_destination_contract,omitempty"`
+}
+
+/*
+InternalOperationResults <block>
+RPC: /chains/<chain_id>/blocks/<block_id> (<dyn>)
+Link: https://tezos.gitlab.io/api/rpc.html#get-block-id
+*/
+type InternalOperationResults struct {
+	Kind        string          `json:"kind"`
+	Source      string          `json:"source"`
+	Nonce       int             `json:"nonce"`
+	Amount      BigInt          `json:"amount,omitempty"`
+	Destination string          `json:"destination,omitempty"`
+	Parameters  *Parameters     `json:"parameters,omitempty"`
+	Balance     BigInt          `json:"balance,omitempty"`
+	Script      *Script         `json:"script,omitempty"`
+	Delegate    string          `json:"delegate,omitempty"`
+	Type        *Micheline      `json:"type,omitempty"`
+	Tag         string          `json:"tag,omitempty"`
+	Payload     *Micheline      `json:"payload,omitempty"`
+	Result      OperationResult `json:"result"`
+}
+
+/*
+UnmarshalJSON Function
+Description: Implements the json.Unmarshaler interface for InternalOperationResults. Oxford renamed the
+source of internal operations to sender; both are decoded into Source.
+
+Parameters:
+	v:
+		The JSON representation of an internal operation result.
+*/
+func (i *InternalOperationResults) UnmarshalJSON(v []byte) error {
+	type internalOperationResults InternalOperationResults
+	var result struct {
+		internalOperationResults
+		Sender string `json:"sender"`
+	}
+
+	err := json.Unmarshal(v, &result)
+	if err != nil {
+		return err
+	}
+
+	*i = InternalOperationResults(result.internalOperationResults)
+	if i.Source == "" {
+		i.Source = result.Sender
+	}
+
+	return nil
+}
+
+/*
+InternalOperationResults Function
+Description: Returns the internal operation results of every content of the operation, in order.
+*/
+func (o *Operations) InternalOperationResults() []InternalOperationResults {
+	results := []InternalOperationResults{}
+	for _, contents := range o.Contents {
+		if contents.Metadata != nil {
+			results = append(results, contents.Metadata.InternalOperationResults...)
+		}
+	}
+
+	return results
 }
 
 /*
@@ -243,7 +604,8 @@ Parameters:
 Head RPC
 Path: /chains/<chain_id>/blocks/head (GET)
 Link: https://tezos.gitlab.io/api/rpc.html#get-chains-chain-id-blocks
-Description: All the information about the head block.
+Description: All the information about the head block. If the head activates a protocol other than the one
+operations are forged for, the cached network constants and protocol are refreshed.
 */
 func (t *GoTezos) Head() (*Block, error) {
 	resp, err := t.get("/chains/main/blocks/head")
@@ -257,6 +619,8 @@ func (t *GoTezos) Head() (*Block, error) {
 		return &block, errors.Wrapf(err, "could not get head block")
 	}
 
+	t.followProtocol(&block)
+
 	return &block, nil
 }
 
@@ -264,33 +628,75 @@ func (t *GoTezos) Head() (*Block, error) {
 Block RPC
 Path: /chains/<chain_id>/blocks/<block_id> (GET)
 Link: https://tezos.gitlab.io/api/rpc.html#get-chains-chain-id-blocks
-Description:  All the information about block.
+Description:  All the information about block. Blocks larger than the max response size are refused, see
+WithMaxResponseSize.
 
 Parameters:
 	id:
-		hash = <string> : The block hash.
-		level = <int> : The block level.
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+	opts:
+		Query parameters: WithMetadata and WithForceMetadata.
 */
-func (t *GoTezos) Block(id interface{}) (*Block, error) {
-	blockID, err := idToString(id)
+func (t *GoTezos) Block(id BlockID, opts ...RPCOption) (*Block, error) {
+	var block Block
+	err := checkRPCOptions(opts, "metadata", "force_metadata")
 	if err != nil {
-		return &Block{}, errors.Wrapf(err, "could not get block '%s'", blockID)
+		return &block, errors.Wrap(err, "invalid input")
 	}
 
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s", blockID))
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s", id.ID()), opts...)
 	if err != nil {
-		return &Block{}, errors.Wrapf(err, "could not get block '%s'", blockID)
+		return &block, errors.Wrapf(err, "could not get block '%s'", id.ID())
 	}
 
-	var block Block
 	err = json.Unmarshal(resp, &block)
 	if err != nil {
-		return &block, errors.Wrapf(err, "could not get block '%s'", blockID)
+		return &block, errors.Wrapf(err, "could not get block '%s'", id.ID())
 	}
 
 	return &block, nil
 }
 
+/*
+BlockAtLevel Function
+Description: All the information about the block at a level, without resolving its hash first.
+
+Parameters:
+	level:
+		The level of the block, not negative.
+	opts:
+		Query parameters, see Block.
+*/
+func (t *GoTezos) BlockAtLevel(level int, opts ...RPCOption) (*Block, error) {
+	if level < 0 {
+		return &Block{}, errors.Errorf("invalid level '%d'", level)
+	}
+
+	return t.Block(BlockIDLevel(level), opts...)
+}
+
+/*
+BlockAtOffset Function
+Description: All the information about the block at an offset from another, e.g. BlockAtOffset("head", -5)
+for the fifth predecessor of the head, without resolving its hash first.
+
+Parameters:
+	base:
+		The block the offset is from: head, genesis, checkpoint, savepoint, caboose, a level or a block hash.
+	offset:
+		The number of levels from the base, negative for its predecessors.
+	opts:
+		Query parameters, see Block.
+*/
+func (t *GoTezos) BlockAtOffset(base string, offset int, opts ...RPCOption) (*Block, error) {
+	id, err := NewBlockIDOffset(base, offset)
+	if err != nil {
+		return &Block{}, err
+	}
+
+	return t.Block(id, opts...)
+}
+
 /*
 OperationHashes RPC
 Path: ../<block_id>/operation_hashes (GET)
@@ -298,11 +704,11 @@ Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-contracts-contra
 Description: The hashes of all the operations included in the block.
 
 Parameters:
-	blockhash:
-		The hash of block (height) of which you want to make the query.
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
 */
-func (t *GoTezos) OperationHashes(blockhash string) (*[]string, error) {
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/operation_hashes", blockhash))
+func (t *GoTezos) OperationHashes(blockID BlockID) (*[]string, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/operation_hashes", blockID.ID()))
 	if err != nil {
 		return &[]string{}, errors.Wrapf(err, "could not get operation hashes")
 	}
@@ -316,13 +722,102 @@ func (t *GoTezos) OperationHashes(blockhash string) (*[]string, error) {
 	return &operations, nil
 }
 
-func idToString(id interface{}) (string, error) {
-	switch v := id.(type) {
-	case int:
-		return strconv.Itoa(v), nil
-	case string:
-		return v, nil
+/*
+BlockID -
+Description: Identifies a block for the block-scoped RPCs (../<block_id>/..). Use BlockIDHead,
+BlockIDHash, BlockIDLevel, BlockIDHeadPredecessor or BlockIDOffset.
+
+Any block can be queried on an archive node. Full nodes keep every block but prune the context (balances,
+storage, counters...) of those below their savepoint, and rolling nodes also delete the blocks below their
+caboose: the context RPCs fail there. See Savepoint, Caboose and NearestAvailableBlock.
+*/
+type BlockID interface {
+	ID() string
+}
+
+// BlockIDHead is the BlockID of the current head.
+type BlockIDHead struct{}
+
+// ID satisfies the BlockID interface.
+func (b BlockIDHead) ID() string {
+	return "head"
+}
+
+// BlockIDHash is the BlockID of a block hash.
+type BlockIDHash string
+
+// ID satisfies the BlockID interface.
+func (b BlockIDHash) ID() string {
+	return string(b)
+}
+
+// BlockIDLevel is the BlockID of a block level.
+type BlockIDLevel int
+
+// ID satisfies the BlockID interface.
+func (b BlockIDLevel) ID() string {
+	return strconv.Itoa(int(b))
+}
+
+// BlockIDHeadPredecessor is the BlockID of the nth predecessor of the current head (e.g. head~2).
+type BlockIDHeadPredecessor int
+
+// ID satisfies the BlockID interface.
+func (b BlockIDHeadPredecessor) ID() string {
+	return fmt.Sprintf("head~%d", int(b))
+}
+
+// blockAliases are the names of the blocks a node keeps track of, usable as block IDs.
+var blockAliases = []string{"head", "genesis", "checkpoint", "savepoint", "caboose"}
+
+/*
+BlockIDOffset -
+Description: The BlockID of the block at an offset from another (e.g. head~5 or genesis+10). Build it with
+NewBlockIDOffset to check the base.
+*/
+type BlockIDOffset struct {
+	// The block the offset is from: an alias (e.g. head), a level or a block hash.
+	Base string
+	// The number of levels from the base, negative for its predecessors.
+	Offset int
+}
+
+/*
+NewBlockIDOffset Function
+Description: Returns the BlockID of the block at an offset from another.
+
+Parameters:
+	base:
+		The block the offset is from: head, genesis, checkpoint, savepoint, caboose, a level or a block hash.
+	offset:
+		The number of levels from the base, negative for its predecessors.
+*/
+func NewBlockIDOffset(base string, offset int) (BlockIDOffset, error) {
+	valid := false
+	for _, alias := range blockAliases {
+		valid = valid || base == alias
+	}
+	if level, err := strconv.Atoi(base); err == nil {
+		valid = level >= 0
+	} else if _, err := b58cdecodeChecked(base, prefix_branch, 32); err == nil {
+		valid = true
+	}
+
+	if !valid {
+		return BlockIDOffset{}, errors.Errorf("invalid block '%s'", base)
+	}
+
+	return BlockIDOffset{Base: base, Offset: offset}, nil
+}
+
+// ID satisfies the BlockID interface.
+func (b BlockIDOffset) ID() string {
+	switch {
+	case b.Offset < 0:
+		return fmt.Sprintf("%s~%d", b.Base, -b.Offset)
+	case b.Offset > 0:
+		return fmt.Sprintf("%s+%d", b.Base, b.Offset)
 	default:
-		return "", errors.Errorf("id must be block level (int) or block hash (string)")
+		return b.Base
 	}
 }
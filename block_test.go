@@ -2,6 +2,7 @@ package gotezos
 
 import (
 	"encoding/json"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -89,6 +90,15 @@ func Test_Block(t *testing.T) {
 				&Block{},
 			},
 		},
+		{
+			"handles rpc error",
+			gtGoldenHTTPMock(newBlockMock().handler(mockRPCErrorResp, blankHandler)),
+			want{
+				true,
+				"could not get block '50': GET /chains/main/blocks/50: rpc error (somekind): someerror",
+				&Block{},
+			},
+		},
 		{
 			"is successful",
 			gtGoldenHTTPMock(newBlockMock().handler(mockBlockResp, blankHandler)),
@@ -108,7 +118,7 @@ func Test_Block(t *testing.T) {
 			gt, err := New(server.URL)
 			assert.Nil(t, err)
 
-			block, err := gt.Block(50)
+			block, err := gt.Block(BlockIDLevel(50))
 			checkErr(t, tt.wantErr, tt.containsErr, err)
 			assert.Equal(t, tt.want.wantBlock, block)
 		})
@@ -158,45 +168,231 @@ func Test_OperationHashes(t *testing.T) {
 			gt, err := New(server.URL)
 			assert.Nil(t, err)
 
-			operationHashes, err := gt.OperationHashes("BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1")
+			operationHashes, err := gt.OperationHashes(BlockIDHash("BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1"))
 			checkErr(t, tt.wantErr, tt.containsErr, err)
 			assert.Equal(t, tt.want.wantOperationHashes, operationHashes)
 		})
 	}
 }
 
-func Test_idToString(t *testing.T) {
+func Test_ContentsJSON(t *testing.T) {
 	cases := []struct {
-		name    string
-		input   interface{}
-		wantErr bool
-		wantID  string
+		name  string
+		input Contents
+		want  string
 	}{
 		{
-			"uses integer id",
-			50,
-			false,
-			"50",
+			"transaction",
+			Contents{
+				Kind:         TRANSACTIONOP,
+				Source:       "tz1YGLnq1Ls4W3rPanAvCvmcuQ1H5rffnc2V",
+				Fee:          BigInt{*big.NewInt(1283)},
+				Counter:      BigInt{*big.NewInt(11)},
+				GasLimit:     BigInt{*big.NewInt(10307)},
+				StorageLimit: BigInt{*big.NewInt(0)},
+				Amount:       BigInt{*big.NewInt(1000000)},
+				Destination:  "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK",
+			},
+			`{"kind":"transaction","source":"tz1YGLnq1Ls4W3rPanAvCvmcuQ1H5rffnc2V","fee":"1283","counter":"11","gas_limit":"10307","storage_limit":"0","amount":"1000000","destination":"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"}`,
 		},
 		{
-			"uses string id",
-			"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1",
-			false,
+			"reveal",
+			Contents{
+				Kind:   REVEALOP,
+				Source: "tz1YGLnq1Ls4W3rPanAvCvmcuQ1H5rffnc2V",
+				Phk:    "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G",
+			},
+			`{"kind":"reveal","source":"tz1YGLnq1Ls4W3rPanAvCvmcuQ1H5rffnc2V","fee":"0","counter":"0","gas_limit":"0","storage_limit":"0","public_key":"edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G"}`,
+		},
+		{
+			"delegation",
+			Contents{
+				Kind:     DELEGATIONOP,
+				Source:   "tz1YGLnq1Ls4W3rPanAvCvmcuQ1H5rffnc2V",
+				Delegate: "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK",
+			},
+			`{"kind":"delegation","source":"tz1YGLnq1Ls4W3rPanAvCvmcuQ1H5rffnc2V","fee":"0","counter":"0","gas_limit":"0","storage_limit":"0","delegate":"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"}`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := json.Marshal(tt.input)
+			assert.Nil(t, err)
+			assert.JSONEq(t, tt.want, string(v))
+
+			var c Contents
+			err = json.Unmarshal(v, &c)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.input.Phk, c.Phk)
+			assert.Equal(t, tt.input.Fee.String(), c.Fee.String())
+		})
+	}
+
+	// Only contents quote big numbers, as the node expects.
+	v, err := json.Marshal(struct {
+		Balance *BigInt `json:"balance"`
+	}{&BigInt{*big.NewInt(1000000)}})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"balance":1000000}`, string(v))
+
+	var balance BigInt
+	assert.Nil(t, json.Unmarshal([]byte(`1000000`), &balance))
+	assert.Equal(t, int64(1000000), balance.Int64())
+}
+
+func Test_BlockID(t *testing.T) {
+	cases := []struct {
+		name  string
+		input BlockID
+		want  string
+	}{
+		{
+			"uses head",
+			BlockIDHead{},
+			"head",
+		},
+		{
+			"uses hash",
+			BlockIDHash("BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1"),
 			"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1",
 		},
 		{
-			"uses bad id type",
-			45.433,
-			true,
-			"",
+			"uses level",
+			BlockIDLevel(50),
+			"50",
+		},
+		{
+			"uses head predecessor",
+			BlockIDHeadPredecessor(2),
+			"head~2",
+		},
+		{
+			"uses negative offset",
+			BlockIDOffset{"head", -5},
+			"head~5",
+		},
+		{
+			"uses positive offset",
+			BlockIDOffset{"genesis", 10},
+			"genesis+10",
+		},
+		{
+			"uses zero offset",
+			BlockIDOffset{"checkpoint", 0},
+			"checkpoint",
 		},
 	}
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			id, err := idToString(tt.input)
-			checkErr(t, tt.wantErr, "", err)
-			assert.Equal(t, tt.wantID, id)
+			assert.Equal(t, tt.want, tt.input.ID())
 		})
 	}
 }
+
+func Test_BlockAtOffset(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write(mockBlockResp)
+	})))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	_, err = gt.BlockAtLevel(100)
+	assert.Nil(t, err)
+	_, err = gt.BlockAtOffset("head", -5)
+	assert.Nil(t, err)
+	_, err = gt.BlockAtOffset("100", 2)
+	assert.Nil(t, err)
+	_, err = gt.BlockAtOffset(mockBlockHash, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"/chains/main/blocks/100",
+		"/chains/main/blocks/head~5",
+		"/chains/main/blocks/100+2",
+		"/chains/main/blocks/" + mockBlockHash + "~1",
+	}, paths)
+
+	_, err = gt.BlockAtLevel(-1)
+	checkErr(t, true, "invalid level '-1'", err)
+	_, err = gt.BlockAtOffset("tail", -5)
+	checkErr(t, true, "invalid block 'tail'", err)
+	_, err = gt.BlockAtOffset("-3", 1)
+	checkErr(t, true, "invalid block '-3'", err)
+	assert.Len(t, paths, 4)
+}
+
+func Test_InternalOperationResults(t *testing.T) {
+	var operation Operations
+	err := json.Unmarshal([]byte(`{
+		"hash": "opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A",
+		"contents": [{
+			"kind": "transaction",
+			"metadata": {
+				"balance_updates": [],
+				"internal_operation_results": [
+					{
+						"kind": "transaction",
+						"source": "KT1TxqZ8QtKvLu3V3JH7Gx58n7Co8pgtpQU5",
+						"nonce": 0,
+						"amount": "100",
+						"destination": "KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9",
+						"parameters": {"entrypoint": "transfer", "value": {"int": "1"}},
+						"result": {
+							"status": "applied",
+							"storage": {"int": "42"},
+							"big_map_diff": [{"action": "update", "big_map": "17", "key_hash": "exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC", "key": {"int": "1"}, "value": {"int": "2"}}],
+							"consumed_milligas": "1955000"
+						}
+					},
+					{
+						"kind": "origination",
+						"sender": "KT1TxqZ8QtKvLu3V3JH7Gx58n7Co8pgtpQU5",
+						"nonce": 1,
+						"balance": "10",
+						"script": {"code": [], "storage": {"int": "0"}},
+						"result": {"status": "backtracked", "originated_contracts": ["KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9"]}
+					},
+					{
+						"kind": "delegation",
+						"sender": "KT1TxqZ8QtKvLu3V3JH7Gx58n7Co8pgtpQU5",
+						"nonce": 2,
+						"delegate": "tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q",
+						"result": {"status": "failed", "errors": [{"kind": "temporary", "id": "proto.018-Proxford.delegate.unchanged"}]}
+					}
+				]
+			}
+		}]
+	}`), &operation)
+	assert.Nil(t, err)
+
+	results := operation.InternalOperationResults()
+	assert.Len(t, results, 3)
+
+	transaction := results[0]
+	assert.Equal(t, "KT1TxqZ8QtKvLu3V3JH7Gx58n7Co8pgtpQU5", transaction.Source)
+	assert.Equal(t, "100", transaction.Amount.String())
+	assert.Equal(t, "transfer", transaction.Parameters.Entrypoint)
+	assert.Equal(t, APPLIEDSTATUS, transaction.Result.Status)
+	assert.Equal(t, "1955000", transaction.Result.ConsumedMilligas.String())
+	assert.Equal(t, "42", transaction.Result.Storage.Int.String())
+	assert.Len(t, transaction.Result.BigMapDiff, 1)
+	assert.Equal(t, "17", transaction.Result.BigMapDiff[0].BigMap)
+
+	origination := results[1]
+	assert.Equal(t, "KT1TxqZ8QtKvLu3V3JH7Gx58n7Co8pgtpQU5", origination.Source)
+	assert.Equal(t, "10", origination.Balance.String())
+	assert.NotNil(t, origination.Script)
+	assert.Equal(t, BACKTRACKEDSTATUS, origination.Result.Status)
+	assert.Equal(t, []string{"KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9"}, origination.Result.OriginatedContracts)
+
+	delegation := results[2]
+	assert.Equal(t, 2, delegation.Nonce)
+	assert.Equal(t, "tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q", delegation.Delegate)
+	assert.Equal(t, FAILEDSTATUS, delegation.Result.Status)
+	assert.Equal(t, "proto.018-Proxford.delegate.unchanged", delegation.Result.Errors[0].ID)
+}
@@ -0,0 +1,135 @@
+package gotezos
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+/*
+Round Function
+Description: Returns the round the block was baked at, read from its fitness. Blocks before Tenderbake have no
+round, their priority is returned.
+*/
+func (h *Header) Round() (int, error) {
+	// Tenderbake fitness: version, level, locked round, opposite of the predecessor round, round.
+	if len(h.Fitness) != 5 || h.Fitness[0] != "02" {
+		return h.Priority, nil
+	}
+
+	round, err := strconv.ParseUint(h.Fitness[4], 16, 32)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid round '%s' in fitness", h.Fitness[4])
+	}
+
+	return int(round), nil
+}
+
+/*
+RoundDuration Function
+Description: Returns how long a round lasts: minimal_block_delay for round zero, and delay_increment_per_round more
+for each round after it. Tenderbake protocols only.
+
+Parameters:
+	round:
+		The round.
+*/
+func (c *Constants) RoundDuration(round int) (time.Duration, error) {
+	duration, err := roundDurations(c)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to compute round duration")
+	}
+
+	return duration(round), nil
+}
+
+/*
+ExpectedTimestamp Function
+Description: Returns the timestamp of the block at a level and round, estimated from a block before it: the
+level after a block starts once the round of the block is over, and the blocks in between are assumed to be
+baked at round zero. A block at round zero later than expected is late. Tenderbake protocols only.
+
+Parameters:
+	from:
+		The header of a block before the level, e.g. of the head.
+	level:
+		The level of the block.
+	round:
+		The round of the block.
+*/
+func (c *Constants) ExpectedTimestamp(from *Header, level, round int) (time.Time, error) {
+	if level <= from.Level {
+		return time.Time{}, errors.Errorf("failed to compute expected timestamp: level %d is not after level %d", level, from.Level)
+	}
+
+	duration, err := roundDurations(c)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to compute expected timestamp")
+	}
+
+	fromRound, err := from.Round()
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to compute expected timestamp")
+	}
+
+	start := from.Timestamp.Time.Add(duration(fromRound) + time.Duration(level-from.Level-1)*duration(0))
+	for r := 0; r < round; r++ {
+		start = start.Add(duration(r))
+	}
+
+	return start, nil
+}
+
+/*
+RoundAt Function
+Description: Returns the round in progress at a time for the level after a block, e.g. with the head and the
+current time, the round the next block is awaited at: a round above zero means the block is late. Times before
+round zero starts are at round zero. Tenderbake protocols only.
+
+Parameters:
+	predecessor:
+		The header of the block before the level.
+	at:
+		The time.
+*/
+func (c *Constants) RoundAt(predecessor *Header, at time.Time) (int, error) {
+	duration, err := roundDurations(c)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to compute round")
+	}
+
+	round, err := predecessor.Round()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to compute round")
+	}
+
+	end := predecessor.Timestamp.Time.Add(duration(round))
+	for r := 0; ; r++ {
+		end = end.Add(duration(r))
+		if at.Before(end) {
+			return r, nil
+		}
+	}
+}
+
+// roundDurations returns the duration of the rounds of Tenderbake constants.
+func roundDurations(constants *Constants) (func(round int) time.Duration, error) {
+	if constants.Tenderbake == nil {
+		return nil, errors.New("not a Tenderbake protocol")
+	}
+
+	minimal, err := strconv.Atoi(constants.Tenderbake.MinimalBlockDelay)
+	if err != nil || minimal <= 0 {
+		return nil, errors.Errorf("invalid minimal_block_delay '%s'", constants.Tenderbake.MinimalBlockDelay)
+	}
+
+	increment, err := strconv.Atoi(constants.Tenderbake.DelayIncrementPerRound)
+	if err != nil {
+		return nil, errors.Errorf("invalid delay_increment_per_round '%s'", constants.Tenderbake.DelayIncrementPerRound)
+	}
+
+	return func(round int) time.Duration {
+		return time.Duration(minimal+increment*round) * time.Second
+	}, nil
+}
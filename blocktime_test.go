@@ -0,0 +1,123 @@
+package gotezos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HeaderRound(t *testing.T) {
+	header := Header{Fitness: []string{"02", "0013d620", "", "fffffffe", "00000001"}}
+	round, err := header.Round()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, round)
+
+	header = Header{Fitness: []string{"01", "00000000000a8e2c"}, Priority: 3}
+	round, err = header.Round()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, round)
+
+	header = Header{Fitness: []string{"02", "0013d620", "", "fffffffe", "zz"}}
+	_, err = header.Round()
+	checkErr(t, true, "invalid round 'zz' in fitness", err)
+}
+
+func Test_ExpectedTimestamp(t *testing.T) {
+	constants := &Constants{Tenderbake: &TenderbakeConstants{MinimalBlockDelay: "15", DelayIncrementPerRound: "8"}}
+	at := time.Date(2023, 6, 14, 8, 11, 30, 0, time.UTC)
+	// Baked at round 1, which lasts 23 seconds.
+	head := &Header{Level: 100, Timestamp: Timestamp{at}, Fitness: []string{"02", "00000064", "", "ffffffff", "00000001"}}
+
+	type want struct {
+		err         bool
+		containsErr string
+		timestamp   time.Time
+	}
+
+	cases := []struct {
+		name      string
+		constants *Constants
+		level     int
+		round     int
+		want
+	}{
+		{
+			"estimates the next block at round zero",
+			constants,
+			101,
+			0,
+			want{false, "", at.Add(23 * time.Second)},
+		},
+		{
+			"estimates the next block at a later round",
+			constants,
+			101,
+			2,
+			want{false, "", at.Add(61 * time.Second)},
+		},
+		{
+			"estimates a later level",
+			constants,
+			103,
+			0,
+			want{false, "", at.Add(53 * time.Second)},
+		},
+		{
+			"handles level not after the block",
+			constants,
+			100,
+			0,
+			want{true, "level 100 is not after level 100", time.Time{}},
+		},
+		{
+			"handles constants before tenderbake",
+			&Constants{TimeBetweenBlocks: []string{"60", "40"}},
+			101,
+			0,
+			want{true, "not a Tenderbake protocol", time.Time{}},
+		},
+		{
+			"handles invalid constants",
+			&Constants{Tenderbake: &TenderbakeConstants{MinimalBlockDelay: "0", DelayIncrementPerRound: "8"}},
+			101,
+			0,
+			want{true, "invalid minimal_block_delay '0'", time.Time{}},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			timestamp, err := tt.constants.ExpectedTimestamp(head, tt.level, tt.round)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			assert.Equal(t, tt.want.timestamp, timestamp)
+		})
+	}
+}
+
+func Test_RoundAt(t *testing.T) {
+	constants := &Constants{Tenderbake: &TenderbakeConstants{MinimalBlockDelay: "15", DelayIncrementPerRound: "8"}}
+	at := time.Date(2023, 6, 14, 8, 11, 30, 0, time.UTC)
+	head := &Header{Level: 100, Timestamp: Timestamp{at}, Fitness: []string{"02", "00000064", "", "ffffffff", "00000001"}}
+
+	for _, tt := range []struct {
+		after time.Duration
+		round int
+	}{
+		{10 * time.Second, 0},
+		{37 * time.Second, 0},
+		{38 * time.Second, 1},
+		{61 * time.Second, 2},
+	} {
+		round, err := constants.RoundAt(head, at.Add(tt.after))
+		assert.Nil(t, err)
+		assert.Equal(t, tt.round, round, "%s after the head", tt.after)
+	}
+
+	duration, err := constants.RoundDuration(2)
+	assert.Nil(t, err)
+	assert.Equal(t, 31*time.Second, duration)
+
+	_, err = (&Constants{}).RoundAt(head, at)
+	checkErr(t, true, "failed to compute round: not a Tenderbake protocol", err)
+}
@@ -0,0 +1,97 @@
+package gotezos
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrCircuitOpen is the cause (see errors.Cause) of the errors of requests refused, without contacting the node,
+// while the circuit breaker of the node is open. See WithCircuitBreaker.
+var ErrCircuitOpen = errors.New("circuit breaker is open: node is failing")
+
+/*
+WithCircuitBreaker Function
+Description: Stops sending requests to the node once failures requests in a row failed, so that code using
+several nodes moves on from a dead node without waiting on timeouts. Requests fail with ErrCircuitOpen until
+probeInterval has passed, then a single request is let through to probe the node: if it succeeds requests are
+resumed, otherwise the node is probed again after another probeInterval. A request fails when the node cannot
+be reached or returns a 5xx status, RPC errors (e.g. a failed operation) do not count.
+
+Parameters:
+	failures:
+		The number of failed requests in a row that opens the circuit.
+	probeInterval:
+		How often the node is probed while the circuit is open.
+*/
+func WithCircuitBreaker(failures int, probeInterval time.Duration) Option {
+	return func(o *options) {
+		o.breaker = &circuitBreaker{threshold: failures, probeInterval: probeInterval, now: time.Now}
+	}
+}
+
+type circuitBreaker struct {
+	mu            sync.Mutex
+	threshold     int
+	probeInterval time.Duration
+	now           func() time.Time
+
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// allow returns whether a request can be sent: the circuit is closed, or it is time to probe the node.
+func (c *circuitBreaker) allow() bool {
+	if c == nil {
+		return true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.failures < c.threshold {
+		return true
+	}
+
+	if c.probing || c.now().Sub(c.openedAt) < c.probeInterval {
+		return false
+	}
+
+	c.probing = true
+	return true
+}
+
+// record records the outcome of a request allowed by allow.
+func (c *circuitBreaker) record(ok bool) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.probing = false
+	if ok {
+		c.failures = 0
+		return
+	}
+
+	c.failures++
+	if c.failures >= c.threshold {
+		c.openedAt = c.now()
+	}
+}
+
+// release releases a request allowed by allow without recording an outcome, e.g. a canceled request.
+func (c *circuitBreaker) release() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.probing = false
+}
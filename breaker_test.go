@@ -0,0 +1,81 @@
+package gotezos
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CircuitBreaker(t *testing.T) {
+	var failing int32
+	var requests int32
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if atomic.LoadInt32(&failing) == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		rw.Write([]byte(`"NetXdQprcVkpaWU"`))
+	})))
+	defer server.Close()
+
+	gt, err := New(server.URL, WithCircuitBreaker(2, time.Minute))
+	assert.Nil(t, err)
+
+	now := time.Now()
+	gt.breaker.now = func() time.Time { return now }
+
+	atomic.StoreInt32(&failing, 1)
+	for i := 0; i < 2; i++ {
+		_, err = gt.ChainID()
+		checkErr(t, true, "response returned code 503", err)
+	}
+
+	_, err = gt.ChainID()
+	assert.Equal(t, ErrCircuitOpen, errors.Cause(err))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	// The probe fails, the circuit stays open for another probe interval.
+	now = now.Add(time.Minute)
+	_, err = gt.ChainID()
+	checkErr(t, true, "response returned code 503", err)
+	_, err = gt.ChainID()
+	assert.Equal(t, ErrCircuitOpen, errors.Cause(err))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+	// The probe succeeds, requests are resumed.
+	atomic.StoreInt32(&failing, 0)
+	now = now.Add(time.Minute)
+	for i := 0; i < 2; i++ {
+		chainID, err := gt.ChainID()
+		assert.Nil(t, err)
+		assert.Equal(t, "NetXdQprcVkpaWU", *chainID)
+	}
+	assert.Equal(t, int32(5), atomic.LoadInt32(&requests))
+}
+
+func Test_CircuitBreakerProbe(t *testing.T) {
+	now := time.Now()
+	breaker := &circuitBreaker{threshold: 1, probeInterval: time.Second, now: func() time.Time { return now }}
+
+	assert.True(t, breaker.allow())
+	breaker.record(false)
+	assert.False(t, breaker.allow())
+
+	// A single probe is let through at a time, a canceled probe lets another through.
+	now = now.Add(time.Second)
+	assert.True(t, breaker.allow())
+	assert.False(t, breaker.allow())
+	breaker.release()
+	assert.True(t, breaker.allow())
+	breaker.record(true)
+	assert.True(t, breaker.allow())
+
+	var disabled *circuitBreaker
+	assert.True(t, disabled.allow())
+}
@@ -0,0 +1,104 @@
+package gotezos
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+/*
+StorageBurn Function
+Description: Returns the tez, in mutez, burned to pay for new storage: cost_per_byte for each byte.
+
+Parameters:
+	bytes:
+		The bytes of storage paid for, e.g. the paid_storage_size_diff of an operation result.
+*/
+func (c *Constants) StorageBurn(bytes int64) (*BigInt, error) {
+	var costPerByte big.Int
+	if _, ok := costPerByte.SetString(c.CostPerByte, 10); !ok {
+		return nil, errors.Errorf("invalid cost_per_byte '%s'", c.CostPerByte)
+	}
+
+	var burn BigInt
+	burn.Mul(&costPerByte, big.NewInt(bytes))
+	return &burn, nil
+}
+
+/*
+AllocationBurn Function
+Description: Returns the tez, in mutez, burned when an account is allocated: origination_size bytes paid for, e.g.
+by a transfer to an empty implicit account.
+*/
+func (c *Constants) AllocationBurn() (*BigInt, error) {
+	return c.StorageBurn(int64(c.OriginationSize))
+}
+
+/*
+OriginationBurn Function
+Description: Returns the tez, in mutez, burned by the origination of a contract: the allocation of the contract
+and the storage of its code and initial storage. Global constants in the code are paid for as written.
+
+Parameters:
+	script:
+		The code and initial storage of the contract.
+*/
+func (c *Constants) OriginationBurn(script Script) (*BigInt, error) {
+	size, err := ScriptSize(script)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute origination burn")
+	}
+
+	return c.StorageBurn(int64(c.OriginationSize) + size)
+}
+
+/*
+MaxCost Function
+Description: Returns the most tez, in mutez, the source of manager operation contents can spend: their fees,
+the amounts transferred and balances originated, and the burn of their storage limits. The burn of allocations
+is part of the storage limits.
+
+Parameters:
+	contents:
+		The contents of the operation, with their fees and limits set (see WalletClient.Prepare).
+*/
+func (c *Constants) MaxCost(contents ...Contents) (*BigInt, error) {
+	var cost BigInt
+	var storage int64
+	for _, content := range contents {
+		cost.Add(&cost.Int, &content.Fee.Int)
+		cost.Add(&cost.Int, &content.Amount.Int)
+		cost.Add(&cost.Int, &content.Balance.Int)
+		storage += content.StorageLimit.Int64()
+	}
+
+	burn, err := c.StorageBurn(storage)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute max cost")
+	}
+	cost.Add(&cost.Int, &burn.Int)
+
+	return &cost, nil
+}
+
+/*
+ScriptSize Function
+Description: Returns the bytes of storage the code and storage of a contract take, as paid for at its origination.
+
+Parameters:
+	script:
+		The code and storage of the contract, in optimized form for the storage to be sized as on chain.
+*/
+func ScriptSize(script Script) (int64, error) {
+	code, err := script.Code.MarshalBinary()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to encode code")
+	}
+
+	storage, err := script.Storage.MarshalBinary()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to encode storage")
+	}
+
+	return int64(len(code) + len(storage)), nil
+}
@@ -0,0 +1,47 @@
+package gotezos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Burn(t *testing.T) {
+	constants := Constants{CostPerByte: "250", OriginationSize: 257}
+
+	burn, err := constants.StorageBurn(100)
+	assert.Nil(t, err)
+	assert.Equal(t, "25000", burn.String())
+
+	burn, err = constants.AllocationBurn()
+	assert.Nil(t, err)
+	assert.Equal(t, "64250", burn.String())
+
+	code, err := ParseMichelson("{ parameter unit ; storage unit ; code { CDR ; NIL operation ; PAIR } }")
+	assert.Nil(t, err)
+	script := Script{Code: code, Storage: NewMichelinePrim("Unit")}
+
+	size, err := ScriptSize(script)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(30), size)
+
+	burn, err = constants.OriginationBurn(script)
+	assert.Nil(t, err)
+	assert.Equal(t, "71750", burn.String())
+
+	var transfer Contents
+	transfer.Fee.SetInt64(400)
+	transfer.Amount.SetInt64(1000000)
+	transfer.StorageLimit.SetInt64(257)
+	var origination Contents
+	origination.Fee.SetInt64(600)
+	origination.Balance.SetInt64(5000)
+	origination.StorageLimit.SetInt64(284)
+
+	cost, err := constants.MaxCost(transfer, origination)
+	assert.Nil(t, err)
+	assert.Equal(t, "1141250", cost.String())
+
+	_, err = (&Constants{}).MaxCost(transfer)
+	checkErr(t, true, "failed to compute max cost: invalid cost_per_byte ''", err)
+}
@@ -19,7 +19,7 @@ type Checkpoint struct {
 		Level          int       `json:"level"`
 		Proto          int       `json:"proto"`
 		Predecessor    string    `json:"predecessor"`
-		Timestamp      time.Time `json:"timestamp"`
+		Timestamp      Timestamp `json:"timestamp"`
 		ValidationPass int       `json:"validation_pass"`
 		OperationsHash string    `json:"operations_hash"`
 		Fitness        []string  `json:"fitness"`
@@ -54,6 +54,8 @@ type BlocksInput struct {
 	Head *string
 	// When `min_date` is provided, heads with a timestamp before `min_date` are filtered out
 	MinDate *time.Time
+	// More query parameters: WithLength, WithHead and WithMinDate.
+	Options []RPCOption
 }
 
 /*
@@ -69,6 +71,11 @@ Parameters:
 		Modifies the Blocks RPC query by passing optional URL parameters.
 */
 func (t *GoTezos) Blocks(input *BlocksInput) (*[][]string, error) {
+	err := checkRPCOptions(input.Options, "length", "head", "min_date")
+	if err != nil {
+		return &[][]string{}, errors.Wrap(err, "invalid input")
+	}
+
 	resp, err := t.get("/chains/main/blocks", input.contructRPCOptions()...)
 	if err != nil {
 		return &[][]string{}, errors.Wrap(err, "failed to get blocks")
@@ -83,32 +90,39 @@ func (t *GoTezos) Blocks(input *BlocksInput) (*[][]string, error) {
 	return &blocks, nil
 }
 
-func (b *BlocksInput) contructRPCOptions() []rpcOptions {
-	var opts []rpcOptions
+func (b *BlocksInput) contructRPCOptions() []RPCOption {
+	var opts []RPCOption
 	if b.Length > 0 {
-		opts = append(opts, rpcOptions{
-			"length",
-			strconv.Itoa(b.Length),
+		opts = append(opts, RPCOption{
+			Key:   "length",
+			Value: strconv.Itoa(b.Length),
 		})
 	}
 
 	if b.Head != nil {
-		opts = append(opts, rpcOptions{
-			"head",
-			*b.Head,
+		opts = append(opts, RPCOption{
+			Key:   "head",
+			Value: *b.Head,
 		})
 	}
 
 	if b.MinDate != nil {
-		opts = append(opts, rpcOptions{
-			"min_date",
-			strconv.Itoa(int(b.MinDate.Unix())),
+		opts = append(opts, RPCOption{
+			Key:   "min_date",
+			Value: strconv.Itoa(int(b.MinDate.Unix())),
 		})
 	}
 
-	return opts
+	return append(opts, b.Options...)
 }
 
+const (
+	// MainnetChainID is the chain ID of the Tezos mainnet.
+	MainnetChainID = "NetXdQprcVkpaWU"
+	// GhostnetChainID is the chain ID of Ghostnet, the long running Tezos testnet.
+	GhostnetChainID = "NetXnHfVqm9iesp"
+)
+
 /*
 ChainID RPC
 Path: /chains/<chain_id>/chain_id (GET)
@@ -151,6 +165,77 @@ func (t *GoTezos) Checkpoint() (*Checkpoint, error) {
 	return &c, nil
 }
 
+/*
+HistoryLevel Result
+RPC: /chains/<chain_id>/levels/savepoint, /chains/<chain_id>/levels/caboose (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-chains-chain-id-levels-savepoint
+*/
+type HistoryLevel struct {
+	BlockHash string `json:"block_hash"`
+	Level     int    `json:"level"`
+}
+
+/*
+Savepoint RPC
+Path: /chains/<chain_id>/levels/savepoint (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-chains-chain-id-levels-savepoint
+Description: The lowest block whose context (e.g. balances, storage and counters) and metadata the node keeps.
+It is the genesis on archive nodes, while full and rolling nodes prune the context of the blocks of the older
+cycles. Context RPCs at a block below the savepoint fail, see NearestAvailableBlock.
+*/
+func (t *GoTezos) Savepoint() (*HistoryLevel, error) {
+	return t.historyLevel("savepoint")
+}
+
+/*
+Caboose RPC
+Path: /chains/<chain_id>/levels/caboose (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-chains-chain-id-levels-caboose
+Description: The lowest block the node keeps, at least its header. It is the genesis on archive and full
+nodes, while rolling nodes delete the blocks of the older cycles.
+*/
+func (t *GoTezos) Caboose() (*HistoryLevel, error) {
+	return t.historyLevel("caboose")
+}
+
+func (t *GoTezos) historyLevel(name string) (*HistoryLevel, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/levels/%s", name))
+	if err != nil {
+		return &HistoryLevel{}, errors.Wrapf(err, "failed to get %s", name)
+	}
+
+	var level HistoryLevel
+	err = json.Unmarshal(resp, &level)
+	if err != nil {
+		return &HistoryLevel{}, errors.Wrapf(err, "failed to unmarshal %s", name)
+	}
+
+	return &level, nil
+}
+
+/*
+NearestAvailableBlock Function
+Description: Returns the block nearest to a level whose context the node still keeps: the level itself, or the
+savepoint if the context of the level has been pruned (on full and rolling nodes). Use it to query e.g. balances
+as far back as the node allows.
+
+Parameters:
+	level:
+		The level of which you want to make the query.
+*/
+func (t *GoTezos) NearestAvailableBlock(level int) (BlockIDLevel, error) {
+	savepoint, err := t.Savepoint()
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get nearest available block to level '%d'", level)
+	}
+
+	if level < savepoint.Level {
+		return BlockIDLevel(savepoint.Level), nil
+	}
+
+	return BlockIDLevel(level), nil
+}
+
 /*
 InvalidBlocks RPC
 Path: /chains/<chain_id>/invalid_blocks (GET)
@@ -214,6 +214,61 @@ func Test_Checkpoint(t *testing.T) {
 	}
 }
 
+func Test_HistoryLevels(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(routesHandlerMock(map[string][]byte{
+		"/levels/savepoint": []byte(`{"block_hash":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","level":5000}`),
+		"/levels/caboose":   []byte(`{"block_hash":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","level":4000}`),
+	}, blankHandler)))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	savepoint, err := gt.Savepoint()
+	assert.Nil(t, err)
+	assert.Equal(t, &HistoryLevel{BlockHash: "BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1", Level: 5000}, savepoint)
+
+	caboose, err := gt.Caboose()
+	assert.Nil(t, err)
+	assert.Equal(t, 4000, caboose.Level)
+
+	cases := []struct {
+		name  string
+		level int
+		want  BlockIDLevel
+	}{
+		{"keeps available level", 6000, BlockIDLevel(6000)},
+		{"keeps savepoint", 5000, BlockIDLevel(5000)},
+		{"moves pruned level to savepoint", 100, BlockIDLevel(5000)},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			block, err := gt.NearestAvailableBlock(tt.level)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, block)
+		})
+	}
+}
+
+func Test_HistoryLevelsErrors(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(routesHandlerMock(map[string][]byte{
+		"/levels/savepoint": mockRPCErrorResp,
+		"/levels/caboose":   []byte(`junk`),
+	}, blankHandler)))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	_, err = gt.Savepoint()
+	checkErr(t, true, "failed to get savepoint", err)
+	_, err = gt.Caboose()
+	checkErr(t, true, "failed to unmarshal caboose", err)
+	_, err = gt.NearestAvailableBlock(100)
+	checkErr(t, true, "failed to get nearest available block to level '100'", err)
+}
+
 func Test_InvalidBlocks(t *testing.T) {
 
 	var goldenInvalidBlocks []InvalidBlock
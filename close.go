@@ -0,0 +1,86 @@
+package gotezos
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// ErrClosed is the cause of the errors of requests made with a GoTezos after Close.
+var ErrClosed = errors.New("client is closed")
+
+/*
+Close Function
+Description: Closes GoTezos, so that long-running services can recycle clients without leaking goroutines and
+connections: open streams are aborted, monitors and pollers (e.g. MonitorMempool and PollHeads) stop as if their
+context was done, and the idle connections of the transport are closed. Requests in flight run to completion,
+any request made after fails with ErrClosed as the cause. Closing a closed GoTezos does nothing.
+*/
+func (t *GoTezos) Close() error {
+	t.mu.Lock()
+	if t.done == nil {
+		t.done = make(chan struct{})
+	}
+	select {
+	case <-t.done:
+	default:
+		close(t.done)
+	}
+	t.mu.Unlock()
+
+	if t.client != nil {
+		t.client.CloseIdleConnections()
+	}
+
+	return nil
+}
+
+// closing returns a channel closed once GoTezos is closed.
+func (t *GoTezos) closing() <-chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.done == nil {
+		t.done = make(chan struct{})
+	}
+
+	return t.done
+}
+
+func (t *GoTezos) isClosed() bool {
+	select {
+	case <-t.closing():
+		return true
+	default:
+		return false
+	}
+}
+
+// untilClosed returns a context done once ctx is done or GoTezos is closed. The context must be canceled once
+// no longer used, so that its goroutine exits.
+func (t *GoTezos) untilClosed(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	closing := t.closing()
+	go func() {
+		select {
+		case <-closing:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
+
+// cancelingBody cancels the context of its request once closed.
+type cancelingBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,68 @@
+package gotezos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Close(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chains/main/mempool/monitor_operations" {
+			w.Write(mockBlockResp)
+			return
+		}
+
+		w.Write([]byte(`[{"hash":"op1"}]`))
+		w.(http.Flusher).Flush()
+
+		<-r.Context().Done()
+	})))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	operations, mempoolErrs, err := gt.MonitorMempool(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "op1", (<-operations).Hash)
+
+	blocks, pollErrs, err := gt.PollHeads(context.Background(), &HeadPollerInput{Interval: time.Hour})
+	assert.Nil(t, err)
+	assert.NotNil(t, <-blocks)
+
+	assert.Nil(t, gt.Close())
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for range operations {
+		}
+		checkErr(t, true, "context canceled", <-mempoolErrs)
+
+		for range blocks {
+		}
+		checkErr(t, true, "context canceled", <-pollErrs)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("monitors did not stop on close")
+	}
+
+	_, err = gt.Head()
+	checkErr(t, true, "client is closed", err)
+	assert.Equal(t, ErrClosed, errors.Cause(err))
+
+	_, _, err = gt.MonitorMempool(context.Background(), nil)
+	assert.Equal(t, ErrClosed, errors.Cause(err))
+
+	assert.Nil(t, gt.Close())
+	assert.Nil(t, (&GoTezos{}).Close())
+}
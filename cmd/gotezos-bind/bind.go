@@ -0,0 +1,525 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	gotezos "github.com/goat-systems/go-tezos/v2"
+	"github.com/pkg/errors"
+)
+
+// leafKind is how a leaf of a Michelson type is represented in Go.
+type leafKind int
+
+const (
+	leafMicheline leafKind = iota
+	leafInt
+	leafString
+	leafBool
+	leafBytes
+	leafTimestamp
+	leafUnit
+)
+
+var leafKinds = map[string]leafKind{
+	"int":       leafInt,
+	"nat":       leafInt,
+	"mutez":     leafInt,
+	"big_map":   leafInt,
+	"string":    leafString,
+	"address":   leafString,
+	"contract":  leafString,
+	"key_hash":  leafString,
+	"key":       leafString,
+	"signature": leafString,
+	"chain_id":  leafString,
+	"bool":      leafBool,
+	"bytes":     leafBytes,
+	"timestamp": leafTimestamp,
+	"unit":      leafUnit,
+}
+
+var goTypes = map[leafKind]string{
+	leafMicheline: "gotezos.Micheline",
+	leafInt:       "*big.Int",
+	leafString:    "string",
+	leafBool:      "bool",
+	leafBytes:     "[]byte",
+	leafTimestamp: "time.Time",
+}
+
+// field is a leaf of a type bound to a Go parameter or struct field.
+type field struct {
+	Name      string
+	GoType    string
+	Michelson string
+	Kind      leafKind
+	// The statements decoding the storage field from the leaves of the storage.
+	Decode string
+}
+
+type entrypoint struct {
+	Name      string
+	Method    string
+	Michelson string
+	Params    []field
+	Value     string
+}
+
+type binding struct {
+	Package     string
+	Type        string
+	Receiver    string
+	Contract    string
+	StorageType string
+	Storage     []field
+	Entrypoints []entrypoint
+	Imports     []string
+}
+
+// names hands out unique Go identifiers.
+type names map[string]bool
+
+func (n names) unique(name string) string {
+	candidate := name
+	for i := 2; n[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	n[candidate] = true
+	return candidate
+}
+
+// generate returns the Go source of a binding of a contract script.
+func generate(pkg, typeName, contract string, script gotezos.Micheline) ([]byte, error) {
+	parameter, storage, err := sections(script)
+	if err != nil {
+		return nil, err
+	}
+
+	b := binding{
+		Package:     pkg,
+		Type:        typeName,
+		Receiver:    "c",
+		Contract:    contract,
+		StorageType: goExpr(storage),
+	}
+	imports := map[string]bool{"context": true, "errors": true}
+
+	fieldNames := names{}
+	for i, leaf := range leaves(storage) {
+		kind := kindOf(leaf)
+		if kind == leafUnit {
+			continue
+		}
+		name := exported(fieldName(leaf), "Field")
+		if len(leaves(storage)) == 1 && fieldName(leaf) == "" {
+			name = "Value"
+		}
+		name = fieldNames.unique(name)
+		b.Storage = append(b.Storage, field{
+			Name:      name,
+			GoType:    goTypes[kind],
+			Michelson: leaf.CompactMichelson(),
+			Kind:      kind,
+			Decode:    decodeStmt(name, kind, fmt.Sprintf("fields[%d]", i)),
+		})
+		addImport(imports, kind)
+		imports["fmt"] = true
+	}
+
+	methods := names{"Address": true, "Node": true, "Wallet": true, "Call": true, "Storage": true}
+	for _, e := range entrypoints(parameter) {
+		method := methods.unique(exported(e.name, "Entrypoint"))
+		methods[method+"Parameters"] = true
+
+		params := names{"ctx": true, "c": true}
+		var fields []field
+		value := valueExpr(e.typ, true, params, &fields)
+		for _, f := range fields {
+			addImport(imports, f.Kind)
+		}
+
+		b.Entrypoints = append(b.Entrypoints, entrypoint{
+			Name:      e.name,
+			Method:    method,
+			Michelson: e.typ.CompactMichelson(),
+			Params:    fields,
+			Value:     value,
+		})
+	}
+
+	for imp := range imports {
+		b.Imports = append(b.Imports, imp)
+	}
+	sort.Strings(b.Imports)
+
+	var buf bytes.Buffer
+	if err := bindingTemplate.Execute(&buf, b); err != nil {
+		return nil, errors.Wrap(err, "failed to generate binding")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to format binding")
+	}
+	return src, nil
+}
+
+// sections returns the parameter and storage types of a script.
+func sections(script gotezos.Micheline) (gotezos.Micheline, gotezos.Micheline, error) {
+	var parameter, storage *gotezos.Micheline
+	for i, node := range script.Seq {
+		if node.Kind != gotezos.MichelineKindPrim || len(node.Args) != 1 {
+			continue
+		}
+		switch node.Prim {
+		case "parameter":
+			parameter = &script.Seq[i].Args[0]
+		case "storage":
+			storage = &script.Seq[i].Args[0]
+		}
+	}
+
+	if parameter == nil || storage == nil {
+		return gotezos.Micheline{}, gotezos.Micheline{}, errors.New("script has no parameter or storage section")
+	}
+	return *parameter, *storage, nil
+}
+
+type namedType struct {
+	name string
+	typ  gotezos.Micheline
+}
+
+// entrypoints returns the entrypoints of a parameter type: its annotated branches, and default for the whole
+// parameter if no branch is annotated default.
+func entrypoints(parameter gotezos.Micheline) []namedType {
+	var found []namedType
+	var walk func(typ gotezos.Micheline)
+	walk = func(typ gotezos.Micheline) {
+		if name := entrypointName(typ); name != "" {
+			found = append(found, namedType{name: name, typ: typ})
+		}
+		if typ.Kind == gotezos.MichelineKindPrim && typ.Prim == "or" && len(typ.Args) == 2 {
+			walk(typ.Args[0])
+			walk(typ.Args[1])
+		}
+	}
+	walk(parameter)
+
+	for _, e := range found {
+		if e.name == "default" {
+			return found
+		}
+	}
+	if len(found) == 0 || entrypointName(parameter) == "" {
+		found = append([]namedType{{name: "default", typ: parameter}}, found...)
+	}
+	return found
+}
+
+// leaves returns the leaves of a type, flattening its pairs the way gotezos.FlattenPair flattens values.
+func leaves(typ gotezos.Micheline) []gotezos.Micheline {
+	if typ.Kind != gotezos.MichelineKindPrim || typ.Prim != "pair" || len(typ.Args) < 2 {
+		return []gotezos.Micheline{typ}
+	}
+
+	var l []gotezos.Micheline
+	for _, arg := range typ.Args {
+		l = append(l, leaves(arg)...)
+	}
+	return l
+}
+
+// valueExpr returns the Go expression building a value of the type from the parameters it adds to fields.
+func valueExpr(typ gotezos.Micheline, root bool, params names, fields *[]field) string {
+	if typ.Kind == gotezos.MichelineKindPrim && typ.Prim == "pair" && len(typ.Args) >= 2 {
+		args := make([]string, len(typ.Args))
+		for i, arg := range typ.Args {
+			args[i] = valueExpr(arg, false, params, fields)
+		}
+		return fmt.Sprintf(`gotezos.NewMichelinePrim("Pair", %s)`, strings.Join(args, ", "))
+	}
+
+	kind := kindOf(typ)
+	if kind == leafUnit {
+		return `gotezos.NewMichelinePrim("Unit")`
+	}
+
+	name := fieldName(typ)
+	if root || name == "" {
+		name = "value"
+		if !root {
+			name = fmt.Sprintf("arg%d", len(*fields))
+		}
+	}
+	name = params.unique(unexported(name))
+
+	*fields = append(*fields, field{
+		Name:      name,
+		GoType:    goTypes[kind],
+		Michelson: typ.CompactMichelson(),
+		Kind:      kind,
+	})
+
+	switch kind {
+	case leafInt:
+		return fmt.Sprintf("gotezos.NewMichelineBigInt(%s)", name)
+	case leafString:
+		return fmt.Sprintf("gotezos.NewMichelineString(%s)", name)
+	case leafBool:
+		return fmt.Sprintf("gotezos.NewMichelineBool(%s)", name)
+	case leafBytes:
+		return fmt.Sprintf("gotezos.NewMichelineBytes(%s)", name)
+	case leafTimestamp:
+		return fmt.Sprintf("gotezos.NewMichelineString(%s.UTC().Format(time.RFC3339))", name)
+	default:
+		return name
+	}
+}
+
+// goExpr returns the Go expression of a Micheline node.
+func goExpr(m gotezos.Micheline) string {
+	var expr string
+	switch m.Kind {
+	case gotezos.MichelineKindInt:
+		expr = fmt.Sprintf("gotezos.NewMichelineInt(%s)", m.Int.String())
+	case gotezos.MichelineKindString:
+		expr = fmt.Sprintf("gotezos.NewMichelineString(%q)", m.String)
+	case gotezos.MichelineKindBytes:
+		expr = fmt.Sprintf("gotezos.NewMichelineBytes(%#v)", m.Bytes)
+	case gotezos.MichelineKindSeq:
+		nodes := make([]string, len(m.Seq))
+		for i, node := range m.Seq {
+			nodes[i] = goExpr(node)
+		}
+		expr = fmt.Sprintf("gotezos.NewMichelineSeq(%s)", strings.Join(nodes, ", "))
+	default:
+		args := []string{fmt.Sprintf("%q", m.Prim)}
+		for _, arg := range m.Args {
+			args = append(args, goExpr(arg))
+		}
+		expr = fmt.Sprintf("gotezos.NewMichelinePrim(%s)", strings.Join(args, ", "))
+		if len(m.Annots) > 0 {
+			annots := make([]string, len(m.Annots))
+			for i, annot := range m.Annots {
+				annots[i] = fmt.Sprintf("%q", annot)
+			}
+			expr += fmt.Sprintf(".WithAnnots(%s)", strings.Join(annots, ", "))
+		}
+	}
+	return expr
+}
+
+// decodeStmt returns the statements setting a field of the storage from its leaf in readable form.
+func decodeStmt(name string, kind leafKind, leaf string) string {
+	check := func(cond, assign string) string {
+		return fmt.Sprintf("\tif %s {\n\t\treturn nil, invalid(%q, %s)\n\t}\n\t%s", cond, name, leaf, assign)
+	}
+
+	switch kind {
+	case leafInt:
+		return check(leaf+".Kind != gotezos.MichelineKindInt", fmt.Sprintf("storage.%s = %s.Int", name, leaf))
+	case leafString:
+		return check(leaf+".Kind != gotezos.MichelineKindString", fmt.Sprintf("storage.%s = %s.String", name, leaf))
+	case leafBool:
+		return check(
+			fmt.Sprintf("%[1]s.Kind != gotezos.MichelineKindPrim || (%[1]s.Prim != \"True\" && %[1]s.Prim != \"False\")", leaf),
+			fmt.Sprintf("storage.%s = %s.Prim == \"True\"", name, leaf),
+		)
+	case leafBytes:
+		return check(leaf+".Kind != gotezos.MichelineKindBytes", fmt.Sprintf("storage.%s = %s.Bytes", name, leaf))
+	case leafTimestamp:
+		return check(
+			fmt.Sprintf("%s.Kind != gotezos.MichelineKindString", leaf),
+			fmt.Sprintf("if storage.%[1]s, err = gotezos.ParseTimestamp(%[2]s.String); err != nil {\n\t\treturn nil, invalid(%[1]q, %[2]s)\n\t}", name, leaf),
+		)
+	default:
+		return fmt.Sprintf("\tstorage.%s = %s", name, leaf)
+	}
+}
+
+func kindOf(typ gotezos.Micheline) leafKind {
+	if typ.Kind != gotezos.MichelineKindPrim {
+		return leafMicheline
+	}
+	return leafKinds[typ.Prim]
+}
+
+func addImport(imports map[string]bool, kind leafKind) {
+	switch kind {
+	case leafInt:
+		imports["math/big"] = true
+	case leafTimestamp:
+		imports["time"] = true
+	}
+}
+
+// entrypointName returns the field annotation (%name) of a type, if any.
+func entrypointName(typ gotezos.Micheline) string {
+	for _, annot := range typ.Annots {
+		if strings.HasPrefix(annot, "%") && len(annot) > 1 {
+			return annot[1:]
+		}
+	}
+	return ""
+}
+
+// fieldName returns the field annotation of a type, or else its type annotation (:name), if any.
+func fieldName(typ gotezos.Micheline) string {
+	if name := entrypointName(typ); name != "" {
+		return name
+	}
+	for _, annot := range typ.Annots {
+		if strings.HasPrefix(annot, ":") && len(annot) > 1 {
+			return annot[1:]
+		}
+	}
+	return ""
+}
+
+// exported turns a Michelson name (e.g. update_operators) into an exported Go identifier (UpdateOperators).
+func exported(name, fallback string) string {
+	var sb strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		sb.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+
+	ident := sb.String()
+	if ident == "" || !unicode.IsLetter(rune(ident[0])) {
+		ident = fallback + ident
+	}
+	return ident
+}
+
+// unexported turns a Michelson name into an unexported Go identifier (e.g. token_id into tokenID).
+func unexported(name string) string {
+	ident := exported(name, "arg")
+	ident = strings.ToLower(ident[:1]) + ident[1:]
+	if token.Lookup(ident).IsKeyword() {
+		ident += "Arg"
+	}
+	return ident
+}
+
+var bindingTemplate = template.Must(template.New("binding").Parse(`// Code generated by gotezos-bind. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+
+	gotezos "github.com/goat-systems/go-tezos/v2"
+)
+
+{{- $t := .Type}}
+{{- $r := .Receiver}}
+
+{{if .Contract}}
+// {{$t}}Address is the address of the contract the binding was generated from.
+const {{$t}}Address = "{{.Contract}}"
+{{end}}
+
+var {{$t}}StorageType = {{.StorageType}}
+
+// {{$t}} is a binding of a Tezos contract: a method per entrypoint and the storage decoded into {{$t}}Storage.
+type {{$t}} struct {
+	Address string
+	// Node reads the storage of the contract.
+	Node gotezos.IFace
+	// Wallet signs and injects the calls to the entrypoints.
+	Wallet *gotezos.WalletClient
+}
+
+// {{$t}}Storage is the storage of the contract.
+type {{$t}}Storage struct {
+{{- range .Storage}}
+	// {{.Michelson}}
+	{{.Name}} {{.GoType}}
+{{- end}}
+}
+
+// New{{$t}} returns a binding of the contract at address. Set Wallet to call the entrypoints.
+func New{{$t}}(address string, node gotezos.IFace) *{{$t}} {
+	return &{{$t}}{Address: address, Node: node}
+}
+
+// Storage returns the storage of the contract at the head.
+func ({{$r}} *{{$t}}) Storage() (*{{$t}}Storage, error) {
+	storage, err := {{$r}}.Node.ContractStorage(&gotezos.ContractStorageInput{BlockID: gotezos.BlockIDHead{}, Contract: {{$r}}.Address})
+	if err != nil {
+		return nil, err
+	}
+
+	return Decode{{$t}}Storage(*storage)
+}
+
+// Decode{{$t}}Storage decodes the storage of the contract, in readable or optimized form.
+func Decode{{$t}}Storage(value gotezos.Micheline) (*{{$t}}Storage, error) {
+	readable, err := gotezos.UnparseData({{$t}}StorageType, value, gotezos.UnparsingModeReadable)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := gotezos.FlattenPair({{$t}}StorageType, readable)
+	if err != nil {
+		return nil, err
+	}
+
+{{- if .Storage}}
+
+	invalid := func(name string, value gotezos.Micheline) error {
+		return fmt.Errorf("failed to decode storage: invalid %s %s", name, value.CompactMichelson())
+	}
+{{- else}}
+	_ = fields
+{{- end}}
+
+	var storage {{$t}}Storage
+{{- range .Storage}}
+{{.Decode}}
+{{- end}}
+
+	return &storage, nil
+}
+
+// Call calls an entrypoint of the contract with the wallet, sending amount mutez, and returns the hash of
+// the operation.
+func ({{$r}} *{{$t}}) Call(ctx context.Context, parameters gotezos.Parameters, amount int64) (*string, error) {
+	if {{$r}}.Wallet == nil {
+		return nil, errors.New("no wallet to call the contract with")
+	}
+
+	var contents gotezos.Contents
+	contents.Kind = gotezos.TRANSACTIONOP
+	contents.Destination = {{$r}}.Address
+	contents.Amount.SetInt64(amount)
+	contents.Parameters = &parameters
+
+	return {{$r}}.Wallet.Send(ctx, contents)
+}
+{{range .Entrypoints}}
+// {{.Method}}Parameters returns the parameters of a call to the {{.Name}} entrypoint:
+//
+//	{{.Michelson}}
+func ({{$r}} *{{$t}}) {{.Method}}Parameters({{range $i, $p := .Params}}{{if $i}}, {{end}}{{$p.Name}} {{$p.GoType}}{{end}}) gotezos.Parameters {
+	return gotezos.Parameters{
+		Entrypoint: "{{.Name}}",
+		Value:      {{.Value}},
+	}
+}
+
+// {{.Method}} calls the {{.Name}} entrypoint and returns the hash of the operation.
+func ({{$r}} *{{$t}}) {{.Method}}(ctx context.Context{{range .Params}}, {{.Name}} {{.GoType}}{{end}}) (*string, error) {
+	return {{$r}}.Call(ctx, {{$r}}.{{.Method}}Parameters({{range $i, $p := .Params}}{{if $i}}, {{end}}{{$p.Name}}{{end}}), 0)
+}
+{{end}}
+`))
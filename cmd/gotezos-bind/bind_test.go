@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gotezos "github.com/goat-systems/go-tezos/v2"
+	"github.com/goat-systems/go-tezos/v2/gotezostest"
+	"github.com/stretchr/testify/assert"
+)
+
+const tokenScript = `parameter (or (or (pair %transfer (address :from) (pair (address :to) (nat :value)))
+                  (pair %approve (address :spender) (nat :value)))
+              (or (bool %setPause) (unit %default))) ;
+storage (pair (big_map %ledger address nat)
+              (pair (address %admin) (pair (bool %paused) (timestamp %lastUpdate)))) ;
+code { FAILWITH }`
+
+func Test_Generate(t *testing.T) {
+	script, err := gotezos.ParseMichelson(tokenScript)
+	assert.Nil(t, err)
+
+	src, err := generate("token", "Token", "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", script)
+	assert.Nil(t, err)
+
+	for _, want := range []string{
+		"// Code generated by gotezos-bind. DO NOT EDIT.\n\npackage token\n",
+		`const TokenAddress = "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"`,
+		"\tLedger *big.Int\n",
+		"\tAdmin string\n",
+		"\tPaused bool\n",
+		"\tLastUpdate time.Time\n",
+		"func DecodeTokenStorage(value gotezos.Micheline) (*TokenStorage, error) {",
+		"func (c *Token) Transfer(ctx context.Context, from string, to string, value *big.Int) (*string, error) {",
+		"func (c *Token) Approve(ctx context.Context, spender string, value *big.Int) (*string, error) {",
+		"func (c *Token) SetPause(ctx context.Context, value bool) (*string, error) {",
+		"func (c *Token) Default(ctx context.Context) (*string, error) {",
+		`Value:      gotezos.NewMichelinePrim("Pair", gotezos.NewMichelineString(from), gotezos.NewMichelinePrim("Pair", gotezos.NewMichelineString(to), gotezos.NewMichelineBigInt(value))),`,
+	} {
+		assert.Contains(t, string(src), want)
+	}
+
+	_, err = generate("token", "Token", "", gotezos.NewMichelineSeq(gotezos.NewMichelinePrim("code", gotezos.NewMichelineSeq())))
+	assert.Contains(t, err.Error(), "no parameter")
+}
+
+func Test_Run(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gotezos-bind")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "token.tz")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(tokenScript), 0644))
+
+	env := map[string]string{"GOPACKAGE": "token"}
+	getenv := func(key string) string { return env[key] }
+
+	var stdout bytes.Buffer
+	err = run([]string{"-type", "Token", "-script", path}, &stdout, getenv)
+	assert.Nil(t, err)
+	assert.Contains(t, stdout.String(), "package token\n")
+	assert.Contains(t, stdout.String(), "type Token struct {")
+
+	script, err := gotezos.ParseMichelson(tokenScript)
+	assert.Nil(t, err)
+	code, err := json.Marshal(script)
+	assert.Nil(t, err)
+
+	node := gotezostest.NewServer()
+	defer node.Close()
+	node.HandleJSON(`/context/contracts/KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn/script$`, map[string]json.RawMessage{
+		"code":    code,
+		"storage": json.RawMessage(`{"int":"0"}`),
+	})
+
+	out := filepath.Join(dir, "token.go")
+	err = run([]string{"-node", node.URL, "-type", "Token", "-contract", "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", "-out", out}, &stdout, getenv)
+	assert.Nil(t, err)
+
+	src, err := ioutil.ReadFile(out)
+	assert.Nil(t, err)
+	assert.Contains(t, string(src), `const TokenAddress = "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"`)
+
+	err = run([]string{"-script", path}, &stdout, getenv)
+	assert.Contains(t, err.Error(), "usage: gotezos-bind")
+
+	err = run([]string{"-type", "Token", "-script", path, "-contract", "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"}, &stdout, getenv)
+	assert.Contains(t, err.Error(), "usage: gotezos-bind")
+
+	assert.Nil(t, ioutil.WriteFile(path, []byte("parameter (or unit"), 0644))
+	err = run([]string{"-type", "Token", "-script", path}, &stdout, getenv)
+	assert.Contains(t, err.Error(), "failed to read script")
+}
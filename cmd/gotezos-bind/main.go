@@ -0,0 +1,128 @@
+/*
+Command gotezos-bind generates a typed Go binding of a Tezos smart contract on top of the go-tezos library,
+the way abigen does for Ethereum contracts. The binding has a method per entrypoint, taking the leaves of
+the entrypoint's parameter as Go arguments, and a struct for the storage of the contract.
+
+	gotezos-bind -type <name> (-contract <KT1> [-node <url>] | -script <file>) [-pkg <package>] [-out <file>]
+
+The script is read from a deployed contract through the node, or from a file of Michelson source (.tz) or
+Micheline JSON. The node defaults to $GOTEZOS_NODE, or http://127.0.0.1:8732, and the package to $GOPACKAGE
+so that the command can be used with go generate:
+
+	//go:generate go run github.com/goat-systems/go-tezos/v2/cmd/gotezos-bind -type Token -contract KT1... -out token.go
+
+Michelson values are mapped to Go types as follows: int, nat and mutez (and big map ids) to *big.Int,
+string, address, contract, key_hash, key, signature and chain_id to string, bool to bool, bytes to []byte,
+timestamp to time.Time, and other types to gotezos.Micheline.
+*/
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	gotezos "github.com/goat-systems/go-tezos/v2"
+	"github.com/pkg/errors"
+)
+
+const defaultNode = "http://127.0.0.1:8732"
+
+const usage = "usage: gotezos-bind -type <name> (-contract <KT1> [-node <url>] | -script <file>) [-pkg <package>] [-out <file>]"
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Getenv); err != nil {
+		fmt.Fprintln(os.Stderr, "gotezos-bind:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer, getenv func(string) string) error {
+	flags := flag.NewFlagSet("gotezos-bind", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	node := flags.String("node", getenv("GOTEZOS_NODE"), "the Tezos node to read the contract from")
+	contract := flags.String("contract", "", "the contract to bind")
+	scriptFile := flags.String("script", "", "the script to bind, in Michelson (.tz) or Micheline JSON")
+	typeName := flags.String("type", "", "the name of the binding type")
+	pkg := flags.String("pkg", getenv("GOPACKAGE"), "the package of the binding")
+	out := flags.String("out", "", "the file to write the binding to, stdout if not set")
+	flags.Usage = func() {
+		fmt.Fprintln(stdout, usage)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *typeName == "" || (*contract == "") == (*scriptFile == "") {
+		return errors.New(usage)
+	}
+
+	if *pkg == "" {
+		*pkg = "main"
+	}
+
+	var script gotezos.Micheline
+	var err error
+	if *scriptFile != "" {
+		script, err = readScript(*scriptFile)
+	} else {
+		if *node == "" {
+			*node = defaultNode
+		}
+		script, err = fetchScript(*node, *contract)
+	}
+	if err != nil {
+		return err
+	}
+
+	src, err := generate(*pkg, *typeName, *contract, script)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err = stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(*out, src, 0644)
+}
+
+// readScript reads a script written in Micheline JSON or in Michelson.
+func readScript(path string) (gotezos.Micheline, error) {
+	source, err := ioutil.ReadFile(path)
+	if err != nil {
+		return gotezos.Micheline{}, errors.Wrap(err, "failed to read script")
+	}
+
+	var script gotezos.Micheline
+	if strings.HasPrefix(strings.TrimSpace(string(source)), "[") {
+		err = json.Unmarshal(source, &script)
+	} else {
+		script, err = gotezos.ParseMichelson(string(source))
+	}
+	if err != nil {
+		return gotezos.Micheline{}, errors.Wrapf(err, "failed to read script '%s'", path)
+	}
+
+	return script, nil
+}
+
+func fetchScript(node, contract string) (gotezos.Micheline, error) {
+	gt, err := gotezos.New(node)
+	if err != nil {
+		return gotezos.Micheline{}, err
+	}
+
+	script, err := gt.ContractScript(&gotezos.ContractScriptInput{BlockID: gotezos.BlockIDHead{}, Contract: contract})
+	if err != nil {
+		return gotezos.Micheline{}, err
+	}
+
+	return script.Code, nil
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	gotezos "github.com/goat-systems/go-tezos/v2"
+	"github.com/pkg/errors"
+)
+
+type cli struct {
+	node   string
+	stdout io.Writer
+	getenv func(string) string
+	gt     *gotezos.GoTezos
+
+	// The public network queried instead of node, if any.
+	network *gotezos.Network
+}
+
+func (c *cli) client() (*gotezos.GoTezos, error) {
+	if c.gt != nil {
+		return c.gt, nil
+	}
+
+	if c.network != nil {
+		gt, err := gotezos.NewWithNetwork(*c.network)
+		if err != nil {
+			return nil, err
+		}
+		c.gt = gt
+
+		return gt, nil
+	}
+
+	gt, err := gotezos.New(c.node)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not connect to %s", c.node)
+	}
+	c.gt = gt
+
+	return gt, nil
+}
+
+func (c *cli) wallet() (*gotezos.Wallet, error) {
+	if esk := c.getenv("GOTEZOS_ENCRYPTED_KEY"); esk != "" {
+		wallet, err := gotezos.ImportEncryptedWallet(c.getenv("GOTEZOS_PASSWORD"), strings.TrimPrefix(esk, "encrypted:"))
+		return wallet, errors.Wrap(err, "could not import wallet")
+	}
+
+	sk := strings.TrimPrefix(c.getenv("GOTEZOS_SECRET_KEY"), "unencrypted:")
+	if sk == "" {
+		return nil, errors.New("no wallet: set GOTEZOS_SECRET_KEY or GOTEZOS_ENCRYPTED_KEY")
+	}
+
+	wallet, err := gotezos.ImportWallet(c.getenv("GOTEZOS_ADDRESS"), c.getenv("GOTEZOS_PUBLIC_KEY"), sk)
+	return wallet, errors.Wrap(err, "could not import wallet")
+}
+
+func (c *cli) print(v interface{}) error {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(c.stdout, string(out))
+	return err
+}
+
+func balance(c *cli, args []string) error {
+	flags := flag.NewFlagSet("balance", flag.ContinueOnError)
+	flags.SetOutput(c.stdout)
+	block := flags.String("block", "head", "the block to query")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		return errors.New("usage: " + usages["balance"])
+	}
+
+	gt, err := c.client()
+	if err != nil {
+		return err
+	}
+
+	balance, err := gt.Balance(blockID(*block), flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(c.stdout, *balance)
+	return err
+}
+
+func rights(c *cli, args []string) error {
+	flags := flag.NewFlagSet("rights", flag.ContinueOnError)
+	flags.SetOutput(c.stdout)
+	delegate := flags.String("delegate", "", "the delegate")
+	cycle := flags.Int("cycle", -1, "the cycle, defaults to the current cycle")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *delegate == "" {
+		return errors.New("usage: " + usages["rights"])
+	}
+
+	gt, err := c.client()
+	if err != nil {
+		return err
+	}
+
+	bakingInput := &gotezos.BakingRightsInput{BlockID: gotezos.BlockIDHead{}, Delegate: delegate}
+	endorsingInput := &gotezos.EndorsingRightsInput{BlockID: gotezos.BlockIDHead{}, Delegate: delegate}
+	if *cycle >= 0 {
+		bakingInput.Cycle, endorsingInput.Cycle = cycle, cycle
+	}
+
+	baking, err := gt.BakingRights(bakingInput)
+	if err != nil {
+		return err
+	}
+
+	endorsing, err := gt.EndorsingRights(endorsingInput)
+	if err != nil {
+		return err
+	}
+
+	return c.print(map[string]interface{}{
+		"baking_rights":    baking,
+		"endorsing_rights": endorsing,
+	})
+}
+
+func rewards(c *cli, args []string) error {
+	flags := flag.NewFlagSet("rewards", flag.ContinueOnError)
+	flags.SetOutput(c.stdout)
+	delegate := flags.String("delegate", "", "the delegate")
+	cycle := flags.Int("cycle", -1, "the cycle")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *delegate == "" || *cycle < 0 {
+		return errors.New("usage: " + usages["rewards"])
+	}
+
+	gt, err := c.client()
+	if err != nil {
+		return err
+	}
+
+	frozenBalance, err := gt.FrozenBalance(*cycle, *delegate)
+	if err != nil {
+		return err
+	}
+
+	return c.print(frozenBalance)
+}
+
+// managerFlags are the flags shared by the commands injecting a manager operation.
+type managerFlags struct {
+	fee          *int64
+	gasLimit     *int64
+	storageLimit *int64
+	dryRun       *bool
+}
+
+func newManagerFlags(flags *flag.FlagSet) managerFlags {
+	return managerFlags{
+		fee:          flags.Int64("fee", -1, "the fee in mutez, estimated if not set"),
+		gasLimit:     flags.Int64("gas-limit", -1, "the gas limit, estimated if not set"),
+		storageLimit: flags.Int64("storage-limit", -1, "the storage limit, estimated if not set"),
+		dryRun:       flags.Bool("dry-run", false, "run the operation without injecting it"),
+	}
+}
+
+func transfer(c *cli, args []string) error {
+	flags := flag.NewFlagSet("transfer", flag.ContinueOnError)
+	flags.SetOutput(c.stdout)
+	to := flags.String("to", "", "the destination")
+	amount := flags.Int64("amount", -1, "the amount in mutez")
+	manager := newManagerFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *to == "" || *amount < 0 {
+		return errors.New("usage: " + usages["transfer"])
+	}
+
+	return c.inject(manager, gotezos.Contents{
+		Kind:        gotezos.TRANSACTIONOP,
+		Amount:      bigInt(*amount),
+		Destination: *to,
+	})
+}
+
+func delegate(c *cli, args []string) error {
+	flags := flag.NewFlagSet("delegate", flag.ContinueOnError)
+	flags.SetOutput(c.stdout)
+	baker := flags.String("baker", "", "the delegate, the delegation is withdrawn if not set")
+	manager := newManagerFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	return c.inject(manager, gotezos.Contents{
+		Kind:     gotezos.DELEGATIONOP,
+		Delegate: *baker,
+	})
+}
+
+func originate(c *cli, args []string) error {
+	flags := flag.NewFlagSet("originate", flag.ContinueOnError)
+	flags.SetOutput(c.stdout)
+	balance := flags.Int64("balance", -1, "the initial balance in mutez")
+	baker := flags.String("baker", "", "the delegate of the contract")
+	manager := newManagerFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *balance < 0 {
+		return errors.New("usage: " + usages["originate"])
+	}
+
+	return c.inject(manager, gotezos.Contents{
+		Kind:     gotezos.ORIGINATIONOP,
+		Balance:  bigInt(*balance),
+		Delegate: *baker,
+	})
+}
+
+// inject sets the presets of the flags on the contents, then prepares, signs and injects them with the wallet.
+func (c *cli) inject(flags managerFlags, contents gotezos.Contents) error {
+	gt, err := c.client()
+	if err != nil {
+		return err
+	}
+
+	wallet, err := c.wallet()
+	if err != nil {
+		return err
+	}
+
+	if *flags.gasLimit >= 0 {
+		contents.GasLimit = bigInt(*flags.gasLimit)
+	}
+	if *flags.fee >= 0 {
+		contents.Fee = bigInt(*flags.fee)
+	}
+
+	client := gotezos.NewWalletClient(gt, wallet)
+	operation, err := client.Prepare(contents)
+	if err != nil {
+		return err
+	}
+
+	// Prepare estimates the storage limit along with the gas limit, so the preset is applied afterwards.
+	if *flags.storageLimit >= 0 {
+		operation[len(operation)-1].StorageLimit = bigInt(*flags.storageLimit)
+	}
+
+	if *flags.dryRun {
+		return c.print(operation)
+	}
+
+	hash, err := client.Inject(context.Background(), operation...)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(c.stdout, *hash)
+	return err
+}
+
+func blockID(block string) gotezos.BlockID {
+	if block == "head" {
+		return gotezos.BlockIDHead{}
+	}
+
+	var level int
+	if _, err := fmt.Sscanf(block, "%d", &level); err == nil && fmt.Sprint(level) == block {
+		return gotezos.BlockIDLevel(level)
+	}
+
+	return gotezos.BlockIDHash(block)
+}
+
+func bigInt(v int64) gotezos.BigInt {
+	var i gotezos.BigInt
+	i.SetInt64(v)
+	return i
+}
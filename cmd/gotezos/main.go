@@ -0,0 +1,106 @@
+/*
+Command gotezos exposes common Tezos operations on top of the go-tezos library.
+
+	gotezos [-node <url> | -network <name>] <command> [flags] [arguments]
+
+Commands:
+
+	balance [-block <block>] <address>   Prints the balance of a contract.
+	transfer -to <address> -amount <mutez>
+	                                     Transfers tez from the wallet.
+	delegate [-baker <address>]          Sets (or withdraws, without -baker) the delegate of the wallet.
+	originate -balance <mutez> [-baker <address>]
+	                                     Originates a manager contract from the wallet.
+	rights -delegate <address> [-cycle <cycle>]
+	                                     Prints the baking and endorsing rights of a delegate.
+	rewards -delegate <address> -cycle <cycle>
+	                                     Prints the frozen deposits, fees and rewards of a delegate.
+
+The node defaults to $GOTEZOS_NODE, or http://127.0.0.1:8732. A public network (mainnet, ghostnet or rionet)
+can be queried through its public nodes with -network, or $GOTEZOS_NETWORK, instead. Commands that inject operations sign them
+with the wallet given by $GOTEZOS_SECRET_KEY (edsk), $GOTEZOS_PUBLIC_KEY and $GOTEZOS_ADDRESS, or by
+$GOTEZOS_ENCRYPTED_KEY (edesk) and $GOTEZOS_PASSWORD, which is revealed first if needed. Gas and storage
+limits and the fee are estimated by running the operation first, unless they are given with -gas-limit,
+-storage-limit and -fee.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	gotezos "github.com/goat-systems/go-tezos/v2"
+)
+
+const defaultNode = "http://127.0.0.1:8732"
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Getenv); err != nil {
+		fmt.Fprintln(os.Stderr, "gotezos:", err)
+		os.Exit(1)
+	}
+}
+
+var commands = map[string]func(c *cli, args []string) error{
+	"balance":   balance,
+	"transfer":  transfer,
+	"delegate":  delegate,
+	"originate": originate,
+	"rights":    rights,
+	"rewards":   rewards,
+}
+
+var usages = map[string]string{
+	"balance":   "balance [-block <block>] <address>",
+	"transfer":  "transfer -to <address> -amount <mutez>",
+	"delegate":  "delegate [-baker <address>]",
+	"originate": "originate -balance <mutez> [-baker <address>]",
+	"rights":    "rights -delegate <address> [-cycle <cycle>]",
+	"rewards":   "rewards -delegate <address> -cycle <cycle>",
+}
+
+func run(args []string, stdout io.Writer, getenv func(string) string) error {
+	flags := flag.NewFlagSet("gotezos", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	node := flags.String("node", getenv("GOTEZOS_NODE"), "the Tezos node to query")
+	network := flags.String("network", getenv("GOTEZOS_NETWORK"), "the public network to query, e.g. ghostnet")
+	flags.Usage = func() {
+		fmt.Fprintln(stdout, "usage: gotezos [-node <url> | -network <name>] <command> [flags] [arguments]")
+		fmt.Fprintln(stdout, "\ncommands:")
+		for _, name := range []string{"balance", "transfer", "delegate", "originate", "rights", "rewards"} {
+			fmt.Fprintln(stdout, "\t"+usages[name])
+		}
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return fmt.Errorf("no command given")
+	}
+
+	cmd, ok := commands[flags.Arg(0)]
+	if !ok {
+		flags.Usage()
+		return fmt.Errorf("unknown command %q", flags.Arg(0))
+	}
+
+	c := &cli{node: *node, stdout: stdout, getenv: getenv}
+	if *node == "" && *network != "" {
+		n, ok := gotezos.NetworkByName(*network)
+		if !ok {
+			return fmt.Errorf("unknown network %q", *network)
+		}
+		c.network = &n
+	}
+
+	if c.node == "" {
+		c.node = defaultNode
+	}
+
+	return cmd(c, flags.Args()[1:])
+}
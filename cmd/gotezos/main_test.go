@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	gotezos "github.com/goat-systems/go-tezos/v2"
+	"github.com/goat-systems/go-tezos/v2/gotezostest"
+	"github.com/stretchr/testify/assert"
+)
+
+var testWallet = map[string]string{
+	"GOTEZOS_ADDRESS":    "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK",
+	"GOTEZOS_PUBLIC_KEY": "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G",
+	"GOTEZOS_SECRET_KEY": "edskSA4oADtx6DTT6eXdBc6Pv5MoVBGXUzy8bBryi6D96RQNQYcRfVEXd2nuE2ZZPxs4YLZeM7KazUULFT1SfMDNyKFCUgk6vR",
+}
+
+func runCLI(node *gotezostest.Server, env map[string]string, args ...string) (string, error) {
+	var stdout bytes.Buffer
+	err := run(append([]string{"-node", node.URL}, args...), &stdout, func(key string) string {
+		return env[key]
+	})
+	return stdout.String(), err
+}
+
+func Test_Balance(t *testing.T) {
+	node := gotezostest.NewServer()
+	defer node.Close()
+	node.SetBalance("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "1000000")
+
+	out, err := runCLI(node, nil, "balance", "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	assert.Nil(t, err)
+	assert.Equal(t, "1000000\n", out)
+
+	out, err = runCLI(node, nil, "balance", "-block", "42", "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	assert.Nil(t, err)
+	assert.Equal(t, "1000000\n", out)
+
+	requests := node.Requests()
+	assert.Equal(t, "/chains/main/blocks/42/context/contracts/tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx/balance", requests[len(requests)-1].Path)
+
+	_, err = runCLI(node, nil, "balance")
+	assert.Contains(t, err.Error(), "usage: balance")
+}
+
+func Test_Transfer(t *testing.T) {
+	node := gotezostest.NewServer()
+	defer node.Close()
+	node.SetCounter(testWallet["GOTEZOS_ADDRESS"], 10)
+	node.SetManagerKey(testWallet["GOTEZOS_ADDRESS"], testWallet["GOTEZOS_PUBLIC_KEY"])
+	node.HandleJSON(`/helpers/scripts/run_operation$`, json.RawMessage(`{"contents":[{"kind":"transaction","metadata":{"balance_updates":[],"operation_result":{"status":"applied","consumed_milligas":"1000001"}}}]}`))
+
+	out, err := runCLI(node, testWallet, "transfer", "-to", "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "-amount", "1500")
+	assert.Nil(t, err)
+	assert.Equal(t, gotezostest.OperationHash+"\n", out)
+
+	requests := node.Requests()
+	injection := requests[len(requests)-1]
+	assert.Equal(t, "/injection/operation", injection.Path)
+
+	var signed string
+	assert.Nil(t, json.Unmarshal(injection.Body, &signed))
+
+	gt, err := gotezos.New(node.URL)
+	assert.Nil(t, err)
+
+	_, contents, err := gt.UnforgeOperation(signed, true)
+	assert.Nil(t, err)
+	assert.Len(t, *contents, 1)
+
+	transaction := (*contents)[0]
+	assert.Equal(t, gotezos.TRANSACTIONOP, transaction.Kind)
+	assert.Equal(t, "1500", transaction.Amount.String())
+	assert.Equal(t, "11", transaction.Counter.String())
+	assert.Equal(t, "1101", transaction.GasLimit.String())
+	assert.Equal(t, "0", transaction.StorageLimit.String())
+	assert.True(t, transaction.Fee.Int64() > 100+110)
+}
+
+func Test_DelegateDryRun(t *testing.T) {
+	node := gotezostest.NewServer()
+	defer node.Close()
+	node.HandleJSON(`/helpers/scripts/run_operation$`, json.RawMessage(`{"contents":[{"kind":"reveal","metadata":{"balance_updates":[],"operation_result":{"status":"applied","consumed_milligas":"1000000"}}},{"kind":"delegation","metadata":{"balance_updates":[],"operation_result":{"status":"applied","consumed_milligas":"1000000"}}}]}`))
+
+	out, err := runCLI(node, testWallet, "delegate", "-baker", "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "-fee", "1500", "-gas-limit", "1000", "-storage-limit", "0", "-dry-run")
+	assert.Nil(t, err)
+
+	var contents []gotezos.Contents
+	assert.Nil(t, json.Unmarshal([]byte(out), &contents))
+	assert.Len(t, contents, 2)
+	assert.Equal(t, gotezos.REVEALOP, contents[0].Kind)
+	assert.Equal(t, testWallet["GOTEZOS_PUBLIC_KEY"], contents[0].Phk)
+	assert.Equal(t, "1100", contents[0].GasLimit.String())
+	assert.True(t, contents[0].Fee.Int64() > 100+110)
+	assert.Equal(t, gotezos.DELEGATIONOP, contents[1].Kind)
+	assert.Equal(t, "1500", contents[1].Fee.String())
+	assert.Equal(t, "1000", contents[1].GasLimit.String())
+	assert.Equal(t, "2", contents[1].Counter.String())
+
+	for _, request := range node.Requests() {
+		assert.NotEqual(t, "/injection/operation", request.Path)
+	}
+}
+
+func Test_Errors(t *testing.T) {
+	node := gotezostest.NewServer()
+	defer node.Close()
+
+	_, err := runCLI(node, nil)
+	assert.Contains(t, err.Error(), "no command given")
+
+	_, err = runCLI(node, nil, "bake")
+	assert.Contains(t, err.Error(), `unknown command "bake"`)
+
+	_, err = runCLI(node, nil, "transfer", "-to", "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "-amount", "1")
+	assert.Contains(t, err.Error(), "no wallet")
+
+	_, err = runCLI(node, nil, "rewards", "-delegate", "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	assert.True(t, strings.HasPrefix(err.Error(), "usage: rewards"))
+
+	var stdout bytes.Buffer
+	err = run([]string{"-network", "carthagenet", "balance", "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}, &stdout, func(string) string { return "" })
+	assert.EqualError(t, err, `unknown network "carthagenet"`)
+}
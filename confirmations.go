@@ -0,0 +1,212 @@
+package gotezos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+/*
+ConfirmationInput -
+Description: The input for tracking the confirmations of an injected operation.
+Function: func (t *GoTezos) TrackConfirmations(ctx context.Context, input *ConfirmationInput) (<-chan Confirmation, <-chan error, error) {}
+*/
+type ConfirmationInput struct {
+	// The hash of the injected operation.
+	OperationHash string `validate:"required"`
+
+	// The number of confirmations after which tracking stops. If zero, tracking stops only when the context is done.
+	Confirmations int
+
+	// How often the head is polled. Defaults to 10 seconds.
+	Interval time.Duration
+
+	// The number of blocks to wait for the operation to be included before giving up. If zero, wait indefinitely.
+	MaxBlocks int
+
+	// The level from which blocks are searched for the operation, e.g. the level of the head when it was injected.
+	// Defaults to max_operations_ttl blocks before the head, the oldest block the operation could be included in.
+	FromLevel int
+}
+
+/*
+Confirmation -
+Description: The inclusion of an operation as seen from the current head.
+*/
+type Confirmation struct {
+	OperationHash string
+	BlockHash     string
+	Level         int
+	Confirmations int
+}
+
+/*
+TrackConfirmations Function
+Description: Watches for the inclusion of an injected operation by polling the head. Blocks are searched from
+FromLevel, so an operation included before tracking started is found too. Once the operation is included a
+Confirmation is sent for every new head, with the number of blocks baked on top of the inclusion block. When a
+new head does not extend the previous one, even at the same level, the replaced blocks are searched again.
+Both channels are closed when tracking stops: once the requested confirmations are reached, the operation
+was not included within MaxBlocks, an RPC fails, or the context is done. The error channel receives the reason
+tracking stopped early, if any.
+
+Parameters:
+	ctx:
+		Cancels tracking.
+	input:
+		The operation to track and the tracking options. OperationHash is required.
+*/
+func (t *GoTezos) TrackConfirmations(ctx context.Context, input *ConfirmationInput) (<-chan Confirmation, <-chan error, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid input")
+	}
+
+	interval := input.Interval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	confirmations := make(chan Confirmation)
+	errs := make(chan error, 1)
+
+	ctx, cancel := t.untilClosed(ctx)
+	go func() {
+		defer cancel()
+		defer close(errs)
+		defer close(confirmations)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var included *Confirmation
+		start, from, last, lastHash := -1, -1, -1, ""
+		// scanned holds the hash of every block searched, to find where a reorganization forked.
+		scanned := make(map[int]string)
+		for {
+			head, err := t.Head()
+			if err != nil {
+				errs <- errors.Wrap(err, "failed to track confirmations")
+				return
+			}
+
+			if start < 0 {
+				start, from = head.Header.Level, input.FromLevel
+				if from <= 0 {
+					from = head.Header.Level - head.Metadata.MaxOperationsTTL
+				}
+				if from < 0 {
+					from = 0
+				}
+				last = from - 1
+			}
+
+			if head.Hash != lastHash {
+				if lastHash != "" && head.Header.Predecessor != lastHash {
+					top := last
+					if top >= head.Header.Level {
+						top = head.Header.Level - 1
+					}
+
+					last, err = t.forkLevel(scanned, from, top)
+					if err != nil {
+						errs <- errors.Wrap(err, "failed to track confirmations")
+						return
+					}
+
+					if included != nil && included.Level > last {
+						included = nil
+					}
+				}
+
+				for level := last + 1; included == nil && level <= head.Header.Level; level++ {
+					block := head
+					if level != head.Header.Level {
+						block, err = t.Block(BlockIDLevel(level))
+						if err != nil {
+							errs <- errors.Wrap(err, "failed to track confirmations")
+							return
+						}
+					}
+
+					scanned[level] = block.Hash
+					if blockContainsOperation(block, input.OperationHash) {
+						included = &Confirmation{
+							OperationHash: input.OperationHash,
+							BlockHash:     block.Hash,
+							Level:         block.Header.Level,
+						}
+					}
+				}
+				last, lastHash = head.Header.Level, head.Hash
+
+				if included == nil && input.MaxBlocks > 0 && head.Header.Level-start >= input.MaxBlocks {
+					errs <- fmt.Errorf("failed to track confirmations: operation %s not included within %d blocks", input.OperationHash, input.MaxBlocks)
+					return
+				}
+
+				if included != nil {
+					confirmation := *included
+					confirmation.Confirmations = head.Header.Level - included.Level
+
+					select {
+					case confirmations <- confirmation:
+					case <-ctx.Done():
+						errs <- ctx.Err()
+						return
+					}
+
+					if input.Confirmations > 0 && confirmation.Confirmations >= input.Confirmations {
+						return
+					}
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return confirmations, errs, nil
+}
+
+// forkLevel returns the highest level, at or below top, whose searched block is still on the chain,
+// or from-1 if none is.
+func (t *GoTezos) forkLevel(scanned map[int]string, from, top int) (int, error) {
+	for level := top; level >= from; level-- {
+		hash, ok := scanned[level]
+		if !ok {
+			continue
+		}
+
+		block, err := t.Block(BlockIDLevel(level))
+		if err != nil {
+			return 0, err
+		}
+
+		if block.Hash == hash {
+			return level, nil
+		}
+	}
+
+	return from - 1, nil
+}
+
+func blockContainsOperation(block *Block, operationHash string) bool {
+	for _, operations := range block.Operations {
+		for _, operation := range operations {
+			if operation.Hash == operationHash {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,271 @@
+package gotezos
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var mockOperationHash = "ooYnhCVjVK6JNiy7D4YSwtH8vebZP7QUKCgMsCwHbrgaYvNRcnU"
+
+type chainHandlerMock struct {
+	mu     sync.Mutex
+	served bool
+	heads  []int
+	// chains holds the chain as seen by each head, it allows simulating reorganizations.
+	chains []map[int]Block
+}
+
+func (c *chainHandlerMock) handler(next http.Handler) http.Handler {
+	regLevel := regexp.MustCompile(`\/chains\/main\/blocks\/([0-9]+)$`)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if r.URL.Path == "/chains/main/blocks/head" {
+			if c.served && len(c.heads) > 1 {
+				c.heads, c.chains = c.heads[1:], c.chains[1:]
+			}
+			c.served = true
+
+			block := c.chains[0][c.heads[0]]
+			v, _ := json.Marshal(&block)
+			w.Write(v)
+			return
+		}
+
+		if match := regLevel.FindStringSubmatch(r.URL.Path); match != nil {
+			level, _ := strconv.Atoi(match[1])
+			block := c.chains[0][level]
+			v, _ := json.Marshal(&block)
+			w.Write(v)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func mockChainBlock(level int, hash string, operationHashes ...string) Block {
+	var operations []Operations
+	for _, h := range operationHashes {
+		operations = append(operations, Operations{Hash: h})
+	}
+
+	return Block{
+		Hash:       hash,
+		Header:     Header{Level: level},
+		Operations: [][]Operations{operations},
+	}
+}
+
+func Test_TrackConfirmations(t *testing.T) {
+	canonical := map[int]Block{
+		100: mockChainBlock(100, "BL100"),
+		101: mockChainBlock(101, "BL101", mockOperationHash),
+		102: mockChainBlock(102, "BL102"),
+		103: mockChainBlock(103, "BL103"),
+	}
+	fork := map[int]Block{
+		100: mockChainBlock(100, "BL100"),
+		101: mockChainBlock(101, "BL101b"),
+		102: mockChainBlock(102, "BL102b", mockOperationHash),
+		103: mockChainBlock(103, "BL103b"),
+	}
+
+	replaced := map[int]Block{
+		99:  mockChainBlock(99, "BL99"),
+		100: mockChainBlock(100, "BL100b", mockOperationHash),
+		101: mockChainBlock(101, "BL101c"),
+	}
+	withTTL := func(block Block, ttl int) Block {
+		block.Metadata.MaxOperationsTTL = ttl
+		return block
+	}
+
+	type want struct {
+		err           bool
+		containsErr   string
+		confirmations []Confirmation
+	}
+
+	cases := []struct {
+		name   string
+		chain  *chainHandlerMock
+		input  ConfirmationInput
+		cancel bool
+		want
+	}{
+		{
+			"is successful",
+			&chainHandlerMock{
+				heads:  []int{100, 101, 102, 103},
+				chains: []map[int]Block{canonical, canonical, canonical, canonical},
+			},
+			ConfirmationInput{OperationHash: mockOperationHash, Confirmations: 2},
+			false,
+			want{
+				false,
+				"",
+				[]Confirmation{
+					{mockOperationHash, "BL101", 101, 0},
+					{mockOperationHash, "BL101", 101, 1},
+					{mockOperationHash, "BL101", 101, 2},
+				},
+			},
+		},
+		{
+			"finds operation in skipped blocks",
+			&chainHandlerMock{
+				heads:  []int{100, 103},
+				chains: []map[int]Block{canonical, canonical},
+			},
+			ConfirmationInput{OperationHash: mockOperationHash, Confirmations: 2},
+			false,
+			want{
+				false,
+				"",
+				[]Confirmation{
+					{mockOperationHash, "BL101", 101, 2},
+				},
+			},
+		},
+		{
+			"finds operation included before tracking",
+			&chainHandlerMock{
+				heads:  []int{103},
+				chains: []map[int]Block{canonical},
+			},
+			ConfirmationInput{OperationHash: mockOperationHash, Confirmations: 2, FromLevel: 100},
+			false,
+			want{
+				false,
+				"",
+				[]Confirmation{
+					{mockOperationHash, "BL101", 101, 2},
+				},
+			},
+		},
+		{
+			"searches max_operations_ttl blocks back by default",
+			&chainHandlerMock{
+				heads: []int{103},
+				chains: []map[int]Block{{
+					100: canonical[100],
+					101: canonical[101],
+					102: canonical[102],
+					103: withTTL(canonical[103], 2),
+				}},
+			},
+			ConfirmationInput{OperationHash: mockOperationHash, Confirmations: 2},
+			false,
+			want{
+				false,
+				"",
+				[]Confirmation{
+					{mockOperationHash, "BL101", 101, 2},
+				},
+			},
+		},
+		{
+			"follows new heads at the same level",
+			&chainHandlerMock{
+				heads:  []int{100, 100, 101},
+				chains: []map[int]Block{canonical, replaced, replaced},
+			},
+			ConfirmationInput{OperationHash: mockOperationHash, Confirmations: 1},
+			false,
+			want{
+				false,
+				"",
+				[]Confirmation{
+					{mockOperationHash, "BL100b", 100, 0},
+					{mockOperationHash, "BL100b", 100, 1},
+				},
+			},
+		},
+		{
+			"follows reorganizations",
+			&chainHandlerMock{
+				heads:  []int{100, 101, 103},
+				chains: []map[int]Block{canonical, canonical, fork},
+			},
+			ConfirmationInput{OperationHash: mockOperationHash, Confirmations: 1},
+			false,
+			want{
+				false,
+				"",
+				[]Confirmation{
+					{mockOperationHash, "BL101", 101, 0},
+					{mockOperationHash, "BL102b", 102, 1},
+				},
+			},
+		},
+		{
+			"is not included within max blocks",
+			&chainHandlerMock{
+				heads:  []int{100, 101, 102},
+				chains: []map[int]Block{fork, fork, fork},
+			},
+			ConfirmationInput{OperationHash: "ooNotIncluded", MaxBlocks: 2},
+			false,
+			want{
+				true,
+				"not included within 2 blocks",
+				nil,
+			},
+		},
+		{
+			"is cancelled",
+			&chainHandlerMock{
+				heads:  []int{100},
+				chains: []map[int]Block{canonical},
+			},
+			ConfirmationInput{OperationHash: mockOperationHash},
+			true,
+			want{
+				true,
+				"context canceled",
+				nil,
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(gtGoldenHTTPMock(tt.chain.handler(blankHandler)))
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+
+			tt.input.Interval = time.Millisecond
+			confirmations, errs, err := gt.TrackConfirmations(ctx, &tt.input)
+			assert.Nil(t, err)
+
+			var got []Confirmation
+			for confirmation := range confirmations {
+				got = append(got, confirmation)
+			}
+			checkErr(t, tt.want.err, tt.want.containsErr, <-errs)
+			assert.Equal(t, tt.want.confirmations, got)
+		})
+	}
+
+	_, _, err := (&GoTezos{}).TrackConfirmations(context.Background(), &ConfirmationInput{})
+	checkErr(t, true, "invalid input", err)
+}
@@ -0,0 +1,81 @@
+package gotezos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+/*
+ConsensusKey Result
+RPC: ../<block_id>/context/delegates/<pkh>/consensus_key (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-delegates-pkh-consensus-key
+Description: The key a delegate bakes and attests with, and the keys it is updated to in the coming cycles.
+*/
+type ConsensusKey struct {
+	Active   ConsensusKeyInfo      `json:"active"`
+	Pendings []PendingConsensusKey `json:"pendings,omitempty"`
+}
+
+// ConsensusKeyInfo is a consensus key and its key hash.
+type ConsensusKeyInfo struct {
+	Pkh string `json:"pkh"`
+	Pk  string `json:"pk"`
+}
+
+// PendingConsensusKey is a consensus key that becomes active at a cycle.
+type PendingConsensusKey struct {
+	Cycle int    `json:"cycle"`
+	Pkh   string `json:"pkh"`
+	Pk    string `json:"pk"`
+}
+
+/*
+ConsensusKey RPC
+Path: ../<block_id>/context/delegates/<pkh>/consensus_key (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-delegates-pkh-consensus-key
+Description: The active and pending consensus keys of a delegate (Lima and later).
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+	delegate:
+		The tz(1-3) address of the delegate.
+*/
+func (t *GoTezos) ConsensusKey(blockID BlockID, delegate string) (*ConsensusKey, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/delegates/%s/consensus_key", blockID.ID(), delegate))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get consensus key of delegate '%s'", delegate)
+	}
+
+	var key ConsensusKey
+	err = json.Unmarshal(resp, &key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal consensus key of delegate '%s'", delegate)
+	}
+
+	return &key, nil
+}
+
+/*
+UpdateConsensusKey Function
+Description: Sets the consensus key of the wallet, which must be a registered delegate, and returns the hash
+of the operation. The key becomes active after consensus_rights_delay cycles (preserved_cycles before Paris),
+see ConsensusKey.
+
+Parameters:
+	ctx:
+		Cancels waiting for the operation, see WalletClient.Wait.
+	pk:
+		The public key (edpk, sppk or p2pk) to bake and attest with.
+*/
+func (w *WalletClient) UpdateConsensusKey(ctx context.Context, pk string) (*string, error) {
+	hash, err := w.Send(ctx, Contents{Kind: UPDATECONSENSUSKEYOP, Pk: pk})
+	if err != nil {
+		return hash, errors.Wrap(err, "failed to update consensus key")
+	}
+
+	return hash, nil
+}
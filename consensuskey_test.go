@@ -0,0 +1,151 @@
+package gotezos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var mockConsensusKeyResp = []byte(`{"active":{"pkh":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","pk":"edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G"},"pendings":[{"cycle":712,"pkh":"tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH","pk":"edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"}]}`)
+
+func consensusKeyHandlerMock(resp []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/consensus_key") {
+			w.Write(resp)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func Test_ConsensusKey(t *testing.T) {
+	cases := []struct {
+		name    string
+		resp    []byte
+		want    *ConsensusKey
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			"returns the active and pending consensus keys",
+			mockConsensusKeyResp,
+			&ConsensusKey{
+				Active: ConsensusKeyInfo{Pkh: "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc", Pk: "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G"},
+				Pendings: []PendingConsensusKey{
+					{Cycle: 712, Pkh: "tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH", Pk: "edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"},
+				},
+			},
+			false,
+			"",
+		},
+		{
+			"handles rpc error",
+			mockRPCErrorResp,
+			nil,
+			true,
+			"could not get consensus key of delegate 'tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc'",
+		},
+		{
+			"handles failure to unmarshal",
+			[]byte(`junk`),
+			nil,
+			true,
+			"could not unmarshal consensus key of delegate 'tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc'",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(gtGoldenHTTPMock(consensusKeyHandlerMock(tt.resp, blankHandler)))
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			key, err := gt.ConsensusKey(BlockIDHead{}, "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc")
+			checkErr(t, tt.wantErr, tt.errMsg, err)
+			assert.Equal(t, tt.want, key)
+		})
+	}
+}
+
+func Test_ForgeConsensusKeyOperations(t *testing.T) {
+	sppk := b58cencode(append([]byte{2}, bytes.Repeat([]byte{7}, 32)...), prefix_sppk)
+
+	update := Contents{
+		Kind:         UPDATECONSENSUSKEYOP,
+		Source:       mockAddressTz1,
+		Fee:          BigInt{*big.NewInt(1000)},
+		Counter:      BigInt{*big.NewInt(12)},
+		GasLimit:     BigInt{*big.NewInt(1100)},
+		StorageLimit: BigInt{*big.NewInt(100)},
+		Pk:           "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G",
+	}
+
+	updateSecp := update
+	updateSecp.Counter = BigInt{*big.NewInt(13)}
+	updateSecp.Pk = sppk
+
+	drain := Contents{
+		Kind:         DRAINDELEGATEOP,
+		ConsensusKey: "tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH",
+		Delegate:     "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+		Destination:  "tz3WMqdzXqRWXwyvj5Hp2H7QEepaUuS7vd9K",
+	}
+
+	contents := []Contents{drain, update, updateSecp}
+
+	gt := &GoTezos{}
+	forge, err := gt.ForgeOperation(mockBlockHash, contents...)
+	assert.Nil(t, err)
+
+	branch, unforged, err := gt.UnforgeOperation(*forge, false)
+	assert.Nil(t, err)
+	assert.Equal(t, mockBlockHash, *branch)
+	assert.Equal(t, contents, *unforged)
+
+	v, err := json.Marshal(drain)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"kind":"drain_delegate","consensus_key":"tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH","delegate":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","destination":"tz3WMqdzXqRWXwyvj5Hp2H7QEepaUuS7vd9K"}`, string(v))
+
+	v, err = json.Marshal(update)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"kind":"update_consensus_key","source":"tz1YGLnq1Ls4W3rPanAvCvmcuQ1H5rffnc2V","fee":"1000","counter":"12","gas_limit":"1100","storage_limit":"100","pk":"edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G"}`, string(v))
+
+	var result Contents
+	err = json.Unmarshal([]byte(`{"kind":"drain_delegate","consensus_key":"tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH","delegate":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","destination":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","metadata":{"balance_updates":[{"kind":"contract","contract":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","change":"-15000000","origin":"block"}],"allocated_destination_contract":true}}`), &result)
+	assert.Nil(t, err)
+	assert.True(t, result.Metadata.AllocatedDestinationContract)
+
+	invalid := update
+	invalid.Pk = "edpk_not_a_key"
+	_, err = gt.ForgeOperation(mockBlockHash, invalid)
+	checkErr(t, true, "invalid public key 'edpk_not_a_key'", err)
+
+	invalid = drain
+	invalid.Destination = mockSmartRollup
+	_, err = gt.ForgeOperation(mockBlockHash, invalid)
+	checkErr(t, true, "invalid key hash", err)
+}
+
+func Test_WalletClientUpdateConsensusKey(t *testing.T) {
+	client, mock := testWalletClient(t)
+
+	_, err := client.UpdateConsensusKey(context.Background(), "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G")
+	assert.Nil(t, err)
+	assert.Len(t, mock.injected, 1)
+
+	_, unforged, err := client.Client.UnforgeOperation(mock.injected[0], true)
+	assert.Nil(t, err)
+	last := (*unforged)[len(*unforged)-1]
+	assert.Equal(t, UPDATECONSENSUSKEYOP, last.Kind)
+	assert.Equal(t, "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G", last.Pk)
+}
@@ -1,28 +1,208 @@
 package gotezos
 
 import (
+	"encoding/binary"
+	"encoding/json"
 	"fmt"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
 )
 
+// UnparsingMode is how the node unparses Micheline when normalizing contract data.
+type UnparsingMode string
+
+const (
+	// UnparsingModeReadable unparses addresses, keys, signatures and timestamps as strings.
+	UnparsingModeReadable UnparsingMode = "Readable"
+	// UnparsingModeOptimized unparses addresses, keys, signatures and timestamps as bytes and ints.
+	UnparsingModeOptimized UnparsingMode = "Optimized"
+	// UnparsingModeOptimizedLegacy is UnparsingModeOptimized without pair combs.
+	UnparsingModeOptimizedLegacy UnparsingMode = "Optimized_legacy"
+)
+
+/*
+ContractStorageInput -
+Description: The input for the ContractStorage rpc query.
+Function: func (t *GoTezos) ContractStorage(input *ContractStorageInput) (*Micheline, error) {}
+*/
+type ContractStorageInput struct {
+	// The block (hash, level, head or head~<n>) of which you want to make the query.
+	// Required.
+	BlockID BlockID `validate:"required"`
+
+	// The contract address.
+	// Required.
+	Contract string `validate:"required"`
+
+	// If set, the storage is normalized by the node with the given unparsing mode.
+	UnparsingMode UnparsingMode
+}
+
+/*
+ContractScriptInput -
+Description: The input for the ContractScript rpc query.
+Function: func (t *GoTezos) ContractScript(input *ContractScriptInput) (*Script, error) {}
+*/
+type ContractScriptInput struct {
+	// The block (hash, level, head or head~<n>) of which you want to make the query.
+	// Required.
+	BlockID BlockID `validate:"required"`
+
+	// The contract address.
+	// Required.
+	Contract string `validate:"required"`
+
+	// If set, the script is normalized by the node with the given unparsing mode.
+	UnparsingMode UnparsingMode
+}
+
+/*
+Script -
+RPC: ../<block_id>/context/contracts/<contract_id>/script (GET)
+Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-contracts-contract-id-script
+*/
+type Script struct {
+	Code    Micheline `json:"code"`
+	Storage Micheline `json:"storage"`
+}
+
+/*
+OriginatedContract Function
+Description: Returns the address of a contract originated by an operation: the blake2b-160 hash of the
+operation hash and the origination index. The index counts the originations of the operation, internal ones
+included, from zero.
+
+Parameters:
+	operationHash:
+		The hash of the operation.
+	index:
+		The index of the origination in the operation.
+*/
+func OriginatedContract(operationHash string, index int) (string, error) {
+	hash, err := b58cdecodeChecked(operationHash, prefix_o, 32)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid operation hash '%s'", operationHash)
+	}
+
+	nonce := make([]byte, 4)
+	binary.BigEndian.PutUint32(nonce, uint32(index))
+
+	digest, err := blake2b.New(20, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to hash origination nonce")
+	}
+	digest.Write(append(hash, nonce...))
+
+	return b58cencode(digest.Sum(nil), prefix_kt), nil
+}
+
 /*
 ContractStorage RPC
 Path: ../<block_id>/context/contracts/<contract_id>/storage (GET)
+Path: ../<block_id>/context/contracts/<contract_id>/storage/normalized (POST)
 Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-contracts-contract-id-storage
-Description: Access the data of the contract.
+Description: Access the data of the contract, at any block whose context the node keeps (all of them on
+archive nodes, those above the savepoint otherwise, see NearestAvailableBlock).
 
 Parameters:
-	blockhash:
-		The hash of block (height) of which you want to make the query.
-	KT1:
-		The contract address.
+	input:
+		Modifies the ContractStorage RPC query. BlockID and Contract are required.
 */
-func (t *GoTezos) ContractStorage(blockhash string, KT1 string) (*[]byte, error) {
-	query := fmt.Sprintf("/chains/main/blocks/%s/context/contracts/%s/storage", blockhash, KT1)
-	resp, err := t.get(query)
+func (t *GoTezos) ContractStorage(input *ContractStorageInput) (*Micheline, error) {
+	err := validator.New().Struct(input)
 	if err != nil {
-		return &resp, errors.Wrap(err, "could not get storage '%s'")
+		return nil, errors.Wrap(err, "invalid input")
 	}
-	return &resp, nil
+
+	resp, err := t.contractData(input.BlockID, input.Contract, "storage", input.UnparsingMode)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get storage '%s'", input.Contract)
+	}
+
+	var storage Micheline
+	err = json.Unmarshal(resp, &storage)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal storage '%s'", input.Contract)
+	}
+
+	return &storage, nil
+}
+
+/*
+ContractScript RPC
+Path: ../<block_id>/context/contracts/<contract_id>/script (GET)
+Path: ../<block_id>/context/contracts/<contract_id>/script/normalized (POST)
+Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-contracts-contract-id-script
+Description: Access the code and data of the contract.
+
+Parameters:
+	input:
+		Modifies the ContractScript RPC query. BlockID and Contract are required.
+*/
+func (t *GoTezos) ContractScript(input *ContractScriptInput) (*Script, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	resp, err := t.contractData(input.BlockID, input.Contract, "script", input.UnparsingMode)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get script '%s'", input.Contract)
+	}
+
+	var script Script
+	err = json.Unmarshal(resp, &script)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal script '%s'", input.Contract)
+	}
+
+	return &script, nil
+}
+
+/*
+ManagerKey RPC
+Path: ../<block_id>/context/contracts/<contract_id>/manager_key (GET)
+Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-contracts-contract-id-manager-key
+Description: Access the public key of the manager of an implicit account. Returns nil if the public key
+was not revealed yet.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+	address:
+		The implicit account (tz1, tz2, tz3 or tz4).
+*/
+func (t *GoTezos) ManagerKey(blockID BlockID, address string) (*string, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/contracts/%s/manager_key", blockID.ID(), address))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get manager key of '%s'", address)
+	}
+
+	var key *string
+	err = json.Unmarshal(resp, &key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal manager key of '%s'", address)
+	}
+
+	return key, nil
+}
+
+func (t *GoTezos) contractData(blockID BlockID, contract, data string, mode UnparsingMode) ([]byte, error) {
+	query := fmt.Sprintf("/chains/main/blocks/%s/context/contracts/%s/%s", blockID.ID(), contract, data)
+	if mode == "" {
+		return t.get(query)
+	}
+
+	body, err := json.Marshal(struct {
+		UnparsingMode UnparsingMode `json:"unparsing_mode"`
+	}{
+		UnparsingMode: mode,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return t.post(fmt.Sprintf("%s/normalized", query), body)
 }
@@ -1,6 +1,7 @@
 package gotezos
 
 import (
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -8,31 +9,78 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+var mockScriptResp = []byte(`{"code":[{"prim":"parameter","args":[{"prim":"string"}]},{"prim":"storage","args":[{"prim":"string"}]},{"prim":"code","args":[[{"prim":"CAR"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}],"storage":{"string":"Hello Tezos!"}}`)
+
+func normalizedHandlerMock(t *testing.T, mode UnparsingMode, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Regexp(t, `\/normalized$`, r.URL.Path)
+		assert.JSONEq(t, `{"unparsing_mode":"`+string(mode)+`"}`, string(body))
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 func Test_ContractStorage(t *testing.T) {
-	goldenStorage := []byte(`"Hello Tezos!"`)
+	goldenStorage := NewMichelineString("Hello Tezos!")
+
 	type want struct {
 		err         bool
 		containsErr string
-		rpcerr      *[]byte
+		storage     *Micheline
 	}
 
 	cases := []struct {
 		name        string
 		inputHanler http.Handler
+		input       ContractStorageInput
 		want
 	}{
+		{
+			"handles invalid input",
+			gtGoldenHTTPMock(blankHandler),
+			ContractStorageInput{Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg"},
+			want{
+				true,
+				"invalid input",
+				nil,
+			},
+		},
 		{
 			"returns rpc error",
 			gtGoldenHTTPMock(storageHandlerMock(mockRPCErrorResp, blankHandler)),
+			ContractStorageInput{BlockID: BlockIDHash(mockBlockHash), Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg"},
 			want{
 				true,
 				"could not get storage",
-				&mockRPCErrorResp,
+				nil,
+			},
+		},
+		{
+			"fails to unmarshal",
+			gtGoldenHTTPMock(storageHandlerMock([]byte(`"Hello Tezos!"`), blankHandler)),
+			ContractStorageInput{BlockID: BlockIDHash(mockBlockHash), Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg"},
+			want{
+				true,
+				"could not unmarshal storage",
+				nil,
 			},
 		},
 		{
 			"is successful",
-			gtGoldenHTTPMock(storageHandlerMock(goldenStorage, blankHandler)),
+			gtGoldenHTTPMock(storageHandlerMock([]byte(`{"string":"Hello Tezos!"}`), blankHandler)),
+			ContractStorageInput{BlockID: BlockIDHash(mockBlockHash), Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg"},
+			want{
+				false,
+				"",
+				&goldenStorage,
+			},
+		},
+		{
+			"is successful with unparsing mode",
+			gtGoldenHTTPMock(normalizedHandlerMock(t, UnparsingModeReadable, storageHandlerMock([]byte(`{"string":"Hello Tezos!"}`), blankHandler))),
+			ContractStorageInput{BlockID: BlockIDHead{}, Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", UnparsingMode: UnparsingModeReadable},
 			want{
 				false,
 				"",
@@ -49,15 +97,196 @@ func Test_ContractStorage(t *testing.T) {
 			gt, err := New(server.URL)
 			assert.Nil(t, err)
 
-			rpcerr, err := gt.ContractStorage("BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1", "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg")
+			storage, err := gt.ContractStorage(&tt.input)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			assert.Equal(t, tt.want.storage, storage)
+		})
+	}
+}
+
+func Test_ContractScript(t *testing.T) {
+	type want struct {
+		err         bool
+		containsErr string
+		code        []string
+	}
+
+	cases := []struct {
+		name        string
+		inputHanler http.Handler
+		input       ContractScriptInput
+		want
+	}{
+		{
+			"returns rpc error",
+			gtGoldenHTTPMock(scriptHandlerMock(mockRPCErrorResp, blankHandler)),
+			ContractScriptInput{BlockID: BlockIDHash(mockBlockHash), Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg"},
+			want{
+				true,
+				"could not get script",
+				nil,
+			},
+		},
+		{
+			"fails to unmarshal",
+			gtGoldenHTTPMock(scriptHandlerMock([]byte(`junk`), blankHandler)),
+			ContractScriptInput{BlockID: BlockIDHash(mockBlockHash), Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg"},
+			want{
+				true,
+				"could not unmarshal script",
+				nil,
+			},
+		},
+		{
+			"is successful",
+			gtGoldenHTTPMock(scriptHandlerMock(mockScriptResp, blankHandler)),
+			ContractScriptInput{BlockID: BlockIDHash(mockBlockHash), Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg"},
+			want{
+				false,
+				"",
+				[]string{"parameter", "storage", "code"},
+			},
+		},
+		{
+			"is successful with unparsing mode",
+			gtGoldenHTTPMock(normalizedHandlerMock(t, UnparsingModeOptimized, scriptHandlerMock(mockScriptResp, blankHandler))),
+			ContractScriptInput{BlockID: BlockIDLevel(100), Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", UnparsingMode: UnparsingModeOptimized},
+			want{
+				false,
+				"",
+				[]string{"parameter", "storage", "code"},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.inputHanler)
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			script, err := gt.ContractScript(&tt.input)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
 			if tt.want.err {
-				assert.NotNil(t, err)
-				assert.Contains(t, err.Error(), tt.want.containsErr)
-			} else {
-				assert.Nil(t, err)
+				assert.Nil(t, script)
+				return
+			}
+
+			var code []string
+			for _, section := range script.Code.Seq {
+				code = append(code, section.Prim)
 			}
+			assert.Equal(t, tt.want.code, code)
+			assert.Equal(t, NewMichelineString("Hello Tezos!"), script.Storage)
+		})
+	}
+}
+
+func Test_ManagerKey(t *testing.T) {
+	type want struct {
+		err         bool
+		containsErr string
+		key         *string
+	}
+
+	key := "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G"
+
+	cases := []struct {
+		name        string
+		inputHanler http.Handler
+		want
+	}{
+		{
+			"returns rpc error",
+			gtGoldenHTTPMock(managerKeyHandlerMock(mockRPCErrorResp, blankHandler)),
+			want{
+				true,
+				"could not get manager key",
+				nil,
+			},
+		},
+		{
+			"fails to unmarshal",
+			gtGoldenHTTPMock(managerKeyHandlerMock([]byte(`junk`), blankHandler)),
+			want{
+				true,
+				"could not unmarshal manager key",
+				nil,
+			},
+		},
+		{
+			"is successful when not revealed",
+			gtGoldenHTTPMock(managerKeyHandlerMock([]byte(`null`), blankHandler)),
+			want{
+				false,
+				"",
+				nil,
+			},
+		},
+		{
+			"is successful",
+			gtGoldenHTTPMock(managerKeyHandlerMock([]byte(`"`+key+`"`), blankHandler)),
+			want{
+				false,
+				"",
+				&key,
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.inputHanler)
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
 
-			assert.Equal(t, tt.want.rpcerr, rpcerr)
+			managerKey, err := gt.ManagerKey(BlockIDHead{}, "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK")
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			assert.Equal(t, tt.want.key, managerKey)
+		})
+	}
+}
+
+func Test_OriginatedContract(t *testing.T) {
+	cases := []struct {
+		name          string
+		operationHash string
+		index         int
+		wantErr       string
+		want          string
+	}{
+		{
+			"returns invalid operation hash",
+			"BLyvCRkxuTXkx1KeGvrcEXiPYj4p1tFxzvFDhoHE7SFKtmP1rbk",
+			0,
+			"invalid operation hash",
+			"",
+		},
+		{
+			"is successful",
+			"ooAD9LiA6B2WkPL1TerMKmE9yRS88iLJ6aLu36KYeW7AiMWeFLo",
+			0,
+			"",
+			"KT1TjrEBttTzFDHjHk5BtGforkjnC5GpvcWf",
+		},
+		{
+			"is successful with index",
+			"ooAD9LiA6B2WkPL1TerMKmE9yRS88iLJ6aLu36KYeW7AiMWeFLo",
+			1,
+			"",
+			"KT1QAP9Gda5wxFY4qUFZ1otWKK4twGS76nrc",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			contract, err := OriginatedContract(tt.operationHash, tt.index)
+			checkErr(t, tt.wantErr != "", tt.wantErr, err)
+			assert.Equal(t, tt.want, contract)
 		})
 	}
 }
@@ -16,14 +16,30 @@ type prefix []byte
 var (
 	// For (de)constructing addresses
 	prefix_tz1       prefix = []byte{6, 161, 159}
+	prefix_tz2       prefix = []byte{6, 161, 161}
+	prefix_tz3       prefix = []byte{6, 161, 164}
 	prefix_kt        prefix = []byte{2, 90, 121}
 	prefix_edsk      prefix = []byte{43, 246, 78, 7}
 	prefix_edsk2     prefix = []byte{13, 15, 58, 7}
 	prefix_edpk      prefix = []byte{13, 15, 37, 217}
+	prefix_sppk      prefix = []byte{3, 254, 226, 86}
+	prefix_p2pk      prefix = []byte{3, 178, 139, 127}
 	prefix_edesk     prefix = []byte{7, 90, 60, 179, 41}
 	prefix_edsig     prefix = []byte{9, 245, 205, 134, 18}
+	prefix_spsig     prefix = []byte{13, 115, 101, 19, 63}
+	prefix_p2sig     prefix = []byte{54, 240, 44, 52}
+	prefix_sig       prefix = []byte{4, 130, 43}
 	prefix_watermark prefix = []byte{3}
 	prefix_branch    prefix = []byte{1, 52}
+	prefix_vh        prefix = []byte{1, 106, 242}
+	prefix_chain_id  prefix = []byte{87, 82, 0}
+	prefix_expr      prefix = []byte{13, 44, 64, 27}
+	prefix_o         prefix = []byte{5, 116}
+	prefix_sr1       prefix = []byte{6, 124, 117}
+	prefix_src1      prefix = []byte{17, 165, 134, 138}
+	prefix_srs1      prefix = []byte{17, 165, 235, 240}
+	prefix_llo       prefix = []byte{29, 159, 109}
+	prefix_co        prefix = []byte{79, 199}
 )
 
 //b58cencode encodes a byte array into base58 with prefix
@@ -44,6 +60,107 @@ func b58cdecode(payload string, prefix []byte) []byte {
 	return b58c[len(prefix):]
 }
 
+// b58cdecodeChecked decodes a base58check string, verifying its checksum, prefix and payload length.
+func b58cdecodeChecked(payload string, prefix prefix, length int) ([]byte, error) {
+	b58c, err := decode(payload)
+	if err != nil {
+		return nil, errors.New("invalid base58 checksum")
+	}
+	if !bytes.HasPrefix(b58c, prefix) || len(b58c) != len(prefix)+length {
+		return nil, errors.New("unexpected prefix or length")
+	}
+	return b58c[len(prefix):], nil
+}
+
+// keyHashToBytes returns the binary form (tag + 20 byte hash) of a tz1, tz2 or tz3 address.
+func keyHashToBytes(keyHash string) ([]byte, error) {
+	for tag, p := range []prefix{prefix_tz1, prefix_tz2, prefix_tz3} {
+		if hash, err := b58cdecodeChecked(keyHash, p, 20); err == nil {
+			return append([]byte{byte(tag)}, hash...), nil
+		}
+	}
+	return nil, errors.New("invalid key hash '" + keyHash + "'")
+}
+
+// bytesToKeyHash is the inverse of keyHashToBytes.
+func bytesToKeyHash(keyHash []byte) (string, error) {
+	if len(keyHash) != 21 || int(keyHash[0]) > 2 {
+		return "", errors.New("invalid key hash bytes")
+	}
+	return b58cencode(keyHash[1:], []prefix{prefix_tz1, prefix_tz2, prefix_tz3}[keyHash[0]]), nil
+}
+
+// addressToBytes returns the 22 byte binary form of a tz1, tz2, tz3 or KT1 address.
+func addressToBytes(address string) ([]byte, error) {
+	if hash, err := b58cdecodeChecked(address, prefix_kt, 20); err == nil {
+		return append(append([]byte{1}, hash...), 0), nil
+	}
+
+	keyHash, err := keyHashToBytes(address)
+	if err != nil {
+		return nil, errors.New("invalid address '" + address + "'")
+	}
+	return append([]byte{0}, keyHash...), nil
+}
+
+// bytesToAddress is the inverse of addressToBytes.
+func bytesToAddress(address []byte) (string, error) {
+	if len(address) == 22 && address[0] == 0 {
+		return bytesToKeyHash(address[1:])
+	}
+	if len(address) == 22 && address[0] == 1 && address[21] == 0 {
+		return b58cencode(address[1:21], prefix_kt), nil
+	}
+	return "", errors.New("invalid address bytes")
+}
+
+// publicKeyToBytes returns the binary form (tag + key) of an edpk, sppk or p2pk public key.
+func publicKeyToBytes(publicKey string) ([]byte, error) {
+	if key, err := b58cdecodeChecked(publicKey, prefix_edpk, 32); err == nil {
+		return append([]byte{0}, key...), nil
+	}
+	if key, err := b58cdecodeChecked(publicKey, prefix_sppk, 33); err == nil {
+		return append([]byte{1}, key...), nil
+	}
+	if key, err := b58cdecodeChecked(publicKey, prefix_p2pk, 33); err == nil {
+		return append([]byte{2}, key...), nil
+	}
+	return nil, errors.New("invalid public key '" + publicKey + "'")
+}
+
+// bytesToPublicKey is the inverse of publicKeyToBytes.
+func bytesToPublicKey(key []byte) (string, error) {
+	if len(key) == 33 && key[0] == 0 {
+		return b58cencode(key[1:], prefix_edpk), nil
+	}
+	if len(key) == 34 && key[0] == 1 {
+		return b58cencode(key[1:], prefix_sppk), nil
+	}
+	if len(key) == 34 && key[0] == 2 {
+		return b58cencode(key[1:], prefix_p2pk), nil
+	}
+	return "", errors.New("invalid public key bytes")
+}
+
+// signatureToBytes returns the 64 byte binary form of an edsig, spsig1, p2sig or sig signature.
+func signatureToBytes(signature string) ([]byte, error) {
+	for _, p := range []prefix{prefix_edsig, prefix_spsig, prefix_p2sig, prefix_sig} {
+		if sig, err := b58cdecodeChecked(signature, p, 64); err == nil {
+			return sig, nil
+		}
+	}
+	return nil, errors.New("invalid signature '" + signature + "'")
+}
+
+// chainIDToBytes returns the 4 byte binary form of a chain id.
+func chainIDToBytes(chainID string) ([]byte, error) {
+	v, err := b58cdecodeChecked(chainID, prefix_chain_id, 4)
+	if err != nil {
+		return nil, errors.New("invalid chain id '" + chainID + "'")
+	}
+	return v, nil
+}
+
 //Helper Functions to round float64
 func roundPlus(f float64, places int) float64 {
 	shift := math.Pow(10, float64(places))
@@ -103,6 +220,9 @@ func decode(encoded string) ([]byte, error) {
 	if err != nil {
 		return []byte{}, err
 	}
+	if len(dataBytes) < 4 {
+		return []byte{}, errors.New("invalid base58 checksum")
+	}
 	data, checksum := dataBytes[:len(dataBytes)-4], dataBytes[len(dataBytes)-4:]
 
 	for i := 0; i < zeroCount; i++ {
@@ -0,0 +1,255 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+/*
+DALConstants Result
+RPC: ../<block_id>/context/constants (GET)
+Description: The parameters of the Data Availability Layer, the dal_parametric constant (Oxford and later).
+*/
+type DALConstants struct {
+	FeatureEnable        bool `json:"feature_enable"`
+	IncentivesEnable     bool `json:"incentives_enable,omitempty"`
+	NumberOfSlots        int  `json:"number_of_slots"`
+	AttestationLag       int  `json:"attestation_lag"`
+	AttestationThreshold int  `json:"attestation_threshold"`
+	RedundancyFactor     int  `json:"redundancy_factor"`
+	PageSize             int  `json:"page_size"`
+	SlotSize             int  `json:"slot_size"`
+	NumberOfShards       int  `json:"number_of_shards"`
+}
+
+/*
+DALSlotHeader -
+RPC: /chains/<chain_id>/blocks/<block_id> (<dyn>)
+Description: The slot header of a dal_publish_commitment operation.
+*/
+type DALSlotHeader struct {
+	SlotIndex       int    `json:"slot_index"`
+	Commitment      string `json:"commitment"`
+	CommitmentProof string `json:"commitment_proof"`
+}
+
+/*
+DALPublishedSlotHeader Result
+RPC: ../<block_id>/context/dal/published_slot_headers (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-dal-published-slot-headers
+*/
+type DALPublishedSlotHeader struct {
+	Level      int    `json:"level"`
+	Index      int    `json:"index"`
+	Commitment string `json:"commitment"`
+}
+
+/*
+DALShards Result
+RPC: ../<block_id>/context/dal/shards (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-dal-shards
+*/
+type DALShards struct {
+	Delegate string `json:"delegate"`
+	Indexes  []int  `json:"indexes"`
+}
+
+/*
+DALAttestationStatus -
+Description: The slots attested at a level. Slots are attested attestation_lag levels after they are
+published: the slots attested at Level were published at PublishedLevel.
+*/
+type DALAttestationStatus struct {
+	Level          int
+	PublishedLevel int
+	// The indexes of the attested slots, in increasing order.
+	Slots []int
+}
+
+/*
+Attested Function
+Description: Returns whether a slot is attested.
+
+Parameters:
+	slot:
+		The index of the slot.
+*/
+func (s *DALAttestationStatus) Attested(slot int) bool {
+	for _, attested := range s.Slots {
+		if attested == slot {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+DALShardsInput -
+Description: The input for the dal shards rpc query.
+Function: func (t *GoTezos) DALShards(input *DALShardsInput) ([]DALShards, error) {}
+*/
+type DALShardsInput struct {
+	// The block (hash, level, head or head~<n>) of which you want to make the query.
+	// Required.
+	BlockID BlockID `validate:"required"`
+
+	// The level of which you want the shards. Defaults to the level after the block.
+	Level *int
+
+	// The delegates of which you want the shards. Defaults to all delegates.
+	Delegates []string
+}
+
+/*
+DALShards RPC
+Path: ../<block_id>/context/dal/shards (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-dal-shards
+Description: Returns the indexes of the DAL shards assigned to each delegate at a level.
+
+Parameters:
+	input:
+		The block, level and delegates of which you want the shards. BlockID is required.
+*/
+func (t *GoTezos) DALShards(input *DALShardsInput) ([]DALShards, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return []DALShards{}, errors.Wrap(err, "invalid input")
+	}
+
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/dal/shards", input.BlockID.ID()), input.contructRPCOptions()...)
+	if err != nil {
+		return []DALShards{}, errors.Wrap(err, "could not get dal shards")
+	}
+
+	var shards []DALShards
+	err = json.Unmarshal(resp, &shards)
+	if err != nil {
+		return []DALShards{}, errors.Wrap(err, "could not unmarshal dal shards")
+	}
+
+	return shards, nil
+}
+
+func (d *DALShardsInput) contructRPCOptions() []RPCOption {
+	var opts []RPCOption
+	if d.Level != nil {
+		opts = append(opts, RPCOption{
+			Key:   "level",
+			Value: strconv.Itoa(*d.Level),
+		})
+	}
+
+	for _, delegate := range d.Delegates {
+		opts = append(opts, RPCOption{
+			Key:   "delegates",
+			Value: delegate,
+		})
+	}
+
+	return opts
+}
+
+/*
+DALPublishedSlotHeaders RPC
+Path: ../<block_id>/context/dal/published_slot_headers (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-dal-published-slot-headers
+Description: Returns the slot headers (commitments) published in a block.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+*/
+func (t *GoTezos) DALPublishedSlotHeaders(blockID BlockID) ([]DALPublishedSlotHeader, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/dal/published_slot_headers", blockID.ID()))
+	if err != nil {
+		return []DALPublishedSlotHeader{}, errors.Wrap(err, "could not get dal published slot headers")
+	}
+
+	var headers []DALPublishedSlotHeader
+	err = json.Unmarshal(resp, &headers)
+	if err != nil {
+		return []DALPublishedSlotHeader{}, errors.Wrap(err, "could not unmarshal dal published slot headers")
+	}
+
+	return headers, nil
+}
+
+/*
+DALAttestationStatus Function
+Description: Returns the DAL slots attested at a block, from the dal_attestation of its metadata, along
+with the level they were published at.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want the attested slots.
+*/
+func (t *GoTezos) DALAttestationStatus(blockID BlockID) (*DALAttestationStatus, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/metadata", blockID.ID()))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get dal attestation status")
+	}
+
+	var metadata Metadata
+	err = json.Unmarshal(resp, &metadata)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal block metadata")
+	}
+
+	if metadata.DALAttestation == nil {
+		return nil, errors.Errorf("could not get dal attestation status: block '%s' has no dal_attestation", blockID.ID())
+	}
+
+	constants, err := t.Constants(blockID)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get dal attestation status")
+	}
+
+	if constants.DAL == nil {
+		return nil, errors.New("could not get dal attestation status: network has no dal_parametric constants")
+	}
+
+	level := metadata.Level.Level
+	if metadata.LevelInfo != nil {
+		level = metadata.LevelInfo.Level
+	}
+
+	return &DALAttestationStatus{
+		Level:          level,
+		PublishedLevel: level - constants.DAL.AttestationLag,
+		Slots:          dalAttestedSlots(&metadata.DALAttestation.Int),
+	}, nil
+}
+
+/*
+AttestedSlots Function
+Description: Returns the indexes of the slots attested by a dal_attestation or attestation_with_dal
+operation, in increasing order.
+*/
+func (c *Contents) AttestedSlots() []int {
+	switch c.Kind {
+	case DALATTESTATIONOP:
+		return dalAttestedSlots(&c.Attestation.Int)
+	case ATTESTATIONWITHDALOP:
+		return dalAttestedSlots(&c.DALAttestation.Int)
+	}
+
+	return nil
+}
+
+// dalAttestedSlots returns the indexes of the bits set in a DAL attestation bitset.
+func dalAttestedSlots(bitset *big.Int) []int {
+	slots := []int{}
+	for i := 0; i < bitset.BitLen(); i++ {
+		if bitset.Bit(i) == 1 {
+			slots = append(slots, i)
+		}
+	}
+
+	return slots
+}
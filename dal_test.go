@@ -0,0 +1,179 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	mockDALShardsResp               = []byte(`[{"delegate":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","indexes":[0,7,12]},{"delegate":"tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH","indexes":[3]}]`)
+	mockDALPublishedSlotHeadersResp = []byte(`[{"version":"0","level":1200,"index":2,"commitment":"sh1u3tr3YKy7ZEEUurXvZ4xMGDzvdZrJhNRNMqvFKxmQLMUoTzjiWjuxGdsMypCtmXgAEB6N8y"}]`)
+	mockDALMetadataResp             = []byte(`{"protocol":"PsParisCZo7KAh1Z1smVd9ZMZ1HHn5gkzbM94V3PLCpknFWhUAi","level_info":{"level":1208,"level_position":1207,"cycle":9,"cycle_position":56,"expected_commitment":false},"dal_attestation":"37"}`)
+	mockDALConstantsResp            = []byte(`{"hard_gas_limit_per_operation":"1040000","dal_parametric":{"feature_enable":true,"incentives_enable":false,"number_of_slots":32,"attestation_lag":8,"attestation_threshold":66,"redundancy_factor":8,"page_size":3967,"slot_size":126944,"number_of_shards":512}}`)
+)
+
+func Test_DALShards(t *testing.T) {
+	var query string
+	routes := map[string][]byte{"/context/dal/shards": mockDALShardsResp}
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		routesHandlerMock(routes, blankHandler).ServeHTTP(w, r)
+	})))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	level := 1210
+	shards, err := gt.DALShards(&DALShardsInput{
+		BlockID:   BlockIDHead{},
+		Level:     &level,
+		Delegates: []string{"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc", "tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "delegates=tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc&delegates=tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH&level=1210", query)
+	assert.Equal(t, []DALShards{
+		{Delegate: "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc", Indexes: []int{0, 7, 12}},
+		{Delegate: "tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH", Indexes: []int{3}},
+	}, shards)
+
+	_, err = gt.DALShards(&DALShardsInput{})
+	checkErr(t, true, "invalid input", err)
+}
+
+func Test_DALPublishedSlotHeaders(t *testing.T) {
+	cases := []struct {
+		name    string
+		handler http.Handler
+		want    []DALPublishedSlotHeader
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			"returns the published slot headers",
+			routesHandlerMock(map[string][]byte{"/context/dal/published_slot_headers": mockDALPublishedSlotHeadersResp}, blankHandler),
+			[]DALPublishedSlotHeader{{Level: 1200, Index: 2, Commitment: "sh1u3tr3YKy7ZEEUurXvZ4xMGDzvdZrJhNRNMqvFKxmQLMUoTzjiWjuxGdsMypCtmXgAEB6N8y"}},
+			false,
+			"",
+		},
+		{
+			"handles rpc error",
+			routesHandlerMock(map[string][]byte{"/context/dal/published_slot_headers": mockRPCErrorResp}, blankHandler),
+			[]DALPublishedSlotHeader{},
+			true,
+			"could not get dal published slot headers",
+		},
+		{
+			"handles failure to unmarshal",
+			routesHandlerMock(map[string][]byte{"/context/dal/published_slot_headers": []byte(`junk`)}, blankHandler),
+			[]DALPublishedSlotHeader{},
+			true,
+			"could not unmarshal dal published slot headers",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(gtGoldenHTTPMock(tt.handler))
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			headers, err := gt.DALPublishedSlotHeaders(BlockIDHead{})
+			checkErr(t, tt.wantErr, tt.errMsg, err)
+			assert.Equal(t, tt.want, headers)
+		})
+	}
+}
+
+func Test_DALAttestationStatus(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(routesHandlerMock(map[string][]byte{
+		"/blocks/1208/metadata":          mockDALMetadataResp,
+		"/blocks/1208/context/constants": mockDALConstantsResp,
+		"/blocks/1100/metadata":          []byte(`{"protocol":"PtNairobiyssHuh87hEhfVBGCVrK3WnS8Z2FT4ymB5tAa4r1nQf"}`),
+	}, blankHandler)))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	status, err := gt.DALAttestationStatus(BlockIDLevel(1208))
+	assert.Nil(t, err)
+	assert.Equal(t, &DALAttestationStatus{Level: 1208, PublishedLevel: 1200, Slots: []int{0, 2, 5}}, status)
+	assert.True(t, status.Attested(2))
+	assert.False(t, status.Attested(1))
+
+	_, err = gt.DALAttestationStatus(BlockIDLevel(1100))
+	checkErr(t, true, "block '1100' has no dal_attestation", err)
+}
+
+func Test_DALConstants(t *testing.T) {
+	var constants Constants
+	err := json.Unmarshal(mockDALConstantsResp, &constants)
+	assert.Nil(t, err)
+	assert.Equal(t, &DALConstants{
+		FeatureEnable:        true,
+		NumberOfSlots:        32,
+		AttestationLag:       8,
+		AttestationThreshold: 66,
+		RedundancyFactor:     8,
+		PageSize:             3967,
+		SlotSize:             126944,
+		NumberOfShards:       512,
+	}, constants.DAL)
+
+	assert.Nil(t, expectedConstants(t).DAL)
+}
+
+func Test_DALContents(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		slots []int
+	}{
+		{
+			"dal_attestation",
+			`{"kind":"dal_attestation","attestation":"6","level":1207,"round":0,"slot":4}`,
+			[]int{1, 2},
+		},
+		{
+			"attestation_with_dal",
+			`{"block_payload_hash":"vh2TyrWeZ2dydEy9ZjmvrjQvyCs5sdHZPypcZrXDUSM1tNuPermf","dal_attestation":"9","kind":"attestation_with_dal","level":1207,"round":0,"slot":4}`,
+			[]int{0, 3},
+		},
+		{
+			"dal_publish_commitment",
+			`{"counter":"7","fee":"1000","gas_limit":"2000","kind":"dal_publish_commitment","slot_header":{"slot_index":2,"commitment":"sh1u3tr3YKy7ZEEUurXvZ4xMGDzvdZrJhNRNMqvFKxmQLMUoTzjiWjuxGdsMypCtmXgAEB6N8y","commitment_proof":"8a0a"},"source":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","storage_limit":"0"}`,
+			nil,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var contents Contents
+			err := json.Unmarshal([]byte(tt.input), &contents)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.slots, contents.AttestedSlots())
+
+			out, err := json.Marshal(contents)
+			assert.Nil(t, err)
+
+			var want, got map[string]interface{}
+			assert.Nil(t, json.Unmarshal([]byte(tt.input), &want))
+			assert.Nil(t, json.Unmarshal(out, &got))
+			assert.Equal(t, want, got)
+		})
+	}
+
+	var contents Contents
+	err := json.Unmarshal([]byte(`{"kind":"dal_publish_commitment","slot_header":{"slot_index":2,"commitment":"sh1","commitment_proof":"8a0a"}}`), &contents)
+	assert.Nil(t, err)
+	assert.Equal(t, &DALSlotHeader{SlotIndex: 2, Commitment: "sh1", CommitmentProof: "8a0a"}, contents.SlotHeader)
+	assert.Equal(t, 0, contents.Attestation.Cmp(big.NewInt(0)))
+}
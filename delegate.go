@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
-	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/pkg/errors"
@@ -40,6 +39,9 @@ type Delegate struct {
 	DelegatedBalance  string   `json:"delegated_balance"`
 	Deactivated       bool     `json:"deactivated"`
 	GracePeriod       int      `json:"grace_period"`
+
+	// The delegate exactly as returned by the node, see Get.
+	Raw json.RawMessage `json:"-"`
 }
 
 /*
@@ -48,10 +50,13 @@ RPC: ../<block_id>/helpers/baking_rights (GET)
 Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-helpers-baking-rights
 */
 type BakingRights []struct {
-	Level         int       `json:"level"`
-	Delegate      string    `json:"delegate"`
-	Priority      int       `json:"priority"`
-	EstimatedTime time.Time `json:"estimated_time"`
+	Level    int    `json:"level"`
+	Delegate string `json:"delegate"`
+	// The priority of the right before Tenderbake.
+	Priority int `json:"priority"`
+	// The round of the right on Tenderbake.
+	Round         int       `json:"round"`
+	EstimatedTime Timestamp `json:"estimated_time"`
 }
 
 /*
@@ -61,10 +66,14 @@ Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-helpers-baking-rights
 */
 // EndorsingRights is a representation of endorsing rights on the Tezos network
 type EndorsingRights []struct {
-	Level         int       `json:"level"`
-	Delegate      string    `json:"delegate"`
-	Slots         []int     `json:"slots"`
-	EstimatedTime time.Time `json:"estimated_time"`
+	Level    int    `json:"level"`
+	Delegate string `json:"delegate"`
+	// The slots of the right before Tenderbake.
+	Slots []int `json:"slots"`
+	// The first slot and the number of slots of the right on Tenderbake.
+	FirstSlot      int       `json:"first_slot"`
+	EndorsingPower int       `json:"endorsing_power"`
+	EstimatedTime  Timestamp `json:"estimated_time"`
 }
 
 /*
@@ -82,12 +91,15 @@ type BakingRightsInput struct {
 	// The delegate public key hash of which you want to make the query.
 	Delegate *string
 
-	// The max priotity of which you want to make the query.
+	// The max priotity of which you want to make the query, the max round on Tenderbake (see WithMaxRound).
 	MaxPriority *int
 
-	// The hash of block (height) of which you want to make the query.
+	// More query parameters: WithLevel, WithCycle, WithDelegate, WithConsensusKey, WithMaxRound and WithAll.
+	Options []RPCOption
+
+	// The block (hash, level, head or head~<n>) of which you want to make the query.
 	// Required.
-	BlockHash *string `validate:"required"`
+	BlockID BlockID `validate:"required"`
 }
 
 /*
@@ -105,42 +117,49 @@ type EndorsingRightsInput struct {
 	// The delegate public key hash of which you want to make the query.
 	Delegate *string
 
-	// The hash of block (height) of which you want to make the query.
+	// More query parameters: WithLevel, WithCycle, WithDelegate and WithConsensusKey.
+	Options []RPCOption
+
+	// The block (hash, level, head or head~<n>) of which you want to make the query.
 	// Required.
-	BlockHash *string `validate:"required"`
+	BlockID BlockID `validate:"required"`
 }
 
 /*
 DelegatesInput -
 Description: The input for the delegates rpc query.
-Function: func (t *GoTezos) Delegates(blockhash string) ([]string, error) {}
+Function: func (t *GoTezos) Delegates(input *DelegatesInput) (*[]string, error) {}
 */
 type DelegatesInput struct {
-	// The block level of which you want to make the query.
-	active *bool
+	// Only list the active delegates.
+	Active bool
 
-	// The cycle of which you want to make the query.
-	inactive *bool
+	// Only list the inactive delegates. Both active and inactive delegates are listed with Active.
+	Inactive bool
+
+	// More query parameters: WithMinimalStake and WithoutMinimalStake.
+	Options []RPCOption
 
-	// The hash of block (height) of which you want to make the query.
+	// The block (hash, level, head or head~<n>) of which you want to make the query.
 	// Required.
-	BlockHash *string `validate:"required"`
+	BlockID BlockID `validate:"required"`
 }
 
 /*
 DelegatedContracts RPC
 Path: ../<block_id>/context/delegates/<pkh>/delegated_contracts (GET)
 Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-delegates-pkh-delegated-contracts
-Description: Returns the list of contracts that delegate to a given delegate.
+Description: Returns the list of contracts that delegate to a given delegate. Full and rolling nodes only
+answer for the blocks from their savepoint on, see Savepoint.
 
 Parameters:
-	blockhash:
-		The hash of block (height) of which you want to make the query.
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
 	delegate:
 		The tz(1-3) address of the delegate.
 */
-func (t *GoTezos) DelegatedContracts(blockhash, delegate string) (*[]string, error) {
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/delegates/%s/delegated_contracts", blockhash, delegate))
+func (t *GoTezos) DelegatedContracts(blockID BlockID, delegate string) (*[]string, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/delegates/%s/delegated_contracts", blockID.ID(), delegate))
 	if err != nil {
 		return &[]string{}, errors.Wrapf(err, "could not get delegations for '%s'", delegate)
 	}
@@ -154,6 +173,59 @@ func (t *GoTezos) DelegatedContracts(blockhash, delegate string) (*[]string, err
 	return &list, nil
 }
 
+/*
+DelegatedContractsIterator RPC
+Path: ../<block_id>/context/delegates/<pkh>/delegated_contracts (GET)
+Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-delegates-pkh-delegated-contracts
+Description: Like DelegatedContracts, but returns an iterator that decodes the contracts as they are read.
+The iterator must be closed.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+	delegate:
+		The tz(1-3) address of the delegate.
+*/
+func (t *GoTezos) DelegatedContractsIterator(blockID BlockID, delegate string) (*StringIterator, error) {
+	body, err := t.stream(fmt.Sprintf("/chains/main/blocks/%s/context/delegates/%s/delegated_contracts", blockID.ID(), delegate))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get delegations for '%s'", delegate)
+	}
+
+	return newStringIterator(body), nil
+}
+
+/*
+DelegatedContractsPage RPC
+Path: ../<block_id>/context/delegates/<pkh>/delegated_contracts (GET)
+Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-delegates-pkh-delegated-contracts
+Description: Returns at most limit of the contracts that delegate to a given delegate, starting at offset.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+	delegate:
+		The tz(1-3) address of the delegate.
+	offset:
+		The number of contracts to skip.
+	limit:
+		The maximum number of contracts to return.
+*/
+func (t *GoTezos) DelegatedContractsPage(blockID BlockID, delegate string, offset, limit int) (*[]string, error) {
+	it, err := t.DelegatedContractsIterator(blockID, delegate)
+	if err != nil {
+		return &[]string{}, err
+	}
+	defer it.Close()
+
+	page, err := it.Page(offset, limit)
+	if err != nil {
+		return &[]string{}, errors.Wrapf(err, "could not unmarshal delegations for '%s'", delegate)
+	}
+
+	return &page, nil
+}
+
 /*
 DelegatedContractsAtCycle RPC
 Path: ../<block_id>/context/delegates/<pkh>/delegated_contracts (GET)
@@ -172,7 +244,7 @@ func (t *GoTezos) DelegatedContractsAtCycle(cycle int, delegate string) (*[]stri
 		return &[]string{}, errors.Wrapf(err, "could not get delegations for '%s' at cycle '%d'", delegate, cycle)
 	}
 
-	delegations, err := t.DelegatedContracts(snapshot.BlockHash, delegate)
+	delegations, err := t.DelegatedContracts(BlockIDHash(snapshot.BlockHash), delegate)
 	if err != nil {
 		return &[]string{}, errors.Wrapf(err, "could not get delegations at cycle '%d'", cycle)
 	}
@@ -224,8 +296,8 @@ Parameters:
 	delegate:
 		The tz(1-3) address of the delegate.
 */
-func (t *GoTezos) Delegate(blockhash, delegate string) (*Delegate, error) {
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/delegates/%s", blockhash, delegate))
+func (t *GoTezos) Delegate(blockID BlockID, delegate string) (*Delegate, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/delegates/%s", blockID.ID(), delegate))
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not get delegate '%s'", delegate)
 	}
@@ -246,13 +318,13 @@ Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-delegates-pkh-st
 Description: Everything about a delegate.
 
 Parameters:
-	blockhash:
-		The hash of block (height) of which you want to make the query.
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
 	delegate:
 		The tz(1-3) address of the delegate.
 */
-func (t *GoTezos) StakingBalance(blockhash, delegate string) (*string, error) {
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/delegates/%s/staking_balance", blockhash, delegate))
+func (t *GoTezos) StakingBalance(blockID BlockID, delegate string) (*string, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/delegates/%s/staking_balance", blockID.ID(), delegate))
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not get staking balance for '%s'", delegate)
 	}
@@ -284,7 +356,7 @@ func (t *GoTezos) StakingBalanceAtCycle(cycle int, delegate string) (*string, er
 		return nil, errors.Wrapf(err, "could not get staking balance for '%s' at cycle '%d'", delegate, cycle)
 	}
 
-	balance, err := t.StakingBalance(snapshot.BlockHash, delegate)
+	balance, err := t.StakingBalance(BlockIDHash(snapshot.BlockHash), delegate)
 	if err != nil {
 		return balance, errors.Wrapf(err, "could not get staking balance for '%s' at cycle '%d'", delegate, cycle)
 	}
@@ -309,7 +381,7 @@ were baked at the first priority.
 
 Parameters:
 	BakingRightsInput:
-		Modifies the BakingRights RPC query by passing optional URL parameters. BlockHash is required.
+		Modifies the BakingRights RPC query by passing optional URL parameters. BlockID is required.
 
 */
 func (t *GoTezos) BakingRights(input *BakingRightsInput) (*BakingRights, error) {
@@ -318,7 +390,12 @@ func (t *GoTezos) BakingRights(input *BakingRightsInput) (*BakingRights, error)
 		return &BakingRights{}, errors.Wrap(err, "invalid input")
 	}
 
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/helpers/baking_rights", *input.BlockHash), input.contructRPCOptions()...)
+	err = checkRPCOptions(input.Options, "level", "cycle", "delegate", "consensus_key", "max_round", "all")
+	if err != nil {
+		return &BakingRights{}, errors.Wrap(err, "invalid input")
+	}
+
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/helpers/baking_rights", input.BlockID.ID()), input.contructRPCOptions(t.Protocol().Tenderbake)...)
 	if err != nil {
 		return &BakingRights{}, errors.Wrapf(err, "could not get baking rights")
 	}
@@ -332,37 +409,42 @@ func (t *GoTezos) BakingRights(input *BakingRightsInput) (*BakingRights, error)
 	return &bakingRights, nil
 }
 
-func (b *BakingRightsInput) contructRPCOptions() []rpcOptions {
-	var opts []rpcOptions
+func (b *BakingRightsInput) contructRPCOptions(tenderbake bool) []RPCOption {
+	var opts []RPCOption
 	if b.Cycle != nil {
-		opts = append(opts, rpcOptions{
-			"cycle",
-			strconv.Itoa(*b.Cycle),
+		opts = append(opts, RPCOption{
+			Key:   "cycle",
+			Value: strconv.Itoa(*b.Cycle),
 		})
 	}
 
 	if b.Delegate != nil {
-		opts = append(opts, rpcOptions{
-			"delegate",
-			*b.Delegate,
+		opts = append(opts, RPCOption{
+			Key:   "delegate",
+			Value: *b.Delegate,
 		})
 	}
 
 	if b.Level != nil {
-		opts = append(opts, rpcOptions{
-			"level",
-			strconv.Itoa(*b.Level),
+		opts = append(opts, RPCOption{
+			Key:   "level",
+			Value: strconv.Itoa(*b.Level),
 		})
 	}
 
 	if b.MaxPriority != nil {
-		opts = append(opts, rpcOptions{
-			"max_priority",
-			strconv.Itoa(*b.MaxPriority),
+		key := "max_priority"
+		if tenderbake {
+			key = "max_round"
+		}
+
+		opts = append(opts, RPCOption{
+			Key:   key,
+			Value: strconv.Itoa(*b.MaxPriority),
 		})
 	}
 
-	return opts
+	return append(opts, b.Options...)
 }
 
 /*
@@ -377,11 +459,12 @@ Parameter `delegate` can be used to restrict the results to the given delegates.
 Returns the list of endorsement slots. Also returns the minimal timestamps that
 correspond to these slots. The timestamps are omitted for levels in the past, and
 are only estimates for levels later that the next block, based on the hypothesis
-that all predecessor blocks were baked at the first priority.
+that all predecessor blocks were baked at the first priority. On Tenderbake, each delegate of a level is
+returned as a right of its own, with its first slot and endorsing power instead of slots.
 
 Parameters:
 	BakingRightsInput:
-		Modifies the BakingRights RPC query by passing optional URL parameters. BlockHash is required.
+		Modifies the BakingRights RPC query by passing optional URL parameters. BlockID is required.
 
 */
 func (t *GoTezos) EndorsingRights(input *EndorsingRightsInput) (*EndorsingRights, error) {
@@ -390,7 +473,12 @@ func (t *GoTezos) EndorsingRights(input *EndorsingRightsInput) (*EndorsingRights
 		return &EndorsingRights{}, errors.Wrap(err, "invalid input")
 	}
 
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/helpers/endorsing_rights", *input.BlockHash), input.contructRPCOptions()...)
+	err = checkRPCOptions(input.Options, "level", "cycle", "delegate", "consensus_key")
+	if err != nil {
+		return &EndorsingRights{}, errors.Wrap(err, "invalid input")
+	}
+
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/helpers/endorsing_rights", input.BlockID.ID()), input.contructRPCOptions()...)
 	if err != nil {
 		return &EndorsingRights{}, errors.Wrap(err, "could not get endorsing rights")
 	}
@@ -401,54 +489,85 @@ func (t *GoTezos) EndorsingRights(input *EndorsingRightsInput) (*EndorsingRights
 		return &endorsingRights, errors.Wrapf(err, "could not unmarshal endorsing rights")
 	}
 
-	return &endorsingRights, nil
+	// On Tenderbake, the rights of a level are listed by delegate under the level, with their first slot and
+	// endorsing (or attestation) power.
+	var levels []struct {
+		Level     int `json:"level"`
+		Delegates []struct {
+			Delegate         string `json:"delegate"`
+			FirstSlot        int    `json:"first_slot"`
+			EndorsingPower   int    `json:"endorsing_power"`
+			AttestationPower int    `json:"attestation_power"`
+		} `json:"delegates"`
+		EstimatedTime Timestamp `json:"estimated_time"`
+	}
+	err = json.Unmarshal(resp, &levels)
+	if err != nil {
+		return &endorsingRights, errors.Wrapf(err, "could not unmarshal endorsing rights")
+	}
+
+	rights := EndorsingRights{}
+	for i, level := range levels {
+		if level.Delegates == nil {
+			rights = append(rights, endorsingRights[i])
+			continue
+		}
+
+		for _, delegate := range level.Delegates {
+			right := endorsingRights[i]
+			right.Delegate, right.FirstSlot = delegate.Delegate, delegate.FirstSlot
+			right.EndorsingPower = delegate.EndorsingPower + delegate.AttestationPower
+			rights = append(rights, right)
+		}
+	}
+
+	return &rights, nil
 }
 
-func (b *EndorsingRightsInput) contructRPCOptions() []rpcOptions {
-	var opts []rpcOptions
+func (b *EndorsingRightsInput) contructRPCOptions() []RPCOption {
+	var opts []RPCOption
 	if b.Cycle != nil {
-		opts = append(opts, rpcOptions{
-			"cycle",
-			strconv.Itoa(*b.Cycle),
+		opts = append(opts, RPCOption{
+			Key:   "cycle",
+			Value: strconv.Itoa(*b.Cycle),
 		})
 	}
 
 	if b.Delegate != nil {
-		opts = append(opts, rpcOptions{
-			"delegate",
-			*b.Delegate,
+		opts = append(opts, RPCOption{
+			Key:   "delegate",
+			Value: *b.Delegate,
 		})
 	}
 
 	if b.Level != nil {
-		opts = append(opts, rpcOptions{
-			"level",
-			strconv.Itoa(*b.Level),
+		opts = append(opts, RPCOption{
+			Key:   "level",
+			Value: strconv.Itoa(*b.Level),
 		})
 	}
 
-	return opts
+	return append(opts, b.Options...)
 }
 
 /*
 Delegates RPC
 Path: ../<block_id>/context/delegates (GET)
 Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-delegates
-Description: Lists all registered delegates.
+Description: Lists all registered delegates, or only the active or inactive ones. See DelegatesIterator to
+read them lazily, e.g. to enumerate the delegates of mainnet.
 
 Parameters:
-	cycle:
-		The cycle of which you want to make the query.
-	delegate:
-		The tz(1-3) address of the delegate.
+	input:
+		Modifies the Delegates RPC query. BlockID is required.
 */
 func (t *GoTezos) Delegates(input *DelegatesInput) (*[]string, error) {
-	err := validator.New().Struct(input)
+	err := input.validate()
 	if err != nil {
 		return &[]string{}, errors.Wrap(err, "invalid input")
 	}
 
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/delegates", *input.BlockHash))
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/delegates", input.BlockID.ID()), input.contructRPCOptions()...)
 	if err != nil {
 		return &[]string{}, errors.Wrap(err, "could not get delegates")
 	}
@@ -461,3 +580,85 @@ func (t *GoTezos) Delegates(input *DelegatesInput) (*[]string, error) {
 
 	return &list, nil
 }
+
+func (d *DelegatesInput) validate() error {
+	err := validator.New().Struct(d)
+	if err != nil {
+		return err
+	}
+
+	return checkRPCOptions(d.Options, "with_minimal_stake", "without_minimal_stake")
+}
+
+func (d *DelegatesInput) contructRPCOptions() []RPCOption {
+	var opts []RPCOption
+	if d.Active {
+		opts = append(opts, RPCOption{
+			Key:   "active",
+			Value: "true",
+		})
+	}
+
+	if d.Inactive {
+		opts = append(opts, RPCOption{
+			Key:   "inactive",
+			Value: "true",
+		})
+	}
+
+	return append(opts, d.Options...)
+}
+
+/*
+DelegatesIterator RPC
+Path: ../<block_id>/context/delegates (GET)
+Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-delegates
+Description: Like Delegates, but returns an iterator that decodes the delegates as they are read.
+The iterator must be closed.
+
+Parameters:
+	input:
+		Modifies the Delegates RPC query. BlockID is required.
+*/
+func (t *GoTezos) DelegatesIterator(input *DelegatesInput) (*StringIterator, error) {
+	err := input.validate()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	body, err := t.stream(fmt.Sprintf("/chains/main/blocks/%s/context/delegates", input.BlockID.ID()), input.contructRPCOptions()...)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get delegates")
+	}
+
+	return newStringIterator(body), nil
+}
+
+/*
+DelegatesPage RPC
+Path: ../<block_id>/context/delegates (GET)
+Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-delegates
+Description: Returns at most limit of the registered delegates, starting at offset.
+
+Parameters:
+	input:
+		Modifies the Delegates RPC query. BlockID is required.
+	offset:
+		The number of delegates to skip.
+	limit:
+		The maximum number of delegates to return.
+*/
+func (t *GoTezos) DelegatesPage(input *DelegatesInput, offset, limit int) (*[]string, error) {
+	it, err := t.DelegatesIterator(input)
+	if err != nil {
+		return &[]string{}, err
+	}
+	defer it.Close()
+
+	page, err := it.Page(offset, limit)
+	if err != nil {
+		return &[]string{}, errors.Wrap(err, "could not unmarshal delegates")
+	}
+
+	return &page, nil
+}
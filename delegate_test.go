@@ -65,7 +65,99 @@ func Test_Delegations(t *testing.T) {
 			gt, err := New(server.URL)
 			assert.Nil(t, err)
 
-			delegations, err := gt.DelegatedContracts(mockBlockHash, "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc")
+			delegations, err := gt.DelegatedContracts(BlockIDHash(mockBlockHash), "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc")
+			checkErr(t, tt.wantErr, tt.containsErr, err)
+			assert.Equal(t, tt.want.wantDelegations, delegations)
+		})
+	}
+}
+
+func Test_DelegatedContractsPage(t *testing.T) {
+	var goldenDelegations []string
+	json.Unmarshal(mockDelegationsResp, &goldenDelegations)
+
+	type input struct {
+		handler http.Handler
+		offset  int
+		limit   int
+	}
+
+	type want struct {
+		wantErr         bool
+		containsErr     string
+		wantDelegations *[]string
+	}
+
+	cases := []struct {
+		name string
+		input
+		want
+	}{
+		{
+			"returns http error",
+			input{
+				gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusInternalServerError)
+				})),
+				0,
+				2,
+			},
+			want{
+				true,
+				"could not get delegations for",
+				&[]string{},
+			},
+		},
+		{
+			"fails to unmarshal",
+			input{
+				gtGoldenHTTPMock(delegationsHandlerMock([]byte(`junk`), blankHandler)),
+				0,
+				2,
+			},
+			want{
+				true,
+				"could not unmarshal delegations for",
+				&[]string{},
+			},
+		},
+		{
+			"is successful",
+			input{
+				gtGoldenHTTPMock(delegationsHandlerMock(mockDelegationsResp, blankHandler)),
+				1,
+				2,
+			},
+			want{
+				false,
+				"",
+				&[]string{goldenDelegations[1], goldenDelegations[2]},
+			},
+		},
+		{
+			"is past the end",
+			input{
+				gtGoldenHTTPMock(delegationsHandlerMock(mockDelegationsResp, blankHandler)),
+				len(goldenDelegations),
+				2,
+			},
+			want{
+				false,
+				"",
+				&[]string{},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.input.handler)
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			delegations, err := gt.DelegatedContractsPage(BlockIDHash(mockBlockHash), "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc", tt.input.offset, tt.input.limit)
 			checkErr(t, tt.wantErr, tt.containsErr, err)
 			assert.Equal(t, tt.want.wantDelegations, delegations)
 		})
@@ -265,7 +357,7 @@ func Test_Delegate(t *testing.T) {
 			gt, err := New(server.URL)
 			assert.Nil(t, err)
 
-			delegate, err := gt.Delegate(mockBlockHash, "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc")
+			delegate, err := gt.Delegate(BlockIDHash(mockBlockHash), "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc")
 			if tt.wantErr {
 				assert.NotNil(t, err)
 				assert.Contains(t, err.Error(), tt.want.containsErr)
@@ -332,7 +424,7 @@ func Test_StakingBalance(t *testing.T) {
 			gt, err := New(server.URL)
 			assert.Nil(t, err)
 
-			stakingBalance, err := gt.StakingBalance(mockBlockHash, "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc")
+			stakingBalance, err := gt.StakingBalance(BlockIDHash(mockBlockHash), "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc")
 			if tt.wantErr {
 				assert.NotNil(t, err)
 				assert.Contains(t, err.Error(), tt.want.containsErr)
@@ -466,7 +558,7 @@ func Test_BakingRights(t *testing.T) {
 			assert.Nil(t, err)
 
 			bakingRights, err := gt.BakingRights(&BakingRightsInput{
-				BlockHash: &mockBlockHash,
+				BlockID: BlockIDHash(mockBlockHash),
 			})
 			if tt.wantErr {
 				assert.NotNil(t, err)
@@ -479,3 +571,74 @@ func Test_BakingRights(t *testing.T) {
 		})
 	}
 }
+
+func Test_TenderbakeRights(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		switch {
+		case regBakingRights.MatchString(r.URL.Path):
+			w.Write([]byte(`[{"level":2000,"delegate":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","round":2,"estimated_time":"2022-04-01T10:00:00Z"}]`))
+		case regEndorsingRights.MatchString(r.URL.Path):
+			w.Write([]byte(`[{"level":2000,"delegates":[{"delegate":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","first_slot":4,"endorsing_power":12},{"delegate":"tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH","first_slot":0,"attestation_power":3}],"estimated_time":"2022-04-01T10:00:00Z"}]`))
+		}
+	})))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+	gt.SetProtocol("Psithaca2MLRFYargivpo7YvUr7wUDqyxrdhC5CQq78mRvimz6A")
+
+	maxRound := 3
+	baking, err := gt.BakingRights(&BakingRightsInput{BlockID: BlockIDHead{}, MaxPriority: &maxRound})
+	assert.Nil(t, err)
+	assert.Len(t, *baking, 1)
+	assert.Equal(t, 2, (*baking)[0].Round)
+	assert.Equal(t, "max_round=3", queries[0])
+
+	endorsing, err := gt.EndorsingRights(&EndorsingRightsInput{BlockID: BlockIDHead{}})
+	assert.Nil(t, err)
+	assert.Len(t, *endorsing, 2)
+	assert.Equal(t, 2000, (*endorsing)[0].Level)
+	assert.Equal(t, "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc", (*endorsing)[0].Delegate)
+	assert.Equal(t, 4, (*endorsing)[0].FirstSlot)
+	assert.Equal(t, 12, (*endorsing)[0].EndorsingPower)
+	assert.Equal(t, "tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH", (*endorsing)[1].Delegate)
+	assert.Equal(t, 3, (*endorsing)[1].EndorsingPower)
+	assert.Equal(t, (*endorsing)[0].EstimatedTime, (*endorsing)[1].EstimatedTime)
+
+	gt.SetProtocol("PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS")
+	_, err = gt.BakingRights(&BakingRightsInput{BlockID: BlockIDHead{}, MaxPriority: &maxRound})
+	assert.Nil(t, err)
+	assert.Equal(t, "max_priority=3", queries[2])
+}
+
+func Test_Delegates(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Write([]byte(`["tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH","tz1YGLnq1Ls4W3rPanAvCvmcuQ1H5rffnc2V"]`))
+	})))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	delegates, err := gt.Delegates(&DelegatesInput{BlockID: BlockIDHead{}})
+	assert.Nil(t, err)
+	assert.Len(t, *delegates, 3)
+
+	_, err = gt.Delegates(&DelegatesInput{BlockID: BlockIDHead{}, Active: true, Options: []RPCOption{WithMinimalStake()}})
+	assert.Nil(t, err)
+
+	page, err := gt.DelegatesPage(&DelegatesInput{BlockID: BlockIDHead{}, Inactive: true}, 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH"}, *page)
+	assert.Equal(t, []string{"", "active=true&with_minimal_stake=true", "inactive=true"}, queries)
+
+	_, err = gt.Delegates(&DelegatesInput{BlockID: BlockIDHead{}, Options: []RPCOption{WithAll()}})
+	checkErr(t, true, "invalid input: option 'all' is not supported", err)
+	_, err = gt.DelegatesIterator(&DelegatesInput{})
+	checkErr(t, true, "invalid input", err)
+	assert.Len(t, queries, 3)
+}
@@ -0,0 +1,138 @@
+package gotezos
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// APPLIEDSTATUS is the status of an operation result that was applied.
+	APPLIEDSTATUS = "applied"
+	// FAILEDSTATUS is the status of an operation result that failed.
+	FAILEDSTATUS = "failed"
+	// BACKTRACKEDSTATUS is the status of an operation result that was applied, then reverted because a
+	// later operation of the same batch or contract call failed.
+	BACKTRACKEDSTATUS = "backtracked"
+	// SKIPPEDSTATUS is the status of an operation result that was not run because an earlier one failed.
+	SKIPPEDSTATUS = "skipped"
+)
+
+/*
+DryRunResult -
+Description: The typed results and estimated costs of an operation that was run with DryRun.
+Function: func (t *GoTezos) DryRun(contents ...Contents) (*DryRunResult, error) {}
+*/
+type DryRunResult struct {
+	// The forged (unsigned) operation that was run.
+	Operation string
+
+	// The operation contents with the metadata computed by the node.
+	Contents []Contents
+
+	// The status of the operation. "applied" if every content applied, otherwise the first other status
+	// (e.g. "failed", "backtracked" or "skipped").
+	Status string
+
+	// The errors returned by the node for any content or internal operation.
+	Errors []Error
+
+	// The sum of the fees of the contents.
+	Fee BigInt
+
+	// The gas consumed, internal operations included.
+	ConsumedGas BigInt
+
+	// The storage paid for, in bytes, internal operations included.
+	PaidStorageSizeDiff BigInt
+
+	// The tez (in mutez) burned for storage and allocations.
+	Burn BigInt
+}
+
+/*
+DryRun Function
+Description: Forges the contents on the current head, applies a fake signature and runs the
+operation with the RunOperation RPC. Nothing is injected. The node's results are aggregated into
+the estimated gas, storage and burn the operation would cost.
+
+Parameters:
+	contents:
+		The operation contents to run.
+*/
+func (t *GoTezos) DryRun(contents ...Contents) (*DryRunResult, error) {
+	// The burn is computed from the constants.
+	constants := t.cachedConstants()
+	if constants == nil {
+		return nil, errors.New("failed to dry run operation: no network constants, see SetConstants")
+	}
+
+	head, err := t.Head()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to dry run operation")
+	}
+
+	chainID, err := t.ChainID()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to dry run operation")
+	}
+
+	forge, err := t.ForgeOperation(head.Hash, contents...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to dry run operation")
+	}
+
+	operation, err := t.RunOperation(BlockIDHash(head.Hash), Operations{
+		Branch:    head.Hash,
+		ChainID:   *chainID,
+		Contents:  contents,
+		Signature: b58cencode(make([]byte, 64), prefix_edsig),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to dry run operation")
+	}
+
+	result := &DryRunResult{
+		Operation: *forge,
+		Contents:  operation.Contents,
+		Status:    APPLIEDSTATUS,
+	}
+
+	var costPerByte, originationSize big.Int
+	if _, ok := costPerByte.SetString(constants.CostPerByte, 10); !ok {
+		return nil, errors.Errorf("failed to dry run operation: invalid cost_per_byte '%s'", constants.CostPerByte)
+	}
+	originationSize.SetInt64(int64(constants.OriginationSize))
+
+	var burnedBytes big.Int
+	for _, content := range operation.Contents {
+		result.Fee.Add(&result.Fee.Int, &content.Fee.Int)
+		if content.Metadata == nil || content.Metadata.OperationResult == nil {
+			continue
+		}
+
+		results := []OperationResult{*content.Metadata.OperationResult}
+		for _, internal := range content.Metadata.InternalOperationResults {
+			results = append(results, internal.Result)
+		}
+
+		for _, r := range results {
+			if r.Status != APPLIEDSTATUS && result.Status == APPLIEDSTATUS {
+				result.Status = r.Status
+			}
+			result.Errors = append(result.Errors, r.Errors...)
+			result.ConsumedGas.Add(&result.ConsumedGas.Int, &r.ConsumedGas.Int)
+			result.PaidStorageSizeDiff.Add(&result.PaidStorageSizeDiff.Int, &r.PaidStorageSizeDiff.Int)
+			burnedBytes.Add(&burnedBytes, &r.PaidStorageSizeDiff.Int)
+
+			allocations := int64(len(r.OriginatedContracts))
+			if r.AllocatedDestinationContract {
+				allocations++
+			}
+			burnedBytes.Add(&burnedBytes, new(big.Int).Mul(&originationSize, big.NewInt(allocations)))
+		}
+	}
+	result.Burn.Mul(&burnedBytes, &costPerByte)
+
+	return result, nil
+}
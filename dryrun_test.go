@@ -0,0 +1,133 @@
+package gotezos
+
+import (
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	mockRunOperationApplied = []byte(`{"contents":[{"kind":"transaction","source":"tz1YGLnq1Ls4W3rPanAvCvmcuQ1H5rffnc2V","fee":"1283","counter":"11","gas_limit":"10307","storage_limit":"257","amount":"1000000","destination":"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK","metadata":{"balance_updates":[],"operation_result":{"status":"applied","balance_updates":[],"consumed_gas":"10207","allocated_destination_contract":true}}}],"signature":"edsigtXomBKi5CTRf5cjATJWSyaRvhfYNHqSUGrn4SdbYRcGwQrUGjzEfQDTuqHhuA8b2d8NarZjz8TRf65WkpQmo423BtomS8Q"}`)
+	mockRunOperationFailed  = []byte(`{"contents":[{"kind":"reveal","source":"tz1YGLnq1Ls4W3rPanAvCvmcuQ1H5rffnc2V","fee":"1269","counter":"11","gas_limit":"10000","storage_limit":"0","public_key":"edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G","metadata":{"balance_updates":[],"operation_result":{"status":"backtracked","consumed_gas":"10000"}}},{"kind":"transaction","source":"tz1YGLnq1Ls4W3rPanAvCvmcuQ1H5rffnc2V","fee":"1283","counter":"12","gas_limit":"10307","storage_limit":"257","amount":"1000000","destination":"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn","metadata":{"balance_updates":[],"operation_result":{"status":"failed","errors":[{"kind":"temporary","id":"proto.006-PsCARTHA.michelson_v1.script_rejected"}]}}}],"signature":"edsigtXomBKi5CTRf5cjATJWSyaRvhfYNHqSUGrn4SdbYRcGwQrUGjzEfQDTuqHhuA8b2d8NarZjz8TRf65WkpQmo423BtomS8Q"}`)
+)
+
+func Test_DryRun(t *testing.T) {
+	transaction := Contents{
+		Kind:         TRANSACTIONOP,
+		Source:       mockAddressTz1,
+		Fee:          BigInt{*big.NewInt(1283)},
+		Counter:      BigInt{*big.NewInt(11)},
+		GasLimit:     BigInt{*big.NewInt(10307)},
+		StorageLimit: BigInt{*big.NewInt(257)},
+		Amount:       BigInt{*big.NewInt(1000000)},
+		Destination:  "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK",
+	}
+
+	type want struct {
+		err         bool
+		containsErr string
+		status      string
+		errors      int
+		fee         int64
+		gas         int64
+		burn        int64
+	}
+
+	cases := []struct {
+		name        string
+		inputHanler http.Handler
+		contents    []Contents
+		want
+	}{
+		{
+			"handles failure to get chain id",
+			gtGoldenHTTPMock(chainIDHandlerMock(mockRPCErrorResp, newBlockMock().handler(mockBlockResp, blankHandler))),
+			[]Contents{transaction},
+			want{
+				true,
+				"failed to get chain id",
+				"",
+				0, 0, 0, 0,
+			},
+		},
+		{
+			"handles failure to forge",
+			gtGoldenHTTPMock(chainIDHandlerMock([]byte(`"NetXdQprcVkpaWU"`), newBlockMock().handler(mockBlockResp, blankHandler))),
+			[]Contents{{Kind: "not_a_kind"}},
+			want{
+				true,
+				"unsupported kind",
+				"",
+				0, 0, 0, 0,
+			},
+		},
+		{
+			"handles failure to run operation",
+			gtGoldenHTTPMock(runOperationHandlerMock(mockRPCErrorResp, chainIDHandlerMock([]byte(`"NetXdQprcVkpaWU"`), newBlockMock().handler(mockBlockResp, blankHandler)))),
+			[]Contents{transaction},
+			want{
+				true,
+				"failed to run operation",
+				"",
+				0, 0, 0, 0,
+			},
+		},
+		{
+			"is successful",
+			gtGoldenHTTPMock(runOperationHandlerMock(mockRunOperationApplied, chainIDHandlerMock([]byte(`"NetXdQprcVkpaWU"`), newBlockMock().handler(mockBlockResp, blankHandler)))),
+			[]Contents{transaction},
+			want{
+				false,
+				"",
+				"applied",
+				0,
+				1283,
+				10207,
+				257000,
+			},
+		},
+		{
+			"returns failed results",
+			gtGoldenHTTPMock(runOperationHandlerMock(mockRunOperationFailed, chainIDHandlerMock([]byte(`"NetXdQprcVkpaWU"`), newBlockMock().handler(mockBlockResp, blankHandler)))),
+			[]Contents{transaction},
+			want{
+				false,
+				"",
+				"backtracked",
+				1,
+				2552,
+				10000,
+				0,
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.inputHanler)
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			result, err := gt.DryRun(tt.contents...)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			if tt.want.err {
+				return
+			}
+
+			assert.NotEmpty(t, result.Operation)
+			assert.Equal(t, tt.want.status, result.Status)
+			assert.Len(t, result.Errors, tt.want.errors)
+			assert.Equal(t, tt.want.fee, result.Fee.Int64())
+			assert.Equal(t, tt.want.gas, result.ConsumedGas.Int64())
+			assert.Equal(t, tt.want.burn, result.Burn.Int64())
+		})
+	}
+
+	_, err := (&GoTezos{}).DryRun(transaction)
+	checkErr(t, true, "failed to dry run operation: no network constants", err)
+}
@@ -0,0 +1,71 @@
+package gotezos
+
+/*
+Event -
+Description: A contract event, emitted by the EMIT instruction as an internal operation of a
+contract call.
+*/
+type Event struct {
+	// The hash of the operation the event was emitted by.
+	OperationHash string
+	// The contract that emitted the event.
+	Source string
+	// The nonce of the internal operation.
+	Nonce int
+	// The tag of the event, empty if the event is untagged.
+	Tag string
+	// The type of the payload.
+	Type Micheline
+	// The payload of the event, nil if the event carries no payload.
+	Payload *Micheline
+}
+
+/*
+Events Function
+Description: Returns the events emitted by the applied operations of the block, in order.
+*/
+func (b *Block) Events() []Event {
+	events := []Event{}
+	for _, pass := range b.Operations {
+		for _, operation := range pass {
+			events = append(events, operation.Events()...)
+		}
+	}
+
+	return events
+}
+
+/*
+Events Function
+Description: Returns the events emitted by the operation, in order. Events of internal operations
+that were not applied (failed, backtracked or skipped) are not returned.
+*/
+func (o *Operations) Events() []Event {
+	events := []Event{}
+	for _, contents := range o.Contents {
+		if contents.Metadata == nil {
+			continue
+		}
+
+		for _, internal := range contents.Metadata.InternalOperationResults {
+			if internal.Kind != EVENTOP || internal.Result.Status != APPLIEDSTATUS {
+				continue
+			}
+
+			event := Event{
+				OperationHash: o.Hash,
+				Source:        internal.Source,
+				Nonce:         internal.Nonce,
+				Tag:           internal.Tag,
+				Payload:       internal.Payload,
+			}
+			if internal.Type != nil {
+				event.Type = *internal.Type
+			}
+
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
@@ -0,0 +1,40 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var mockEventsOperation = []byte(`{"protocol":"PtLimaPtLMwfNinJi9rCfDPWea8dFgTZ1MeJ9f1m2SRic6ayiwW","chain_id":"NetXdQprcVkpaWU","hash":"ooJvd4uGCu8iYrqeV3Mt3qzbQ2ykP4FXnzbWwHrNfJiTRvm5tDa","branch":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","contents":[{"kind":"transaction","source":"tz1YGLnq1Ls4W3rPanAvCvmcuQ1H5rffnc2V","fee":"1283","counter":"11","gas_limit":"10307","storage_limit":"257","amount":"0","destination":"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn","metadata":{"balance_updates":[],"operation_result":{"status":"applied","consumed_milligas":"2036000"},"internal_operation_results":[{"kind":"event","source":"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn","nonce":0,"type":{"prim":"nat"},"tag":"minted","payload":{"int":"42"},"result":{"status":"applied","consumed_milligas":"1000"}},{"kind":"transaction","source":"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn","nonce":1,"amount":"1","destination":"tz1YGLnq1Ls4W3rPanAvCvmcuQ1H5rffnc2V","result":{"status":"applied"}},{"kind":"event","source":"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn","nonce":2,"type":{"prim":"unit"},"result":{"status":"applied","consumed_milligas":"1000"}},{"kind":"event","source":"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn","nonce":3,"type":{"prim":"nat"},"tag":"burned","payload":{"int":"1"},"result":{"status":"backtracked"}}]}}],"signature":"edsigtXomBKi5CTRf5cjATJWSyaRvhfYNHqSUGrn4SdbYRcGwQrUGjzEfQDTuqHhuA8b2d8NarZjz8TRf65WkpQmo423BtomS8Q"}`)
+
+func Test_Events(t *testing.T) {
+	var operation Operations
+	err := json.Unmarshal(mockEventsOperation, &operation)
+	assert.Nil(t, err)
+
+	payload := NewMichelineInt(42)
+	expected := []Event{
+		{
+			OperationHash: "ooJvd4uGCu8iYrqeV3Mt3qzbQ2ykP4FXnzbWwHrNfJiTRvm5tDa",
+			Source:        "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn",
+			Nonce:         0,
+			Tag:           "minted",
+			Type:          NewMichelinePrim("nat"),
+			Payload:       &payload,
+		},
+		{
+			OperationHash: "ooJvd4uGCu8iYrqeV3Mt3qzbQ2ykP4FXnzbWwHrNfJiTRvm5tDa",
+			Source:        "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn",
+			Nonce:         2,
+			Type:          NewMichelinePrim("unit"),
+		},
+	}
+	assert.Equal(t, expected, operation.Events())
+
+	block := Block{
+		Operations: [][]Operations{{}, {}, {}, {operation, {Hash: "onvsLP3JFZia2mzZKWaFuFkWg2L5p3BDUhzh5Kr6CiDDN3rtQ1D"}}},
+	}
+	assert.Equal(t, expected, block.Events())
+}
@@ -0,0 +1,82 @@
+package gotezos
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+/*
+FeePolicy -
+Description: The fees paid and the tez spent by the operations injected with a WalletClient, and with the
+convenience functions of GoTezos (e.g. Originate) once set with SetFeePolicy. The zero value (and a nil
+policy) pays the minimal fee bakers accept with their default configuration and spends without limit.
+*/
+type FeePolicy struct {
+	// The fee in mutez of the contents without a fee, instead of the minimal fee.
+	Fixed int64
+
+	// The max sum in mutez of the fees of an operation. Operations with a higher fee are not injected.
+	MaxFee int64
+
+	// The max tez in mutez an operation can burn for storage, given its storage limits and the cost per byte
+	// of the network. Operations that can burn more are not injected.
+	MaxBurn int64
+}
+
+/*
+SetFeePolicy Func
+Description: Sets the fee policy of the convenience functions injecting operations (e.g. Originate) and of
+the WalletClients created from GoTezos.
+
+Parameters:
+	policy:
+		The fee policy, nil for the minimal fees without limits.
+*/
+func (t *GoTezos) SetFeePolicy(policy *FeePolicy) {
+	t.feePolicy = policy
+}
+
+// fixedFee returns the fee of the contents without a fee, or 0 for the minimal fee.
+func (p *FeePolicy) fixedFee() int64 {
+	if p == nil {
+		return 0
+	}
+
+	return p.Fixed
+}
+
+// check returns an error if the contents exceed the limits of the policy.
+func (p *FeePolicy) check(contents []Contents, constants func() (*Constants, error)) error {
+	if p == nil || (p.MaxFee <= 0 && p.MaxBurn <= 0) {
+		return nil
+	}
+
+	var fee, storage big.Int
+	for _, content := range contents {
+		fee.Add(&fee, &content.Fee.Int)
+		storage.Add(&storage, &content.StorageLimit.Int)
+	}
+
+	if p.MaxFee > 0 && fee.Cmp(big.NewInt(p.MaxFee)) > 0 {
+		return errors.Errorf("fee of %s mutez exceeds the max fee of %d mutez", fee.String(), p.MaxFee)
+	}
+
+	if p.MaxBurn > 0 && storage.Sign() > 0 {
+		c, err := constants()
+		if err != nil {
+			return err
+		}
+
+		burn, err := c.StorageBurn(storage.Int64())
+		if err != nil {
+			return err
+		}
+
+		if burn.Cmp(big.NewInt(p.MaxBurn)) > 0 {
+			return errors.Errorf("burn of up to %s mutez exceeds the max burn of %d mutez", burn.String(), p.MaxBurn)
+		}
+	}
+
+	return nil
+}
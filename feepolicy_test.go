@@ -0,0 +1,78 @@
+package gotezos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FeePolicy(t *testing.T) {
+	type want struct {
+		err         bool
+		containsErr string
+		fees        []string
+	}
+
+	cases := []struct {
+		name   string
+		policy *FeePolicy
+		want
+	}{
+		{
+			"pays minimal fees without a policy",
+			nil,
+			want{false, "", []string{"428", "111"}},
+		},
+		{
+			"pays a fixed fee",
+			&FeePolicy{Fixed: 2000, MaxFee: 4000, MaxBurn: 324000},
+			want{false, "", []string{"2000", "2000"}},
+		},
+		{
+			"handles fee above the max fee",
+			&FeePolicy{Fixed: 2000, MaxFee: 3999},
+			want{true, "fee of 4000 mutez exceeds the max fee of 3999 mutez", nil},
+		},
+		{
+			"handles burn above the max burn",
+			&FeePolicy{MaxBurn: 323999},
+			want{true, "burn of up to 324000 mutez exceeds the max burn of 323999 mutez", nil},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := testWalletClient(t)
+			client.FeePolicy = tt.policy
+
+			var contents Contents
+			contents.Kind = TRANSACTIONOP
+			contents.Destination = "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+			contents.Amount.SetInt64(1500)
+
+			operation, err := client.Prepare(contents)
+			assert.Nil(t, err)
+
+			_, err = client.Inject(context.Background(), operation...)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+
+			if tt.want.err {
+				assert.Len(t, mock.injected, 0)
+				return
+			}
+
+			var fees []string
+			for _, content := range operation {
+				fees = append(fees, content.Fee.String())
+			}
+			assert.Equal(t, tt.want.fees, fees)
+			assert.Len(t, mock.injected, 1)
+		})
+	}
+
+	gt := testGoTezos(t, gtGoldenHTTPMock(blankHandler))
+	policy := &FeePolicy{MaxFee: 10000}
+	gt.SetFeePolicy(policy)
+	assert.Equal(t, policy, NewWalletClient(gt, &Wallet{}).FeePolicy)
+}
@@ -0,0 +1,127 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+/*
+GlobalConstant RPC
+Path: ../<block_id>/context/global_constants/<expr_hash> (GET)
+Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-global-constants-script-expr-hash
+Description: Gets the value of a registered global constant.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+	address:
+		The expr hash of the global constant.
+*/
+func (t *GoTezos) GlobalConstant(blockID BlockID, address string) (*Micheline, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/global_constants/%s", blockID.ID(), address))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get global constant '%s'", address)
+	}
+
+	var value Micheline
+	err = json.Unmarshal(resp, &value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal global constant '%s'", address)
+	}
+
+	return &value, nil
+}
+
+/*
+ExpandGlobalConstants Function
+Description: Returns a copy of a script or expression where every constant reference
+(constant "expr...") is replaced by the value of the global constant, recursively.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+	m:
+		The script or expression to expand.
+*/
+func (t *GoTezos) ExpandGlobalConstants(blockID BlockID, m Micheline) (*Micheline, error) {
+	expanded, err := t.expandGlobalConstants(blockID, m, map[string]Micheline{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to expand global constants")
+	}
+
+	return &expanded, nil
+}
+
+func (t *GoTezos) expandGlobalConstants(blockID BlockID, m Micheline, cache map[string]Micheline) (Micheline, error) {
+	switch m.Kind {
+	case MichelineKindSeq:
+		seq := make([]Micheline, len(m.Seq))
+		for i, node := range m.Seq {
+			expanded, err := t.expandGlobalConstants(blockID, node, cache)
+			if err != nil {
+				return Micheline{}, err
+			}
+			seq[i] = expanded
+		}
+		m.Seq = seq
+	case MichelineKindPrim:
+		if m.Prim == "constant" {
+			if len(m.Args) != 1 || m.Args[0].Kind != MichelineKindString {
+				return Micheline{}, errors.New("constant must have a single string argument")
+			}
+			address := m.Args[0].String
+
+			if value, ok := cache[address]; ok {
+				return value, nil
+			}
+
+			value, err := t.GlobalConstant(blockID, address)
+			if err != nil {
+				return Micheline{}, err
+			}
+
+			expanded, err := t.expandGlobalConstants(blockID, *value, cache)
+			if err != nil {
+				return Micheline{}, err
+			}
+			cache[address] = expanded
+
+			return expanded, nil
+		}
+
+		if m.Args != nil {
+			args := make([]Micheline, len(m.Args))
+			for i, arg := range m.Args {
+				expanded, err := t.expandGlobalConstants(blockID, arg, cache)
+				if err != nil {
+					return Micheline{}, err
+				}
+				args[i] = expanded
+			}
+			m.Args = args
+		}
+	}
+
+	return m, nil
+}
+
+/*
+GlobalConstantHash Function
+Description: Returns the expr hash a value is registered under by a register_global_constant operation.
+
+Parameters:
+	value:
+		The value of the global constant.
+*/
+func GlobalConstantHash(value Micheline) (string, error) {
+	v, err := value.MarshalBinary()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to hash global constant")
+	}
+
+	hash := blake2b.Sum256(v)
+	return b58cencode(hash[:], prefix_expr), nil
+}
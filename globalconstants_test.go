@@ -0,0 +1,152 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func globalConstantsHandlerMock(constants map[string]Micheline, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/context/global_constants/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		address := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		value, ok := constants[address]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		v, _ := json.Marshal(value)
+		w.Write(v)
+	})
+}
+
+func Test_ExpandGlobalConstants(t *testing.T) {
+	nat := NewMichelinePrim("nat")
+	inner, err := GlobalConstantHash(nat)
+	assert.Nil(t, err)
+
+	pair := NewMichelinePrim("pair", NewMichelinePrim("constant", NewMichelineString(inner)), NewMichelinePrim("constant", NewMichelineString(inner)))
+	outer, err := GlobalConstantHash(pair)
+	assert.Nil(t, err)
+
+	constants := map[string]Micheline{
+		inner: nat,
+		outer: pair,
+	}
+
+	type want struct {
+		err         bool
+		containsErr string
+		expanded    *Micheline
+	}
+
+	expanded := NewMichelineSeq(
+		NewMichelinePrim("parameter", NewMichelinePrim("pair", nat, nat)),
+		NewMichelinePrim("storage", NewMichelinePrim("unit")),
+	)
+
+	cases := []struct {
+		name  string
+		input Micheline
+		want
+	}{
+		{
+			"expands nested constants",
+			NewMichelineSeq(
+				NewMichelinePrim("parameter", NewMichelinePrim("constant", NewMichelineString(outer))),
+				NewMichelinePrim("storage", NewMichelinePrim("unit")),
+			),
+			want{
+				false,
+				"",
+				&expanded,
+			},
+		},
+		{
+			"is missing a constant",
+			NewMichelinePrim("constant", NewMichelineString("exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC")),
+			want{
+				true,
+				"could not get global constant 'exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC'",
+				nil,
+			},
+		},
+		{
+			"is malformed",
+			NewMichelinePrim("constant", NewMichelineInt(1)),
+			want{
+				true,
+				"constant must have a single string argument",
+				nil,
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(gtGoldenHTTPMock(globalConstantsHandlerMock(constants, blankHandler)))
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			expanded, err := gt.ExpandGlobalConstants(BlockIDHead{}, tt.input)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			assert.Equal(t, tt.want.expanded, expanded)
+		})
+	}
+}
+
+func Test_GlobalConstantHash(t *testing.T) {
+	hash, err := GlobalConstantHash(NewMichelineInt(999))
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(hash, "expr"))
+	assert.Len(t, hash, 54)
+
+	other, err := GlobalConstantHash(NewMichelineInt(998))
+	assert.Nil(t, err)
+	assert.NotEqual(t, hash, other)
+
+	_, err = GlobalConstantHash(NewMichelinePrim("NOT_A_PRIM"))
+	assert.NotNil(t, err)
+}
+
+func Test_ForgeRegisterGlobalConstantOperation(t *testing.T) {
+	value := NewMichelinePrim("pair", NewMichelinePrim("nat"), NewMichelinePrim("string").WithAnnots("%name"))
+	contents := Contents{
+		Kind:         REGISTERGLOBALCONSTANTOP,
+		Source:       mockAddressTz1,
+		Fee:          BigInt{*big.NewInt(1000)},
+		Counter:      BigInt{*big.NewInt(12)},
+		GasLimit:     BigInt{*big.NewInt(1500)},
+		StorageLimit: BigInt{*big.NewInt(100)},
+		Value:        &value,
+	}
+
+	gt := &GoTezos{}
+	forge, err := gt.ForgeOperation(mockBlockHash, contents)
+	assert.Nil(t, err)
+
+	branch, unforged, err := gt.UnforgeOperation(*forge, false)
+	assert.Nil(t, err)
+	assert.Equal(t, mockBlockHash, *branch)
+	assert.Equal(t, []Contents{contents}, *unforged)
+
+	v, err := json.Marshal(contents)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"kind":"register_global_constant","source":"tz1YGLnq1Ls4W3rPanAvCvmcuQ1H5rffnc2V","fee":"1000","counter":"12","gas_limit":"1500","storage_limit":"100","value":{"prim":"pair","args":[{"prim":"nat"},{"prim":"string","annots":["%name"]}]}}`, string(v))
+
+	_, err = gt.ForgeOperation(mockBlockHash, Contents{Kind: REGISTERGLOBALCONSTANTOP, Source: mockAddressTz1})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "value is required")
+}
@@ -5,6 +5,7 @@ go 1.13
 require (
 	github.com/btcsuite/btcutil v1.0.1
 	github.com/go-playground/validator/v10 v10.1.0
+	github.com/miekg/pkcs11 v1.1.1
 	github.com/pkg/errors v0.8.1
 	github.com/stretchr/testify v1.4.0
 	golang.org/x/crypto v0.0.0-20200115085410-6d4e4cb37c7d
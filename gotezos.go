@@ -1,13 +1,20 @@
 package gotezos
 
 import (
+	"bufio"
 	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -22,11 +29,144 @@ Description: Contains a client (http.Client), network contents, and the host of
 RPC related functions.
 */
 type GoTezos struct {
-	client           client
+	client client
+	// Guards networkConstants and protocol, which followProtocol updates from the goroutines of monitors.
+	stateMu          sync.RWMutex
 	networkConstants *Constants
+	protocol         *Protocol
 	host             string
+	// Whether idle connections are kept open for the next requests instead of closed after each request.
+	keepIdleConns bool
+	breaker       *circuitBreaker
+	feePolicy     *FeePolicy
+	// The max size of responses read whole, 0 if unlimited.
+	maxResponseSize int64
+	// The max duration of requests whose response is read whole, 0 if unlimited. Streams are not limited.
+	requestTimeout time.Duration
+	// The User-Agent of requests, Go's default if empty.
+	userAgent string
+	// The header of the correlation id of requests, none if empty.
+	correlationHeader string
+	correlationID     func() string
+	// Whether network calls are refused, see NewOffline.
+	offline bool
+	// Guards done, which is closed by Close.
+	mu   sync.Mutex
+	done chan struct{}
 }
 
+/*
+IFace -
+Description: The exported methods of GoTezos, so that the client can be substituted (e.g. by a mock) in
+tests of code depending on it. GoTezos implements IFace.
+*/
+type IFace interface {
+	AdaptiveIssuanceLaunchCycle(blockID BlockID) (*int, error)
+	BakingRights(input *BakingRightsInput) (*BakingRights, error)
+	Balance(blockID BlockID, address string) (*string, error)
+	BalancesAt(blockID BlockID, addresses ...string) (map[string]string, error)
+	BigMapIterator(input *BigMapIteratorInput) (*BigMapIterator, error)
+	BigMapKeys(blockID BlockID, bigMap string, keyType Micheline, keys ...Micheline) ([]BigMapKey, error)
+	BigMapUpdates(start, end int) ([]BigMapUpdate, error)
+	BigMapValues(input *BigMapValuesInput) ([]Micheline, error)
+	BinarySchema(method, path string, output bool) (*BinarySchema, error)
+	Block(id BlockID, opts ...RPCOption) (*Block, error)
+	BlockAtLevel(level int, opts ...RPCOption) (*Block, error)
+	BlockAtOffset(base string, offset int, opts ...RPCOption) (*Block, error)
+	BlockHeaderSchema() (*BinarySchema, error)
+	Blocks(input *BlocksInput) (*[][]string, error)
+	Bootstrap() (*Bootstrap, error)
+	Caboose() (*HistoryLevel, error)
+	ChainID() (*string, error)
+	Checkpoint() (*Checkpoint, error)
+	Close() error
+	Commit() (*string, error)
+	Connections() (*Connections, error)
+	ConsensusKey(blockID BlockID, delegate string) (*ConsensusKey, error)
+	Constants(blockID BlockID) (*Constants, error)
+	ContractScript(input *ContractScriptInput) (*Script, error)
+	ContractStorage(input *ContractStorageInput) (*Micheline, error)
+	Counter(blockID BlockID, pkh string) (*int, error)
+	CurrentYearlyRate(blockID BlockID) (float64, error)
+	Cycle(cycle int) (*Cycle, error)
+	DALAttestationStatus(blockID BlockID) (*DALAttestationStatus, error)
+	DALPublishedSlotHeaders(blockID BlockID) ([]DALPublishedSlotHeader, error)
+	DALShards(input *DALShardsInput) ([]DALShards, error)
+	Delegate(blockID BlockID, delegate string) (*Delegate, error)
+	DelegatedContracts(blockID BlockID, delegate string) (*[]string, error)
+	DelegatedContractsAtCycle(cycle int, delegate string) (*[]string, error)
+	DelegatedContractsIterator(blockID BlockID, delegate string) (*StringIterator, error)
+	DelegatedContractsPage(blockID BlockID, delegate string, offset, limit int) (*[]string, error)
+	Delegates(input *DelegatesInput) (*[]string, error)
+	DelegatesIterator(input *DelegatesInput) (*StringIterator, error)
+	DelegatesPage(input *DelegatesInput, offset, limit int) (*[]string, error)
+	DeleteInvalidBlock(blockHash string) error
+	DryRun(contents ...Contents) (*DryRunResult, error)
+	EndorsingRights(input *EndorsingRightsInput) (*EndorsingRights, error)
+	ExpandGlobalConstants(blockID BlockID, m Micheline) (*Micheline, error)
+	ExpectedIssuance(blockID BlockID) ([]ExpectedIssuance, error)
+	ForgeMultisigMainOperation(branch string, input *MultisigMainInput) (*string, error)
+	ForgeOperation(branch string, contents ...Contents) (*string, error)
+	FrozenBalance(cycle int, delegate string) (*FrozenBalance, error)
+	GlobalConstant(blockID BlockID, address string) (*Micheline, error)
+	Head() (*Block, error)
+	Health(ctx context.Context) (*Health, error)
+	InjectionOperation(input *InjectionOperationInput) (*[]byte, error)
+	InvalidBlock(blockHash string) (*InvalidBlock, error)
+	InvalidBlocks() (*[]InvalidBlock, error)
+	IsRevealed(blockID BlockID, address string) (bool, error)
+	LiquidityBaking(blockID BlockID) (*LiquidityBaking, error)
+	LiquidityBakingCPMMAddress(blockID BlockID) (string, error)
+	ManagerKey(blockID BlockID, address string) (*string, error)
+	MinimalFees(contents ...Contents) ([]int64, error)
+	MonitorBaker(ctx context.Context, input *BakerMonitorInput) (<-chan BakerEvent, <-chan error, error)
+	MonitorMempool(ctx context.Context, input *MempoolMonitorInput) (<-chan MempoolOperation, <-chan error, error)
+	MonitorValidBlocks(ctx context.Context, input *ValidBlocksMonitorInput) (<-chan ValidBlock, <-chan error, error)
+	MultisigStorage(blockID BlockID, contract string) (*MultisigStorage, error)
+	NearestAvailableBlock(level int) (BlockIDLevel, error)
+	NewBatch(concurrency int) *Batch
+	NormalizeData(input *NormalizeDataInput) (*Micheline, error)
+	OperationHashes(blockID BlockID) (*[]string, error)
+	OperationSchema() (*BinarySchema, error)
+	Originate(ctx context.Context, signer *Wallet, code, storage Micheline, balance int64) (*Origination, error)
+	PollHeads(ctx context.Context, input *HeadPollerInput) (<-chan *Block, <-chan error, error)
+	PreapplyOperations(blockID BlockID, contents []Contents, signature string) (*[]byte, error)
+	PrependReveal(blockID BlockID, publicKey string, contents ...Contents) ([]Contents, error)
+	Protocol() Protocol
+	RunCode(input *RunCodeInput) (*RunCodeResult, error)
+	RunOperation(blockID BlockID, operation Operations) (*Operations, error)
+	RunScriptView(input *RunViewInput) (*Micheline, error)
+	RunView(input *RunViewInput) (*Micheline, error)
+	Savepoint() (*HistoryLevel, error)
+	SetClient(client *http.Client)
+	SetConstants(constants Constants)
+	SetDelegate(ctx context.Context, signer *Wallet, source, delegate string) (*string, error)
+	SetFeePolicy(policy *FeePolicy)
+	SetProtocol(hash string)
+	SmartRollupCommitment(blockID BlockID, rollup, hash string) (*SmartRollupCommitment, error)
+	SmartRollupGenesisInfo(blockID BlockID, rollup string) (*SmartRollupGenesisInfo, error)
+	SmartRollupInbox(blockID BlockID) (*SmartRollupInbox, error)
+	SmartRollupLastCementedCommitment(blockID BlockID, rollup string) (*SmartRollupCementedCommitment, error)
+	SmartRollupStakedOnCommitment(blockID BlockID, rollup, staker string) (*SmartRollupStakedOnCommitment, error)
+	SmartRollups(blockID BlockID) ([]string, error)
+	StakingBalance(blockID BlockID, delegate string) (*string, error)
+	StakingBalanceAtCycle(cycle int, delegate string) (*string, error)
+	TokenSnapshot(input *TokenSnapshotInput) (*TokenSnapshot, error)
+	TotalFrozenStake(blockID BlockID) (*BigInt, error)
+	TotalSupply(blockID BlockID) (*BigInt, error)
+	TraceCode(input *RunCodeInput) (*TraceCodeResult, error)
+	TrackConfirmations(ctx context.Context, input *ConfirmationInput) (<-chan Confirmation, <-chan error, error)
+	TypecheckCode(input *TypecheckCodeInput) (*TypecheckCodeResult, error)
+	TypecheckData(input *TypecheckDataInput) (*TypecheckDataResult, error)
+	UnforgeOperation(operation string, signed bool) (*string, *[]Contents, error)
+	UpcomingRights(input *UpcomingRightsInput) ([]UpcomingRight, error)
+	UserActivatedProtocolOverrides() (*UserActivatedProtocolOverrides, error)
+	VerifyForgedOperation(forged, branch string, contents ...Contents) error
+	Version() (*Version, error)
+}
+
+var _ IFace = &GoTezos{}
+
 /*
 RPCError Struct
 Description: Contains the standard error format returned by the Tezos RPC
@@ -42,9 +182,95 @@ Description: Contains multiple RPCError's.
 */
 type RPCErrors []RPCError
 
-type rpcOptions struct {
-	Key   string
-	Value string
+/*
+RequestError Struct
+Description: The error of a request to the node that failed (could not be sent, returned a status other than 200
+or an RPC error), identifying which of the many requests of e.g. a payout run failed. Find it in the errors
+returned by GoTezos with AsRequestError, its Cause is the failure of the request.
+*/
+type RequestError struct {
+	// The HTTP method of the request, e.g. GET.
+	Method string
+	// The path of the request, with its query (e.g. /chains/main/blocks/head~2/context/delegates?active=true).
+	Path string
+	// The block identifier of the path (e.g. head~2), empty for requests not on a block.
+	BlockID string
+	// The status code of the response, 0 if there was no response.
+	StatusCode int
+	// The correlation id sent with the request, empty if none, see WithCorrelationID.
+	CorrelationID string
+	// The failure of the request.
+	Err error
+}
+
+func newRequestError(req *http.Request, statusCode int, err error) *RequestError {
+	var blockID string
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	for i := 0; i+3 < len(segments); i++ {
+		if segments[i] == "chains" && segments[i+2] == "blocks" {
+			blockID = segments[i+3]
+			break
+		}
+	}
+
+	return &RequestError{
+		Method:     req.Method,
+		Path:       req.URL.RequestURI(),
+		BlockID:    blockID,
+		StatusCode: statusCode,
+		Err:        err,
+	}
+}
+
+// requestError is newRequestError with the correlation id of the request.
+func (t *GoTezos) requestError(req *http.Request, statusCode int, err error) *RequestError {
+	requestErr := newRequestError(req, statusCode, err)
+	if t.correlationHeader != "" {
+		requestErr.CorrelationID = req.Header.Get(t.correlationHeader)
+	}
+
+	return requestErr
+}
+
+// Error implements the error interface.
+func (r *RequestError) Error() string {
+	return fmt.Sprintf("%s %s: %s", r.Method, r.Path, r.Err.Error())
+}
+
+// Cause returns the failure of the request, see errors.Cause.
+func (r *RequestError) Cause() error {
+	return r.Err
+}
+
+// Unwrap returns the failure of the request, see the errors package of the standard library.
+func (r *RequestError) Unwrap() error {
+	return r.Err
+}
+
+/*
+AsRequestError Function
+Description: Returns the RequestError of the request an error of GoTezos failed on, if it failed on one, e.g. to
+log the path and block of the failing request. Errors returned by GoTezos are wrapped with github.com/pkg/errors,
+whose wrappers errors.As of the standard library does not see through.
+
+Parameters:
+	err:
+		The error returned by GoTezos.
+*/
+func AsRequestError(err error) (*RequestError, bool) {
+	for err != nil {
+		if requestErr, ok := err.(*RequestError); ok {
+			return requestErr, true
+		}
+
+		cause, ok := err.(interface{ Cause() error })
+		if !ok {
+			return nil, false
+		}
+		err = cause.Cause()
+	}
+
+	return nil, false
 }
 
 type client interface {
@@ -52,26 +278,266 @@ type client interface {
 	CloseIdleConnections()
 }
 
+/*
+Option -
+Description: Configures New. See WithChainID and WithProtocol for checks of the node,
+WithMaxIdleConnsPerHost, WithIdleConnTimeout, WithForceAttemptHTTP2, WithDisableKeepAlives,
+WithCircuitBreaker, WithMaxResponseSize and WithRequestTimeout for the connections to it, and WithUserAgent and
+WithCorrelationID for the identification of requests.
+*/
+type Option func(*options)
+
+type options struct {
+	chainID   string
+	protocols []string
+
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+	forceAttemptHTTP2   bool
+	disableKeepAlives   bool
+
+	breaker *circuitBreaker
+
+	maxResponseSize int64
+	requestTimeout  time.Duration
+
+	userAgent         string
+	correlationHeader string
+	correlationID     func() string
+}
+
+/*
+WithChainID Function
+Description: Makes New refuse a node that is not on the given chain, e.g. to keep code meant for mainnet
+from being pointed at a testnet node.
+
+Parameters:
+	chainID:
+		The expected chain ID, e.g. MainnetChainID.
+*/
+func WithChainID(chainID string) Option {
+	return func(o *options) {
+		o.chainID = chainID
+	}
+}
+
+/*
+WithProtocol Function
+Description: Makes New refuse a node whose head is not on one of the given protocols.
+
+Parameters:
+	protocols:
+		The expected protocols, by hash or by name (e.g. "Rio").
+*/
+func WithProtocol(protocols ...string) Option {
+	return func(o *options) {
+		o.protocols = append(o.protocols, protocols...)
+	}
+}
+
+/*
+WithMaxIdleConnsPerHost Function
+Description: Keeps up to n idle connections open to the node, to be reused by the next requests. By default
+connections are closed once a request completes. Clients making many requests (e.g. indexers) should set it
+to their concurrency so that connections are reused rather than reopened.
+
+Parameters:
+	n:
+		The max number of idle connections.
+*/
+func WithMaxIdleConnsPerHost(n int) Option {
+	return func(o *options) {
+		o.maxIdleConnsPerHost = n
+	}
+}
+
+/*
+WithIdleConnTimeout Function
+Description: Keeps idle connections open to the node for up to timeout, to be reused by the next requests.
+By default connections are closed once a request completes.
+
+Parameters:
+	timeout:
+		The max time a connection is idle before it is closed.
+*/
+func WithIdleConnTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.idleConnTimeout = timeout
+	}
+}
+
+/*
+WithForceAttemptHTTP2 Function
+Description: Attempts HTTP/2 with nodes served over TLS, multiplexing requests over a single connection.
+HTTP/1.1 is used by default.
+
+Parameters:
+	force:
+		Whether HTTP/2 is attempted.
+*/
+func WithForceAttemptHTTP2(force bool) Option {
+	return func(o *options) {
+		o.forceAttemptHTTP2 = force
+	}
+}
+
+/*
+WithDisableKeepAlives Function
+Description: Opens a new connection to the node for every request, e.g. for nodes behind a load balancer that
+should spread requests across its backends.
+
+Parameters:
+	disable:
+		Whether keep-alives are disabled.
+*/
+func WithDisableKeepAlives(disable bool) Option {
+	return func(o *options) {
+		o.disableKeepAlives = disable
+	}
+}
+
+/*
+WithMaxResponseSize Function
+Description: Fails requests whose response is larger than size bytes (decompressed) with ErrResponseTooLarge,
+rather than reading it into memory, e.g. to protect a service querying blocks with thousands of operations.
+Responses are not limited by default. Streams (e.g. monitors and iterators) are not limited, as they are not
+read whole.
+
+Parameters:
+	size:
+		The max size of a response in bytes.
+*/
+func WithMaxResponseSize(size int64) Option {
+	return func(o *options) {
+		o.maxResponseSize = size
+	}
+}
+
+// DefaultRequestTimeout is the max duration of requests by default, see WithRequestTimeout.
+const DefaultRequestTimeout = 10 * time.Second
+
+/*
+WithRequestTimeout Function
+Description: Sets the max duration of a request, from sending it to reading its response, DefaultRequestTimeout
+by default. Streams (e.g. monitors and iterators) are not limited, as they last as long as they are read: monitor
+streams are instead reopened once they receive no data for their heartbeat.
+
+Parameters:
+	timeout:
+		The max duration of a request.
+*/
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.requestTimeout = timeout
+	}
+}
+
+/*
+WithUserAgent Function
+Description: Sends a User-Agent identifying the application with every request, so that node operators and
+hosted RPC providers can attribute its traffic. Go's default User-Agent is sent otherwise.
+
+Parameters:
+	userAgent:
+		The User-Agent, e.g. "payouts/1.2 (ops@baker.example)".
+*/
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+/*
+WithCorrelationID Function
+Description: Sends an id with every request in a header, so that a request can be found in the logs of the
+node or of the proxy in front of it. Each request gets a new id unless its context has one, see
+ContextWithCorrelationID. The id of a failed request is in its RequestError.
+
+Parameters:
+	header:
+		The header of the id, e.g. X-Request-ID.
+	id:
+		Returns a new id, 16 random bytes hex encoded if nil.
+*/
+func WithCorrelationID(header string, id func() string) Option {
+	return func(o *options) {
+		o.correlationHeader = header
+		o.correlationID = id
+	}
+}
+
+type correlationIDKey struct{}
+
+/*
+ContextWithCorrelationID Function
+Description: Returns a context whose requests are sent with an id rather than a new one each, e.g. the id of the
+request a service is serving, to trace it to the node. Only sent if WithCorrelationID is set.
+
+Parameters:
+	ctx:
+		The parent context.
+	id:
+		The correlation id.
+*/
+func ContextWithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+func newCorrelationID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
 /*
 New Func
-Description: Returns a pointer to a GoTezos and initializes the library with the host's Tezos netowrk constants.
+Description: Returns a pointer to a GoTezos and initializes the library with the host's Tezos netowrk constants
+and the protocol operations are forged for. If the options expect a chain or protocol the node is not on, no
+GoTezos is returned.
 
 Parameters:
 	host:
 		A Tezos node.
+
+	opts:
+		Optional checks of the node and tuning of the connections to it, see Option.
 */
-func New(host string) (*GoTezos, error) {
+func New(host string, opts ...Option) (*GoTezos, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	// The client has no timeout, which would cut streams: requests are limited one by one, see do.
 	gt := &GoTezos{
 		client: &http.Client{
-			Timeout: time.Second * 10,
 			Transport: &http.Transport{
 				Dial: (&net.Dialer{
 					Timeout: 10 * time.Second,
 				}).Dial,
 				TLSHandshakeTimeout: 10 * time.Second,
+				MaxIdleConnsPerHost: o.maxIdleConnsPerHost,
+				IdleConnTimeout:     o.idleConnTimeout,
+				ForceAttemptHTTP2:   o.forceAttemptHTTP2,
+				DisableKeepAlives:   o.disableKeepAlives,
 			},
 		},
-		host: cleanseHost(host),
+		host:          cleanseHost(host),
+		keepIdleConns: o.maxIdleConnsPerHost > 0 || o.idleConnTimeout > 0,
+		breaker:       o.breaker,
+
+		maxResponseSize: o.maxResponseSize,
+		requestTimeout:  o.requestTimeout,
+
+		userAgent:         o.userAgent,
+		correlationHeader: o.correlationHeader,
+		correlationID:     o.correlationID,
+	}
+	if gt.correlationID == nil {
+		gt.correlationID = newCorrelationID
+	}
+	if gt.requestTimeout <= 0 {
+		gt.requestTimeout = DefaultRequestTimeout
 	}
 
 	block, err := gt.Head()
@@ -79,18 +545,43 @@ func New(host string) (*GoTezos, error) {
 		return gt, errors.Wrap(err, "could not initialize library with network constants")
 	}
 
-	constants, err := gt.Constants(block.Hash)
+	if err := o.verify(block); err != nil {
+		return gt, err
+	}
+	gt.SetProtocol(block.Protocol)
+
+	constants, err := gt.Constants(BlockIDHash(block.Hash))
 	if err != nil {
 		return gt, errors.Wrap(err, "could not initialize library with network constants")
 	}
-	gt.networkConstants = constants
+	gt.SetConstants(*constants)
 
 	return gt, nil
 }
 
+func (o *options) verify(block *Block) error {
+	if o.chainID != "" && block.ChainID != o.chainID {
+		return errors.Errorf("node is on chain '%s', expected '%s'", block.ChainID, o.chainID)
+	}
+
+	if len(o.protocols) == 0 {
+		return nil
+	}
+
+	name := ProtocolByHash(block.Protocol).Name
+	for _, protocol := range o.protocols {
+		if protocol == block.Protocol || (name != "" && protocol == name) {
+			return nil
+		}
+	}
+
+	return errors.Errorf("node is on protocol '%s', expected one of '%s'", block.Protocol, strings.Join(o.protocols, "', '"))
+}
+
 /*
 SetClient Func
-Description: Overrides GoTezos's client. *http.Client satisfies the client interface.
+Description: Overrides GoTezos's client. *http.Client satisfies the client interface. The Timeout of the client
+applies to streams as well, leave it unset to keep monitors open, requests are limited by WithRequestTimeout.
 
 Parameters:
 	client:
@@ -109,10 +600,21 @@ Parameters:
 		Tezos Network Constants.
 */
 func (t *GoTezos) SetConstants(constants Constants) {
+	t.stateMu.Lock()
+	defer t.stateMu.Unlock()
+
 	t.networkConstants = &constants
 }
 
-func (t *GoTezos) post(path string, body []byte, opts ...rpcOptions) ([]byte, error) {
+// cachedConstants returns the constants cached by New or SetConstants, nil if none. They must not be modified.
+func (t *GoTezos) cachedConstants() *Constants {
+	t.stateMu.RLock()
+	defer t.stateMu.RUnlock()
+
+	return t.networkConstants
+}
+
+func (t *GoTezos) post(path string, body []byte, opts ...RPCOption) ([]byte, error) {
 	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s%s", t.host, path), bytes.NewBuffer(body))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to construct request")
@@ -123,18 +625,23 @@ func (t *GoTezos) post(path string, body []byte, opts ...rpcOptions) ([]byte, er
 	return t.do(req)
 }
 
-func (t *GoTezos) get(path string, opts ...rpcOptions) ([]byte, error) {
+func (t *GoTezos) get(path string, opts ...RPCOption) ([]byte, error) {
+	return t.getContext(context.Background(), path, opts...)
+}
+
+func (t *GoTezos) getContext(ctx context.Context, path string, opts ...RPCOption) ([]byte, error) {
 	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", t.host, path), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to construct request")
 	}
+	req = req.WithContext(ctx)
 
 	constructQueryParams(req, opts...)
 
 	return t.do(req)
 }
 
-func (t *GoTezos) delete(path string, opts ...rpcOptions) ([]byte, error) {
+func (t *GoTezos) delete(path string, opts ...RPCOption) ([]byte, error) {
 	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s%s", t.host, path), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to construct request")
@@ -146,31 +653,309 @@ func (t *GoTezos) delete(path string, opts ...rpcOptions) ([]byte, error) {
 }
 
 func (t *GoTezos) do(req *http.Request) ([]byte, error) {
-	resp, err := t.client.Do(req)
+	if t.requestTimeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), t.requestTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := t.send(req)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to complete request")
+		return nil, t.requestError(req, 0, err)
 	}
+	defer resp.Body.Close()
 
-	byts, err := ioutil.ReadAll(resp.Body)
+	byts, err := ioutil.ReadAll(limitBody(resp.Body, t.maxResponseSize, errResponseTooLarge(t.maxResponseSize)))
 	if err != nil {
-		return byts, errors.Wrap(err, "could not read response body")
+		return byts, t.requestError(req, resp.StatusCode, errors.Wrap(err, "could not read response body"))
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return byts, fmt.Errorf("response returned code %d with body %s", resp.StatusCode, string(byts))
+		return byts, t.requestError(req, resp.StatusCode, fmt.Errorf("response returned code %d with body %s", resp.StatusCode, string(byts)))
 	}
 
 	err = handleRPCError(byts)
 	if err != nil {
-		return byts, err
+		return byts, t.requestError(req, resp.StatusCode, err)
 	}
 
-	t.client.CloseIdleConnections()
+	if !t.keepIdleConns {
+		t.client.CloseIdleConnections()
+	}
 
 	return byts, nil
 }
 
-func constructQueryParams(req *http.Request, opts ...rpcOptions) {
+// stream is like get but returns the response body unread, so large responses can be decoded incrementally.
+// The caller must close the body.
+func (t *GoTezos) stream(path string, opts ...RPCOption) (io.ReadCloser, error) {
+	return t.openStream(context.Background(), path, 0, true, opts...)
+}
+
+// streamContext is like stream but aborts the request, including reading the body, once ctx is done. It is for
+// streams of events (e.g. monitors), whose body is not checked for RPC errors as it is read as events come.
+func (t *GoTezos) streamContext(ctx context.Context, path string, opts ...RPCOption) (io.ReadCloser, error) {
+	return t.openStream(ctx, path, 0, false, opts...)
+}
+
+// getDecoded is like get but decodes the response with decode as it is read, rather than reading it whole first.
+// Decoding fails once more than the max response size is read, see WithMaxResponseSize. Responses are checked
+// for RPC errors as by get, see openStream.
+func (t *GoTezos) getDecoded(path string, decode func(*json.Decoder) error, opts ...RPCOption) error {
+	ctx := context.Background()
+	if t.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.requestTimeout)
+		defer cancel()
+	}
+
+	body, err := t.openStream(ctx, path, t.maxResponseSize, true, opts...)
+	if err != nil {
+		return err
+	}
+
+	err = decode(json.NewDecoder(body))
+	body.Close()
+	if err != nil {
+		return err
+	}
+
+	if !t.keepIdleConns {
+		t.client.CloseIdleConnections()
+	}
+
+	return nil
+}
+
+// rpcErrorWindow is the max size of the responses openStream checks for RPC errors: the errors of the node are
+// small, larger responses are not errors.
+const rpcErrorWindow = 4096
+
+// openStream sends a GET request and returns the response body unread, failing reads past maxSize bytes unless
+// maxSize is 0. If checkErrors, a response of at most rpcErrorWindow bytes that is an RPC error fails the
+// request, as in do; streams of events are not checked, as the check waits for the window to be read. The
+// stream is aborted by Close.
+func (t *GoTezos) openStream(ctx context.Context, path string, maxSize int64, checkErrors bool, opts ...RPCOption) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", t.host, path), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to construct request")
+	}
+	ctx, cancel := t.untilClosed(ctx)
+	req = req.WithContext(ctx)
+
+	constructQueryParams(req, opts...)
+
+	resp, err := t.send(req)
+	if err != nil {
+		cancel()
+		return nil, t.requestError(req, 0, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer cancel()
+		defer resp.Body.Close()
+		byts, _ := ioutil.ReadAll(resp.Body)
+		return nil, t.requestError(req, resp.StatusCode, fmt.Errorf("response returned code %d with body %s", resp.StatusCode, string(byts)))
+	}
+
+	body := limitBody(&cancelingBody{ReadCloser: resp.Body, cancel: cancel}, maxSize, t.requestError(req, resp.StatusCode, errResponseTooLarge(maxSize)))
+	if !checkErrors {
+		return body, nil
+	}
+
+	r := bufio.NewReaderSize(body, rpcErrorWindow)
+	if head, err := r.Peek(rpcErrorWindow); err == io.EOF {
+		if err := handleRPCError(head); err != nil {
+			body.Close()
+			return nil, t.requestError(req, resp.StatusCode, err)
+		}
+	}
+
+	return &bufferedBody{Reader: r, Closer: body}, nil
+}
+
+// bufferedBody reads a body through a buffer.
+type bufferedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// ErrResponseTooLarge is the cause (see errors.Cause) of the errors of requests whose response is larger than the
+// max response size, see WithMaxResponseSize.
+var ErrResponseTooLarge = errors.New("response too large")
+
+func errResponseTooLarge(maxSize int64) error {
+	return errors.Wrapf(ErrResponseTooLarge, "response is larger than %d bytes", maxSize)
+}
+
+// limitedBody fails the reads of a body past its max size with err, without reading the rest of the body.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	err       error
+}
+
+func limitBody(body io.ReadCloser, maxSize int64, err error) io.ReadCloser {
+	if maxSize <= 0 {
+		return body
+	}
+
+	return &limitedBody{ReadCloser: body, remaining: maxSize, err: err}
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if l.remaining <= 0 {
+		// A body of exactly the max size is read whole, so only fail if there is more.
+		n, err := l.ReadCloser.Read(p[:1])
+		if n > 0 {
+			return 0, l.err
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.ReadCloser.Read(p)
+	l.remaining -= int64(n)
+
+	return n, err
+}
+
+// send sends a request accepting a gzip encoded response, which is decompressed as it is read. Block responses
+// are megabytes of JSON that compress well, so this cuts the transfer time from remote nodes (or proxies) that
+// compress. The client's transport is not relied on for this since clients set with SetClient may not.
+//
+// The body of a request is reset before it is sent, so that a request can be sent again (e.g. retried) with its
+// whole body.
+func (t *GoTezos) send(req *http.Request) (*http.Response, error) {
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to construct request")
+		}
+		req.Body = body
+	}
+	req.Header.Set("Accept-Encoding", "gzip")
+	if t.userAgent != "" {
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+	// A request sent again keeps its id.
+	if t.correlationHeader != "" && req.Header.Get(t.correlationHeader) == "" {
+		id, ok := req.Context().Value(correlationIDKey{}).(string)
+		if !ok {
+			id = t.correlationID()
+		}
+		req.Header.Set(t.correlationHeader, id)
+	}
+
+	if t.offline {
+		return nil, errors.Wrap(ErrOffline, "failed to complete request")
+	}
+
+	if t.isClosed() {
+		return nil, errors.Wrap(ErrClosed, "failed to complete request")
+	}
+
+	if !t.breaker.allow() {
+		return nil, errors.Wrap(ErrCircuitOpen, "failed to complete request")
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil && req.Context().Err() != nil {
+		// The request was canceled, which says nothing of the node.
+		t.breaker.release()
+	} else {
+		t.breaker.record(err == nil && resp.StatusCode < http.StatusInternalServerError)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to complete request")
+	}
+
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return resp, nil
+	}
+
+	body, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		resp.Body.Close()
+		return nil, errors.Wrap(err, "could not decompress response body")
+	}
+
+	resp.Body = &gzipBody{Reader: body, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+
+	return resp, nil
+}
+
+// gzipBody decompresses a response body, closing it once closed.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (g *gzipBody) Close() error {
+	g.Reader.Close()
+	return g.body.Close()
+}
+
+// DefaultStreamHeartbeat is the default max time to wait for data on a monitor stream before it is considered
+// dead.
+const DefaultStreamHeartbeat = time.Minute
+
+// heartbeatBody fails the reads of a streamed body that receive no data for timeout, closing the body, so that
+// a connection that silently died (e.g. dropped by a NAT) is detected instead of blocking forever. The error of
+// such reads is a net.Error timing out.
+type heartbeatBody struct {
+	io.ReadCloser
+	timeout time.Duration
+}
+
+func withHeartbeat(body io.ReadCloser, timeout time.Duration) io.ReadCloser {
+	if timeout <= 0 {
+		timeout = DefaultStreamHeartbeat
+	}
+
+	return &heartbeatBody{ReadCloser: body, timeout: timeout}
+}
+
+func (h *heartbeatBody) Read(p []byte) (int, error) {
+	timer := time.AfterFunc(h.timeout, func() {
+		h.ReadCloser.Close()
+	})
+
+	n, err := h.ReadCloser.Read(p)
+	if !timer.Stop() {
+		return n, heartbeatTimeout{h.timeout}
+	}
+
+	return n, err
+}
+
+type heartbeatTimeout struct {
+	timeout time.Duration
+}
+
+func (h heartbeatTimeout) Error() string {
+	return fmt.Sprintf("stream received no data for %s", h.timeout)
+}
+
+func (h heartbeatTimeout) Timeout() bool {
+	return true
+}
+
+func (h heartbeatTimeout) Temporary() bool {
+	return true
+}
+
+func constructQueryParams(req *http.Request, opts ...RPCOption) {
 	q := req.URL.Query()
 	for _, opt := range opts {
 		q.Add(opt.Key, opt.Value)
@@ -180,15 +965,34 @@ func constructQueryParams(req *http.Request, opts ...rpcOptions) {
 }
 
 func handleRPCError(resp []byte) error {
-	if strings.Contains(string(resp), "error") {
-		rpcErrors := RPCErrors{}
+	if !strings.Contains(string(resp), "error") {
+		return nil
+	}
+
+	rpcErrors := RPCErrors{}
+	if bytes.HasPrefix(bytes.TrimSpace(resp), []byte("{")) {
+		// Objects (e.g. operation results or invalid blocks) may carry errors of their own, only an object that
+		// is an error itself is an RPC error.
+		var rpcError RPCError
+		err := json.Unmarshal(resp, &rpcError)
+		if err != nil {
+			return errors.Wrap(err, "could not unmarshal rpc error")
+		}
+
+		if rpcError.Kind != "" && rpcError.Error != "" {
+			rpcErrors = append(rpcErrors, rpcError)
+		}
+	} else {
 		err := json.Unmarshal(resp, &rpcErrors)
 		if err != nil {
 			return errors.Wrap(err, "could not unmarshal rpc error")
 		}
-		return fmt.Errorf("rpc error (%s): %s", rpcErrors[0].Kind, rpcErrors[0].Error)
 	}
-	return nil
+
+	if len(rpcErrors) == 0 {
+		return nil
+	}
+	return fmt.Errorf("rpc error (%s): %s", rpcErrors[0].Kind, rpcErrors[0].Error)
 }
 
 func cleanseHost(host string) string {
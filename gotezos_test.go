@@ -1,6 +1,9 @@
 package gotezos
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -8,7 +11,9 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -70,6 +75,221 @@ func Test_New(t *testing.T) {
 	}
 }
 
+func Test_New_Options(t *testing.T) {
+	cases := []struct {
+		name    string
+		opts    []Option
+		wantErr string
+	}{
+		{
+			"is successful with expected chain and protocol hash",
+			[]Option{WithChainID(MainnetChainID), WithProtocol("PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS")},
+			"",
+		},
+		{
+			"is successful with expected protocol name",
+			[]Option{WithProtocol("Carthage", "Babylon")},
+			"",
+		},
+		{
+			"handles unexpected chain",
+			[]Option{WithChainID("NetXnHfVqm9iesp")},
+			"node is on chain 'NetXdQprcVkpaWU', expected 'NetXnHfVqm9iesp'",
+		},
+		{
+			"handles unexpected protocol",
+			[]Option{WithChainID(MainnetChainID), WithProtocol("Carthage", "PsDELPH1Kxsxt8f9eWbxQeRxkjfbxoqM52jvs5Y5fBxWWh4ifpo")},
+			"node is on protocol 'PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS', expected one of 'Carthage', 'PsDELPH1Kxsxt8f9eWbxQeRxkjfbxoqM52jvs5Y5fBxWWh4ifpo'",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(gtGoldenHTTPMock(blankHandler))
+			defer server.Close()
+
+			gt, err := New(server.URL, tt.opts...)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.NotNil(t, gt)
+			} else {
+				assert.Nil(t, err)
+				assert.NotNil(t, gt)
+			}
+		})
+	}
+}
+
+func Test_New_TransportOptions(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(blankHandler))
+	defer server.Close()
+
+	gt, err := New(server.URL, WithMaxIdleConnsPerHost(64), WithIdleConnTimeout(time.Minute), WithForceAttemptHTTP2(true), WithDisableKeepAlives(true))
+	assert.Nil(t, err)
+
+	transport := gt.client.(*http.Client).Transport.(*http.Transport)
+	assert.Equal(t, 64, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, time.Minute, transport.IdleConnTimeout)
+	assert.True(t, transport.ForceAttemptHTTP2)
+	assert.True(t, transport.DisableKeepAlives)
+	assert.True(t, gt.keepIdleConns)
+	// Streams would be cut by a timeout of the client.
+	assert.Zero(t, gt.client.(*http.Client).Timeout)
+	assert.Equal(t, DefaultRequestTimeout, gt.requestTimeout)
+
+	gt = testGoTezos(t, gtGoldenHTTPMock(blankHandler))
+	assert.False(t, gt.keepIdleConns)
+}
+
+func Test_MaxResponseSize(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(bigMapHandlerMock(100, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/balance"):
+			w.Write([]byte(`"1000000000"`))
+		case r.URL.Path == "/chains/main/blocks/head":
+			w.Write(mockBlockResp)
+		}
+	}))))
+	defer server.Close()
+
+	gt, err := New(server.URL, WithMaxResponseSize(1<<30))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1<<30), gt.maxResponseSize)
+
+	// The balance is 12 bytes, read whole.
+	gt.maxResponseSize = 12
+	balance, err := gt.Balance(BlockIDHead{}, mockAddressTz1)
+	assert.Nil(t, err)
+	assert.Equal(t, "1000000000", *balance)
+
+	gt.maxResponseSize = 11
+	_, err = gt.Balance(BlockIDHead{}, mockAddressTz1)
+	assert.Equal(t, ErrResponseTooLarge, errors.Cause(err))
+	checkErr(t, true, "response is larger than 11 bytes", err)
+
+	// Blocks and big map values are decoded as they are read.
+	gt.maxResponseSize = 64
+	_, err = gt.Block(BlockIDHead{})
+	assert.Equal(t, ErrResponseTooLarge, errors.Cause(err))
+	requestErr, ok := AsRequestError(err)
+	assert.True(t, ok)
+	assert.Equal(t, "/chains/main/blocks/head", requestErr.Path)
+
+	_, err = gt.BigMapValues(&BigMapValuesInput{BlockID: BlockIDHead{}, BigMap: "511"})
+	assert.Equal(t, ErrResponseTooLarge, errors.Cause(err))
+
+	values, err := gt.BigMapValues(&BigMapValuesInput{BlockID: BlockIDHead{}, BigMap: "511", Length: 2})
+	assert.Nil(t, err)
+	assert.Len(t, values, 2)
+
+	gt.maxResponseSize = 0
+	values, err = gt.BigMapValues(&BigMapValuesInput{BlockID: BlockIDHead{}, BigMap: "511"})
+	assert.Nil(t, err)
+	assert.Len(t, values, 100)
+}
+
+func Test_RequestTimeout(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/chains/main/chain_id":
+			time.Sleep(100 * time.Millisecond)
+			w.Write([]byte(`"NetXdQprcVkpaWU"`))
+		case "/chains/main/blocks/10":
+			time.Sleep(100 * time.Millisecond)
+			w.Write(mockBlockResp)
+		case "/chains/main/blocks/head":
+			w.Write(mockBlockResp)
+		}
+	})))
+	defer server.Close()
+
+	gt, err := New(server.URL, WithRequestTimeout(50*time.Millisecond))
+	assert.Nil(t, err)
+	assert.Equal(t, 50*time.Millisecond, gt.requestTimeout)
+
+	_, err = gt.ChainID()
+	checkErr(t, true, "context deadline exceeded", err)
+
+	// Responses decoded as they are read are limited as well.
+	_, err = gt.Block(BlockIDLevel(10))
+	checkErr(t, true, "context deadline exceeded", err)
+
+	gt.requestTimeout = time.Second
+	chainID, err := gt.ChainID()
+	assert.Nil(t, err)
+	assert.Equal(t, "NetXdQprcVkpaWU", *chainID)
+}
+
+// gzipHandlerMock gzips the responses of next to requests accepting gzip.
+func gzipHandlerMock(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Accept-Encoding") != "gzip" {
+			next.ServeHTTP(rw, req)
+			return
+		}
+
+		rec := httptest.NewRecorder()
+		next.ServeHTTP(rec, req)
+
+		rw.Header().Set("Content-Encoding", "gzip")
+		rw.WriteHeader(rec.Code)
+		gz := gzip.NewWriter(rw)
+		gz.Write(rec.Body.Bytes())
+		gz.Close()
+	})
+}
+
+func Test_GzipResponses(t *testing.T) {
+	server := httptest.NewServer(gzipHandlerMock(gtGoldenHTTPMock(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`["KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg","KT1FPyY6mAhnzyVGP8ApGvuKkJYPNrdx3nkp"]`))
+	}))))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedConstants(t), gt.networkConstants)
+
+	contracts, err := gt.DelegatedContractsIterator(BlockIDHead{}, "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK")
+	assert.Nil(t, err)
+	defer contracts.Close()
+
+	var got []string
+	for contracts.Next() {
+		got = append(got, contracts.Value())
+	}
+	assert.Nil(t, contracts.Err())
+	assert.Equal(t, []string{"KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", "KT1FPyY6mAhnzyVGP8ApGvuKkJYPNrdx3nkp"}, got)
+}
+
+func Test_PostReplaysBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/redirect" {
+			http.Redirect(rw, req, "/echo", http.StatusTemporaryRedirect)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(req.Body)
+		rw.Write(body)
+	}))
+	defer server.Close()
+
+	gt := &GoTezos{client: &http.Client{}, host: server.URL}
+	body := []byte(`{"kind":"transaction","amount":"1000"}`)
+
+	resp, err := gt.post("/redirect", body)
+	assert.Nil(t, err)
+	assert.Equal(t, body, resp)
+
+	// Sending the same request twice replays its body rather than the drained buffer.
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/echo", bytes.NewBuffer(body))
+	assert.Nil(t, err)
+	for i := 0; i < 2; i++ {
+		resp, err = gt.do(req)
+		assert.Nil(t, err)
+		assert.Equal(t, body, resp)
+	}
+}
+
 func Test_SetClient(t *testing.T) {
 	gt := GoTezos{}
 
@@ -93,7 +313,7 @@ func Test_post(t *testing.T) {
 		handler http.Handler
 		body    []byte
 		post    string
-		opts    []rpcOptions
+		opts    []RPCOption
 	}
 
 	type want struct {
@@ -146,7 +366,7 @@ func Test_post(t *testing.T) {
 				})),
 				[]byte("some_body"),
 				"/some/endpoint",
-				[]rpcOptions{
+				[]RPCOption{
 					{
 						Key:   "my_key",
 						Value: "my_val",
@@ -183,7 +403,7 @@ func Test_get(t *testing.T) {
 	type input struct {
 		handler http.Handler
 		get     string
-		params  []rpcOptions
+		params  []RPCOption
 	}
 
 	type want struct {
@@ -230,7 +450,7 @@ func Test_get(t *testing.T) {
 					w.Write([]byte("success"))
 				})),
 				"/some/endpoint",
-				[]rpcOptions{
+				[]RPCOption{
 					{
 						Key:   "my_key",
 						Value: "my_val",
@@ -356,6 +576,97 @@ func Test_do(t *testing.T) {
 	}
 }
 
+func Test_RequestError(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/total_supply") {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write(mockRPCErrorResp)
+	})))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	_, err = gt.ExpectedIssuance(BlockIDHeadPredecessor(2))
+	requestErr, ok := AsRequestError(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.MethodGet, requestErr.Method)
+	assert.Equal(t, "/chains/main/blocks/head~2/context/issuance/expected_issuance", requestErr.Path)
+	assert.Equal(t, "head~2", requestErr.BlockID)
+	assert.Equal(t, http.StatusOK, requestErr.StatusCode)
+	assert.Contains(t, requestErr.Err.Error(), "rpc error")
+	checkErr(t, true, "could not get expected issuance: GET /chains/main/blocks/head~2/context/issuance/expected_issuance: rpc error", err)
+
+	_, err = gt.TotalSupply(BlockIDLevel(100))
+	requestErr, ok = AsRequestError(err)
+	assert.True(t, ok)
+	assert.Equal(t, "100", requestErr.BlockID)
+	assert.Equal(t, http.StatusBadGateway, requestErr.StatusCode)
+
+	_, err = gt.ChainID()
+	requestErr, ok = AsRequestError(err)
+	assert.True(t, ok)
+	assert.Equal(t, "/chains/main/chain_id", requestErr.Path)
+	assert.Equal(t, "", requestErr.BlockID)
+
+	server.Close()
+	_, err = gt.Head()
+	requestErr, ok = AsRequestError(err)
+	assert.True(t, ok)
+	assert.Equal(t, "head", requestErr.BlockID)
+	assert.Equal(t, 0, requestErr.StatusCode)
+
+	_, ok = AsRequestError(errors.New("not a request error"))
+	assert.False(t, ok)
+	_, ok = AsRequestError(nil)
+	assert.False(t, ok)
+}
+
+func Test_RequestIdentification(t *testing.T) {
+	var userAgents, ids []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.Header.Get("User-Agent"))
+		ids = append(ids, r.Header.Get("X-Request-ID"))
+		gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		})).ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	gt, err := New(server.URL, WithUserAgent("payouts/1.2"), WithCorrelationID("X-Request-ID", nil))
+	assert.Nil(t, err)
+
+	_, err = gt.ChainID()
+	requestErr, ok := AsRequestError(err)
+	assert.True(t, ok)
+
+	assert.Len(t, ids, 3)
+	for i := range ids {
+		assert.Equal(t, "payouts/1.2", userAgents[i])
+		assert.Regexp(t, "^[0-9a-f]{32}$", ids[i])
+	}
+	assert.NotEqual(t, ids[0], ids[1])
+	assert.Equal(t, ids[2], requestErr.CorrelationID)
+
+	gt.Health(ContextWithCorrelationID(context.Background(), "payout-run-1"))
+	assert.Equal(t, "payout-run-1", ids[3])
+
+	n := 0
+	gt, err = New(server.URL, WithCorrelationID("X-Request-ID", func() string {
+		n++
+		return fmt.Sprintf("id-%d", n)
+	}))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"id-1", "id-2"}, ids[4:])
+	assert.Equal(t, "Go-http-client/1.1", userAgents[4])
+
+	gt, err = New(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"", ""}, ids[6:])
+}
+
 func Test_handleRPCError(t *testing.T) {
 	cases := []struct {
 		name        string
@@ -381,6 +692,24 @@ func Test_handleRPCError(t *testing.T) {
 			false,
 			"",
 		},
+		{
+			"object with errors is not an rpc error",
+			[]byte(`{"contents":[{"metadata":{"operation_result":{"status":"failed","errors":[]}}}]}`),
+			false,
+			"",
+		},
+		{
+			"found an rpc error object",
+			[]byte(`{"kind":"temporary","id":"proto.alpha.contract.counter_in_the_past","error":"counter_in_the_past"}`),
+			true,
+			"rpc error (temporary): counter_in_the_past",
+		},
+		{
+			"object with errors at the top level is not an rpc error",
+			[]byte(`{"block":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","level":10,"errors":[{"kind":"permanent","error":"invalid_signature"}]}`),
+			false,
+			"",
+		},
 	}
 
 	for _, tt := range cases {
@@ -399,11 +728,11 @@ func Test_handleRPCError(t *testing.T) {
 func Test_constructQuery(t *testing.T) {
 	cases := []struct {
 		name string
-		opts []rpcOptions
+		opts []RPCOption
 	}{
 		{
 			"adds url parameters to http request",
-			[]rpcOptions{
+			[]RPCOption{
 				{
 					Key:   "key",
 					Value: "val",
@@ -477,3 +806,27 @@ func expectedConstants(t *testing.T) *Constants {
 
 	return &expectedConstants
 }
+
+type headMock struct {
+	IFace
+	head *Block
+}
+
+func (h *headMock) Head() (*Block, error) {
+	return h.head, nil
+}
+
+func Test_IFace(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(newBlockMock().handler(mockBlockResp, blankHandler)))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	clients := []IFace{gt, &headMock{head: &Block{Hash: mockBlockHash}}}
+	for _, client := range clients {
+		block, err := client.Head()
+		assert.Nil(t, err)
+		assert.NotEmpty(t, block.Hash)
+	}
+}
@@ -0,0 +1,365 @@
+/*
+Package gotezostest provides a mock Tezos node for unit testing code built on gotezos without a live node.
+
+	node := gotezostest.NewServer()
+	defer node.Close()
+
+	node.SetBalance("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "1000000")
+
+	gt, err := gotezos.New(node.URL)
+	...
+	balance, err := gt.Balance(gotezos.BlockIDHead{}, "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+*/
+package gotezostest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+
+	gotezos "github.com/goat-systems/go-tezos/v2"
+)
+
+const (
+	// BlockHash is the hash of the default head block.
+	BlockHash = "BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1"
+	// ChainID is the default chain id.
+	ChainID = "NetXdQprcVkpaWU"
+	// OperationHash is the default hash returned for injected operations.
+	OperationHash = "opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A"
+	// Protocol is the protocol of the default head block.
+	Protocol = "PsRiotumaAMotcRoDWW1bysEhQy2n1M5fy8JgRp8jjRfHGmfeA7"
+)
+
+var (
+	regBlock     = regexp.MustCompile(`^/chains/main/blocks/[^/]+$`)
+	regConstants = regexp.MustCompile(`^/chains/main/blocks/[^/]+/context/constants$`)
+	regBalance   = regexp.MustCompile(`^/chains/main/blocks/[^/]+/context/contracts/([^/]+)/balance$`)
+	regCounter   = regexp.MustCompile(`^/chains/main/blocks/[^/]+/context/contracts/([^/]+)/counter$`)
+	regManager   = regexp.MustCompile(`^/chains/main/blocks/[^/]+/context/contracts/([^/]+)/manager_key$`)
+	regInjection = regexp.MustCompile(`^/injection/operation$`)
+	regChainID   = regexp.MustCompile(`^/chains/main/chain_id$`)
+)
+
+/*
+Request -
+Description: A request received by the Server.
+*/
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   []byte
+}
+
+type route struct {
+	pattern *regexp.Regexp
+	handler http.Handler
+}
+
+/*
+Server -
+Description: A mock Tezos node. It answers the RPCs gotezos.New needs (head and constants), balances,
+counters, manager keys, the chain id and injections with canned responses that can be overridden, and any path with
+handlers registered with Handle. Unknown paths are answered with 404.
+*/
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	routes        []route
+	requests      []Request
+	head          []byte
+	constants     []byte
+	balances      map[string]string
+	counters      map[string]int
+	managerKeys   map[string]string
+	operationHash string
+	injectionErr  []byte
+}
+
+/*
+NewServer Function
+Description: Starts and returns a Server with a default head block (at level 1 of the Rio protocol) and
+default constants. The caller must Close it.
+*/
+func NewServer() *Server {
+	s := &Server{
+		balances:      map[string]string{},
+		counters:      map[string]int{},
+		managerKeys:   map[string]string{},
+		operationHash: OperationHash,
+	}
+	s.SetHead(DefaultHead())
+	s.SetConstants(DefaultConstants())
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+/*
+DefaultHead Function
+Description: Returns the head block a new Server answers with.
+*/
+func DefaultHead() gotezos.Block {
+	return gotezos.Block{
+		Protocol: Protocol,
+		ChainID:  ChainID,
+		Hash:     BlockHash,
+		Header: gotezos.Header{
+			Level:       1,
+			Predecessor: BlockHash,
+		},
+	}
+}
+
+/*
+DefaultConstants Function
+Description: Returns the constants a new Server answers with, close to those of mainnet.
+*/
+func DefaultConstants() gotezos.Constants {
+	return gotezos.Constants{
+		ProofOfWorkNonceSize:         8,
+		NonceLength:                  32,
+		MaxOperationDataLength:       32768,
+		PreservedCycles:              2,
+		BlocksPerCycle:               10800,
+		BlocksPerCommitment:          240,
+		HardGasLimitPerOperation:     "1040000",
+		HardGasLimitPerBlock:         "1386666",
+		HardStorageLimitPerOperation: "60000",
+		CostPerByte:                  "250",
+		OriginationSize:              257,
+	}
+}
+
+/*
+Handle Function
+Description: Answers requests whose path matches pattern with handler. Handlers take precedence over the
+canned responses and are tried in the order they were registered.
+
+Parameters:
+	pattern:
+		A regular expression matched against the request path.
+	handler:
+		The handler of the matching requests.
+*/
+func (s *Server) Handle(pattern string, handler http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routes = append(s.routes, route{pattern: regexp.MustCompile(pattern), handler: handler})
+}
+
+/*
+HandleJSON Function
+Description: Answers requests whose path matches pattern with v marshaled to JSON.
+
+Parameters:
+	pattern:
+		A regular expression matched against the request path.
+	v:
+		The response.
+*/
+func (s *Server) HandleJSON(pattern string, v interface{}) {
+	resp := mustMarshal(v)
+	s.Handle(pattern, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(resp)
+	})
+}
+
+/*
+HandleError Function
+Description: Answers requests whose path matches pattern with an RPC error.
+
+Parameters:
+	pattern:
+		A regular expression matched against the request path.
+	kind:
+		The kind of the error, e.g. temporary or permanent.
+	id:
+		The id of the error, e.g. proto.018-Proxford.contract.balance_too_low.
+*/
+func (s *Server) HandleError(pattern, kind, id string) {
+	s.HandleJSON(pattern, []map[string]string{{"kind": kind, "id": id, "error": id}})
+}
+
+/*
+SetHead Function
+Description: Sets the block answered for the head and for any other block id.
+
+Parameters:
+	block:
+		The block.
+*/
+func (s *Server) SetHead(block gotezos.Block) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.head = mustMarshal(block)
+}
+
+/*
+SetConstants Function
+Description: Sets the constants answered for any block id.
+
+Parameters:
+	constants:
+		The constants.
+*/
+func (s *Server) SetConstants(constants gotezos.Constants) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.constants = mustMarshal(constants)
+}
+
+/*
+SetBalance Function
+Description: Sets the balance of a contract. Balances of other contracts are 0.
+
+Parameters:
+	address:
+		The contract.
+	balance:
+		The balance in mutez.
+*/
+func (s *Server) SetBalance(address, balance string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.balances[address] = balance
+}
+
+/*
+SetCounter Function
+Description: Sets the counter of a contract. Counters of other contracts are 0.
+
+Parameters:
+	address:
+		The contract.
+	counter:
+		The counter.
+*/
+func (s *Server) SetCounter(address string, counter int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counters[address] = counter
+}
+
+/*
+SetManagerKey Function
+Description: Sets the revealed public key of an implicit account. Other accounts are not revealed.
+
+Parameters:
+	address:
+		The implicit account.
+	key:
+		The public key (e.g. edpk...).
+*/
+func (s *Server) SetManagerKey(address, key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.managerKeys[address] = key
+}
+
+/*
+SetInjectionResponse Function
+Description: Sets the operation hash answered for injected operations.
+
+Parameters:
+	operationHash:
+		The operation hash.
+*/
+func (s *Server) SetInjectionResponse(operationHash string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.operationHash = operationHash
+	s.injectionErr = nil
+}
+
+/*
+SetInjectionError Function
+Description: Makes injections fail with an RPC error.
+
+Parameters:
+	kind:
+		The kind of the error, e.g. temporary or permanent.
+	id:
+		The id of the error, e.g. proto.018-Proxford.contract.counter_in_the_past.
+*/
+func (s *Server) SetInjectionError(kind, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.injectionErr = mustMarshal([]map[string]string{{"kind": kind, "id": id, "error": id}})
+}
+
+/*
+Requests Function
+Description: Returns the requests received so far, in order.
+*/
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request{}, s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Body: body})
+	routes := append([]route{}, s.routes...)
+	s.mu.Unlock()
+
+	for _, route := range routes {
+		if route.pattern.MatchString(r.URL.Path) {
+			route.handler.ServeHTTP(w, r)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := r.URL.Path
+	switch {
+	case regBlock.MatchString(path):
+		w.Write(s.head)
+	case regConstants.MatchString(path):
+		w.Write(s.constants)
+	case regBalance.MatchString(path):
+		balance, ok := s.balances[regBalance.FindStringSubmatch(path)[1]]
+		if !ok {
+			balance = "0"
+		}
+		w.Write(mustMarshal(balance))
+	case regCounter.MatchString(path):
+		w.Write(mustMarshal(fmt.Sprint(s.counters[regCounter.FindStringSubmatch(path)[1]])))
+	case regManager.MatchString(path):
+		key, ok := s.managerKeys[regManager.FindStringSubmatch(path)[1]]
+		if !ok {
+			w.Write([]byte("null"))
+			return
+		}
+		w.Write(mustMarshal(key))
+	case regChainID.MatchString(path):
+		w.Write(mustMarshal(ChainID))
+	case regInjection.MatchString(path) && r.Method == http.MethodPost:
+		if s.injectionErr != nil {
+			w.Write(s.injectionErr)
+			return
+		}
+		w.Write(mustMarshal(s.operationHash))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func mustMarshal(v interface{}) []byte {
+	resp, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("gotezostest: could not marshal response: %s", err))
+	}
+	return resp
+}
@@ -0,0 +1,97 @@
+package gotezostest
+
+import (
+	"net/http"
+	"testing"
+
+	gotezos "github.com/goat-systems/go-tezos/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Server(t *testing.T) {
+	node := NewServer()
+	defer node.Close()
+
+	gt, err := gotezos.New(node.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, "Rio", gt.Protocol().Name)
+
+	head, err := gt.Head()
+	assert.Nil(t, err)
+	assert.Equal(t, BlockHash, head.Hash)
+
+	constants, err := gt.Constants(gotezos.BlockIDHead{})
+	assert.Nil(t, err)
+	assert.Equal(t, 10800, constants.BlocksPerCycle)
+
+	node.SetBalance("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "1000000")
+	balance, err := gt.Balance(gotezos.BlockIDHead{}, "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	assert.Nil(t, err)
+	assert.Equal(t, "1000000", *balance)
+
+	balance, err = gt.Balance(gotezos.BlockIDLevel(1), "tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q")
+	assert.Nil(t, err)
+	assert.Equal(t, "0", *balance)
+
+	node.SetCounter("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", 42)
+	counter, err := gt.Counter(gotezos.BlockIDHead{}, "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	assert.Nil(t, err)
+	assert.Equal(t, 42, *counter)
+
+	key, err := gt.ManagerKey(gotezos.BlockIDHead{}, "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	assert.Nil(t, err)
+	assert.Nil(t, key)
+
+	node.SetManagerKey("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G")
+	key, err = gt.ManagerKey(gotezos.BlockIDHead{}, "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	assert.Nil(t, err)
+	assert.Equal(t, "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G", *key)
+
+	chainID, err := gt.ChainID()
+	assert.Nil(t, err)
+	assert.Equal(t, ChainID, *chainID)
+
+	operation := "a732d3520eeaa3de"
+	hash, err := gt.InjectionOperation(&gotezos.InjectionOperationInput{Operation: &operation})
+	assert.Nil(t, err)
+	assert.Equal(t, `"`+OperationHash+`"`, string(*hash))
+
+	node.SetInjectionError("temporary", "proto.022-PsRiotum.contract.counter_in_the_past")
+	_, err = gt.InjectionOperation(&gotezos.InjectionOperationInput{Operation: &operation})
+	assert.NotNil(t, err)
+
+	requests := node.Requests()
+	last := requests[len(requests)-1]
+	assert.Equal(t, http.MethodPost, last.Method)
+	assert.Equal(t, "/injection/operation", last.Path)
+	assert.Equal(t, `"a732d3520eeaa3de"`, string(last.Body))
+}
+
+func Test_ServerHandlers(t *testing.T) {
+	node := NewServer()
+	defer node.Close()
+
+	node.HandleJSON(`/context/delegates/[^/]+/staking_balance$`, "5000")
+	node.HandleError(`/context/contracts/[^/]+/balance$`, "permanent", "proto.022-PsRiotum.contract.non_existing_contract")
+	node.Handle(`/votes/current_quorum$`, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	gt, err := gotezos.New(node.URL)
+	assert.Nil(t, err)
+
+	stakingBalance, err := gt.StakingBalance(gotezos.BlockIDHead{}, "tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q")
+	assert.Nil(t, err)
+	assert.Equal(t, "5000", *stakingBalance)
+
+	_, err = gt.Balance(gotezos.BlockIDHead{}, "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	assert.NotNil(t, err)
+
+	resp, err := http.Get(node.URL + "/chains/main/blocks/head/votes/current_quorum")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+	resp, err = http.Get(node.URL + "/unknown")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
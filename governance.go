@@ -0,0 +1,129 @@
+package gotezos
+
+import (
+	"github.com/pkg/errors"
+)
+
+const (
+	// VotingPeriodProposal is the voting period in which delegates submit and upvote proposals.
+	VotingPeriodProposal = "proposal"
+	// VotingPeriodExploration is the voting period in which delegates vote on the most upvoted proposal.
+	VotingPeriodExploration = "exploration"
+	// VotingPeriodCooldown is the voting period between the exploration and promotion votes.
+	VotingPeriodCooldown = "cooldown"
+	// VotingPeriodPromotion is the voting period in which delegates vote to adopt the proposal.
+	VotingPeriodPromotion = "promotion"
+	// VotingPeriodAdoption is the voting period at the end of which the adopted proposal activates.
+	VotingPeriodAdoption = "adoption"
+
+	// Supermajority is the share of yay ballots (of yay and nay ballots) an exploration or promotion vote needs
+	// to pass, in hundredths of a percent.
+	Supermajority = 8000
+)
+
+// votingPeriodKinds are the voting periods of an amendment, in order (Florence and later).
+var votingPeriodKinds = []string{
+	VotingPeriodProposal,
+	VotingPeriodExploration,
+	VotingPeriodCooldown,
+	VotingPeriodPromotion,
+	VotingPeriodAdoption,
+}
+
+/*
+VotingTimeline -
+Description: The current voting period and the periods left until the amendment in progress activates,
+assuming every vote passes, with the thresholds votes must reach.
+Function: func NewVotingTimeline(constants Constants, level int, info VotingPeriodInfo, participationEMA int) (*VotingTimeline, error) {}
+*/
+type VotingTimeline struct {
+	// The current voting period.
+	Current VotingTimelinePeriod
+
+	// The number of blocks of the current period left after the level of the timeline.
+	Remaining int
+
+	// The current period followed by the periods left until the adoption period, each starting the level after
+	// the previous one ends. A failed exploration or promotion vote starts a proposal period instead.
+	Periods []VotingTimelinePeriod
+
+	// The participation, in hundredths of a percent of the voting power, an exploration or promotion vote
+	// needs to pass.
+	Quorum int
+
+	// The upvotes, in hundredths of a percent of the voting power, a proposal needs to be voted on.
+	ProposalQuorum int
+
+	// The share of yay ballots, in hundredths of a percent of yay and nay ballots, a vote needs to pass.
+	Supermajority int
+}
+
+/*
+VotingTimelinePeriod -
+Description: A voting period of a VotingTimeline.
+*/
+type VotingTimelinePeriod struct {
+	Index int
+	// The kind of the period, see the VotingPeriod constants.
+	Kind string
+	// The first and last levels of the period.
+	StartLevel int
+	EndLevel   int
+}
+
+/*
+NewVotingTimeline Function
+Description: Computes the boundaries of the current and next voting periods and the thresholds of the
+amendment in progress, e.g. for a governance dashboard.
+
+Parameters:
+	constants:
+		The constants of the network, see Constants.
+	level:
+		The level info describes.
+	info:
+		The voting period info of a block, see Metadata.VotingPeriodInfo (Florence and later).
+	participationEMA:
+		The participation exponential moving average of the block, in hundredths of a percent, that the
+		quorum is computed from (RPC: ../<block_id>/votes/participation_ema).
+*/
+func NewVotingTimeline(constants Constants, level int, info VotingPeriodInfo, participationEMA int) (*VotingTimeline, error) {
+	blocksPerPeriod := constants.BlocksPerVotingPeriod
+	if constants.CyclesPerVotingPeriod > 0 {
+		blocksPerPeriod = constants.CyclesPerVotingPeriod * constants.BlocksPerCycle
+	}
+	if blocksPerPeriod <= 0 {
+		return nil, errors.New("failed to compute voting timeline: no voting period length in constants")
+	}
+
+	kind := -1
+	for i, k := range votingPeriodKinds {
+		if k == info.VotingPeriod.Kind {
+			kind = i
+		}
+	}
+	if kind < 0 {
+		return nil, errors.Errorf("failed to compute voting timeline: unknown voting period '%s'", info.VotingPeriod.Kind)
+	}
+
+	timeline := &VotingTimeline{
+		Remaining:      info.Remaining,
+		Quorum:         constants.QuorumMin + participationEMA*(constants.QuorumMax-constants.QuorumMin)/10000,
+		ProposalQuorum: constants.MinProposalQuorum,
+		Supermajority:  Supermajority,
+	}
+
+	start := level - info.Position
+	for i := kind; i < len(votingPeriodKinds); i++ {
+		timeline.Periods = append(timeline.Periods, VotingTimelinePeriod{
+			Index:      info.VotingPeriod.Index + i - kind,
+			Kind:       votingPeriodKinds[i],
+			StartLevel: start,
+			EndLevel:   start + blocksPerPeriod - 1,
+		})
+		start += blocksPerPeriod
+	}
+	timeline.Current = timeline.Periods[0]
+
+	return timeline, nil
+}
@@ -0,0 +1,105 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewVotingTimeline(t *testing.T) {
+	var constants Constants
+	err := json.Unmarshal([]byte(`{"blocks_per_cycle":16384,"cycles_per_voting_period":5,"quorum_min":2000,"quorum_max":7000,"min_proposal_quorum":500}`), &constants)
+	assert.Nil(t, err)
+
+	info := VotingPeriodInfo{VotingPeriod: VotingPeriod{Index: 110, Kind: VotingPeriodProposal, StartPosition: 4546560}, Position: 40960, Remaining: 40959}
+
+	type want struct {
+		wantErr     bool
+		containsErr string
+		timeline    *VotingTimeline
+	}
+
+	cases := []struct {
+		name      string
+		constants Constants
+		level     int
+		info      VotingPeriodInfo
+		want
+	}{
+		{
+			"is successful",
+			constants,
+			4587521,
+			info,
+			want{
+				false,
+				"",
+				&VotingTimeline{
+					Current:   VotingTimelinePeriod{Index: 110, Kind: VotingPeriodProposal, StartLevel: 4546561, EndLevel: 4628480},
+					Remaining: 40959,
+					Periods: []VotingTimelinePeriod{
+						{Index: 110, Kind: VotingPeriodProposal, StartLevel: 4546561, EndLevel: 4628480},
+						{Index: 111, Kind: VotingPeriodExploration, StartLevel: 4628481, EndLevel: 4710400},
+						{Index: 112, Kind: VotingPeriodCooldown, StartLevel: 4710401, EndLevel: 4792320},
+						{Index: 113, Kind: VotingPeriodPromotion, StartLevel: 4792321, EndLevel: 4874240},
+						{Index: 114, Kind: VotingPeriodAdoption, StartLevel: 4874241, EndLevel: 4956160},
+					},
+					Quorum:         5820,
+					ProposalQuorum: 500,
+					Supermajority:  8000,
+				},
+			},
+		},
+		{
+			"is successful with blocks per voting period",
+			Constants{BlocksPerVotingPeriod: 20480, QuorumMin: 2000, QuorumMax: 7000, MinProposalQuorum: 500},
+			1270000,
+			VotingPeriodInfo{VotingPeriod: VotingPeriod{Index: 60, Kind: VotingPeriodPromotion}, Position: 20479},
+			want{
+				false,
+				"",
+				&VotingTimeline{
+					Current: VotingTimelinePeriod{Index: 60, Kind: VotingPeriodPromotion, StartLevel: 1249521, EndLevel: 1270000},
+					Periods: []VotingTimelinePeriod{
+						{Index: 60, Kind: VotingPeriodPromotion, StartLevel: 1249521, EndLevel: 1270000},
+						{Index: 61, Kind: VotingPeriodAdoption, StartLevel: 1270001, EndLevel: 1290480},
+					},
+					Quorum:         5820,
+					ProposalQuorum: 500,
+					Supermajority:  8000,
+				},
+			},
+		},
+		{
+			"handles missing voting period length",
+			Constants{},
+			4587521,
+			info,
+			want{
+				true,
+				"no voting period length in constants",
+				nil,
+			},
+		},
+		{
+			"handles unknown voting period",
+			constants,
+			4587521,
+			VotingPeriodInfo{VotingPeriod: VotingPeriod{Kind: "testing_vote"}},
+			want{
+				true,
+				"unknown voting period 'testing_vote'",
+				nil,
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			timeline, err := NewVotingTimeline(tt.constants, tt.level, tt.info, 7640)
+			checkErr(t, tt.wantErr, tt.containsErr, err)
+			assert.Equal(t, tt.want.timeline, timeline)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package gotezos
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+/*
+HeadPollerInput -
+Description: The input for polling the heads of the chain.
+Function: func (t *GoTezos) PollHeads(ctx context.Context, input *HeadPollerInput) (<-chan *Block, <-chan error, error) {}
+*/
+type HeadPollerInput struct {
+	// How often the head is polled. Defaults to 10 seconds.
+	Interval time.Duration
+}
+
+/*
+PollHeads Function
+Description: Polls the head of the chain for nodes the monitor RPCs cannot be streamed from (e.g. behind a
+proxy buffering responses), and emits each new block exactly once, starting with the head at the time of the
+call. A head already emitted is skipped, and the levels skipped between two polls are fetched and emitted in
+order. A head replacing an emitted block of the same or a lower level (a reorganization) is emitted as well.
+Both channels are closed when polling stops: once an RPC fails or the context is done. The error channel
+receives the reason polling stopped.
+
+Parameters:
+	ctx:
+		Cancels polling.
+	input:
+		The polling interval.
+*/
+func (t *GoTezos) PollHeads(ctx context.Context, input *HeadPollerInput) (<-chan *Block, <-chan error, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid input")
+	}
+
+	interval := input.Interval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	blocks := make(chan *Block)
+	errs := make(chan error, 1)
+
+	ctx, cancel := t.untilClosed(ctx)
+	go func() {
+		defer cancel()
+		defer close(errs)
+		defer close(blocks)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		emit := func(block *Block) bool {
+			select {
+			case blocks <- block:
+				return true
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return false
+			}
+		}
+
+		var last *Block
+		for {
+			head, err := t.Head()
+			if err != nil {
+				errs <- errors.Wrap(err, "failed to poll heads")
+				return
+			}
+
+			if last == nil || head.Hash != last.Hash {
+				if last != nil {
+					for level := last.Header.Level + 1; level < head.Header.Level; level++ {
+						block, err := t.Block(BlockIDLevel(level))
+						if err != nil {
+							errs <- errors.Wrap(err, "failed to poll heads")
+							return
+						}
+
+						if !emit(block) {
+							return
+						}
+					}
+				}
+
+				if !emit(head) {
+					return
+				}
+				last = head
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return blocks, errs, nil
+}
@@ -0,0 +1,89 @@
+package gotezos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PollHeads(t *testing.T) {
+	canonical := map[int]Block{
+		100: mockChainBlock(100, "BL100"),
+		101: mockChainBlock(101, "BL101"),
+		102: mockChainBlock(102, "BL102"),
+		103: mockChainBlock(103, "BL103"),
+	}
+	fork := map[int]Block{
+		102: mockChainBlock(102, "BL102"),
+		103: mockChainBlock(103, "BL103b"),
+	}
+
+	type want struct {
+		err         bool
+		containsErr string
+		hashes      []string
+	}
+
+	cases := []struct {
+		name    string
+		handler http.Handler
+		want
+	}{
+		{
+			"is successful",
+			(&chainHandlerMock{
+				heads:  []int{100, 100, 103, 103},
+				chains: []map[int]Block{canonical, canonical, canonical, fork},
+			}).handler(blankHandler),
+			want{
+				true,
+				"context canceled",
+				[]string{"BL100", "BL101", "BL102", "BL103", "BL103b"},
+			},
+		},
+		{
+			"handles failure to get head",
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			}),
+			want{
+				true,
+				"failed to poll heads",
+				nil,
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(gtGoldenHTTPMock(tt.handler))
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			blocks, errs, err := gt.PollHeads(ctx, &HeadPollerInput{Interval: time.Millisecond})
+			assert.Nil(t, err)
+
+			var got []string
+			for block := range blocks {
+				got = append(got, block.Hash)
+				if len(got) == len(tt.want.hashes) {
+					cancel()
+				}
+			}
+			checkErr(t, tt.want.err, tt.want.containsErr, <-errs)
+			assert.Equal(t, tt.want.hashes, got)
+		})
+	}
+
+	_, _, err := (&GoTezos{}).PollHeads(context.Background(), nil)
+	checkErr(t, true, "invalid input", err)
+}
@@ -0,0 +1,93 @@
+package gotezos
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// HealthSyncedDelays is the number of block delays the head can be behind the current time for the node to be
+// considered synced, leaving room for blocks baked at a later priority or round.
+const HealthSyncedDelays = 3
+
+/*
+Health -
+Description: The health of a node, for health probes and dashboards.
+*/
+type Health struct {
+	// Whether the head is at most HealthSyncedDelays block delays old.
+	Synced    bool
+	ChainID   string
+	Protocol  string
+	HeadHash  string
+	HeadLevel int
+	// The timestamp of the head and its age when the node was queried.
+	HeadTimestamp time.Time
+	HeadAge       time.Duration
+	// The time between blocks at priority (or round) zero, minimal_block_delay since Tenderbake.
+	BlockDelay time.Duration
+	// The time the node took to return its head.
+	Latency time.Duration
+}
+
+/*
+Health Function
+Path: /chains/main/blocks/head/header (GET)
+Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-header
+Description: Returns the health of the node in a single request: the chain ID and protocol of its head, how
+old the head is compared to the block delay of the constants, and how long the request took. An error is
+returned if the node cannot be reached.
+
+Parameters:
+	ctx:
+		Cancels the request, e.g. on the timeout of the probe.
+*/
+func (t *GoTezos) Health(ctx context.Context) (*Health, error) {
+	constants := t.cachedConstants()
+	if constants == nil {
+		var err error
+		constants, err = t.Constants(BlockIDHead{})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to check health")
+		}
+	}
+
+	delay, err := blockDelays(constants)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check health")
+	}
+
+	start := time.Now()
+	resp, err := t.getContext(ctx, "/chains/main/blocks/head/header")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check health")
+	}
+	now := time.Now()
+
+	var header struct {
+		Protocol string `json:"protocol"`
+		ChainID  string `json:"chain_id"`
+		Hash     string `json:"hash"`
+		Header
+	}
+	err = json.Unmarshal(resp, &header)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal head header")
+	}
+
+	health := &Health{
+		ChainID:       header.ChainID,
+		Protocol:      header.Protocol,
+		HeadHash:      header.Hash,
+		HeadLevel:     header.Level,
+		HeadTimestamp: header.Timestamp.Time,
+		HeadAge:       now.Sub(header.Timestamp.Time),
+		BlockDelay:    delay(0),
+		Latency:       now.Sub(start),
+	}
+	health.Synced = health.HeadAge <= HealthSyncedDelays*health.BlockDelay
+
+	return health, nil
+}
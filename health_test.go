@@ -0,0 +1,85 @@
+package gotezos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func headerHandlerMock(timestamp time.Time, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chains/main/blocks/head/header" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		fmt.Fprintf(w, `{"protocol":"PtHangz2aRngywmSRGGvrcTyMbbdpWdpFKuS4uMWxg2RaH9i1qx","chain_id":"NetXdQprcVkpaWU","hash":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","level":1300000,"timestamp":"%s"}`, timestamp.UTC().Format(time.RFC3339))
+	})
+}
+
+func Test_Health(t *testing.T) {
+	type want struct {
+		err         bool
+		containsErr string
+		synced      bool
+	}
+
+	cases := []struct {
+		name        string
+		inputHanler http.Handler
+		want
+	}{
+		{
+			"handles rpc error",
+			gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			})),
+			want{true, "failed to check health", false},
+		},
+		{
+			"is synced",
+			gtGoldenHTTPMock(headerHandlerMock(time.Now().Add(-time.Minute), blankHandler)),
+			want{false, "", true},
+		},
+		{
+			"is behind",
+			gtGoldenHTTPMock(headerHandlerMock(time.Now().Add(-time.Hour), blankHandler)),
+			want{false, "", false},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.inputHanler)
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			health, err := gt.Health(context.Background())
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			if tt.want.err {
+				return
+			}
+
+			assert.Equal(t, tt.want.synced, health.Synced)
+			assert.Equal(t, "NetXdQprcVkpaWU", health.ChainID)
+			assert.Equal(t, "PtHangz2aRngywmSRGGvrcTyMbbdpWdpFKuS4uMWxg2RaH9i1qx", health.Protocol)
+			assert.Equal(t, "BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1", health.HeadHash)
+			assert.Equal(t, 1300000, health.HeadLevel)
+			assert.Equal(t, time.Minute, health.BlockDelay)
+			assert.True(t, health.HeadAge >= time.Minute)
+			assert.True(t, health.Latency > 0)
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := testGoTezos(t, gtGoldenHTTPMock(headerHandlerMock(time.Now(), blankHandler))).Health(ctx)
+	checkErr(t, true, "context canceled", err)
+}
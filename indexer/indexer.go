@@ -0,0 +1,204 @@
+/*
+Package indexer queries Tezos indexers for data the node RPC does not serve, such as the operation
+history of an account or its token balances. Indexers are used through the Indexer interface so that
+the provider can be swapped: TzKT (NewTzKT) and TzStats (NewTzStats) are supported.
+*/
+package indexer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultLimit is the number of items returned per request when no limit is given.
+	DefaultLimit = 100
+)
+
+/*
+Indexer -
+Description: The queries supported by every indexer backend.
+*/
+type Indexer interface {
+	ContractCalls(input *ContractCallsInput) ([]Operation, error)
+	OperationsByAccount(input *OperationsInput) ([]Operation, error)
+	OriginatedContracts(input *OriginatedContractsInput) ([]OriginatedContract, error)
+	TokenBalances(address string) ([]TokenBalance, error)
+}
+
+/*
+OperationsInput -
+Description: The input for the operation history of an account.
+Function: func (i Indexer) OperationsByAccount(input *OperationsInput) ([]Operation, error) {}
+*/
+type OperationsInput struct {
+	// The account (tz or KT1 address). Required.
+	Address string `validate:"required"`
+
+	// The kinds of operations to return (e.g. transaction, delegation). If empty, all kinds are returned.
+	Types []string
+
+	// The maximum number of operations to return. Defaults to DefaultLimit.
+	Limit int
+
+	// Returns the operations older than the operation with this id, for paging. See Operation.ID.
+	LastID int64
+}
+
+/*
+ContractCallsInput -
+Description: The input for the calls to a smart contract.
+Function: func (i Indexer) ContractCalls(input *ContractCallsInput) ([]Operation, error) {}
+*/
+type ContractCallsInput struct {
+	// The contract (KT1 address). Required.
+	Contract string `validate:"required"`
+
+	// Returns the calls to this entrypoint only, if set.
+	Entrypoint string
+
+	// The maximum number of calls to return. Defaults to DefaultLimit.
+	Limit int
+
+	// Returns the calls older than the call with this id, for paging. See Operation.ID.
+	LastID int64
+}
+
+/*
+OriginatedContractsInput -
+Description: The input for the contracts originated by an account.
+Function: func (i Indexer) OriginatedContracts(input *OriginatedContractsInput) ([]OriginatedContract, error) {}
+*/
+type OriginatedContractsInput struct {
+	// The account (tz or KT1 address). Required.
+	Address string `validate:"required"`
+
+	// The maximum number of contracts to return. Defaults to DefaultLimit.
+	Limit int
+
+	// Returns the contracts originated before the contract with this id, for paging. See OriginatedContract.ID.
+	LastID int64
+}
+
+/*
+OriginatedContract -
+Description: A contract originated by an account, with its origination. Contracts are sorted from the most
+recently originated to the oldest.
+*/
+type OriginatedContract struct {
+	// The KT1 address of the contract.
+	Address string
+	// The id of the origination in the indexer, for paging.
+	ID int64
+	// The hash of the origination.
+	Hash      string
+	Level     int
+	Timestamp time.Time
+	Block     string
+	// The account that sent the origination: the account itself, or a contract it called.
+	Sender string
+}
+
+/*
+Operation -
+Description: An operation involving an account, as seen by an indexer. Operations are sorted from the most
+recent to the oldest.
+*/
+type Operation struct {
+	// The id of the operation in the indexer, for paging.
+	ID         int64
+	Type       string
+	Hash       string
+	Level      int
+	Timestamp  time.Time
+	Block      string
+	Counter    int64
+	Sender     string
+	Target     string
+	Amount     int64
+	Fee        int64
+	Status     string
+	Entrypoint string
+	Parameter  json.RawMessage
+	// The new delegate of delegations.
+	Delegate string
+	// The operation as returned by the indexer.
+	Raw json.RawMessage
+}
+
+/*
+TokenBalance -
+Description: The balance of an account in a token (FA1.2 or FA2).
+*/
+type TokenBalance struct {
+	Contract string
+	TokenID  string
+	Standard string
+	// The balance in the smallest unit of the token, see the decimals of its metadata.
+	Balance  string
+	Metadata json.RawMessage
+}
+
+type client interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+func newClient() *http.Client {
+	return &http.Client{
+		Timeout: time.Second * 10,
+		Transport: &http.Transport{
+			Dial: (&net.Dialer{
+				Timeout: 10 * time.Second,
+			}).Dial,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+	}
+}
+
+func get(c client, host, path string, query url.Values, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", host, path), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to construct request")
+	}
+	req.URL.RawQuery = query.Encode()
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to complete request")
+	}
+	defer resp.Body.Close()
+
+	byts, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "could not read response body")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("response returned code %d with body %s", resp.StatusCode, string(byts))
+	}
+
+	return json.Unmarshal(byts, v)
+}
+
+func cleanseHost(host string) string {
+	host = strings.TrimSuffix(host, "/")
+	if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
+		host = fmt.Sprintf("https://%s", host)
+	}
+	return host
+}
+
+func limit(limit int) int {
+	if limit <= 0 {
+		return DefaultLimit
+	}
+	return limit
+}
@@ -0,0 +1,204 @@
+package indexer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+/*
+Cursor -
+Description: How far an OperationIterator went, to resume a scan after a restart: the id and level of the last
+operation it returned. Save it once the operations up to it are processed, and pass its LastID back in the input
+of the iterator. Token and ParseCursor convert it to and from a string, e.g. for a file or a database.
+*/
+type Cursor struct {
+	// The id of the last operation returned, 0 if none.
+	LastID int64
+	// The level of the last operation returned, 0 if none.
+	Level int
+}
+
+/*
+Token Function
+Description: Returns the cursor as a string, see ParseCursor.
+*/
+func (c Cursor) Token() string {
+	return fmt.Sprintf("%d-%d", c.LastID, c.Level)
+}
+
+/*
+ParseCursor Function
+Description: Returns the cursor of a token, see Cursor.Token. An empty token is the start of a scan.
+
+Parameters:
+	token:
+		The token, e.g. 512-2000000.
+*/
+func ParseCursor(token string) (Cursor, error) {
+	if token == "" {
+		return Cursor{}, nil
+	}
+
+	parts := strings.Split(token, "-")
+	if len(parts) != 2 {
+		return Cursor{}, errors.Errorf("invalid cursor '%s'", token)
+	}
+
+	id, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || id < 0 {
+		return Cursor{}, errors.Errorf("invalid cursor '%s': invalid id", token)
+	}
+
+	level, err := strconv.Atoi(parts[1])
+	if err != nil || level < 0 {
+		return Cursor{}, errors.Errorf("invalid cursor '%s': invalid level", token)
+	}
+
+	return Cursor{LastID: id, Level: level}, nil
+}
+
+/*
+OperationIterator -
+Description: Walks operations from the most recent to the oldest, requesting them page by page from an indexer.
+Its Cursor tells where to resume the scan from.
+
+	cursor, err := indexer.ParseCursor(saved)
+	it, err := indexer.NewOperationsIterator(tzkt, &indexer.OperationsInput{Address: address, LastID: cursor.LastID})
+	for it.Next() {
+		process(it.Value())
+		saved = it.Cursor().Token()
+	}
+	err = it.Err()
+*/
+type OperationIterator struct {
+	list     func(lastID int64, limit int) ([]Operation, error)
+	pageSize int
+	cursor   Cursor
+	page     []Operation
+	value    Operation
+	err      error
+	last     bool
+	done     bool
+}
+
+/*
+NewOperationsIterator Function
+Description: Returns an iterator over the operations an account took part in, see Indexer.OperationsByAccount.
+No request is made until Next is called.
+
+Parameters:
+	indexer:
+		The indexer, e.g. NewTzKT(TzKTMainnet).
+	input:
+		The account to scan. Address is required. Limit is the size of the pages, and the scan starts after
+		LastID, e.g. the LastID of a saved Cursor.
+*/
+func NewOperationsIterator(indexer Indexer, input *OperationsInput) (*OperationIterator, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	query := *input
+	return newOperationIterator(input.Limit, input.LastID, func(lastID int64, limit int) ([]Operation, error) {
+		query.LastID, query.Limit = lastID, limit
+		return indexer.OperationsByAccount(&query)
+	}), nil
+}
+
+/*
+NewContractCallsIterator Function
+Description: Returns an iterator over the calls to a contract, see Indexer.ContractCalls. No request is made
+until Next is called.
+
+Parameters:
+	indexer:
+		The indexer, e.g. NewTzKT(TzKTMainnet).
+	input:
+		The contract to scan. Contract is required. Limit is the size of the pages, and the scan starts after
+		LastID, e.g. the LastID of a saved Cursor.
+*/
+func NewContractCallsIterator(indexer Indexer, input *ContractCallsInput) (*OperationIterator, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	query := *input
+	return newOperationIterator(input.Limit, input.LastID, func(lastID int64, limit int) ([]Operation, error) {
+		query.LastID, query.Limit = lastID, limit
+		return indexer.ContractCalls(&query)
+	}), nil
+}
+
+func newOperationIterator(pageSize int, lastID int64, list func(lastID int64, limit int) ([]Operation, error)) *OperationIterator {
+	return &OperationIterator{
+		list:     list,
+		pageSize: limit(pageSize),
+		cursor:   Cursor{LastID: lastID},
+	}
+}
+
+/*
+Next Function
+Description: Advances the iterator to the next operation, requesting the next page when needed. Returns false
+once the operations are exhausted or an error occurred, see Err. A failed iterator keeps its cursor.
+*/
+func (i *OperationIterator) Next() bool {
+	if i.done {
+		return false
+	}
+
+	if len(i.page) == 0 {
+		if i.last {
+			i.done = true
+			return false
+		}
+
+		page, err := i.list(i.cursor.LastID, i.pageSize)
+		if err != nil {
+			i.err = errors.Wrapf(err, "failed to list operations after cursor '%s'", i.cursor.Token())
+			i.done = true
+			return false
+		}
+
+		i.last = len(page) < i.pageSize
+		i.page = page
+		if len(page) == 0 {
+			i.done = true
+			return false
+		}
+	}
+
+	i.value, i.page = i.page[0], i.page[1:]
+	i.cursor = Cursor{LastID: i.value.ID, Level: i.value.Level}
+	return true
+}
+
+/*
+Value Function
+Description: Returns the operation the iterator is at.
+*/
+func (i *OperationIterator) Value() Operation {
+	return i.value
+}
+
+/*
+Cursor Function
+Description: Returns the cursor of the operation the iterator is at, to resume the scan after it.
+*/
+func (i *OperationIterator) Cursor() Cursor {
+	return i.cursor
+}
+
+/*
+Err Function
+Description: Returns the error that stopped the iterator, if any.
+*/
+func (i *OperationIterator) Err() error {
+	return i.err
+}
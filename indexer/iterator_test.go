@@ -0,0 +1,138 @@
+package indexer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// pagingIndexerMock serves operations with ids from n down to 1, at the level of their id.
+type pagingIndexerMock struct {
+	Indexer
+	n        int64
+	failAt   int64
+	requests int
+}
+
+func (p *pagingIndexerMock) OperationsByAccount(input *OperationsInput) ([]Operation, error) {
+	return p.operations(input.LastID, input.Limit)
+}
+
+func (p *pagingIndexerMock) ContractCalls(input *ContractCallsInput) ([]Operation, error) {
+	return p.operations(input.LastID, input.Limit)
+}
+
+func (p *pagingIndexerMock) operations(lastID int64, limit int) ([]Operation, error) {
+	p.requests++
+	if lastID == 0 {
+		lastID = p.n + 1
+	}
+	if p.failAt > 0 && lastID <= p.failAt {
+		return nil, errors.New("indexer unavailable")
+	}
+
+	operations := []Operation{}
+	for id := lastID - 1; id > 0 && len(operations) < limit; id-- {
+		operations = append(operations, Operation{ID: id, Level: int(id) * 10})
+	}
+	return operations, nil
+}
+
+func operationIDs(it *OperationIterator) []int64 {
+	var ids []int64
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	return ids
+}
+
+func Test_OperationIterator(t *testing.T) {
+	type want struct {
+		err         bool
+		containsErr string
+		ids         []int64
+		requests    int
+		cursor      Cursor
+	}
+
+	cases := []struct {
+		name    string
+		indexer *pagingIndexerMock
+		input   OperationsInput
+		want
+	}{
+		{
+			"walks all pages",
+			&pagingIndexerMock{n: 5},
+			OperationsInput{Address: "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", Limit: 2},
+			want{false, "", []int64{5, 4, 3, 2, 1}, 3, Cursor{LastID: 1, Level: 10}},
+		},
+		{
+			"requests one more page when the last page is full",
+			&pagingIndexerMock{n: 4},
+			OperationsInput{Address: "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", Limit: 2},
+			want{false, "", []int64{4, 3, 2, 1}, 3, Cursor{LastID: 1, Level: 10}},
+		},
+		{
+			"resumes after last id",
+			&pagingIndexerMock{n: 5},
+			OperationsInput{Address: "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", Limit: 2, LastID: 3},
+			want{false, "", []int64{2, 1}, 2, Cursor{LastID: 1, Level: 10}},
+		},
+		{
+			"keeps cursor on failure",
+			&pagingIndexerMock{n: 5, failAt: 4},
+			OperationsInput{Address: "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", Limit: 2},
+			want{true, "failed to list operations after cursor '4-40': indexer unavailable", []int64{5, 4}, 2, Cursor{LastID: 4, Level: 40}},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			it, err := NewOperationsIterator(tt.indexer, &tt.input)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tt.want.ids, operationIDs(it))
+			checkErr(t, tt.want.err, tt.want.containsErr, it.Err())
+			assert.Equal(t, tt.want.requests, tt.indexer.requests)
+			assert.Equal(t, tt.want.cursor, it.Cursor())
+			assert.False(t, it.Next())
+		})
+	}
+
+	_, err := NewOperationsIterator(&pagingIndexerMock{}, &OperationsInput{})
+	checkErr(t, true, "invalid input", err)
+}
+
+func Test_ContractCallsIterator(t *testing.T) {
+	server := httptest.NewServer(indexerHandlerMock(t, "/v1/operations/transactions", map[string]string{"id.lt": "600", "limit": "10"}, http.StatusOK, mockTzKTCalls))
+	defer server.Close()
+
+	it, err := NewContractCallsIterator(NewTzKT(server.URL), &ContractCallsInput{Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", Limit: 10, LastID: 600})
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{512}, operationIDs(it))
+	assert.Nil(t, it.Err())
+	assert.Equal(t, "512-2000000", it.Cursor().Token())
+
+	_, err = NewContractCallsIterator(NewTzKT(server.URL), &ContractCallsInput{})
+	checkErr(t, true, "invalid input", err)
+}
+
+func Test_ParseCursor(t *testing.T) {
+	cursor, err := ParseCursor("512-2000000")
+	assert.Nil(t, err)
+	assert.Equal(t, Cursor{LastID: 512, Level: 2000000}, cursor)
+	assert.Equal(t, "512-2000000", cursor.Token())
+
+	cursor, err = ParseCursor("")
+	assert.Nil(t, err)
+	assert.Equal(t, Cursor{}, cursor)
+
+	for _, token := range []string{"512", "a-1", "1-b", "-1-1"} {
+		_, err = ParseCursor(token)
+		assert.NotNil(t, err, token)
+	}
+}
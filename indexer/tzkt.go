@@ -0,0 +1,442 @@
+package indexer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	gotezos "github.com/goat-systems/go-tezos/v2"
+	"github.com/pkg/errors"
+)
+
+// TzKTMainnet is the public TzKT API of mainnet.
+const TzKTMainnet = "https://api.tzkt.io"
+
+var _ Indexer = &TzKT{}
+
+var _ gotezos.BigMapLister = &TzKT{}
+
+/*
+TzKT Struct
+Description: An Indexer backed by the TzKT API (https://api.tzkt.io).
+*/
+type TzKT struct {
+	client client
+	host   string
+}
+
+/*
+Reward -
+Description: The rewards of a delegator (or of a baker, delegating to itself) for a cycle, as computed by TzKT.
+*/
+type Reward struct {
+	Cycle int
+	Baker string
+	// The balance delegated to (and staked with) the baker, in mutez.
+	Balance int64
+	// The staking balance, or baking power, of the baker in mutez.
+	StakingBalance int64
+	// The rewards as returned by TzKT, of which fields change with protocols.
+	Raw json.RawMessage
+}
+
+type tzktAlias struct {
+	Address string `json:"address"`
+}
+
+type tzktOperation struct {
+	ID          int64             `json:"id"`
+	Type        string            `json:"type"`
+	Hash        string            `json:"hash"`
+	Level       int               `json:"level"`
+	Timestamp   gotezos.Timestamp `json:"timestamp"`
+	Block       string            `json:"block"`
+	Counter     int64             `json:"counter"`
+	Sender      *tzktAlias        `json:"sender"`
+	Target      *tzktAlias        `json:"target"`
+	NewDelegate *tzktAlias        `json:"newDelegate"`
+	Amount      int64             `json:"amount"`
+	BakerFee    int64             `json:"bakerFee"`
+	Status      string            `json:"status"`
+	Parameter   *tzktParameter    `json:"parameter"`
+	Raw         json.RawMessage   `json:"-"`
+}
+
+type tzktOrigination struct {
+	tzktOperation
+	OriginatedContract *tzktAlias `json:"originatedContract"`
+}
+
+type tzktParameter struct {
+	Entrypoint string          `json:"entrypoint"`
+	Value      json.RawMessage `json:"value"`
+}
+
+type tzktTokenBalance struct {
+	Token struct {
+		TokenID  string          `json:"tokenId"`
+		Standard string          `json:"standard"`
+		Metadata json.RawMessage `json:"metadata"`
+		Contract tzktAlias       `json:"contract"`
+	} `json:"token"`
+	Balance string `json:"balance"`
+}
+
+type tzktBigMapKey struct {
+	Hash  string            `json:"hash"`
+	Key   gotezos.Micheline `json:"key"`
+	Value gotezos.Micheline `json:"value"`
+}
+
+type tzktReward struct {
+	Cycle            int       `json:"cycle"`
+	Baker            tzktAlias `json:"baker"`
+	Balance          *int64    `json:"balance"`
+	DelegatedBalance int64     `json:"delegatedBalance"`
+	StakedBalance    int64     `json:"stakedBalance"`
+	StakingBalance   *int64    `json:"stakingBalance"`
+	BakingPower      int64     `json:"bakingPower"`
+}
+
+/*
+NewTzKT Function
+Description: Returns a TzKT indexer.
+
+Parameters:
+	host:
+		The TzKT API, e.g. TzKTMainnet.
+*/
+func NewTzKT(host string) *TzKT {
+	return &TzKT{
+		client: newClient(),
+		host:   cleanseHost(host),
+	}
+}
+
+/*
+SetClient Function
+Description: Overrides the http.Client of the indexer.
+
+Parameters:
+	client:
+		A pointer to an http.Client.
+*/
+func (t *TzKT) SetClient(client *http.Client) {
+	t.client = client
+}
+
+/*
+OperationsByAccount Function
+Path: /v1/accounts/<address>/operations (GET)
+Link: https://api.tzkt.io/#operation/Accounts_GetOperations
+Description: Returns the operations an account took part in, from the most recent.
+
+Parameters:
+	input:
+		The account and paging of the query. Address is required.
+*/
+func (t *TzKT) OperationsByAccount(input *OperationsInput) ([]Operation, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(limit(input.Limit)))
+	query.Set("sort", "1")
+	if len(input.Types) > 0 {
+		query.Set("type", strings.Join(input.Types, ","))
+	}
+	if input.LastID > 0 {
+		query.Set("lastId", strconv.FormatInt(input.LastID, 10))
+	}
+
+	operations, err := t.operations(fmt.Sprintf("/v1/accounts/%s/operations", input.Address), query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get operations of '%s'", input.Address)
+	}
+
+	return operations, nil
+}
+
+/*
+ContractCalls Function
+Path: /v1/operations/transactions (GET)
+Link: https://api.tzkt.io/#operation/Operations_GetTransactions
+Description: Returns the transactions to a contract, from the most recent.
+
+Parameters:
+	input:
+		The contract and paging of the query. Contract is required.
+*/
+func (t *TzKT) ContractCalls(input *ContractCallsInput) ([]Operation, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	query := url.Values{}
+	query.Set("target", input.Contract)
+	query.Set("limit", strconv.Itoa(limit(input.Limit)))
+	query.Set("sort.desc", "id")
+	if input.Entrypoint != "" {
+		query.Set("entrypoint", input.Entrypoint)
+	}
+	if input.LastID > 0 {
+		query.Set("id.lt", strconv.FormatInt(input.LastID, 10))
+	}
+
+	operations, err := t.operations("/v1/operations/transactions", query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get calls to '%s'", input.Contract)
+	}
+
+	return operations, nil
+}
+
+/*
+OriginatedContracts Function
+Path: /v1/operations/originations (GET)
+Link: https://api.tzkt.io/#operation/Operations_GetOriginations
+Description: Returns the contracts originated by an account, directly or through the contracts it called, and
+those it manages (contracts originated before Babylon), from the most recent.
+
+Parameters:
+	input:
+		The account and paging of the query. Address is required.
+*/
+func (t *TzKT) OriginatedContracts(input *OriginatedContractsInput) ([]OriginatedContract, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	query := url.Values{}
+	query.Set("anyof.sender.initiator.contractManager", input.Address)
+	query.Set("status", "applied")
+	query.Set("limit", strconv.Itoa(limit(input.Limit)))
+	query.Set("sort.desc", "id")
+	if input.LastID > 0 {
+		query.Set("id.lt", strconv.FormatInt(input.LastID, 10))
+	}
+
+	var resp []tzktOrigination
+	err = get(t.client, t.host, "/v1/operations/originations", query, &resp)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get contracts originated by '%s'", input.Address)
+	}
+
+	contracts := []OriginatedContract{}
+	for _, o := range resp {
+		if o.OriginatedContract == nil {
+			continue
+		}
+
+		contract := OriginatedContract{
+			Address:   o.OriginatedContract.Address,
+			ID:        o.ID,
+			Hash:      o.Hash,
+			Level:     o.Level,
+			Timestamp: o.Timestamp.Time,
+			Block:     o.Block,
+		}
+		if o.Sender != nil {
+			contract.Sender = o.Sender.Address
+		}
+
+		contracts = append(contracts, contract)
+	}
+
+	return contracts, nil
+}
+
+/*
+TokenBalances Function
+Path: /v1/tokens/balances (GET)
+Link: https://api.tzkt.io/#operation/Tokens_GetTokenBalances
+Description: Returns the non-zero token balances of an account.
+
+Parameters:
+	address:
+		The account.
+*/
+func (t *TzKT) TokenBalances(address string) ([]TokenBalance, error) {
+	query := url.Values{}
+	query.Set("account", address)
+	query.Set("balance.ne", "0")
+	query.Set("limit", "10000")
+
+	var resp []tzktTokenBalance
+	err := get(t.client, t.host, "/v1/tokens/balances", query, &resp)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get token balances of '%s'", address)
+	}
+
+	balances := []TokenBalance{}
+	for _, b := range resp {
+		balances = append(balances, TokenBalance{
+			Contract: b.Token.Contract.Address,
+			TokenID:  b.Token.TokenID,
+			Standard: b.Token.Standard,
+			Balance:  b.Balance,
+			Metadata: b.Token.Metadata,
+		})
+	}
+
+	return balances, nil
+}
+
+/*
+Rewards Function
+Path: /v1/rewards/delegators/<address> (GET)
+Link: https://api.tzkt.io/#operation/Rewards_GetDelegatorRewards
+Description: Returns the rewards of a delegator per cycle, from the most recent cycle.
+
+Parameters:
+	address:
+		The delegator.
+	cycle:
+		Returns the rewards of this cycle only, if not nil.
+*/
+func (t *TzKT) Rewards(address string, cycle *int) ([]Reward, error) {
+	query := url.Values{}
+	if cycle != nil {
+		query.Set("cycle", strconv.Itoa(*cycle))
+	}
+
+	var raw []json.RawMessage
+	err := get(t.client, t.host, fmt.Sprintf("/v1/rewards/delegators/%s", address), query, &raw)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get rewards of '%s'", address)
+	}
+
+	rewards := []Reward{}
+	for _, r := range raw {
+		var reward tzktReward
+		err = json.Unmarshal(r, &reward)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal rewards of '%s'", address)
+		}
+
+		// Protocols with staking split balances into delegated and staked tez.
+		balance := reward.DelegatedBalance + reward.StakedBalance
+		if reward.Balance != nil {
+			balance = *reward.Balance
+		}
+
+		stakingBalance := reward.BakingPower
+		if reward.StakingBalance != nil {
+			stakingBalance = *reward.StakingBalance
+		}
+
+		rewards = append(rewards, Reward{
+			Cycle:          reward.Cycle,
+			Baker:          reward.Baker.Address,
+			Balance:        balance,
+			StakingBalance: stakingBalance,
+			Raw:            r,
+		})
+	}
+
+	return rewards, nil
+}
+
+/*
+BigMapEntries Function
+Path: /v1/bigmaps/<id>/keys (GET)
+Link: https://api.tzkt.io/#operation/BigMaps_GetKeys
+Description: Returns at most limit of the active keys of a big map and their values, starting at offset.
+Keys and values are Micheline as stored by TzKT, gotezos.UnparseData converts them to readable form.
+TzKT implements gotezos.BigMapLister so that gotezos.BigMapIterator can fall back on it.
+
+Parameters:
+	bigMap:
+		The ID of the big map.
+	offset:
+		The number of keys to skip.
+	limit:
+		The maximum number of keys to return.
+*/
+func (t *TzKT) BigMapEntries(bigMap string, offset, limit int) ([]gotezos.BigMapEntry, error) {
+	query := url.Values{}
+	query.Set("active", "true")
+	query.Set("micheline", "2")
+	query.Set("sort.asc", "id")
+	query.Set("offset", strconv.Itoa(offset))
+	query.Set("limit", strconv.Itoa(limit))
+
+	var resp []tzktBigMapKey
+	err := get(t.client, t.host, fmt.Sprintf("/v1/bigmaps/%s/keys", bigMap), query, &resp)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get keys of big map '%s'", bigMap)
+	}
+
+	entries := []gotezos.BigMapEntry{}
+	for i := range resp {
+		entries = append(entries, gotezos.BigMapEntry{
+			KeyHash: resp[i].Hash,
+			Key:     &resp[i].Key,
+			Value:   resp[i].Value,
+		})
+	}
+
+	return entries, nil
+}
+
+func (t *TzKT) operations(path string, query url.Values) ([]Operation, error) {
+	var raw []json.RawMessage
+	err := get(t.client, t.host, path, query, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	operations := []Operation{}
+	for _, r := range raw {
+		var op tzktOperation
+		err = json.Unmarshal(r, &op)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal operations")
+		}
+		op.Raw = r
+
+		operations = append(operations, op.operation())
+	}
+
+	return operations, nil
+}
+
+func (o *tzktOperation) operation() Operation {
+	operation := Operation{
+		ID:        o.ID,
+		Type:      o.Type,
+		Hash:      o.Hash,
+		Level:     o.Level,
+		Timestamp: o.Timestamp.Time,
+		Block:     o.Block,
+		Counter:   o.Counter,
+		Amount:    o.Amount,
+		Fee:       o.BakerFee,
+		Status:    o.Status,
+		Raw:       o.Raw,
+	}
+
+	if o.Sender != nil {
+		operation.Sender = o.Sender.Address
+	}
+	if o.Target != nil {
+		operation.Target = o.Target.Address
+	}
+	if o.NewDelegate != nil {
+		operation.Delegate = o.NewDelegate.Address
+	}
+	if o.Parameter != nil {
+		operation.Entrypoint = o.Parameter.Entrypoint
+		operation.Parameter = o.Parameter.Value
+	}
+
+	return operation
+}
@@ -0,0 +1,288 @@
+package indexer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gotezos "github.com/goat-systems/go-tezos/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	mockTzKTOperations = []byte(`[
+		{"type":"transaction","id":512,"level":2000000,"timestamp":"2021-12-24T10:00:00Z","block":"BLTbZ3U7kHwGqSUdq3e5QMyn2aJu9uZkMdQAtRHGmFf46TKdcfM","hash":"opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A","counter":11,"sender":{"address":"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"},"target":{"address":"KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg"},"amount":1500,"bakerFee":420,"status":"applied","parameter":{"entrypoint":"transfer","value":{"to":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}}},
+		{"type":"delegation","id":256,"level":1999000,"timestamp":"2021-12-23T10:00:00Z","block":"BMWVEwEYw9m5iaHzqxDfkPzZTV4rhkSouRh3DkVMVGkxZ3EVaNs","hash":"oo1Tqbv3sLdH4MUnrCmWxn8DqMSqBYpWaDhbULcf6eWRnNLnxgi","counter":10,"sender":{"address":"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"},"newDelegate":{"address":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"},"bakerFee":380,"status":"applied"}
+	]`)
+	mockTzKTCalls = []byte(`[
+		{"type":"transaction","id":512,"level":2000000,"timestamp":"2021-12-24T10:00:00Z","block":"BLTbZ3U7kHwGqSUdq3e5QMyn2aJu9uZkMdQAtRHGmFf46TKdcfM","hash":"opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A","counter":11,"sender":{"address":"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"},"target":{"address":"KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg"},"amount":1500,"bakerFee":420,"status":"applied","parameter":{"entrypoint":"transfer","value":{"to":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}}}
+	]`)
+	mockTzKTTokenBalances = []byte(`[
+		{"id":1,"account":{"address":"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"},"token":{"id":7,"contract":{"address":"KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg"},"tokenId":"0","standard":"fa2","metadata":{"symbol":"TKN","decimals":"6"}},"balance":"1000000"}
+	]`)
+	mockTzKTBigMapKeys = []byte(`[
+		{"id":1,"active":true,"hash":"exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC","key":{"int":"0"},"value":{"bytes":"0000da6b4273731e9a26903c3fba93a8004ac0a12565"},"firstLevel":1,"lastLevel":2,"updates":1}
+	]`)
+	mockTzKTOriginations = []byte(`[
+		{"type":"origination","id":768,"level":2100000,"timestamp":"2022-01-30T10:00:00Z","block":"BLTbZ3U7kHwGqSUdq3e5QMyn2aJu9uZkMdQAtRHGmFf46TKdcfM","hash":"opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A","counter":12,"sender":{"address":"KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg"},"initiator":{"address":"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"},"status":"applied","originatedContract":{"kind":"smart_contract","address":"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"}},
+		{"type":"origination","id":640,"level":2050000,"timestamp":"2022-01-12T10:00:00Z","block":"BMWVEwEYw9m5iaHzqxDfkPzZTV4rhkSouRh3DkVMVGkxZ3EVaNs","hash":"oo1Tqbv3sLdH4MUnrCmWxn8DqMSqBYpWaDhbULcf6eWRnNLnxgi","counter":11,"sender":{"address":"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"},"status":"applied","originatedContract":{"kind":"smart_contract","address":"KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg"}}
+	]`)
+	mockTzKTRewards = []byte(`[
+		{"cycle":700,"baker":{"address":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"},"delegatedBalance":1000,"stakedBalance":500,"bakingPower":9000000},
+		{"cycle":400,"baker":{"address":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"},"balance":1200,"stakingBalance":8000000}
+	]`)
+)
+
+func indexerHandlerMock(t *testing.T, path string, query map[string]string, status int, resp []byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, path, r.URL.Path)
+		for key, value := range query {
+			assert.Equal(t, value, r.URL.Query().Get(key))
+		}
+
+		w.WriteHeader(status)
+		w.Write(resp)
+	})
+}
+
+func Test_TzKTOperationsByAccount(t *testing.T) {
+	type want struct {
+		err         bool
+		containsErr string
+		operations  []Operation
+	}
+
+	path := "/v1/accounts/tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK/operations"
+
+	cases := []struct {
+		name         string
+		inputHandler http.Handler
+		input        OperationsInput
+		want
+	}{
+		{
+			"returns invalid input",
+			indexerHandlerMock(t, path, nil, http.StatusOK, mockTzKTOperations),
+			OperationsInput{},
+			want{
+				true,
+				"invalid input",
+				nil,
+			},
+		},
+		{
+			"returns http error",
+			indexerHandlerMock(t, path, nil, http.StatusBadRequest, []byte(`{"code":400}`)),
+			OperationsInput{Address: "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"},
+			want{
+				true,
+				"failed to get operations",
+				nil,
+			},
+		},
+		{
+			"fails to unmarshal",
+			indexerHandlerMock(t, path, nil, http.StatusOK, []byte(`[{"id":"junk"}]`)),
+			OperationsInput{Address: "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"},
+			want{
+				true,
+				"failed to unmarshal operations",
+				nil,
+			},
+		},
+		{
+			"is successful",
+			indexerHandlerMock(t, path, map[string]string{"limit": "2", "type": "transaction,delegation", "lastId": "1024", "sort": "1"}, http.StatusOK, mockTzKTOperations),
+			OperationsInput{Address: "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", Types: []string{"transaction", "delegation"}, Limit: 2, LastID: 1024},
+			want{
+				false,
+				"",
+				[]Operation{
+					{
+						ID:         512,
+						Type:       "transaction",
+						Hash:       "opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A",
+						Level:      2000000,
+						Timestamp:  time.Date(2021, 12, 24, 10, 0, 0, 0, time.UTC),
+						Block:      "BLTbZ3U7kHwGqSUdq3e5QMyn2aJu9uZkMdQAtRHGmFf46TKdcfM",
+						Counter:    11,
+						Sender:     "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK",
+						Target:     "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg",
+						Amount:     1500,
+						Fee:        420,
+						Status:     "applied",
+						Entrypoint: "transfer",
+					},
+					{
+						ID:        256,
+						Type:      "delegation",
+						Hash:      "oo1Tqbv3sLdH4MUnrCmWxn8DqMSqBYpWaDhbULcf6eWRnNLnxgi",
+						Level:     1999000,
+						Timestamp: time.Date(2021, 12, 23, 10, 0, 0, 0, time.UTC),
+						Block:     "BMWVEwEYw9m5iaHzqxDfkPzZTV4rhkSouRh3DkVMVGkxZ3EVaNs",
+						Counter:   10,
+						Sender:    "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK",
+						Fee:       380,
+						Status:    "applied",
+						Delegate:  "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.inputHandler)
+			defer server.Close()
+
+			operations, err := NewTzKT(server.URL).OperationsByAccount(&tt.input)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+
+			for i := range operations {
+				assert.NotEmpty(t, operations[i].Raw)
+				operations[i].Raw = nil
+				if operations[i].Parameter != nil {
+					assert.JSONEq(t, `{"to":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}`, string(operations[i].Parameter))
+					operations[i].Parameter = nil
+				}
+			}
+			assert.Equal(t, tt.want.operations, operations)
+		})
+	}
+}
+
+func Test_TzKTTokenBalances(t *testing.T) {
+	path := "/v1/tokens/balances"
+	query := map[string]string{"account": "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", "balance.ne": "0"}
+
+	server := httptest.NewServer(indexerHandlerMock(t, path, query, http.StatusOK, mockTzKTTokenBalances))
+	defer server.Close()
+
+	balances, err := NewTzKT(server.URL).TokenBalances("tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK")
+	assert.Nil(t, err)
+	assert.Len(t, balances, 1)
+	assert.Equal(t, "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", balances[0].Contract)
+	assert.Equal(t, "0", balances[0].TokenID)
+	assert.Equal(t, "fa2", balances[0].Standard)
+	assert.Equal(t, "1000000", balances[0].Balance)
+	assert.JSONEq(t, `{"symbol":"TKN","decimals":"6"}`, string(balances[0].Metadata))
+
+	server = httptest.NewServer(indexerHandlerMock(t, path, query, http.StatusInternalServerError, nil))
+	defer server.Close()
+
+	_, err = NewTzKT(server.URL).TokenBalances("tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK")
+	checkErr(t, true, "failed to get token balances", err)
+}
+
+func Test_TzKTRewards(t *testing.T) {
+	path := "/v1/rewards/delegators/tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"
+	cycle := 700
+
+	server := httptest.NewServer(indexerHandlerMock(t, path, map[string]string{"cycle": "700"}, http.StatusOK, mockTzKTRewards))
+	defer server.Close()
+
+	rewards, err := NewTzKT(server.URL).Rewards("tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", &cycle)
+	assert.Nil(t, err)
+	assert.Len(t, rewards, 2)
+
+	assert.Equal(t, 700, rewards[0].Cycle)
+	assert.Equal(t, "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", rewards[0].Baker)
+	assert.Equal(t, int64(1500), rewards[0].Balance)
+	assert.Equal(t, int64(9000000), rewards[0].StakingBalance)
+
+	assert.Equal(t, 400, rewards[1].Cycle)
+	assert.Equal(t, int64(1200), rewards[1].Balance)
+	assert.Equal(t, int64(8000000), rewards[1].StakingBalance)
+	assert.NotEmpty(t, rewards[1].Raw)
+}
+
+func checkErr(t *testing.T, wantErr bool, errContains string, err error) {
+	if wantErr {
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), errContains)
+	} else {
+		assert.Nil(t, err)
+	}
+}
+
+func Test_TzKTContractCalls(t *testing.T) {
+	path := "/v1/operations/transactions"
+	query := map[string]string{"target": "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", "entrypoint": "transfer", "id.lt": "1024", "limit": "100", "sort.desc": "id"}
+
+	server := httptest.NewServer(indexerHandlerMock(t, path, query, http.StatusOK, mockTzKTCalls))
+	defer server.Close()
+
+	calls, err := NewTzKT(server.URL).ContractCalls(&ContractCallsInput{Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", Entrypoint: "transfer", LastID: 1024})
+	assert.Nil(t, err)
+	assert.Len(t, calls, 1)
+	assert.Equal(t, int64(512), calls[0].ID)
+	assert.Equal(t, "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", calls[0].Target)
+	assert.Equal(t, "transfer", calls[0].Entrypoint)
+	assert.Equal(t, int64(1500), calls[0].Amount)
+
+	_, err = NewTzKT(server.URL).ContractCalls(&ContractCallsInput{})
+	checkErr(t, true, "invalid input", err)
+}
+
+func Test_TzKTOriginatedContracts(t *testing.T) {
+	path := "/v1/operations/originations"
+	query := map[string]string{"anyof.sender.initiator.contractManager": "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", "status": "applied", "id.lt": "1024", "limit": "100", "sort.desc": "id"}
+
+	server := httptest.NewServer(indexerHandlerMock(t, path, query, http.StatusOK, mockTzKTOriginations))
+	defer server.Close()
+
+	contracts, err := NewTzKT(server.URL).OriginatedContracts(&OriginatedContractsInput{Address: "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", LastID: 1024})
+	assert.Nil(t, err)
+	assert.Equal(t, []OriginatedContract{
+		{
+			Address:   "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn",
+			ID:        768,
+			Hash:      "opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A",
+			Level:     2100000,
+			Timestamp: time.Date(2022, 1, 30, 10, 0, 0, 0, time.UTC),
+			Block:     "BLTbZ3U7kHwGqSUdq3e5QMyn2aJu9uZkMdQAtRHGmFf46TKdcfM",
+			Sender:    "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg",
+		},
+		{
+			Address:   "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg",
+			ID:        640,
+			Hash:      "oo1Tqbv3sLdH4MUnrCmWxn8DqMSqBYpWaDhbULcf6eWRnNLnxgi",
+			Level:     2050000,
+			Timestamp: time.Date(2022, 1, 12, 10, 0, 0, 0, time.UTC),
+			Block:     "BMWVEwEYw9m5iaHzqxDfkPzZTV4rhkSouRh3DkVMVGkxZ3EVaNs",
+			Sender:    "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK",
+		},
+	}, contracts)
+
+	_, err = NewTzKT(server.URL).OriginatedContracts(&OriginatedContractsInput{})
+	checkErr(t, true, "invalid input", err)
+
+	server = httptest.NewServer(indexerHandlerMock(t, path, nil, http.StatusInternalServerError, nil))
+	defer server.Close()
+
+	_, err = NewTzKT(server.URL).OriginatedContracts(&OriginatedContractsInput{Address: "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"})
+	checkErr(t, true, "failed to get contracts originated by 'tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK'", err)
+}
+
+func Test_TzKTBigMapEntries(t *testing.T) {
+	path := "/v1/bigmaps/511/keys"
+	query := map[string]string{"active": "true", "micheline": "2", "offset": "100", "limit": "50"}
+
+	server := httptest.NewServer(indexerHandlerMock(t, path, query, http.StatusOK, mockTzKTBigMapKeys))
+	defer server.Close()
+
+	entries, err := NewTzKT(server.URL).BigMapEntries("511", 100, 50)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC", entries[0].KeyHash)
+	assert.Equal(t, "0", entries[0].Key.Int.String())
+	assert.Equal(t, gotezos.MichelineKindBytes, entries[0].Value.Kind)
+
+	server = httptest.NewServer(indexerHandlerMock(t, path, nil, http.StatusNotFound, []byte(`{"code":404}`)))
+	defer server.Close()
+
+	_, err = NewTzKT(server.URL).BigMapEntries("511", 0, 50)
+	checkErr(t, true, "failed to get keys of big map '511'", err)
+}
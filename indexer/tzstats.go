@@ -0,0 +1,296 @@
+package indexer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	gotezos "github.com/goat-systems/go-tezos/v2"
+	"github.com/pkg/errors"
+)
+
+// TzStatsMainnet is the public TzStats (Blockwatch) API of mainnet.
+const TzStatsMainnet = "https://api.tzstats.com"
+
+var _ Indexer = &TzStats{}
+
+/*
+TzStats Struct
+Description: An Indexer backed by the TzStats API of Blockwatch (https://api.tzstats.com). TzStats
+returns amounts in tez, they are converted to mutez.
+*/
+type TzStats struct {
+	client client
+	host   string
+}
+
+type tzstatsOperation struct {
+	ID         int64              `json:"id"`
+	Type       string             `json:"type"`
+	Hash       string             `json:"hash"`
+	Height     int                `json:"height"`
+	Time       gotezos.Timestamp  `json:"time"`
+	Block      string             `json:"block"`
+	Counter    int64              `json:"counter"`
+	Sender     string             `json:"sender"`
+	Receiver   string             `json:"receiver"`
+	Delegate   string             `json:"delegate"`
+	Volume     float64            `json:"volume"`
+	Fee        float64            `json:"fee"`
+	Status     string             `json:"status"`
+	Parameters *tzstatsParameters `json:"parameters"`
+	Raw        json.RawMessage    `json:"-"`
+}
+
+type tzstatsParameters struct {
+	Entrypoint string          `json:"entrypoint"`
+	Value      json.RawMessage `json:"value"`
+}
+
+type tzstatsTokenBalance struct {
+	Contract string          `json:"contract"`
+	TokenID  string          `json:"token_id"`
+	Type     string          `json:"type"`
+	Balance  string          `json:"balance"`
+	Metadata json.RawMessage `json:"metadata"`
+}
+
+/*
+NewTzStats Function
+Description: Returns a TzStats indexer.
+
+Parameters:
+	host:
+		The TzStats API, e.g. TzStatsMainnet.
+*/
+func NewTzStats(host string) *TzStats {
+	return &TzStats{
+		client: newClient(),
+		host:   cleanseHost(host),
+	}
+}
+
+/*
+SetClient Function
+Description: Overrides the http.Client of the indexer.
+
+Parameters:
+	client:
+		A pointer to an http.Client.
+*/
+func (t *TzStats) SetClient(client *http.Client) {
+	t.client = client
+}
+
+/*
+OperationsByAccount Function
+Path: /explorer/account/<address>/operations (GET)
+Link: https://tzstats.com/docs/api#explorer-endpoints
+Description: Returns the operations an account took part in, from the most recent.
+
+Parameters:
+	input:
+		The account and paging of the query. Address is required.
+*/
+func (t *TzStats) OperationsByAccount(input *OperationsInput) ([]Operation, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	query := t.pagingQuery(input.Limit, input.LastID)
+	if len(input.Types) > 0 {
+		query.Set("type", strings.Join(input.Types, ","))
+	}
+
+	operations, err := t.operations(fmt.Sprintf("/explorer/account/%s/operations", input.Address), query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get operations of '%s'", input.Address)
+	}
+
+	return operations, nil
+}
+
+/*
+ContractCalls Function
+Path: /explorer/contract/<address>/calls (GET)
+Link: https://tzstats.com/docs/api#explorer-endpoints
+Description: Returns the calls to a contract, from the most recent.
+
+Parameters:
+	input:
+		The contract and paging of the query. Contract is required.
+*/
+func (t *TzStats) ContractCalls(input *ContractCallsInput) ([]Operation, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	query := t.pagingQuery(input.Limit, input.LastID)
+	if input.Entrypoint != "" {
+		query.Set("entrypoint", input.Entrypoint)
+	}
+
+	operations, err := t.operations(fmt.Sprintf("/explorer/contract/%s/calls", input.Contract), query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get calls to '%s'", input.Contract)
+	}
+
+	return operations, nil
+}
+
+/*
+OriginatedContracts Function
+Path: /explorer/account/<address>/operations (GET)
+Link: https://tzstats.com/docs/api#explorer-endpoints
+Description: Returns the contracts originated by an account, from the most recent. The receiver of an
+origination is the contract it originated. Failed originations are skipped, so fewer contracts than the limit
+may be returned before the last page.
+
+Parameters:
+	input:
+		The account and paging of the query. Address is required.
+*/
+func (t *TzStats) OriginatedContracts(input *OriginatedContractsInput) ([]OriginatedContract, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	query := t.pagingQuery(input.Limit, input.LastID)
+	query.Set("type", "origination")
+
+	operations, err := t.operations(fmt.Sprintf("/explorer/account/%s/operations", input.Address), query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get contracts originated by '%s'", input.Address)
+	}
+
+	contracts := []OriginatedContract{}
+	for _, o := range operations {
+		// The operations of a contract include its own origination.
+		if o.Status != "applied" || o.Target == "" || o.Target == input.Address {
+			continue
+		}
+
+		contracts = append(contracts, OriginatedContract{
+			Address:   o.Target,
+			ID:        o.ID,
+			Hash:      o.Hash,
+			Level:     o.Level,
+			Timestamp: o.Timestamp,
+			Block:     o.Block,
+			Sender:    o.Sender,
+		})
+	}
+
+	return contracts, nil
+}
+
+/*
+TokenBalances Function
+Path: /explorer/account/<address>/balances (GET)
+Link: https://tzstats.com/docs/api#explorer-endpoints
+Description: Returns the non-zero token balances of an account.
+
+Parameters:
+	address:
+		The account.
+*/
+func (t *TzStats) TokenBalances(address string) ([]TokenBalance, error) {
+	query := url.Values{}
+	query.Set("limit", "10000")
+
+	var resp []tzstatsTokenBalance
+	err := get(t.client, t.host, fmt.Sprintf("/explorer/account/%s/balances", address), query, &resp)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get token balances of '%s'", address)
+	}
+
+	balances := []TokenBalance{}
+	for _, b := range resp {
+		if b.Balance == "" || b.Balance == "0" {
+			continue
+		}
+
+		balances = append(balances, TokenBalance{
+			Contract: b.Contract,
+			TokenID:  b.TokenID,
+			Standard: b.Type,
+			Balance:  b.Balance,
+			Metadata: b.Metadata,
+		})
+	}
+
+	return balances, nil
+}
+
+func (t *TzStats) pagingQuery(limitTo int, lastID int64) url.Values {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(limit(limitTo)))
+	query.Set("order", "desc")
+	if lastID > 0 {
+		query.Set("cursor", strconv.FormatInt(lastID, 10))
+	}
+
+	return query
+}
+
+func (t *TzStats) operations(path string, query url.Values) ([]Operation, error) {
+	var raw []json.RawMessage
+	err := get(t.client, t.host, path, query, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	operations := []Operation{}
+	for _, r := range raw {
+		var op tzstatsOperation
+		err = json.Unmarshal(r, &op)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal operations")
+		}
+		op.Raw = r
+
+		operations = append(operations, op.operation())
+	}
+
+	return operations, nil
+}
+
+func (o *tzstatsOperation) operation() Operation {
+	operation := Operation{
+		ID:        o.ID,
+		Type:      o.Type,
+		Hash:      o.Hash,
+		Level:     o.Height,
+		Timestamp: o.Time.Time,
+		Block:     o.Block,
+		Counter:   o.Counter,
+		Sender:    o.Sender,
+		Target:    o.Receiver,
+		Amount:    mutez(o.Volume),
+		Fee:       mutez(o.Fee),
+		Status:    o.Status,
+		Raw:       o.Raw,
+	}
+
+	if o.Type == "delegation" {
+		operation.Delegate = o.Delegate
+	}
+	if o.Parameters != nil {
+		operation.Entrypoint = o.Parameters.Entrypoint
+		operation.Parameter = o.Parameters.Value
+	}
+
+	return operation
+}
+
+func mutez(tez float64) int64 {
+	return int64(math.Round(tez * 1000000))
+}
@@ -0,0 +1,127 @@
+package indexer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	mockTzStatsOperations = []byte(`[
+		{"id":512,"type":"transaction","hash":"opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A","height":2000000,"time":"2021-12-24T10:00:00Z","block":"BLTbZ3U7kHwGqSUdq3e5QMyn2aJu9uZkMdQAtRHGmFf46TKdcfM","counter":11,"sender":"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK","receiver":"KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg","volume":0.0015,"fee":0.00042,"status":"applied","parameters":{"entrypoint":"transfer","value":{"to":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}}},
+		{"id":256,"type":"delegation","hash":"oo1Tqbv3sLdH4MUnrCmWxn8DqMSqBYpWaDhbULcf6eWRnNLnxgi","height":1999000,"time":"2021-12-23T10:00:00Z","block":"BMWVEwEYw9m5iaHzqxDfkPzZTV4rhkSouRh3DkVMVGkxZ3EVaNs","counter":10,"sender":"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK","delegate":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","volume":0,"fee":0.00038,"status":"applied"}
+	]`)
+	mockTzStatsTokenBalances = []byte(`[
+		{"contract":"KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg","token_id":"0","type":"fa2","balance":"1000000","metadata":{"symbol":"TKN","decimals":"6"}},
+		{"contract":"KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg","token_id":"1","type":"fa2","balance":"0"}
+	]`)
+)
+
+func Test_TzStatsOperationsByAccount(t *testing.T) {
+	path := "/explorer/account/tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK/operations"
+	query := map[string]string{"limit": "2", "type": "transaction,delegation", "cursor": "1024", "order": "desc"}
+
+	server := httptest.NewServer(indexerHandlerMock(t, path, query, http.StatusOK, mockTzStatsOperations))
+	defer server.Close()
+
+	operations, err := NewTzStats(server.URL).OperationsByAccount(&OperationsInput{
+		Address: "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK",
+		Types:   []string{"transaction", "delegation"},
+		Limit:   2,
+		LastID:  1024,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, operations, 2)
+
+	assert.Equal(t, int64(512), operations[0].ID)
+	assert.Equal(t, 2000000, operations[0].Level)
+	assert.Equal(t, "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", operations[0].Target)
+	assert.Equal(t, int64(1500), operations[0].Amount)
+	assert.Equal(t, int64(420), operations[0].Fee)
+	assert.Equal(t, "transfer", operations[0].Entrypoint)
+	assert.JSONEq(t, `{"to":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}`, string(operations[0].Parameter))
+
+	assert.Equal(t, "delegation", operations[1].Type)
+	assert.Equal(t, "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", operations[1].Delegate)
+	assert.Equal(t, int64(380), operations[1].Fee)
+	assert.NotEmpty(t, operations[1].Raw)
+
+	_, err = NewTzStats(server.URL).OperationsByAccount(&OperationsInput{})
+	checkErr(t, true, "invalid input", err)
+}
+
+func Test_TzStatsContractCalls(t *testing.T) {
+	path := "/explorer/contract/KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg/calls"
+	query := map[string]string{"limit": "100", "entrypoint": "transfer", "order": "desc"}
+
+	server := httptest.NewServer(indexerHandlerMock(t, path, query, http.StatusOK, mockTzStatsOperations))
+	defer server.Close()
+
+	calls, err := NewTzStats(server.URL).ContractCalls(&ContractCallsInput{Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", Entrypoint: "transfer"})
+	assert.Nil(t, err)
+	assert.Len(t, calls, 2)
+
+	server = httptest.NewServer(indexerHandlerMock(t, path, nil, http.StatusNotFound, []byte(`{"errors":[]}`)))
+	defer server.Close()
+
+	_, err = NewTzStats(server.URL).ContractCalls(&ContractCallsInput{Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg"})
+	checkErr(t, true, "failed to get calls", err)
+}
+
+func Test_TzStatsOriginatedContracts(t *testing.T) {
+	path := "/explorer/account/tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK/operations"
+	query := map[string]string{"limit": "100", "type": "origination", "order": "desc"}
+
+	server := httptest.NewServer(indexerHandlerMock(t, path, query, http.StatusOK, []byte(`[
+		{"id":768,"type":"origination","hash":"opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A","height":2100000,"time":"2022-01-30T10:00:00Z","block":"BLTbZ3U7kHwGqSUdq3e5QMyn2aJu9uZkMdQAtRHGmFf46TKdcfM","counter":12,"sender":"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK","receiver":"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn","status":"applied"},
+		{"id":704,"type":"origination","hash":"ooFKNB2Ld7HGpcMMc6dPxgkhsDMB8ey3dZGMYPeAqKGRE1uzhce","height":2080000,"time":"2022-01-23T10:00:00Z","block":"BMWVEwEYw9m5iaHzqxDfkPzZTV4rhkSouRh3DkVMVGkxZ3EVaNs","counter":11,"sender":"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK","receiver":"KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg","status":"failed"}
+	]`)))
+	defer server.Close()
+
+	contracts, err := NewTzStats(server.URL).OriginatedContracts(&OriginatedContractsInput{Address: "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"})
+	assert.Nil(t, err)
+	assert.Len(t, contracts, 1)
+	assert.Equal(t, "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", contracts[0].Address)
+	assert.Equal(t, int64(768), contracts[0].ID)
+	assert.Equal(t, 2100000, contracts[0].Level)
+	assert.Equal(t, "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", contracts[0].Sender)
+
+	_, err = NewTzStats(server.URL).OriginatedContracts(&OriginatedContractsInput{})
+	checkErr(t, true, "invalid input", err)
+}
+
+func Test_TzStatsTokenBalances(t *testing.T) {
+	path := "/explorer/account/tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK/balances"
+
+	server := httptest.NewServer(indexerHandlerMock(t, path, nil, http.StatusOK, mockTzStatsTokenBalances))
+	defer server.Close()
+
+	balances, err := NewTzStats(server.URL).TokenBalances("tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK")
+	assert.Nil(t, err)
+	assert.Len(t, balances, 1)
+	assert.Equal(t, "fa2", balances[0].Standard)
+	assert.Equal(t, "1000000", balances[0].Balance)
+}
+
+func Test_Indexers(t *testing.T) {
+	tzkt := httptest.NewServer(indexerHandlerMock(t, "/v1/accounts/tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK/operations", nil, http.StatusOK, mockTzKTOperations))
+	defer tzkt.Close()
+
+	tzstats := httptest.NewServer(indexerHandlerMock(t, "/explorer/account/tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK/operations", nil, http.StatusOK, mockTzStatsOperations))
+	defer tzstats.Close()
+
+	var results [][]Operation
+	for _, indexer := range []Indexer{NewTzKT(tzkt.URL), NewTzStats(tzstats.URL)} {
+		operations, err := indexer.OperationsByAccount(&OperationsInput{Address: "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"})
+		assert.Nil(t, err)
+
+		for i := range operations {
+			operations[i].Raw = nil
+		}
+		results = append(results, operations)
+	}
+
+	assert.Equal(t, results[0], results[1])
+}
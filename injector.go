@@ -0,0 +1,368 @@
+package gotezos
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Statuses of the operations recorded by an Injector.
+const (
+	// The operation is signed and recorded, it may or may not have reached the node.
+	InjectionStatusSigned = "signed"
+	// The node accepted the operation.
+	InjectionStatusInjected = "injected"
+	// The operation reached the confirmations of the WalletClient (see WalletClient.Wait).
+	InjectionStatusIncluded = "included"
+	// The dry run of the operation failed, nothing was signed.
+	InjectionStatusFailed = "failed"
+)
+
+// ErrAlreadyInjected is the cause of the error Injector.Send returns for an idempotency key whose operation
+// was injected already.
+var ErrAlreadyInjected = errors.New("operation already injected")
+
+/*
+InjectionRecord -
+Description: What an Injector knows of the operation sent for an idempotency key.
+*/
+type InjectionRecord struct {
+	// The idempotency key of the operation, e.g. "payout/cycle-500/tz1...".
+	Key string `json:"key"`
+	// One of the InjectionStatus constants.
+	Status string `json:"status"`
+	// The forged operation.
+	Forge string `json:"forge,omitempty"`
+	// The forged operation followed by its signature, injected as it is.
+	SignedOperation string `json:"signed_operation,omitempty"`
+	// The hash of the operation, computed from the signed operation before it is injected.
+	OperationHash string `json:"operation_hash,omitempty"`
+	// The receipt of the dry run of the operation, before it was signed.
+	Receipt *OperationReceipt `json:"receipt,omitempty"`
+	// The error of the dry run, or of the last injection.
+	Error string `json:"error,omitempty"`
+	// When the record was last written.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+/*
+InjectionStore -
+Description: Persists the records of an Injector. A record must be durable once Put returns, as the signed
+operation is injected only then.
+*/
+type InjectionStore interface {
+	// Get returns the record of an idempotency key, or nil if there is none.
+	Get(key string) (*InjectionRecord, error)
+	// Put writes a record, replacing the record of its key.
+	Put(record *InjectionRecord) error
+}
+
+/*
+MemoryInjectionStore -
+Description: An InjectionStore keeping the records in memory, e.g. for tests. Records do not survive the process.
+Function: func NewMemoryInjectionStore() *MemoryInjectionStore {}
+*/
+type MemoryInjectionStore struct {
+	mu      sync.Mutex
+	records map[string]InjectionRecord
+}
+
+/*
+NewMemoryInjectionStore Function
+Description: Returns an empty MemoryInjectionStore.
+*/
+func NewMemoryInjectionStore() *MemoryInjectionStore {
+	return &MemoryInjectionStore{records: map[string]InjectionRecord{}}
+}
+
+// Get returns the record of an idempotency key, or nil if there is none.
+func (m *MemoryInjectionStore) Get(key string) (*InjectionRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record, ok := m.records[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return &record, nil
+}
+
+// Put writes a record, replacing the record of its key.
+func (m *MemoryInjectionStore) Put(record *InjectionRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.records[record.Key] = *record
+	return nil
+}
+
+/*
+FileInjectionStore -
+Description: An InjectionStore keeping the records in a JSON file, rewritten through a temporary file and a rename
+on every Put so that a crash leaves either the old or the new records.
+Function: func NewFileInjectionStore(path string) *FileInjectionStore {}
+*/
+type FileInjectionStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+/*
+NewFileInjectionStore Function
+Description: Returns a FileInjectionStore. The file is created by the first Put.
+
+Parameters:
+	path:
+		The path of the JSON file.
+*/
+func NewFileInjectionStore(path string) *FileInjectionStore {
+	return &FileInjectionStore{path: path}
+}
+
+// Get returns the record of an idempotency key, or nil if there is none.
+func (f *FileInjectionStore) Get(key string) (*InjectionRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	records, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+
+	return records[key], nil
+}
+
+// Put writes a record, replacing the record of its key.
+func (f *FileInjectionStore) Put(record *InjectionRecord) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	records, err := f.read()
+	if err != nil {
+		return err
+	}
+	records[record.Key] = record
+
+	v, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal injection records")
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return errors.Wrap(err, "failed to write injection records")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(v); err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), f.path)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to write injection records")
+	}
+
+	return nil
+}
+
+func (f *FileInjectionStore) read() (map[string]*InjectionRecord, error) {
+	records := map[string]*InjectionRecord{}
+
+	v, err := ioutil.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read injection records")
+	}
+
+	err = json.Unmarshal(v, &records)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal injection records")
+	}
+
+	return records, nil
+}
+
+/*
+Injector -
+Description: Sends operations through a WalletClient at most once per idempotency key, e.g. one key per payment
+of a payout run, so that a run restarted after a crash does not pay twice. The signed operation of a key is
+recorded before it is injected: a key whose operation may have reached the node is never signed again, only
+its recorded operation is injected again, which the node refuses once the operation is included.
+Function: func NewInjector(client *WalletClient, store InjectionStore) *Injector {}
+*/
+type Injector struct {
+	Client *WalletClient
+	Store  InjectionStore
+}
+
+/*
+NewInjector Function
+Description: Returns an Injector sending operations through a WalletClient and recording them to a store.
+
+Parameters:
+	client:
+		The WalletClient preparing, signing and injecting the operations.
+	store:
+		The store of the records, which must outlive the process to protect against double injection.
+*/
+func NewInjector(client *WalletClient, store InjectionStore) *Injector {
+	return &Injector{Client: client, Store: store}
+}
+
+/*
+Send Function
+Description: Prepares, dry runs, signs and injects the contents for an idempotency key, recording each step to the
+store. A key already injected or included is refused with an error caused by ErrAlreadyInjected, along with its
+record. A key left signed, e.g. by a crash before or during the injection, has its recorded operation injected
+again rather than new contents signed. A key whose dry run failed is tried again from the start.
+
+If the node refuses the injection of a recorded operation, the record stays signed: check whether its
+OperationHash was included before putting a failed record for the key to send it again.
+
+Parameters:
+	ctx:
+		Cancels the wait for the operation, see WalletClient.Wait.
+	key:
+		The idempotency key of the operation.
+	contents:
+		The contents of the operation, completed as by WalletClient.Prepare.
+*/
+func (i *Injector) Send(ctx context.Context, key string, contents ...Contents) (*InjectionRecord, error) {
+	if key == "" {
+		return nil, errors.New("failed to send operation: no idempotency key")
+	}
+
+	record, err := i.Store.Get(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to send operation '%s'", key)
+	}
+
+	if record != nil {
+		switch record.Status {
+		case InjectionStatusInjected, InjectionStatusIncluded:
+			return record, errors.Wrapf(ErrAlreadyInjected, "failed to send operation '%s'", key)
+		case InjectionStatusSigned:
+			return i.inject(ctx, record)
+		}
+	}
+
+	record, err = i.sign(key, contents)
+	if err != nil {
+		return record, errors.Wrapf(err, "failed to send operation '%s'", key)
+	}
+
+	return i.inject(ctx, record)
+}
+
+// sign prepares, dry runs and signs the contents, and records the signed operation.
+func (i *Injector) sign(key string, contents []Contents) (*InjectionRecord, error) {
+	operation, err := i.Client.Prepare(contents...)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := i.Client.Client.DryRun(operation...)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &InjectionRecord{Key: key}
+	record.Receipt = (&Operations{Contents: result.Contents}).Receipt()
+	if result.Status != APPLIEDSTATUS {
+		record.Status = InjectionStatusFailed
+		record.Error = errors.Errorf("operation %s: %v", result.Status, result.Errors).Error()
+		if err := i.put(record); err != nil {
+			return nil, err
+		}
+		return record, errors.New(record.Error)
+	}
+
+	signed, err := i.Client.sign(operation)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := SignedOperationHash(signed.SignedOperation)
+	if err != nil {
+		return nil, err
+	}
+
+	record.Status = InjectionStatusSigned
+	record.Forge = signed.Operation
+	record.SignedOperation = signed.SignedOperation
+	record.OperationHash = hash
+	if err := i.put(record); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// inject injects the recorded operation and records how far it went.
+func (i *Injector) inject(ctx context.Context, record *InjectionRecord) (*InjectionRecord, error) {
+	hash, err := i.Client.injectSigned(ctx, record.SignedOperation)
+	if hash == nil {
+		record.Error = err.Error()
+		if perr := i.put(record); perr != nil {
+			return record, errors.Wrapf(perr, "failed to send operation '%s'", record.Key)
+		}
+		return record, errors.Wrapf(err, "failed to send operation '%s'", record.Key)
+	}
+
+	record.Status = InjectionStatusInjected
+	record.OperationHash = *hash
+	record.Error = ""
+	if err != nil {
+		record.Error = err.Error()
+	} else if i.Client.Wait {
+		record.Status = InjectionStatusIncluded
+	}
+
+	if perr := i.put(record); perr != nil {
+		return record, errors.Wrapf(perr, "failed to send operation '%s'", record.Key)
+	}
+	if err != nil {
+		return record, errors.Wrapf(err, "failed to send operation '%s'", record.Key)
+	}
+
+	return record, nil
+}
+
+func (i *Injector) put(record *InjectionRecord) error {
+	record.UpdatedAt = time.Now().UTC()
+	return i.Store.Put(record)
+}
+
+/*
+SignedOperationHash Function
+Description: Returns the hash (o...) of a signed operation, as the node returns when it is injected.
+
+Parameters:
+	signedOperation:
+		The forged operation followed by its signature, in hex.
+*/
+func SignedOperationHash(signedOperation string) (string, error) {
+	v, err := hex.DecodeString(signedOperation)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to hash operation: invalid hex")
+	}
+
+	hash := blake2b.Sum256(v)
+	return b58cencode(hash[:], prefix_o), nil
+}
@@ -0,0 +1,167 @@
+package gotezos
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_InjectorSend(t *testing.T) {
+	transfer := Contents{Kind: TRANSACTIONOP, Destination: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}
+	transfer.Amount.SetInt64(1500)
+
+	t.Run("injects once per key", func(t *testing.T) {
+		client, mock := testWalletClient(t)
+		store := NewMemoryInjectionStore()
+		injector := NewInjector(client, store)
+
+		record, err := injector.Send(context.Background(), "payout/500/tz1Kq", transfer)
+		assert.Nil(t, err)
+		assert.Equal(t, InjectionStatusInjected, record.Status)
+		assert.Equal(t, "opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A", record.OperationHash)
+		assert.True(t, strings.HasPrefix(record.SignedOperation, record.Forge))
+		assert.Equal(t, "2001", record.Receipt.ConsumedGas.String())
+		assert.Equal(t, []string{record.SignedOperation}, mock.injected)
+
+		stored, err := store.Get("payout/500/tz1Kq")
+		assert.Nil(t, err)
+		assert.Equal(t, record, stored)
+
+		record, err = injector.Send(context.Background(), "payout/500/tz1Kq", transfer)
+		checkErr(t, true, "failed to send operation 'payout/500/tz1Kq': operation already injected", err)
+		assert.Equal(t, ErrAlreadyInjected, errors.Cause(err))
+		assert.Equal(t, InjectionStatusInjected, record.Status)
+		assert.Len(t, mock.injected, 1)
+
+		_, err = injector.Send(context.Background(), "payout/500/tz1fY", transfer)
+		assert.Nil(t, err)
+		assert.Len(t, mock.injected, 2)
+	})
+
+	t.Run("injects the recorded operation of a signed key", func(t *testing.T) {
+		client, mock := testWalletClient(t)
+		store := NewMemoryInjectionStore()
+		store.Put(&InjectionRecord{Key: "payout", Status: InjectionStatusSigned, SignedOperation: "00ff"})
+
+		record, err := NewInjector(client, store).Send(context.Background(), "payout", transfer)
+		assert.Nil(t, err)
+		assert.Equal(t, InjectionStatusInjected, record.Status)
+		assert.Equal(t, []string{"00ff"}, mock.injected)
+		assert.Equal(t, 0, mock.dryRuns)
+	})
+
+	t.Run("keeps a refused operation signed", func(t *testing.T) {
+		client, mock := testWalletClient(t)
+		mock.injectErr = errors.New("counter_in_the_past")
+		store := NewMemoryInjectionStore()
+		injector := NewInjector(client, store)
+
+		record, err := injector.Send(context.Background(), "payout", transfer)
+		checkErr(t, true, "failed to send operation 'payout': counter_in_the_past", err)
+		assert.Equal(t, InjectionStatusSigned, record.Status)
+		assert.Equal(t, "counter_in_the_past", record.Error)
+		signed := record.SignedOperation
+
+		mock.injectErr = nil
+		record, err = injector.Send(context.Background(), "payout", transfer)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{signed}, mock.injected)
+		assert.Equal(t, "", record.Error)
+	})
+
+	t.Run("records a failed dry run and tries again", func(t *testing.T) {
+		client, mock := testWalletClient(t)
+		applied := mock.dryRun
+		mock.dryRun = &DryRunResult{Status: "failed", Contents: applied.Contents}
+		store := NewMemoryInjectionStore()
+		injector := NewInjector(client, store)
+
+		// Contents with limits are not run by Prepare, nor is the reveal of a revealed source.
+		revealed := "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G"
+		mock.managerKey = &revealed
+		preset := transfer
+		preset.Fee.SetInt64(1500)
+		preset.GasLimit.SetInt64(2000)
+		preset.StorageLimit.SetInt64(257)
+
+		record, err := injector.Send(context.Background(), "payout", preset)
+		checkErr(t, true, "failed to send operation 'payout': operation failed", err)
+		assert.Equal(t, InjectionStatusFailed, record.Status)
+		assert.Equal(t, "", record.SignedOperation)
+		assert.Len(t, mock.injected, 0)
+
+		mock.dryRun = applied
+		record, err = injector.Send(context.Background(), "payout", preset)
+		assert.Nil(t, err)
+		assert.Equal(t, InjectionStatusInjected, record.Status)
+		assert.Len(t, mock.injected, 1)
+	})
+
+	t.Run("records inclusion when waiting", func(t *testing.T) {
+		client, mock := testWalletClient(t)
+		client.Wait = true
+		client.Confirmations = 1
+		mock.confirmations = []Confirmation{{Confirmations: 1}}
+
+		record, err := NewInjector(client, NewMemoryInjectionStore()).Send(context.Background(), "payout", transfer)
+		assert.Nil(t, err)
+		assert.Equal(t, InjectionStatusIncluded, record.Status)
+	})
+
+	t.Run("handles missing key", func(t *testing.T) {
+		client, _ := testWalletClient(t)
+		_, err := NewInjector(client, NewMemoryInjectionStore()).Send(context.Background(), "", transfer)
+		checkErr(t, true, "no idempotency key", err)
+	})
+}
+
+func Test_FileInjectionStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "injections")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "injections.json")
+	store := NewFileInjectionStore(path)
+
+	record, err := store.Get("payout")
+	assert.Nil(t, err)
+	assert.Nil(t, record)
+
+	receipt := &OperationReceipt{Hash: "opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A"}
+	receipt.Burn.SetInt64(64250)
+	err = store.Put(&InjectionRecord{Key: "payout", Status: InjectionStatusSigned, SignedOperation: "00ff", Receipt: receipt})
+	assert.Nil(t, err)
+	err = store.Put(&InjectionRecord{Key: "other", Status: InjectionStatusFailed})
+	assert.Nil(t, err)
+
+	record, err = NewFileInjectionStore(path).Get("payout")
+	assert.Nil(t, err)
+	assert.Equal(t, InjectionStatusSigned, record.Status)
+	assert.Equal(t, "00ff", record.SignedOperation)
+	assert.Equal(t, "64250", record.Receipt.Burn.String())
+
+	files, err := ioutil.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+
+	err = ioutil.WriteFile(path, []byte("{"), 0600)
+	assert.Nil(t, err)
+	_, err = store.Get("payout")
+	checkErr(t, true, "failed to unmarshal injection records", err)
+}
+
+func Test_SignedOperationHash(t *testing.T) {
+	hash, err := SignedOperationHash("00ff")
+	assert.Nil(t, err)
+	assert.Equal(t, "o", hash[:1])
+	assert.Len(t, hash, 51)
+
+	_, err = SignedOperationHash("0g")
+	checkErr(t, true, "invalid hex", err)
+}
@@ -0,0 +1,156 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+/*
+ExpectedIssuance Result
+RPC: ../<block_id>/context/issuance/expected_issuance (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-issuance-expected-issuance
+Description: The rewards, in mutez, expected to be issued in a cycle (Oxford and later).
+*/
+type ExpectedIssuance struct {
+	Cycle                      int    `json:"cycle"`
+	BakingRewardFixedPortion   BigInt `json:"baking_reward_fixed_portion"`
+	BakingRewardBonusPerSlot   BigInt `json:"baking_reward_bonus_per_slot"`
+	AttestingRewardPerSlot     BigInt `json:"attesting_reward_per_slot"`
+	SeedNonceRevelationTip     BigInt `json:"seed_nonce_revelation_tip"`
+	VdfRevelationTip           BigInt `json:"vdf_revelation_tip"`
+	DALAttestingRewardPerShard BigInt `json:"dal_attesting_reward_per_shard,omitempty"`
+}
+
+/*
+ExpectedIssuance RPC
+Path: ../<block_id>/context/issuance/expected_issuance (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-issuance-expected-issuance
+Description: Returns the rewards expected to be issued in the current cycle and the cycles whose rewards
+are already known.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+*/
+func (t *GoTezos) ExpectedIssuance(blockID BlockID) ([]ExpectedIssuance, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/issuance/expected_issuance", blockID.ID()))
+	if err != nil {
+		return []ExpectedIssuance{}, errors.Wrap(err, "could not get expected issuance")
+	}
+
+	var issuance []ExpectedIssuance
+	err = json.Unmarshal(resp, &issuance)
+	if err != nil {
+		return []ExpectedIssuance{}, errors.Wrap(err, "could not unmarshal expected issuance")
+	}
+
+	return issuance, nil
+}
+
+/*
+CurrentYearlyRate RPC
+Path: ../<block_id>/context/issuance/current_yearly_rate (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-issuance-current-yearly-rate
+Description: Returns the current yearly issuance rate, in percent of the total supply (Oxford and later).
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+*/
+func (t *GoTezos) CurrentYearlyRate(blockID BlockID) (float64, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/issuance/current_yearly_rate", blockID.ID()))
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get current yearly rate")
+	}
+
+	var rate string
+	err = json.Unmarshal(resp, &rate)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not unmarshal current yearly rate")
+	}
+
+	r, err := strconv.ParseFloat(rate, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not parse current yearly rate '%s'", rate)
+	}
+
+	return r, nil
+}
+
+/*
+TotalSupply RPC
+Path: ../<block_id>/context/total_supply (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-total-supply
+Description: Returns the total supply of tez, in mutez (Oxford and later).
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+*/
+func (t *GoTezos) TotalSupply(blockID BlockID) (*BigInt, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/total_supply", blockID.ID()))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get total supply")
+	}
+
+	var supply BigInt
+	err = json.Unmarshal(resp, &supply)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal total supply")
+	}
+
+	return &supply, nil
+}
+
+/*
+TotalFrozenStake RPC
+Path: ../<block_id>/context/total_frozen_stake (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-total-frozen-stake
+Description: Returns the total stake frozen by delegates and their stakers, in mutez (Oxford and later).
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+*/
+func (t *GoTezos) TotalFrozenStake(blockID BlockID) (*BigInt, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/total_frozen_stake", blockID.ID()))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get total frozen stake")
+	}
+
+	var stake BigInt
+	err = json.Unmarshal(resp, &stake)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal total frozen stake")
+	}
+
+	return &stake, nil
+}
+
+/*
+AdaptiveIssuanceLaunchCycle RPC
+Path: ../<block_id>/context/adaptive_issuance_launch_cycle (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-adaptive-issuance-launch-cycle
+Description: Returns the cycle adaptive issuance is activated at, nil if its activation vote has not passed.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+*/
+func (t *GoTezos) AdaptiveIssuanceLaunchCycle(blockID BlockID) (*int, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/adaptive_issuance_launch_cycle", blockID.ID()))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get adaptive issuance launch cycle")
+	}
+
+	var cycle *int
+	err = json.Unmarshal(resp, &cycle)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal adaptive issuance launch cycle")
+	}
+
+	return cycle, nil
+}
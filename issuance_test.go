@@ -0,0 +1,104 @@
+package gotezos
+
+import (
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var mockExpectedIssuanceResp = []byte(`[{"cycle":750,"baking_reward_fixed_portion":"4787492","baking_reward_bonus_per_slot":"4875","attesting_reward_per_slot":"9750","seed_nonce_revelation_tip":"1219","vdf_revelation_tip":"1219"},{"cycle":751,"baking_reward_fixed_portion":"4787000","baking_reward_bonus_per_slot":"4874","attesting_reward_per_slot":"9749","seed_nonce_revelation_tip":"1218","vdf_revelation_tip":"1218","dal_attesting_reward_per_shard":"12"}]`)
+
+func Test_Issuance(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(routesHandlerMock(map[string][]byte{
+		"/issuance/expected_issuance":     mockExpectedIssuanceResp,
+		"/issuance/current_yearly_rate":   []byte(`"3.83"`),
+		"/context/total_supply":           []byte(`"1031459372102871"`),
+		"/context/total_frozen_stake":     []byte(`"193211208119432"`),
+		"/adaptive_issuance_launch_cycle": []byte(`748`),
+	}, blankHandler)))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	issuance, err := gt.ExpectedIssuance(BlockIDHead{})
+	assert.Nil(t, err)
+	assert.Len(t, issuance, 2)
+	assert.Equal(t, 750, issuance[0].Cycle)
+	assert.Equal(t, int64(4787492), issuance[0].BakingRewardFixedPortion.Int64())
+	assert.Equal(t, int64(9750), issuance[0].AttestingRewardPerSlot.Int64())
+	assert.Equal(t, int64(0), issuance[0].DALAttestingRewardPerShard.Int64())
+	assert.Equal(t, int64(12), issuance[1].DALAttestingRewardPerShard.Int64())
+
+	rate, err := gt.CurrentYearlyRate(BlockIDHead{})
+	assert.Nil(t, err)
+	assert.Equal(t, 3.83, rate)
+
+	supply, err := gt.TotalSupply(BlockIDHead{})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, supply.Cmp(big.NewInt(1031459372102871)))
+
+	stake, err := gt.TotalFrozenStake(BlockIDHead{})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, stake.Cmp(big.NewInt(193211208119432)))
+
+	cycle, err := gt.AdaptiveIssuanceLaunchCycle(BlockIDHead{})
+	assert.Nil(t, err)
+	assert.Equal(t, 748, *cycle)
+}
+
+func Test_IssuanceErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		resp    []byte
+		wantErr string
+	}{
+		{"handles rpc error", mockRPCErrorResp, "could not get"},
+		{"handles failure to unmarshal", []byte(`{}`), "could not unmarshal"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(gtGoldenHTTPMock(routesHandlerMock(map[string][]byte{
+				"/issuance/expected_issuance":     tt.resp,
+				"/issuance/current_yearly_rate":   tt.resp,
+				"/context/total_supply":           tt.resp,
+				"/context/total_frozen_stake":     tt.resp,
+				"/adaptive_issuance_launch_cycle": tt.resp,
+			}, blankHandler)))
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			_, err = gt.ExpectedIssuance(BlockIDHead{})
+			checkErr(t, true, tt.wantErr+" expected issuance", err)
+			_, err = gt.CurrentYearlyRate(BlockIDHead{})
+			checkErr(t, true, tt.wantErr+" current yearly rate", err)
+			_, err = gt.TotalSupply(BlockIDHead{})
+			checkErr(t, true, tt.wantErr+" total supply", err)
+			_, err = gt.TotalFrozenStake(BlockIDHead{})
+			checkErr(t, true, tt.wantErr+" total frozen stake", err)
+			_, err = gt.AdaptiveIssuanceLaunchCycle(BlockIDHead{})
+			checkErr(t, true, tt.wantErr+" adaptive issuance launch cycle", err)
+		})
+	}
+
+	server := httptest.NewServer(gtGoldenHTTPMock(routesHandlerMock(map[string][]byte{
+		"/adaptive_issuance_launch_cycle": []byte(`null`),
+		"/issuance/current_yearly_rate":   []byte(`"not a rate"`),
+	}, blankHandler)))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	cycle, err := gt.AdaptiveIssuanceLaunchCycle(BlockIDHead{})
+	assert.Nil(t, err)
+	assert.Nil(t, cycle)
+
+	_, err = gt.CurrentYearlyRate(BlockIDHead{})
+	checkErr(t, true, "could not parse current yearly rate 'not a rate'", err)
+}
@@ -0,0 +1,132 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+/*
+StringIterator -
+Description: Walks a JSON list of strings (e.g. addresses) returned by the RPC one entry at a time,
+decoding the response as it is read so that memory use stays bounded regardless of the list size.
+
+	it, err := gt.DelegatedContractsIterator(gotezos.BlockIDHead{}, delegate)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for it.Next() {
+		fmt.Println(it.Value())
+	}
+	return it.Err()
+*/
+type StringIterator struct {
+	body    io.ReadCloser
+	decoder *json.Decoder
+	value   string
+	err     error
+	started bool
+	done    bool
+}
+
+func newStringIterator(body io.ReadCloser) *StringIterator {
+	return &StringIterator{
+		body:    body,
+		decoder: json.NewDecoder(body),
+	}
+}
+
+/*
+Next Function
+Description: Advances the iterator to the next entry. Returns false once the list is exhausted or
+an error occurred, see Err.
+*/
+func (i *StringIterator) Next() bool {
+	if i.done {
+		return false
+	}
+
+	if !i.started {
+		i.started = true
+		token, err := i.decoder.Token()
+		if err != nil {
+			return i.fail(errors.Wrap(err, "could not read list"))
+		}
+
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			return i.fail(errors.Errorf("could not read list: unexpected token %v", token))
+		}
+	}
+
+	if !i.decoder.More() {
+		i.done = true
+		return false
+	}
+
+	err := i.decoder.Decode(&i.value)
+	if err != nil {
+		return i.fail(errors.Wrap(err, "could not unmarshal list entry"))
+	}
+
+	return true
+}
+
+/*
+Value Function
+Description: Returns the entry the iterator is at.
+*/
+func (i *StringIterator) Value() string {
+	return i.value
+}
+
+/*
+Err Function
+Description: Returns the error that stopped the iterator, if any.
+*/
+func (i *StringIterator) Err() error {
+	return i.err
+}
+
+/*
+Close Function
+Description: Releases the underlying response. Safe to call before the list is exhausted.
+*/
+func (i *StringIterator) Close() error {
+	i.done = true
+	return i.body.Close()
+}
+
+/*
+Page Function
+Description: Collects at most limit entries after skipping the first offset entries. The iterator is
+left positioned after the page, so successive calls walk the list page by page.
+
+Parameters:
+	offset:
+		The number of entries to skip.
+	limit:
+		The maximum number of entries to return.
+*/
+func (i *StringIterator) Page(offset, limit int) ([]string, error) {
+	page := []string{}
+	for skipped := 0; skipped < offset; skipped++ {
+		if !i.Next() {
+			return page, i.Err()
+		}
+	}
+
+	for len(page) < limit && i.Next() {
+		page = append(page, i.Value())
+	}
+
+	return page, i.Err()
+}
+
+func (i *StringIterator) fail(err error) bool {
+	i.err = err
+	i.done = true
+	return false
+}
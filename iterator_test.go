@@ -0,0 +1,64 @@
+package gotezos
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StringIterator(t *testing.T) {
+	cases := []struct {
+		name        string
+		input       string
+		want        []string
+		wantErr     bool
+		containsErr string
+	}{
+		{
+			"is successful",
+			`["tz1a", "tz1b", "tz1c"]`,
+			[]string{"tz1a", "tz1b", "tz1c"},
+			false,
+			"",
+		},
+		{
+			"is empty",
+			`[]`,
+			nil,
+			false,
+			"",
+		},
+		{
+			"is not a list",
+			`{"kind":"somekind"}`,
+			nil,
+			true,
+			"could not read list",
+		},
+		{
+			"has a bad entry",
+			`["tz1a", 5]`,
+			[]string{"tz1a"},
+			true,
+			"could not unmarshal list entry",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			it := newStringIterator(ioutil.NopCloser(strings.NewReader(tt.input)))
+			defer it.Close()
+
+			var got []string
+			for it.Next() {
+				got = append(got, it.Value())
+			}
+
+			checkErr(t, tt.wantErr, tt.containsErr, it.Err())
+			assert.Equal(t, tt.want, got)
+			assert.False(t, it.Next())
+		})
+	}
+}
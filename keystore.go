@@ -0,0 +1,289 @@
+package gotezos
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+/*
+Keystore -
+Description: Holds the keys of several wallets, e.g. the payout accounts of a baker, encrypted in memory with a
+passphrase. Once unlocked the wallets can be used by alias or address, and locking zeroes their secret keys.
+Only the public part of the keys (see KeystoreKey) is available while locked. A Keystore is safe for
+concurrent use.
+
+Function: func NewKeystore(passphrase string) (*Keystore, error) {}
+*/
+type Keystore struct {
+	mu   sync.Mutex
+	keys []*keystoreKey
+	salt []byte
+	// An empty message sealed with the passphrase, to check the passphrase of a keystore without keys.
+	check []byte
+	// The key derived from the passphrase, nil while locked.
+	secret *[32]byte
+}
+
+/*
+KeystoreKey -
+Description: The public part of a key of a Keystore.
+*/
+type KeystoreKey struct {
+	Alias   string
+	Address string
+	Pk      string
+}
+
+type keystoreKey struct {
+	KeystoreKey
+	nonce [24]byte
+	// The seed of the secret key, sealed with the key derived from the passphrase.
+	sealed []byte
+	// The wallet of the key, nil while locked.
+	wallet *Wallet
+}
+
+/*
+NewKeystore Function
+Description: Returns an empty, unlocked keystore encrypting its keys with a passphrase.
+
+Parameters:
+	passphrase:
+		The passphrase to unlock the keystore with, see Keystore.Unlock.
+*/
+func NewKeystore(passphrase string) (*Keystore, error) {
+	k := &Keystore{salt: make([]byte, 8)}
+	if _, err := rand.Read(k.salt); err != nil {
+		return nil, errors.Wrap(err, "failed to create keystore: could not generate salt")
+	}
+
+	k.secret = keystoreSecret(passphrase, k.salt)
+	k.check = secretbox.Seal(nil, nil, &[24]byte{}, k.secret)
+
+	return k, nil
+}
+
+/*
+Add Function
+Description: Encrypts the secret key of a wallet into the keystore. The keystore must be unlocked. The wallet
+passed is not zeroed by Lock, the keystore keeps its own copy of the key.
+
+Parameters:
+	alias:
+		The alias of the key, unique in the keystore.
+	wallet:
+		The wallet, with an ed25519 secret key.
+*/
+func (k *Keystore) Add(alias string, wallet *Wallet) error {
+	if wallet == nil || len(wallet.Kp.PrivKey) != ed25519.PrivateKeySize {
+		return errors.New("failed to add key: wallet does not contain a valid ed25519 secret key")
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.secret == nil {
+		return errors.New("failed to add key: keystore is locked")
+	}
+	for _, key := range k.keys {
+		if key.Alias == alias || key.Address == wallet.Address {
+			return errors.Errorf("failed to add key: alias '%s' or address '%s' already in keystore", alias, wallet.Address)
+		}
+	}
+
+	key := &keystoreKey{KeystoreKey: KeystoreKey{Alias: alias, Address: wallet.Address, Pk: wallet.Pk}}
+	if _, err := rand.Read(key.nonce[:]); err != nil {
+		return errors.Wrap(err, "failed to add key: could not generate nonce")
+	}
+
+	seed := ed25519.PrivateKey(wallet.Kp.PrivKey).Seed()
+	key.sealed = secretbox.Seal(nil, seed, &key.nonce, k.secret)
+	key.wallet = keystoreWallet(key.KeystoreKey, seed)
+	zeroize(seed)
+
+	k.keys = append(k.keys, key)
+
+	return nil
+}
+
+/*
+Remove Function
+Description: Removes a key from the keystore, zeroing its wallet if unlocked.
+
+Parameters:
+	aliasOrAddress:
+		The alias or address of the key.
+*/
+func (k *Keystore) Remove(aliasOrAddress string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	for i, key := range k.keys {
+		if key.Alias == aliasOrAddress || key.Address == aliasOrAddress {
+			zeroizeWallet(key.wallet)
+			k.keys = append(k.keys[:i], k.keys[i+1:]...)
+			return nil
+		}
+	}
+
+	return errors.Errorf("failed to remove key: no key '%s' in keystore", aliasOrAddress)
+}
+
+/*
+Wallet Function
+Description: Returns the wallet of a key, to sign with or pass to NewWalletClient. The keystore must be unlocked.
+The wallet is zeroed when the keystore is locked and must not be used after. Its Sk is not set, strings can
+not be zeroed.
+
+Parameters:
+	aliasOrAddress:
+		The alias or address of the key.
+*/
+func (k *Keystore) Wallet(aliasOrAddress string) (*Wallet, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.secret == nil {
+		return nil, errors.New("failed to get wallet: keystore is locked")
+	}
+	for _, key := range k.keys {
+		if key.Alias == aliasOrAddress || key.Address == aliasOrAddress {
+			return key.wallet, nil
+		}
+	}
+
+	return nil, errors.Errorf("failed to get wallet: no key '%s' in keystore", aliasOrAddress)
+}
+
+/*
+Keys Function
+Description: Returns the public part of the keys of the keystore, in the order they were added. Available
+while locked.
+*/
+func (k *Keystore) Keys() []KeystoreKey {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	var keys []KeystoreKey
+	for _, key := range k.keys {
+		keys = append(keys, key.KeystoreKey)
+	}
+
+	return keys
+}
+
+/*
+Unlock Function
+Description: Decrypts the keys of the keystore, making their wallets available. Unlocking an unlocked keystore
+checks the passphrase only.
+
+Parameters:
+	passphrase:
+		The passphrase of the keystore, see NewKeystore.
+*/
+func (k *Keystore) Unlock(passphrase string) error {
+	secret := keystoreSecret(passphrase, k.salt)
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if _, ok := secretbox.Open(nil, k.check, &[24]byte{}, secret); !ok {
+		zeroize(secret[:])
+		return errors.New("failed to unlock keystore: invalid passphrase")
+	}
+	if k.secret != nil {
+		zeroize(secret[:])
+		return nil
+	}
+
+	wallets := make([]*Wallet, len(k.keys))
+	for i, key := range k.keys {
+		seed, ok := secretbox.Open(nil, key.sealed, &key.nonce, secret)
+		if !ok {
+			for _, wallet := range wallets {
+				zeroizeWallet(wallet)
+			}
+			zeroize(secret[:])
+			return errors.Errorf("failed to unlock keystore: could not decrypt key '%s'", key.Alias)
+		}
+		wallets[i] = keystoreWallet(key.KeystoreKey, seed)
+		zeroize(seed)
+	}
+
+	for i, key := range k.keys {
+		key.wallet = wallets[i]
+	}
+	k.secret = secret
+
+	return nil
+}
+
+/*
+Lock Function
+Description: Zeroes the secret keys of the wallets of the keystore and the key derived from its passphrase, until
+it is unlocked again.
+*/
+func (k *Keystore) Lock() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	for _, key := range k.keys {
+		zeroizeWallet(key.wallet)
+		key.wallet = nil
+	}
+	if k.secret != nil {
+		zeroize(k.secret[:])
+		k.secret = nil
+	}
+}
+
+/*
+Locked Function
+Description: Returns whether the keystore is locked.
+*/
+func (k *Keystore) Locked() bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.secret == nil
+}
+
+// keystoreSecret derives the key encrypting the keys of a keystore from its passphrase, as edesk keys are.
+func keystoreSecret(passphrase string, salt []byte) *[32]byte {
+	key := pbkdf2.Key([]byte(passphrase), salt, 32768, 32, sha512.New)
+	var secret [32]byte
+	copy(secret[:], key)
+	zeroize(key)
+
+	return &secret
+}
+
+func keystoreWallet(key KeystoreKey, seed []byte) *Wallet {
+	privKey := ed25519.NewKeyFromSeed(seed)
+	return &Wallet{
+		Address: key.Address,
+		Pk:      key.Pk,
+		Kp:      keyPair{PrivKey: privKey, PubKey: []byte(privKey.Public().(ed25519.PublicKey))},
+	}
+}
+
+// zeroizeWallet zeroes the secret key of a wallet and unsets it, so that signing with the wallet fails.
+func zeroizeWallet(wallet *Wallet) {
+	if wallet != nil {
+		zeroize(wallet.Kp.PrivKey)
+		zeroize(wallet.Seed)
+		wallet.Kp.PrivKey, wallet.Seed = nil, nil
+	}
+}
+
+func zeroize(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
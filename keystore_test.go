@@ -0,0 +1,100 @@
+package gotezos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Keystore(t *testing.T) {
+	payouts, err := ImportWallet("tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G", "edskSA4oADtx6DTT6eXdBc6Pv5MoVBGXUzy8bBryi6D96RQNQYcRfVEXd2nuE2ZZPxs4YLZeM7KazUULFT1SfMDNyKFCUgk6vR")
+	assert.Nil(t, err)
+	fees, err := CreateWallet("normal dash crumble neutral reflect parrot know stairs culture fault check whale flock dog scout", "PYh8nXDQLB")
+	assert.Nil(t, err)
+
+	message := []byte("payout")
+	payoutsSig, err := payouts.Sign(message)
+	assert.Nil(t, err)
+
+	keystore, err := NewKeystore("passphrase")
+	assert.Nil(t, err)
+	assert.False(t, keystore.Locked())
+
+	assert.Nil(t, keystore.Add("payouts", payouts))
+	assert.Nil(t, keystore.Add("fees", fees))
+	checkErr(t, true, "alias 'payouts' or address", keystore.Add("payouts", fees))
+	checkErr(t, true, "already in keystore", keystore.Add("other", payouts))
+	checkErr(t, true, "wallet does not contain a valid ed25519 secret key", keystore.Add("empty", &Wallet{}))
+
+	t.Run("gets wallets by alias or address", func(t *testing.T) {
+		wallet, err := keystore.Wallet("payouts")
+		assert.Nil(t, err)
+		sig, err := wallet.Sign(message)
+		assert.Nil(t, err)
+		assert.Equal(t, payoutsSig, sig)
+
+		wallet, err = keystore.Wallet(fees.Address)
+		assert.Nil(t, err)
+		assert.Equal(t, fees.Pk, wallet.Pk)
+
+		_, err = keystore.Wallet("other")
+		checkErr(t, true, "no key 'other' in keystore", err)
+	})
+
+	t.Run("zeroes wallets on lock", func(t *testing.T) {
+		wallet, err := keystore.Wallet("payouts")
+		assert.Nil(t, err)
+		privKey := wallet.Kp.PrivKey
+
+		keystore.Lock()
+		assert.True(t, keystore.Locked())
+		assert.Equal(t, make([]byte, len(privKey)), []byte(privKey))
+		_, err = wallet.Sign(message)
+		checkErr(t, true, "wallet does not contain a valid ed25519 secret key", err)
+
+		// The wallets added are not the ones zeroed.
+		_, err = payouts.Sign(message)
+		assert.Nil(t, err)
+
+		_, err = keystore.Wallet("payouts")
+		checkErr(t, true, "keystore is locked", err)
+		checkErr(t, true, "keystore is locked", keystore.Add("other", &Wallet{Kp: payouts.Kp}))
+		assert.Equal(t, []KeystoreKey{
+			{Alias: "payouts", Address: payouts.Address, Pk: payouts.Pk},
+			{Alias: "fees", Address: fees.Address, Pk: fees.Pk},
+		}, keystore.Keys())
+	})
+
+	t.Run("unlocks with the passphrase", func(t *testing.T) {
+		checkErr(t, true, "invalid passphrase", keystore.Unlock("other"))
+		assert.True(t, keystore.Locked())
+
+		assert.Nil(t, keystore.Unlock("passphrase"))
+		wallet, err := keystore.Wallet("payouts")
+		assert.Nil(t, err)
+		sig, err := wallet.Sign(message)
+		assert.Nil(t, err)
+		assert.Equal(t, payoutsSig, sig)
+
+		checkErr(t, true, "invalid passphrase", keystore.Unlock("other"))
+		assert.False(t, keystore.Locked())
+	})
+
+	t.Run("removes keys", func(t *testing.T) {
+		wallet, err := keystore.Wallet("fees")
+		assert.Nil(t, err)
+
+		assert.Nil(t, keystore.Remove(fees.Address))
+		assert.Nil(t, wallet.Kp.PrivKey)
+		assert.Len(t, keystore.Keys(), 1)
+		checkErr(t, true, "no key 'fees' in keystore", keystore.Remove("fees"))
+	})
+
+	t.Run("checks the passphrase of an empty keystore", func(t *testing.T) {
+		keystore, err := NewKeystore("passphrase")
+		assert.Nil(t, err)
+		keystore.Lock()
+		checkErr(t, true, "invalid passphrase", keystore.Unlock("other"))
+		assert.Nil(t, keystore.Unlock("passphrase"))
+	})
+}
@@ -0,0 +1,542 @@
+package gotezos
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+var (
+	oidSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+	oidP256      = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+)
+
+/*
+AWSKMSSigner -
+Description: A Signer of which the key is an asymmetric ECC_SECG_P256K1 (tz2) or ECC_NIST_P256 (tz3) key of AWS
+KMS, so that the secret key never leaves KMS. The IAM user of the credentials needs the kms:Sign and
+kms:GetPublicKey permissions on the key.
+
+Function: func NewAWSKMSSigner(region, keyID string, credentials AWSCredentials) *AWSKMSSigner {}
+*/
+type AWSKMSSigner struct {
+	client      client
+	host        string
+	region      string
+	keyID       string
+	credentials AWSCredentials
+	key         ecdsaKeyCache
+}
+
+/*
+AWSCredentials -
+Description: The credentials of an IAM user or role used to sign the requests to AWS KMS.
+*/
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	// The token of temporary credentials (e.g. of an assumed role), optional.
+	SessionToken string
+}
+
+/*
+NewAWSKMSSigner Function
+Description: Returns a Signer signing with a key of AWS KMS.
+
+Parameters:
+	region:
+		The region of the key (e.g. eu-west-1).
+	keyID:
+		The id, ARN or alias (alias/<name>) of the key.
+	credentials:
+		The credentials to sign the requests with.
+*/
+func NewAWSKMSSigner(region, keyID string, credentials AWSCredentials) *AWSKMSSigner {
+	return &AWSKMSSigner{
+		client:      newKMSClient(),
+		host:        fmt.Sprintf("https://kms.%s.amazonaws.com", region),
+		region:      region,
+		keyID:       keyID,
+		credentials: credentials,
+	}
+}
+
+/*
+SetClient Function
+Description: Overrides the http.Client of the signer.
+
+Parameters:
+	client:
+		A pointer to an http.Client.
+*/
+func (a *AWSKMSSigner) SetClient(client *http.Client) {
+	a.client = client
+}
+
+/*
+SetEndpoint Function
+Description: Overrides the endpoint of AWS KMS, e.g. with a VPC endpoint.
+
+Parameters:
+	endpoint:
+		The URL of the endpoint.
+*/
+func (a *AWSKMSSigner) SetEndpoint(endpoint string) {
+	a.host = strings.TrimSuffix(endpoint, "/")
+}
+
+/*
+Sign Function
+Description: Signs the blake2b-256 digest of a message with the key. Returns the spsig1 or p2sig encoded
+signature, with a low s.
+
+Parameters:
+	message:
+		The bytes to sign, see SignOperationWith to sign operations.
+*/
+func (a *AWSKMSSigner) Sign(message []byte) (string, error) {
+	key, err := a.key.get(a.publicKey)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign with aws kms")
+	}
+
+	digest := blake2b.Sum256(message)
+	var resp struct {
+		Signature []byte
+	}
+	err = a.call("Sign", map[string]interface{}{
+		"KeyId":            a.keyID,
+		"Message":          digest[:],
+		"MessageType":      "DIGEST",
+		"SigningAlgorithm": "ECDSA_SHA_256",
+	}, &resp)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign with aws kms")
+	}
+
+	signature, err := key.derSignature(resp.Signature)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign with aws kms")
+	}
+
+	return signature, nil
+}
+
+/*
+PublicKey Function
+Description: Returns the sppk or p2pk public key of the key, fetched once.
+*/
+func (a *AWSKMSSigner) PublicKey() (string, error) {
+	key, err := a.key.get(a.publicKey)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get public key from aws kms")
+	}
+
+	return key.pk, nil
+}
+
+/*
+Address Function
+Description: Returns the tz2 or tz3 address of the key, fetched once.
+*/
+func (a *AWSKMSSigner) Address() (string, error) {
+	key, err := a.key.get(a.publicKey)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get public key from aws kms")
+	}
+
+	return key.address, nil
+}
+
+func (a *AWSKMSSigner) publicKey() (*ecdsaKey, error) {
+	var resp struct {
+		PublicKey []byte
+	}
+	if err := a.call("GetPublicKey", map[string]string{"KeyId": a.keyID}, &resp); err != nil {
+		return nil, err
+	}
+
+	return parseECDSAPublicKey(resp.PublicKey)
+}
+
+// call calls an action of the AWS KMS JSON API, signing the request with signature version 4.
+func (a *AWSKMSSigner) call(action string, in, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %s request", action)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, a.host+"/", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to construct request")
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+action)
+	signAWSRequest(req, body, a.credentials, a.region, "kms", time.Now())
+
+	return kmsDo(a.client, req, out)
+}
+
+// signAWSRequest adds the signature version 4 of a request to its headers, see
+// https://docs.aws.amazon.com/general/latest/gr/sigv4_signing.html.
+func signAWSRequest(req *http.Request, body []byte, credentials AWSCredentials, region, service string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+	if credentials.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", credentials.SessionToken)
+	}
+
+	names := []string{"host"}
+	for name := range req.Header {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.URL.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := strings.Join([]string{amzDate[:8], region, service, "aws4_request"}, "/")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(requestHash[:])}, "\n")
+
+	key := []byte("AWS4" + credentials.SecretAccessKey)
+	for _, part := range []string{amzDate[:8], region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		credentials.AccessKeyID,
+		scope,
+		signedHeaders,
+		hex.EncodeToString(hmacSHA256(key, stringToSign)),
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+/*
+GoogleKMSSigner -
+Description: A Signer of which the key is an EC_SIGN_SECP256K1_SHA256 (tz2) or EC_SIGN_P256_SHA256 (tz3) key
+version of Google Cloud KMS, so that the secret key never leaves KMS. The account of the token needs the
+cloudkms.cryptoKeyVersions.useToSign and cloudkms.cryptoKeyVersions.viewPublicKey permissions on the key.
+
+Function: func NewGoogleKMSSigner(keyVersion string, token func() (string, error)) *GoogleKMSSigner {}
+*/
+type GoogleKMSSigner struct {
+	client     client
+	host       string
+	keyVersion string
+	token      func() (string, error)
+	key        ecdsaKeyCache
+}
+
+/*
+NewGoogleKMSSigner Function
+Description: Returns a Signer signing with a key version of Google Cloud KMS.
+
+Parameters:
+	keyVersion:
+		The resource name of the key version
+		(projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>/cryptoKeyVersions/<version>).
+	token:
+		Returns an OAuth 2.0 access token to authorize the requests with, e.g. the Token of an
+		oauth2.TokenSource. It is called for each request.
+*/
+func NewGoogleKMSSigner(keyVersion string, token func() (string, error)) *GoogleKMSSigner {
+	return &GoogleKMSSigner{
+		client:     newKMSClient(),
+		host:       "https://cloudkms.googleapis.com",
+		keyVersion: keyVersion,
+		token:      token,
+	}
+}
+
+/*
+SetClient Function
+Description: Overrides the http.Client of the signer.
+
+Parameters:
+	client:
+		A pointer to an http.Client.
+*/
+func (g *GoogleKMSSigner) SetClient(client *http.Client) {
+	g.client = client
+}
+
+/*
+SetEndpoint Function
+Description: Overrides the endpoint of Google Cloud KMS, e.g. with a Private Service Connect endpoint.
+
+Parameters:
+	endpoint:
+		The URL of the endpoint.
+*/
+func (g *GoogleKMSSigner) SetEndpoint(endpoint string) {
+	g.host = strings.TrimSuffix(endpoint, "/")
+}
+
+/*
+Sign Function
+Description: Signs the blake2b-256 digest of a message with the key version. Returns the spsig1 or p2sig
+encoded signature, with a low s.
+
+Parameters:
+	message:
+		The bytes to sign, see SignOperationWith to sign operations.
+*/
+func (g *GoogleKMSSigner) Sign(message []byte) (string, error) {
+	key, err := g.key.get(g.publicKey)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign with google kms")
+	}
+
+	// KMS signs the digest as it is, whatever its hash function.
+	digest := blake2b.Sum256(message)
+	body, err := json.Marshal(map[string]interface{}{"digest": map[string][]byte{"sha256": digest[:]}})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign with google kms")
+	}
+
+	var resp struct {
+		Signature []byte `json:"signature"`
+	}
+	if err := g.call(http.MethodPost, ":asymmetricSign", body, &resp); err != nil {
+		return "", errors.Wrap(err, "failed to sign with google kms")
+	}
+
+	signature, err := key.derSignature(resp.Signature)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign with google kms")
+	}
+
+	return signature, nil
+}
+
+/*
+PublicKey Function
+Description: Returns the sppk or p2pk public key of the key version, fetched once.
+*/
+func (g *GoogleKMSSigner) PublicKey() (string, error) {
+	key, err := g.key.get(g.publicKey)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get public key from google kms")
+	}
+
+	return key.pk, nil
+}
+
+/*
+Address Function
+Description: Returns the tz2 or tz3 address of the key version, fetched once.
+*/
+func (g *GoogleKMSSigner) Address() (string, error) {
+	key, err := g.key.get(g.publicKey)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get public key from google kms")
+	}
+
+	return key.address, nil
+}
+
+func (g *GoogleKMSSigner) publicKey() (*ecdsaKey, error) {
+	var resp struct {
+		PEM string `json:"pem"`
+	}
+	if err := g.call(http.MethodGet, "/publicKey", nil, &resp); err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode([]byte(resp.PEM))
+	if block == nil {
+		return nil, errors.New("invalid public key: not pem encoded")
+	}
+
+	return parseECDSAPublicKey(block.Bytes)
+}
+
+func (g *GoogleKMSSigner) call(method, suffix string, body []byte, out interface{}) error {
+	token, err := g.token()
+	if err != nil {
+		return errors.Wrap(err, "failed to get access token")
+	}
+
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/v1/%s%s", g.host, g.keyVersion, suffix), bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to construct request")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	return kmsDo(g.client, req, out)
+}
+
+func newKMSClient() *http.Client {
+	return &http.Client{Timeout: time.Second * 10}
+}
+
+// kmsDo sends a request to a KMS and unmarshals its response, returning the error of the KMS if it fails.
+func kmsDo(c client, req *http.Request, out interface{}) error {
+	resp, err := c.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send request")
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("response returned code %d with body %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return errors.Wrap(json.Unmarshal(body, out), "failed to unmarshal response")
+}
+
+// ecdsaKey is the public key of a secp256k1 or P-256 key kept out of the process, in a KMS or an HSM.
+type ecdsaKey struct {
+	curve   weierstrassCurve
+	prefix  prefix
+	pk      string
+	address string
+}
+
+// ecdsaKeyCache fetches the public key of a key once it succeeds.
+type ecdsaKeyCache struct {
+	mu  sync.Mutex
+	key *ecdsaKey
+}
+
+func (c *ecdsaKeyCache) get(fetch func() (*ecdsaKey, error)) (*ecdsaKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.key == nil {
+		key, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		c.key = key
+	}
+
+	return c.key, nil
+}
+
+// parseECDSAPublicKey parses a DER encoded SubjectPublicKeyInfo of a secp256k1 or P-256 key, which x509 does not
+// parse for secp256k1.
+func parseECDSAPublicKey(der []byte) (*ecdsaKey, error) {
+	var spki struct {
+		Algorithm struct {
+			Algorithm asn1.ObjectIdentifier
+			Curve     asn1.ObjectIdentifier
+		}
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(der, &spki); err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+
+	return newECDSAKey(spki.Algorithm.Curve, spki.PublicKey.Bytes)
+}
+
+// newECDSAKey returns the key of an uncompressed point of a curve.
+func newECDSAKey(curve asn1.ObjectIdentifier, point []byte) (*ecdsaKey, error) {
+	if len(point) != 65 || point[0] != 4 {
+		return nil, errors.New("invalid public key: expected an uncompressed point")
+	}
+	compressed := append([]byte{2 + point[64]&1}, point[1:33]...)
+
+	var key ecdsaKey
+	var pkPrefix, addressPrefix prefix
+	switch {
+	case curve.Equal(oidSecp256k1):
+		key.curve, key.prefix, pkPrefix, addressPrefix = secp256k1, prefix_spsig, prefix_sppk, prefix_tz2
+	case curve.Equal(oidP256):
+		key.curve, key.prefix, pkPrefix, addressPrefix = p256, prefix_p2sig, prefix_p2pk, prefix_tz3
+	default:
+		return nil, errors.Errorf("invalid public key: unsupported curve %s", curve)
+	}
+
+	hash, err := blake2b.New(20, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to hash public key")
+	}
+	hash.Write(compressed)
+
+	key.pk = b58cencode(compressed, pkPrefix)
+	key.address = b58cencode(hash.Sum(nil), addressPrefix)
+
+	return &key, nil
+}
+
+// derSignature converts a DER encoded ECDSA signature, as returned by KMS, to a Tezos signature.
+func (k *ecdsaKey) derSignature(der []byte) (string, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+	if _, err := asn1.Unmarshal(der, &sig); err != nil {
+		return "", errors.Wrap(err, "invalid signature")
+	}
+
+	return k.signature(sig.R, sig.S)
+}
+
+// signature converts an ECDSA signature to a Tezos signature. Its s is made low (n - s if above n / 2) as the
+// signature is otherwise malleable, and rejected by Tezos for secp256k1.
+func (k *ecdsaKey) signature(r, s *big.Int) (string, error) {
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(k.curve.n) >= 0 || s.Cmp(k.curve.n) >= 0 {
+		return "", errors.New("invalid signature: r or s out of range")
+	}
+
+	if s.Cmp(new(big.Int).Rsh(k.curve.n, 1)) > 0 {
+		s = new(big.Int).Sub(k.curve.n, s)
+	}
+
+	signature := make([]byte, 64)
+	copy(signature[32-len(r.Bytes()):], r.Bytes())
+	copy(signature[64-len(s.Bytes()):], s.Bytes())
+
+	return b58cencode(signature, k.prefix), nil
+}
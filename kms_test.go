@@ -0,0 +1,225 @@
+package gotezos
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// kmsTestKey is a key of a mock KMS, signing digests with a high s to check signers normalize it.
+type kmsTestKey struct {
+	curve weierstrassCurve
+	d     *big.Int
+	der   []byte
+}
+
+func newKMSTestKey(t *testing.T, curve string) *kmsTestKey {
+	if curve == "p256" {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.Nil(t, err)
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		assert.Nil(t, err)
+		params := elliptic.P256().Params()
+		return &kmsTestKey{weierstrassCurve{p: params.P, a: big.NewInt(-3), b: params.B, n: params.N, gx: params.Gx, gy: params.Gy}, key.D, der}
+	}
+
+	// The key of sppk7aj8bC26meHTtfixTudrNdCEUfTnXnqQBYbqVAJM4o96fzcEixK.
+	d := hexInt("1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+	q := secp256k1.multiply(point{secp256k1.gx, secp256k1.gy}, d)
+	uncompressed := make([]byte, 65)
+	uncompressed[0] = 4
+	copy(uncompressed[33-len(q[0].Bytes()):], q[0].Bytes())
+	copy(uncompressed[65-len(q[1].Bytes()):], q[1].Bytes())
+
+	der, err := asn1.Marshal(struct {
+		Algorithm struct {
+			Algorithm asn1.ObjectIdentifier
+			Curve     asn1.ObjectIdentifier
+		}
+		PublicKey asn1.BitString
+	}{
+		Algorithm: struct {
+			Algorithm asn1.ObjectIdentifier
+			Curve     asn1.ObjectIdentifier
+		}{asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}, oidSecp256k1},
+		PublicKey: asn1.BitString{Bytes: uncompressed, BitLength: 520},
+	})
+	assert.Nil(t, err)
+
+	return &kmsTestKey{secp256k1, d, der}
+}
+
+func (k *kmsTestKey) sign(t *testing.T, digest []byte) []byte {
+	nonce, err := rand.Int(rand.Reader, k.curve.n)
+	assert.Nil(t, err)
+	nonce.Add(nonce, big.NewInt(1))
+
+	r := new(big.Int).Mod(k.curve.multiply(point{k.curve.gx, k.curve.gy}, nonce)[0], k.curve.n)
+	s := new(big.Int).Mul(r, k.d)
+	s.Add(s, new(big.Int).SetBytes(digest)).Mul(s, new(big.Int).ModInverse(nonce, k.curve.n)).Mod(s, k.curve.n)
+	if s.Cmp(new(big.Int).Rsh(k.curve.n, 1)) <= 0 {
+		s.Sub(k.curve.n, s)
+	}
+
+	der, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+	assert.Nil(t, err)
+	return der
+}
+
+func Test_AWSKMSSigner(t *testing.T) {
+	message := []byte("payout")
+
+	for _, curve := range []string{"secp256k1", "p256"} {
+		t.Run(curve, func(t *testing.T) {
+			key := newKMSTestKey(t, curve)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Regexp(t, "^AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/[0-9]{8}/eu-west-1/kms/aws4_request, ", r.Header.Get("Authorization"))
+				assert.Equal(t, "token", r.Header.Get("X-Amz-Security-Token"))
+
+				var req struct {
+					KeyID       string `json:"KeyId"`
+					Message     []byte
+					MessageType string
+				}
+				assert.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, "alias/payouts", req.KeyID)
+
+				switch r.Header.Get("X-Amz-Target") {
+				case "TrentService.GetPublicKey":
+					json.NewEncoder(w).Encode(map[string][]byte{"PublicKey": key.der})
+				case "TrentService.Sign":
+					assert.Equal(t, "DIGEST", req.MessageType)
+					json.NewEncoder(w).Encode(map[string][]byte{"Signature": key.sign(t, req.Message)})
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			}))
+			defer server.Close()
+
+			signer := NewAWSKMSSigner("eu-west-1", "alias/payouts", AWSCredentials{
+				AccessKeyID:     "AKIDEXAMPLE",
+				SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+				SessionToken:    "token",
+			})
+			signer.SetEndpoint(server.URL)
+
+			checkKMSSigner(t, curve, signer, signer.PublicKey, signer.Address)
+		})
+	}
+
+	t.Run("handles kms error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"AccessDeniedException","message":"denied"}`))
+		}))
+		defer server.Close()
+
+		signer := NewAWSKMSSigner("eu-west-1", "alias/payouts", AWSCredentials{})
+		signer.SetEndpoint(server.URL)
+
+		_, err := signer.Sign(message)
+		checkErr(t, true, "failed to sign with aws kms: response returned code 400 with body {\"__type\":\"AccessDeniedException\"", err)
+	})
+}
+
+func Test_signAWSRequest(t *testing.T) {
+	// The example of https://docs.aws.amazon.com/general/latest/gr/sigv4_signing.html.
+	req, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	assert.Nil(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	now, err := time.Parse("20060102T150405Z", "20150830T123600Z")
+	assert.Nil(t, err)
+
+	signAWSRequest(req, nil, AWSCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}, "us-east-1", "iam", now)
+	assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7", req.Header.Get("Authorization"))
+}
+
+func Test_GoogleKMSSigner(t *testing.T) {
+	keyVersion := "projects/baker/locations/global/keyRings/tezos/cryptoKeys/payouts/cryptoKeyVersions/1"
+
+	for _, curve := range []string{"secp256k1", "p256"} {
+		t.Run(curve, func(t *testing.T) {
+			key := newKMSTestKey(t, curve)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/v1/"+keyVersion+"/publicKey":
+					json.NewEncoder(w).Encode(map[string]string{
+						"pem": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: key.der})),
+					})
+				case r.Method == http.MethodPost && r.URL.Path == "/v1/"+keyVersion+":asymmetricSign":
+					var req struct {
+						Digest struct {
+							SHA256 []byte `json:"sha256"`
+						} `json:"digest"`
+					}
+					assert.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+					json.NewEncoder(w).Encode(map[string][]byte{"signature": key.sign(t, req.Digest.SHA256)})
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			signer := NewGoogleKMSSigner(keyVersion, func() (string, error) { return "token", nil })
+			signer.SetEndpoint(server.URL)
+
+			checkKMSSigner(t, curve, signer, signer.PublicKey, signer.Address)
+		})
+	}
+
+	t.Run("handles token error", func(t *testing.T) {
+		signer := NewGoogleKMSSigner(keyVersion, func() (string, error) { return "", errors.New("token expired") })
+		_, err := signer.Sign([]byte("payout"))
+		checkErr(t, true, "failed to sign with google kms: failed to get access token", err)
+	})
+}
+
+func checkKMSSigner(t *testing.T, curve string, signer Signer, publicKey, address func() (string, error)) {
+	pk, err := publicKey()
+	assert.Nil(t, err)
+	addr, err := address()
+	assert.Nil(t, err)
+	assert.Nil(t, CheckAddressChecksum(addr))
+	if curve == "secp256k1" {
+		assert.Equal(t, "sppk7aj8bC26meHTtfixTudrNdCEUfTnXnqQBYbqVAJM4o96fzcEixK", pk)
+		assert.True(t, strings.HasPrefix(addr, "tz2"))
+	} else {
+		assert.True(t, strings.HasPrefix(pk, "p2pk"))
+		assert.True(t, strings.HasPrefix(addr, "tz3"))
+	}
+
+	message := []byte("payout")
+	signature, err := signer.Sign(message)
+	assert.Nil(t, err)
+	ok, err := VerifySignature(pk, message, signature)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	sig, err := signatureToBytes(signature)
+	assert.Nil(t, err)
+	n := secp256k1.n
+	if curve == "p256" {
+		n = p256.n
+	}
+	assert.True(t, new(big.Int).SetBytes(sig[32:]).Cmp(new(big.Int).Rsh(n, 1)) <= 0, "s is not low")
+
+	signed, err := SignOperationWith(signer, "a732d3520eeaa3de98d78e5e5cb6c85f72204fd46feb9f76853841d4a701add36e00")
+	assert.Nil(t, err)
+	assert.Len(t, signed.SignedOperation, len(signed.Operation)+128)
+}
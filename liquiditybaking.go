@@ -0,0 +1,101 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// LiquidityBakingToggleVoteOn is a vote to continue the liquidity baking subsidy.
+	LiquidityBakingToggleVoteOn = "on"
+	// LiquidityBakingToggleVoteOff is a vote to stop the liquidity baking subsidy.
+	LiquidityBakingToggleVoteOff = "off"
+	// LiquidityBakingToggleVotePass is an abstention from the liquidity baking vote.
+	LiquidityBakingToggleVotePass = "pass"
+)
+
+/*
+LiquidityBaking -
+Description: The liquidity baking state of a block, gathered from the block metadata, the constants
+and the context.
+*/
+type LiquidityBaking struct {
+	// The address of the constant product market making (CPMM) contract receiving the subsidy.
+	CPMMAddress string
+	// The amount of mutez minted to the CPMM contract each block.
+	Subsidy string
+	// The level after which the subsidy stops (protocols before Ithaca only, 0 otherwise).
+	SunsetLevel int
+	// The exponential moving average of the escape votes (Granada to Ithaca).
+	EscapeEma int
+	// The exponential moving average of the toggle votes (Jakarta and later).
+	ToggleEma int
+	// The moving average above which the subsidy is deactivated.
+	EmaThreshold int
+}
+
+/*
+LiquidityBakingCPMMAddress RPC
+Path: ../<block_id>/context/liquidity_baking/cpmm_address (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-liquidity-baking-cpmm-address
+Description: Returns the address of the liquidity baking CPMM contract.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+*/
+func (t *GoTezos) LiquidityBakingCPMMAddress(blockID BlockID) (string, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/liquidity_baking/cpmm_address", blockID.ID()))
+	if err != nil {
+		return "", errors.Wrap(err, "could not get liquidity baking cpmm address")
+	}
+
+	var address string
+	err = json.Unmarshal(resp, &address)
+	if err != nil {
+		return "", errors.Wrap(err, "could not unmarshal liquidity baking cpmm address")
+	}
+
+	return address, nil
+}
+
+/*
+LiquidityBaking Function
+Description: Returns the liquidity baking state (CPMM address, subsidy and vote moving average) at a block.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+*/
+func (t *GoTezos) LiquidityBaking(blockID BlockID) (*LiquidityBaking, error) {
+	address, err := t.LiquidityBakingCPMMAddress(blockID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get liquidity baking state")
+	}
+
+	block, err := t.Block(blockID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get liquidity baking state")
+	}
+
+	constants, err := t.Constants(blockID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get liquidity baking state")
+	}
+
+	threshold := constants.LiquidityBakingToggleEmaThreshold
+	if threshold == 0 {
+		threshold = constants.LiquidityBakingEscapeEmaThreshold
+	}
+
+	return &LiquidityBaking{
+		CPMMAddress:  address,
+		Subsidy:      constants.LiquidityBakingSubsidy,
+		SunsetLevel:  constants.LiquidityBakingSunsetLevel,
+		EscapeEma:    block.Metadata.LiquidityBakingEscapeEma,
+		ToggleEma:    block.Metadata.LiquidityBakingToggleEma,
+		EmaThreshold: threshold,
+	}, nil
+}
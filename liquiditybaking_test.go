@@ -0,0 +1,100 @@
+package gotezos
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	mockLiquidityBakingBlockResp     = []byte(`{"protocol":"PtJakart2xVj7pYXJBXrqHgd82rdkLey5ZeeGwDgPp9rhQUbSqY","hash":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","header":{"level":2490369,"proto":13,"liquidity_baking_toggle_vote":"pass"},"metadata":{"liquidity_baking_toggle_ema":83886101}}`)
+	mockLiquidityBakingConstantsResp = []byte(`{"liquidity_baking_subsidy":"2500000","liquidity_baking_sunset_level":3063809,"liquidity_baking_toggle_ema_threshold":1000000000}`)
+	mockLiquidityBakingCPMMResp      = []byte(`"KT1TxqZ8QtKvLu3V3JH7Gx58n7Co8pgtpQU5"`)
+)
+
+func Test_LiquidityBaking(t *testing.T) {
+	type want struct {
+		err             bool
+		containsErr     string
+		liquidityBaking *LiquidityBaking
+	}
+
+	cases := []struct {
+		name        string
+		inputHanler http.Handler
+		want
+	}{
+		{
+			"handles failure to get cpmm address",
+			gtGoldenHTTPMock(liquidityBakingCPMMHandlerMock(mockRPCErrorResp, blankHandler)),
+			want{
+				true,
+				"could not get liquidity baking cpmm address",
+				nil,
+			},
+		},
+		{
+			"handles failure to unmarshal cpmm address",
+			gtGoldenHTTPMock(liquidityBakingCPMMHandlerMock([]byte(`junk`), blankHandler)),
+			want{
+				true,
+				"could not unmarshal liquidity baking cpmm address",
+				nil,
+			},
+		},
+		{
+			"handles failure to get block",
+			gtGoldenHTTPMock(liquidityBakingCPMMHandlerMock(mockLiquidityBakingCPMMResp, newBlockMock().handler(mockRPCErrorResp, blankHandler))),
+			want{
+				true,
+				"failed to get liquidity baking state",
+				nil,
+			},
+		},
+		{
+			"is successful",
+			gtGoldenHTTPMock(liquidityBakingCPMMHandlerMock(mockLiquidityBakingCPMMResp, newConstantsMock().handler(mockLiquidityBakingConstantsResp, newBlockMock().handler(mockLiquidityBakingBlockResp, blankHandler)))),
+			want{
+				false,
+				"",
+				&LiquidityBaking{
+					CPMMAddress:  "KT1TxqZ8QtKvLu3V3JH7Gx58n7Co8pgtpQU5",
+					Subsidy:      "2500000",
+					SunsetLevel:  3063809,
+					ToggleEma:    83886101,
+					EmaThreshold: 1000000000,
+				},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.inputHanler)
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			liquidityBaking, err := gt.LiquidityBaking(BlockIDHead{})
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			assert.Equal(t, tt.want.liquidityBaking, liquidityBaking)
+		})
+	}
+}
+
+func Test_LiquidityBakingToggleVote(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(newBlockMock().handler(mockLiquidityBakingBlockResp, blankHandler)))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	block, err := gt.Block(BlockIDHead{})
+	assert.Nil(t, err)
+	assert.Equal(t, LiquidityBakingToggleVotePass, block.Header.LiquidityBakingToggleVote)
+	assert.False(t, block.Header.LiquidityBakingEscapeVote)
+	assert.Equal(t, 83886101, block.Metadata.LiquidityBakingToggleEma)
+}
@@ -0,0 +1,172 @@
+package gotezos
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+/*
+MempoolMonitorInput -
+Description: The operation classifications streamed by MonitorMempool. If none are set, only applied
+(validated) operations are streamed, as the node does by default.
+Function: func (t *GoTezos) MonitorMempool(ctx context.Context, input *MempoolMonitorInput) (<-chan MempoolOperation, <-chan error, error) {}
+*/
+type MempoolMonitorInput struct {
+	// Stream operations that apply on the current head ("validated" since Lima).
+	Applied bool
+
+	// Stream operations that were refused by the node.
+	Refused bool
+
+	// Stream operations that are too old to be included.
+	Outdated bool
+
+	// Stream operations refused on the current branch, that may apply on another branch.
+	BranchRefused bool
+
+	// Stream operations that may apply on a later head.
+	BranchDelayed bool
+
+	// How long the node can send nothing before the stream is considered dead (e.g. its connection silently
+	// dropped) and reopened. Defaults to DefaultStreamHeartbeat.
+	Heartbeat time.Duration
+}
+
+func (m *MempoolMonitorInput) contructRPCOptions(protocol Protocol) []RPCOption {
+	if !m.Applied && !m.Refused && !m.Outdated && !m.BranchRefused && !m.BranchDelayed {
+		return nil
+	}
+
+	applied := "applied"
+	if protocol.atLeast("Lima") {
+		applied = "validated"
+	}
+
+	return []RPCOption{
+		{Key: applied, Value: strconv.FormatBool(m.Applied)},
+		{Key: "refused", Value: strconv.FormatBool(m.Refused)},
+		{Key: "outdated", Value: strconv.FormatBool(m.Outdated)},
+		{Key: "branch_refused", Value: strconv.FormatBool(m.BranchRefused)},
+		{Key: "branch_delayed", Value: strconv.FormatBool(m.BranchDelayed)},
+	}
+}
+
+/*
+MempoolOperation -
+RPC: /chains/<chain_id>/mempool/monitor_operations (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-chains-chain-id-mempool-monitor-operations
+Description: An operation of the mempool. Error is set for operations that are not applied.
+*/
+type MempoolOperation struct {
+	Protocol  string     `json:"protocol"`
+	Hash      string     `json:"hash"`
+	Branch    string     `json:"branch"`
+	Contents  []Contents `json:"contents"`
+	Signature string     `json:"signature"`
+	Error     []Error    `json:"error,omitempty"`
+}
+
+/*
+MonitorMempool RPC
+Path: /chains/main/mempool/monitor_operations (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-chains-chain-id-mempool-monitor-operations
+Description: Streams the operations entering the mempool. The node ends the stream when its head
+changes; the stream is reopened so that operations keep being sent across blocks, as it is when nothing
+was received for the heartbeat of the input. Both channels are
+closed when monitoring stops: once an RPC fails or the context is done. The error channel receives
+the reason monitoring stopped, if any.
+
+Parameters:
+	ctx:
+		Cancels monitoring.
+	input:
+		The classifications of the operations to stream.
+*/
+func (t *GoTezos) MonitorMempool(ctx context.Context, input *MempoolMonitorInput) (<-chan MempoolOperation, <-chan error, error) {
+	if input == nil {
+		input = &MempoolMonitorInput{}
+	}
+	opts := input.contructRPCOptions(t.Protocol())
+	ctx, cancel := t.untilClosed(ctx)
+
+	subscribe := func() (io.ReadCloser, error) {
+		body, err := t.streamContext(ctx, "/chains/main/mempool/monitor_operations", opts...)
+		if err != nil {
+			return nil, err
+		}
+		return withHeartbeat(body, input.Heartbeat), nil
+	}
+
+	body, err := subscribe()
+	if err != nil {
+		cancel()
+		return nil, nil, errors.Wrap(err, "failed to monitor mempool")
+	}
+
+	operations := make(chan MempoolOperation)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer cancel()
+		defer close(errs)
+		defer close(operations)
+
+		for {
+			err := monitorMempoolStream(ctx, body, operations)
+			body.Close()
+			if ctx.Err() != nil {
+				errs <- ctx.Err()
+				return
+			}
+			if err != nil {
+				errs <- errors.Wrap(err, "failed to monitor mempool")
+				return
+			}
+
+			body, err = subscribe()
+			if err != nil {
+				if ctx.Err() != nil {
+					err = ctx.Err()
+				}
+				errs <- errors.Wrap(err, "failed to monitor mempool")
+				return
+			}
+		}
+	}()
+
+	return operations, errs, nil
+}
+
+// monitorMempoolStream sends the operations of a monitor_operations stream, a sequence of JSON lists, until
+// it ends. A stream that ends or receives nothing for the heartbeat while waiting for the next list is not an
+// error.
+func monitorMempoolStream(ctx context.Context, body io.Reader, operations chan<- MempoolOperation) error {
+	decoder := json.NewDecoder(body)
+	for {
+		var chunk []MempoolOperation
+		err := decoder.Decode(&chunk)
+		if err == io.EOF {
+			return nil
+		}
+		// Only a silent stream is reopened, other timeouts (e.g. of the client) are errors.
+		if _, ok := errors.Cause(err).(heartbeatTimeout); ok {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "could not unmarshal mempool operations")
+		}
+
+		for _, operation := range chunk {
+			select {
+			case operations <- operation:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
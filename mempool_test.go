@@ -0,0 +1,205 @@
+package gotezos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mempoolMonitorHandlerMock(chunks [][]string, queries *[]string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/chains/main/mempool/monitor_operations") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		*queries = append(*queries, r.URL.RawQuery)
+		if len(chunks) == 0 {
+			<-r.Context().Done()
+			return
+		}
+
+		for _, chunk := range chunks[0] {
+			w.Write([]byte(chunk))
+			w.(http.Flusher).Flush()
+		}
+		chunks = chunks[1:]
+	})
+}
+
+func Test_MonitorMempool(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(gtGoldenHTTPMock(mempoolMonitorHandlerMock([][]string{
+		{
+			`[{"hash":"opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A","protocol":"PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS","branch":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","contents":[{"kind":"endorsement","level":656938}],"signature":"sigvU29YNjSN8foVQRgqBYWS1wsqSGmWtnE6WrTiq9zfMxAnyrq7zJdPGVQiLUTTmqEzDjjRKRsdRnDbsnXUgc1afnqRApru"}]`,
+			`[{"hash":"ooFKNB2Ld7HGpcMMc6dPxgkhsDMB8ey3dZGMYPeAqKGRE1uzhce","protocol":"PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS","branch":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","contents":[{"kind":"transaction","source":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","fee":"1283","counter":"2","gas_limit":"10307","storage_limit":"0","amount":"1","destination":"tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q"}],"signature":"sigvU29YNjSN8foVQRgqBYWS1wsqSGmWtnE6WrTiq9zfMxAnyrq7zJdPGVQiLUTTmqEzDjjRKRsdRnDbsnXUgc1afnqRApru","error":[{"kind":"temporary","id":"proto.005-PsBabyM1.contract.counter_in_the_future"}]}]`,
+		},
+		{
+			`[{"hash":"onxSWdKPPxYTGFu4j2gzYpRfJsrM3EB5ZU6rMFsLb6CS5LcCDUz","protocol":"PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS","branch":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","contents":[{"kind":"endorsement","level":656939}],"signature":"sigvU29YNjSN8foVQRgqBYWS1wsqSGmWtnE6WrTiq9zfMxAnyrq7zJdPGVQiLUTTmqEzDjjRKRsdRnDbsnXUgc1afnqRApru"}]`,
+		},
+	}, &queries, blankHandler)))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	operations, errs, err := gt.MonitorMempool(ctx, &MempoolMonitorInput{Applied: true, BranchDelayed: true})
+	assert.Nil(t, err)
+
+	var hashes []string
+	for operation := range operations {
+		hashes = append(hashes, operation.Hash)
+		if len(hashes) == 2 {
+			assert.Equal(t, "proto.005-PsBabyM1.contract.counter_in_the_future", operation.Error[0].ID)
+			assert.Equal(t, "1", operation.Contents[0].Amount.String())
+		}
+		if len(hashes) == 3 {
+			cancel()
+		}
+	}
+	checkErr(t, true, "context canceled", <-errs)
+
+	assert.Equal(t, []string{
+		"opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A",
+		"ooFKNB2Ld7HGpcMMc6dPxgkhsDMB8ey3dZGMYPeAqKGRE1uzhce",
+		"onxSWdKPPxYTGFu4j2gzYpRfJsrM3EB5ZU6rMFsLb6CS5LcCDUz",
+	}, hashes)
+	assert.Contains(t, queries[0], "applied=true")
+	assert.Contains(t, queries[0], "branch_delayed=true")
+	assert.Contains(t, queries[0], "refused=false")
+
+	gt.SetProtocol("PtLimaPtLMwfNinJi9rCfDPWea8dFgTZ1MeJ9f1m2SRic6ayiwW")
+	assert.Equal(t, []RPCOption{
+		{Key: "validated", Value: "true"},
+		{Key: "refused", Value: "false"},
+		{Key: "outdated", Value: "false"},
+		{Key: "branch_refused", Value: "false"},
+		{Key: "branch_delayed", Value: "false"},
+	}, (&MempoolMonitorInput{Applied: true}).contructRPCOptions(gt.Protocol()))
+	assert.Nil(t, (&MempoolMonitorInput{}).contructRPCOptions(gt.Protocol()))
+}
+
+func Test_MonitorMempoolHeartbeat(t *testing.T) {
+	var subscriptions []string
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subscriptions = append(subscriptions, r.URL.Path)
+		w.Write([]byte(fmt.Sprintf(`[{"hash":"op%d"}]`, len(subscriptions))))
+		w.(http.Flusher).Flush()
+
+		// The connection silently dies.
+		<-r.Context().Done()
+	})))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	operations, errs, err := gt.MonitorMempool(ctx, &MempoolMonitorInput{Heartbeat: 10 * time.Millisecond})
+	assert.Nil(t, err)
+
+	var hashes []string
+	for operation := range operations {
+		hashes = append(hashes, operation.Hash)
+		if len(hashes) == 2 {
+			cancel()
+		}
+	}
+	checkErr(t, true, "context canceled", <-errs)
+	assert.Equal(t, []string{"op1", "op2"}, hashes)
+}
+
+func Test_MonitorMempoolOutlivesRequestTimeout(t *testing.T) {
+	var subscriptions int
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subscriptions++
+		for i := 1; i <= 5; i++ {
+			w.Write([]byte(fmt.Sprintf(`[{"hash":"op%d"}]`, i)))
+			w.(http.Flusher).Flush()
+			time.Sleep(50 * time.Millisecond)
+		}
+
+		<-r.Context().Done()
+	})))
+	defer server.Close()
+
+	// The stream lasts longer than any request may, through the client of New.
+	gt, err := New(server.URL, WithRequestTimeout(100*time.Millisecond))
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	operations, errs, err := gt.MonitorMempool(ctx, nil)
+	assert.Nil(t, err)
+
+	var hashes []string
+	for operation := range operations {
+		hashes = append(hashes, operation.Hash)
+		if len(hashes) == 5 {
+			cancel()
+		}
+	}
+	checkErr(t, true, "context canceled", <-errs)
+	assert.Equal(t, []string{"op1", "op2", "op3", "op4", "op5"}, hashes)
+	assert.Equal(t, 1, subscriptions)
+}
+
+func Test_MonitorMempoolClientTimeout(t *testing.T) {
+	var subscriptions int
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subscriptions++
+		for i := 1; ; i++ {
+			w.Write([]byte(fmt.Sprintf(`[{"hash":"op%d"}]`, i)))
+			w.(http.Flusher).Flush()
+
+			select {
+			case <-time.After(20 * time.Millisecond):
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+	gt.SetClient(&http.Client{Timeout: 100 * time.Millisecond})
+
+	operations, errs, err := gt.MonitorMempool(context.Background(), nil)
+	assert.Nil(t, err)
+
+	// A stream cut while data flows is not reopened, which would send the pending operations again.
+	var hashes []string
+	for operation := range operations {
+		hashes = append(hashes, operation.Hash)
+	}
+	checkErr(t, true, "failed to monitor mempool", <-errs)
+	assert.NotEmpty(t, hashes)
+	assert.Equal(t, 1, subscriptions)
+}
+
+func Test_MonitorMempoolFailure(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write(mockRPCErrorResp)
+	})))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	_, _, err = gt.MonitorMempool(context.Background(), nil)
+	checkErr(t, true, "failed to monitor mempool", err)
+}
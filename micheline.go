@@ -0,0 +1,547 @@
+package gotezos
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+/*
+MichelineKind Representation
+Description: Identifies which of the five Micheline node forms a Micheline value holds.
+*/
+type MichelineKind int
+
+const (
+	// MichelineKindPrim is a primitive application, e.g. {"prim":"Pair","args":[...]}
+	MichelineKindPrim MichelineKind = iota
+	// MichelineKindInt is an integer literal, e.g. {"int":"10"}
+	MichelineKindInt
+	// MichelineKindString is a string literal, e.g. {"string":"tz1..."}
+	MichelineKindString
+	// MichelineKindBytes is a bytes literal, e.g. {"bytes":"0a0b"}
+	MichelineKindBytes
+	// MichelineKindSeq is a sequence of nodes, e.g. [{"prim":"DROP"}]
+	MichelineKindSeq
+)
+
+/*
+Micheline Representation
+Link: https://tezos.gitlab.io/shell/micheline.html
+Description: A node of a Micheline expression, the JSON representation of Michelson code and data.
+Only the fields relevant to Kind are set.
+*/
+type Micheline struct {
+	Kind   MichelineKind
+	Prim   string
+	Args   []Micheline
+	Annots []string
+	Int    *big.Int
+	String string
+	Bytes  []byte
+	Seq    []Micheline
+}
+
+// michelinePrimitives is the Michelson primitive table, indexed by the binary opcode of each primitive.
+var michelinePrimitives = []string{
+	"parameter", "storage", "code", "False", "Elt", "Left", "None", "Pair", "Right", "Some", "True", "Unit",
+	"PACK", "UNPACK", "BLAKE2B", "SHA256", "SHA512", "ABS", "ADD", "AMOUNT", "AND", "BALANCE", "CAR", "CDR",
+	"CHECK_SIGNATURE", "COMPARE", "CONCAT", "CONS", "CREATE_ACCOUNT", "CREATE_CONTRACT", "IMPLICIT_ACCOUNT",
+	"DIP", "DROP", "DUP", "EDIV", "EMPTY_MAP", "EMPTY_SET", "EQ", "EXEC", "FAILWITH", "GE", "GET", "GT",
+	"HASH_KEY", "IF", "IF_CONS", "IF_LEFT", "IF_NONE", "INT", "LAMBDA", "LE", "LEFT", "LOOP", "LSL", "LSR",
+	"LT", "MAP", "MEM", "MUL", "NEG", "NEQ", "NIL", "NONE", "NOT", "NOW", "OR", "PAIR", "PUSH", "RIGHT",
+	"SIZE", "SOME", "SOURCE", "SENDER", "SELF", "STEPS_TO_QUOTA", "SUB", "SWAP", "TRANSFER_TOKENS",
+	"SET_DELEGATE", "UNIT", "UPDATE", "XOR", "ITER", "LOOP_LEFT", "ADDRESS", "CONTRACT", "ISNAT", "CAST",
+	"RENAME", "bool", "contract", "int", "key", "key_hash", "lambda", "list", "map", "big_map", "nat",
+	"option", "or", "pair", "set", "signature", "string", "bytes", "mutez", "timestamp", "unit", "operation",
+	"address", "SLICE", "DIG", "DUG", "EMPTY_BIG_MAP", "APPLY", "chain_id", "CHAIN_ID", "LEVEL",
+	"SELF_ADDRESS", "never", "NEVER", "UNPAIR", "VOTING_POWER", "TOTAL_VOTING_POWER", "KECCAK", "SHA3",
+	"PAIRING_CHECK", "bls12_381_g1", "bls12_381_g2", "bls12_381_fr", "sapling_state",
+	"sapling_transaction_deprecated", "SAPLING_EMPTY_STATE", "SAPLING_VERIFY_UPDATE", "ticket",
+	"TICKET_DEPRECATED", "READ_TICKET", "SPLIT_TICKET", "JOIN_TICKETS", "GET_AND_UPDATE", "chest",
+	"chest_key", "OPEN_CHEST", "VIEW", "view", "constant", "SUB_MUTEZ", "tx_rollup_l2_address",
+	"MIN_BLOCK_TIME", "sapling_transaction", "EMIT", "Lambda_rec", "LAMBDA_REC", "TICKET", "BYTES", "NAT",
+}
+
+var michelinePrimitiveCodes = func() map[string]byte {
+	codes := make(map[string]byte, len(michelinePrimitives))
+	for i, prim := range michelinePrimitives {
+		codes[prim] = byte(i)
+	}
+	return codes
+}()
+
+/*
+NewMichelinePrim Function
+Description: Returns a primitive application node.
+
+Parameters:
+	prim:
+		The primitive (e.g. Pair, PUSH, nat).
+	args:
+		The arguments of the primitive.
+*/
+func NewMichelinePrim(prim string, args ...Micheline) Micheline {
+	return Micheline{Kind: MichelineKindPrim, Prim: prim, Args: args}
+}
+
+/*
+NewMichelineInt Function
+Description: Returns an integer literal node.
+
+Parameters:
+	i:
+		The value of the literal.
+*/
+func NewMichelineInt(i int64) Micheline {
+	return Micheline{Kind: MichelineKindInt, Int: big.NewInt(i)}
+}
+
+/*
+NewMichelineBigInt Function
+Description: Returns an integer literal node.
+
+Parameters:
+	i:
+		The value of the literal.
+*/
+func NewMichelineBigInt(i *big.Int) Micheline {
+	return Micheline{Kind: MichelineKindInt, Int: new(big.Int).Set(i)}
+}
+
+/*
+NewMichelineString Function
+Description: Returns a string literal node.
+
+Parameters:
+	s:
+		The value of the literal.
+*/
+func NewMichelineString(s string) Micheline {
+	return Micheline{Kind: MichelineKindString, String: s}
+}
+
+/*
+NewMichelineBytes Function
+Description: Returns a bytes literal node.
+
+Parameters:
+	b:
+		The value of the literal.
+*/
+func NewMichelineBytes(b []byte) Micheline {
+	return Micheline{Kind: MichelineKindBytes, Bytes: b}
+}
+
+/*
+NewMichelineBool Function
+Description: Returns a boolean literal node (True or False).
+
+Parameters:
+	b:
+		The value of the literal.
+*/
+func NewMichelineBool(b bool) Micheline {
+	if b {
+		return NewMichelinePrim("True")
+	}
+	return NewMichelinePrim("False")
+}
+
+/*
+NewMichelineSeq Function
+Description: Returns a sequence node.
+
+Parameters:
+	nodes:
+		The elements of the sequence.
+*/
+func NewMichelineSeq(nodes ...Micheline) Micheline {
+	if nodes == nil {
+		nodes = []Micheline{}
+	}
+	return Micheline{Kind: MichelineKindSeq, Seq: nodes}
+}
+
+/*
+WithAnnots Function
+Description: Returns a copy of a primitive node with the annotations (e.g. %to, :payload) set.
+
+Parameters:
+	annots:
+		The annotations of the primitive.
+*/
+func (m Micheline) WithAnnots(annots ...string) Micheline {
+	m.Annots = annots
+	return m
+}
+
+type michelineJSON struct {
+	Prim   *string     `json:"prim,omitempty"`
+	Args   []Micheline `json:"args,omitempty"`
+	Annots []string    `json:"annots,omitempty"`
+	Int    *string     `json:"int,omitempty"`
+	String *string     `json:"string,omitempty"`
+	Bytes  *string     `json:"bytes,omitempty"`
+}
+
+/*
+MarshalJSON Function
+Description: Implements the json.Marshaler interface for Micheline.
+*/
+func (m Micheline) MarshalJSON() ([]byte, error) {
+	switch m.Kind {
+	case MichelineKindSeq:
+		seq := m.Seq
+		if seq == nil {
+			seq = []Micheline{}
+		}
+		return json.Marshal(seq)
+	case MichelineKindInt:
+		if m.Int == nil {
+			return nil, errors.New("micheline int node has no value")
+		}
+		i := m.Int.String()
+		return json.Marshal(michelineJSON{Int: &i})
+	case MichelineKindString:
+		return json.Marshal(michelineJSON{String: &m.String})
+	case MichelineKindBytes:
+		b := hex.EncodeToString(m.Bytes)
+		return json.Marshal(michelineJSON{Bytes: &b})
+	case MichelineKindPrim:
+		return json.Marshal(michelineJSON{Prim: &m.Prim, Args: m.Args, Annots: m.Annots})
+	default:
+		return nil, errors.Errorf("unknown micheline kind %d", m.Kind)
+	}
+}
+
+/*
+UnmarshalJSON Function
+Description: Implements the json.Unmarshaler interface for Micheline.
+
+Parameters:
+	b:
+		The JSON representation of a Micheline expression.
+*/
+func (m *Micheline) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if len(b) > 0 && b[0] == '[' {
+		var seq []Micheline
+		if err := json.Unmarshal(b, &seq); err != nil {
+			return err
+		}
+		*m = NewMichelineSeq(seq...)
+		return nil
+	}
+
+	var node michelineJSON
+	if err := json.Unmarshal(b, &node); err != nil {
+		return err
+	}
+
+	switch {
+	case node.Prim != nil:
+		*m = Micheline{Kind: MichelineKindPrim, Prim: *node.Prim, Args: node.Args, Annots: node.Annots}
+	case node.Int != nil:
+		i, ok := new(big.Int).SetString(*node.Int, 10)
+		if !ok {
+			return errors.Errorf("invalid micheline int '%s'", *node.Int)
+		}
+		*m = Micheline{Kind: MichelineKindInt, Int: i}
+	case node.String != nil:
+		*m = NewMichelineString(*node.String)
+	case node.Bytes != nil:
+		v, err := hex.DecodeString(*node.Bytes)
+		if err != nil {
+			return errors.Wrap(err, "invalid micheline bytes")
+		}
+		*m = NewMichelineBytes(v)
+	default:
+		return errors.Errorf("invalid micheline node %s", string(b))
+	}
+
+	return nil
+}
+
+/*
+MarshalBinary Function
+Description: Implements the encoding.BinaryMarshaler interface for Micheline using the binary
+encoding of Micheline found in forged operations.
+*/
+func (m Micheline) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := m.encode(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+/*
+UnmarshalBinary Function
+Description: Implements the encoding.BinaryUnmarshaler interface for Micheline.
+
+Parameters:
+	data:
+		The binary encoding of a single Micheline expression.
+*/
+func (m *Micheline) UnmarshalBinary(data []byte) error {
+	node, n, err := decodeMicheline(data)
+	if err != nil {
+		return err
+	}
+	if n != len(data) {
+		return errors.Errorf("unexpected %d trailing bytes after micheline expression", len(data)-n)
+	}
+	*m = node
+	return nil
+}
+
+/*
+Pack Function
+Description: Serializes the expression the way the Michelson PACK instruction does (0x05 followed by
+the binary encoding). Data must already be in optimized form (e.g. addresses as bytes) to match a
+PACK performed on chain.
+*/
+func (m Micheline) Pack() ([]byte, error) {
+	v, err := m.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to pack micheline")
+	}
+	return append([]byte{0x05}, v...), nil
+}
+
+func (m Micheline) encode(buf *bytes.Buffer) error {
+	switch m.Kind {
+	case MichelineKindInt:
+		if m.Int == nil {
+			return errors.New("micheline int node has no value")
+		}
+		buf.WriteByte(0x00)
+		buf.Write(encodeSignedZarith(m.Int))
+	case MichelineKindString:
+		buf.WriteByte(0x01)
+		writeDynamic(buf, []byte(m.String))
+	case MichelineKindSeq:
+		var inner bytes.Buffer
+		for _, node := range m.Seq {
+			if err := node.encode(&inner); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(0x02)
+		writeDynamic(buf, inner.Bytes())
+	case MichelineKindBytes:
+		buf.WriteByte(0x0a)
+		writeDynamic(buf, m.Bytes)
+	case MichelineKindPrim:
+		code, ok := michelinePrimitiveCodes[m.Prim]
+		if !ok {
+			return errors.Errorf("unknown michelson primitive '%s'", m.Prim)
+		}
+
+		hasAnnots := len(m.Annots) > 0
+		if len(m.Args) > 2 {
+			var args bytes.Buffer
+			for _, arg := range m.Args {
+				if err := arg.encode(&args); err != nil {
+					return err
+				}
+			}
+			buf.WriteByte(0x09)
+			buf.WriteByte(code)
+			writeDynamic(buf, args.Bytes())
+			writeDynamic(buf, []byte(strings.Join(m.Annots, " ")))
+			return nil
+		}
+
+		tag := byte(0x03 + 2*len(m.Args))
+		if hasAnnots {
+			tag++
+		}
+		buf.WriteByte(tag)
+		buf.WriteByte(code)
+		for _, arg := range m.Args {
+			if err := arg.encode(buf); err != nil {
+				return err
+			}
+		}
+		if hasAnnots {
+			writeDynamic(buf, []byte(strings.Join(m.Annots, " ")))
+		}
+	default:
+		return errors.Errorf("unknown micheline kind %d", m.Kind)
+	}
+
+	return nil
+}
+
+func decodeMicheline(data []byte) (Micheline, int, error) {
+	if len(data) < 1 {
+		return Micheline{}, 0, errors.New("failed to decode micheline: unexpected end of data")
+	}
+
+	tag := data[0]
+	switch tag {
+	case 0x00:
+		i, n, err := decodeSignedZarith(data[1:])
+		if err != nil {
+			return Micheline{}, 0, errors.Wrap(err, "failed to decode micheline int")
+		}
+		return Micheline{Kind: MichelineKindInt, Int: i}, n + 1, nil
+	case 0x01:
+		v, n, err := readDynamic(data[1:])
+		if err != nil {
+			return Micheline{}, 0, errors.Wrap(err, "failed to decode micheline string")
+		}
+		return NewMichelineString(string(v)), n + 1, nil
+	case 0x02:
+		v, n, err := readDynamic(data[1:])
+		if err != nil {
+			return Micheline{}, 0, errors.Wrap(err, "failed to decode micheline sequence")
+		}
+		seq := []Micheline{}
+		for len(v) > 0 {
+			node, used, err := decodeMicheline(v)
+			if err != nil {
+				return Micheline{}, 0, err
+			}
+			seq = append(seq, node)
+			v = v[used:]
+		}
+		return NewMichelineSeq(seq...), n + 1, nil
+	case 0x0a:
+		v, n, err := readDynamic(data[1:])
+		if err != nil {
+			return Micheline{}, 0, errors.Wrap(err, "failed to decode micheline bytes")
+		}
+		return NewMichelineBytes(v), n + 1, nil
+	case 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09:
+		if len(data) < 2 {
+			return Micheline{}, 0, errors.New("failed to decode micheline: unexpected end of data")
+		}
+		if int(data[1]) >= len(michelinePrimitives) {
+			return Micheline{}, 0, errors.Errorf("failed to decode micheline: unknown primitive code %d", data[1])
+		}
+		node := Micheline{Kind: MichelineKindPrim, Prim: michelinePrimitives[data[1]]}
+		offset := 2
+
+		if tag == 0x09 {
+			v, n, err := readDynamic(data[offset:])
+			if err != nil {
+				return Micheline{}, 0, errors.Wrap(err, "failed to decode micheline arguments")
+			}
+			offset += n
+			for len(v) > 0 {
+				arg, used, err := decodeMicheline(v)
+				if err != nil {
+					return Micheline{}, 0, err
+				}
+				node.Args = append(node.Args, arg)
+				v = v[used:]
+			}
+		} else {
+			for i := 0; i < int(tag-0x03)/2; i++ {
+				arg, used, err := decodeMicheline(data[offset:])
+				if err != nil {
+					return Micheline{}, 0, err
+				}
+				node.Args = append(node.Args, arg)
+				offset += used
+			}
+		}
+
+		if tag == 0x09 || tag%2 == 0 {
+			v, n, err := readDynamic(data[offset:])
+			if err != nil {
+				return Micheline{}, 0, errors.Wrap(err, "failed to decode micheline annotations")
+			}
+			offset += n
+			if len(v) > 0 {
+				node.Annots = strings.Split(string(v), " ")
+			}
+		}
+
+		return node, offset, nil
+	default:
+		return Micheline{}, 0, errors.Errorf("failed to decode micheline: unknown tag %d", tag)
+	}
+}
+
+func writeDynamic(buf *bytes.Buffer, v []byte) {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(v)))
+	buf.Write(length[:])
+	buf.Write(v)
+}
+
+func readDynamic(data []byte) ([]byte, int, error) {
+	if len(data) < 4 {
+		return nil, 0, errors.New("unexpected end of data")
+	}
+	length := int(binary.BigEndian.Uint32(data[:4]))
+	if len(data) < 4+length {
+		return nil, 0, errors.New("unexpected end of data")
+	}
+	return data[4 : 4+length], 4 + length, nil
+}
+
+func encodeSignedZarith(i *big.Int) []byte {
+	abs := new(big.Int).Abs(i)
+
+	first := byte(new(big.Int).And(abs, big.NewInt(0x3f)).Uint64())
+	if i.Sign() < 0 {
+		first |= 0x40
+	}
+	abs.Rsh(abs, 6)
+
+	out := []byte{first}
+	for abs.Sign() > 0 {
+		out[len(out)-1] |= 0x80
+		out = append(out, byte(new(big.Int).And(abs, big.NewInt(0x7f)).Uint64()))
+		abs.Rsh(abs, 7)
+	}
+
+	return out
+}
+
+func decodeSignedZarith(data []byte) (*big.Int, int, error) {
+	if len(data) < 1 {
+		return nil, 0, errors.New("unexpected end of data")
+	}
+
+	i := new(big.Int).SetUint64(uint64(data[0] & 0x3f))
+	negative := data[0]&0x40 != 0
+
+	n := 1
+	shift := uint(6)
+	for data[n-1]&0x80 != 0 {
+		if n >= len(data) {
+			return nil, 0, errors.New("unexpected end of data")
+		}
+		part := new(big.Int).SetUint64(uint64(data[n] & 0x7f))
+		i.Or(i, part.Lsh(part, shift))
+		shift += 7
+		n++
+	}
+
+	if negative {
+		i.Neg(i)
+	}
+
+	return i, n, nil
+}
+
+// unpairComb flattens a right comb of pairs (Pair a (Pair b c) or Pair a b c) into its elements.
+func unpairComb(m Micheline) []Micheline {
+	if m.Kind != MichelineKindPrim || m.Prim != "Pair" || len(m.Args) < 2 {
+		return []Micheline{m}
+	}
+
+	args := append([]Micheline{}, m.Args[:len(m.Args)-1]...)
+	return append(args, unpairComb(m.Args[len(m.Args)-1])...)
+}
@@ -0,0 +1,157 @@
+package gotezos
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MichelineJSON(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  Micheline
+	}{
+		{
+			"int",
+			`{"int":"-42"}`,
+			NewMichelineInt(-42),
+		},
+		{
+			"string",
+			`{"string":""}`,
+			NewMichelineString(""),
+		},
+		{
+			"bytes",
+			`{"bytes":"0a0b"}`,
+			NewMichelineBytes([]byte{0x0a, 0x0b}),
+		},
+		{
+			"bool",
+			`{"prim":"False"}`,
+			NewMichelineBool(false),
+		},
+		{
+			"empty sequence",
+			`[]`,
+			NewMichelineSeq(),
+		},
+		{
+			"prim with args and annots",
+			`{"prim":"pair","args":[{"prim":"nat","annots":["%counter"]},[{"prim":"DROP"}]],"annots":[":payload"]}`,
+			NewMichelinePrim("pair",
+				NewMichelinePrim("nat").WithAnnots("%counter"),
+				NewMichelineSeq(NewMichelinePrim("DROP")),
+			).WithAnnots(":payload"),
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var m Micheline
+			err := json.Unmarshal([]byte(tt.input), &m)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, m)
+
+			v, err := json.Marshal(m)
+			assert.Nil(t, err)
+			assert.JSONEq(t, tt.input, string(v))
+		})
+	}
+}
+
+func Test_MichelinePack(t *testing.T) {
+	cases := []struct {
+		name  string
+		input Micheline
+		want  string
+	}{
+		{
+			"nat",
+			NewMichelineInt(1),
+			"050001",
+		},
+		{
+			"negative int",
+			NewMichelineInt(-1),
+			"050041",
+		},
+		{
+			"multi byte int",
+			NewMichelineInt(1000000),
+			"050080897a",
+		},
+		{
+			"string",
+			NewMichelineString("Hello"),
+			"05010000000548656c6c6f",
+		},
+		{
+			"pair",
+			NewMichelinePrim("Pair", NewMichelineInt(1), NewMichelineString("a")),
+			"0507070001010000000161",
+		},
+		{
+			"empty sequence",
+			NewMichelineSeq(),
+			"050200000000",
+		},
+		{
+			"annotated prim",
+			NewMichelinePrim("unit").WithAnnots("%default"),
+			"05046c000000082564656661756c74",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			packed, err := tt.input.Pack()
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, hex.EncodeToString(packed))
+
+			var m Micheline
+			err = m.UnmarshalBinary(packed[1:])
+			assert.Nil(t, err)
+			assert.Equal(t, 0, m.Int.Cmp(tt.input.Int))
+			m.Int, tt.input.Int = nil, nil
+			assert.Equal(t, tt.input, m)
+		})
+	}
+}
+
+func Test_MichelineBinary(t *testing.T) {
+	// The manager.tz contract forged by forgeOriginationOperation.
+	code, _ := hex.DecodeString("02000000c105000764085e036c055f036d0000000325646f046c000000082564656661756c740501035d050202000000950200000012020000000d03210316051f02000000020317072e020000006a0743036a00000313020000001e020000000403190325072c020000000002000000090200000004034f0327020000000b051f02000000020321034c031e03540348020000001e020000000403190325072c020000000002000000090200000004034f0327034f0326034202000000080320053d036d0342")
+
+	var m Micheline
+	err := m.UnmarshalBinary(code)
+	assert.Nil(t, err)
+	assert.Equal(t, MichelineKindSeq, m.Kind)
+	assert.Equal(t, "parameter", m.Seq[0].Prim)
+	assert.Equal(t, []string{"%do"}, m.Seq[0].Args[0].Args[0].Annots)
+
+	v, err := m.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Equal(t, code, v)
+
+	err = m.UnmarshalBinary(append(code, 0x00))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "trailing bytes")
+
+	_, err = NewMichelinePrim("NOT_A_PRIM").MarshalBinary()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unknown michelson primitive")
+}
+
+func Test_unpairComb(t *testing.T) {
+	one, two, three := NewMichelineInt(1), NewMichelineInt(2), NewMichelineInt(3)
+
+	assert.Equal(t, []Micheline{one, two, three}, unpairComb(NewMichelinePrim("Pair", one, NewMichelinePrim("Pair", two, three))))
+	assert.Equal(t, []Micheline{one, two, three}, unpairComb(NewMichelinePrim("Pair", one, two, three)))
+	assert.Equal(t, []Micheline{one}, unpairComb(one))
+	assert.Equal(t, 0, big.NewInt(1).Cmp(unpairComb(one)[0].Int))
+}
@@ -0,0 +1,486 @@
+package gotezos
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type michelsonTokenKind int
+
+const (
+	michelsonEOF michelsonTokenKind = iota
+	michelsonIdent
+	michelsonInt
+	michelsonString
+	michelsonBytes
+	michelsonAnnot
+	michelsonOpenBrace
+	michelsonCloseBrace
+	michelsonOpenParen
+	michelsonCloseParen
+	michelsonSemicolon
+)
+
+type michelsonToken struct {
+	kind   michelsonTokenKind
+	text   string
+	line   int
+	column int
+}
+
+func (t michelsonToken) String() string {
+	if t.kind == michelsonEOF {
+		return "end of source"
+	}
+	return fmt.Sprintf("'%s' at line %d, column %d", t.text, t.line, t.column)
+}
+
+/*
+ParseMichelson Function
+Description: Parses Michelson source, as written in .tz files or passed to tezos-client, into Micheline.
+A list of expressions separated by semicolons (e.g. the parameter, storage and code sections of a script)
+is returned as a sequence, a single expression (e.g. Pair 1 "tz1...") as is. Line (#) and block comments
+are ignored.
+
+Parameters:
+	source:
+		The Michelson source.
+*/
+func ParseMichelson(source string) (Micheline, error) {
+	tokens, err := tokenizeMichelson(source)
+	if err != nil {
+		return Micheline{}, errors.Wrap(err, "failed to parse michelson")
+	}
+
+	p := &michelsonParser{tokens: tokens}
+	if p.peek().kind == michelsonEOF {
+		return Micheline{}, errors.New("failed to parse michelson: empty source")
+	}
+
+	nodes, semicolon, err := p.parseSequence(michelsonEOF)
+	if err != nil {
+		return Micheline{}, errors.Wrap(err, "failed to parse michelson")
+	}
+
+	if len(nodes) == 1 && !semicolon {
+		return nodes[0], nil
+	}
+	return NewMichelineSeq(nodes...), nil
+}
+
+type michelsonParser struct {
+	tokens []michelsonToken
+	pos    int
+}
+
+func (p *michelsonParser) peek() michelsonToken {
+	return p.tokens[p.pos]
+}
+
+func (p *michelsonParser) next() michelsonToken {
+	t := p.tokens[p.pos]
+	if t.kind != michelsonEOF {
+		p.pos++
+	}
+	return t
+}
+
+// parseSequence parses expressions separated by semicolons until the end token, which is not consumed.
+// Returns whether any semicolon was found.
+func (p *michelsonParser) parseSequence(end michelsonTokenKind) ([]Micheline, bool, error) {
+	nodes := []Micheline{}
+	semicolon := false
+	for p.peek().kind != end {
+		node, err := p.parseExpr()
+		if err != nil {
+			return nil, false, err
+		}
+		nodes = append(nodes, node)
+
+		switch p.peek().kind {
+		case michelsonSemicolon:
+			p.next()
+			semicolon = true
+		case end:
+		default:
+			return nil, false, errors.Errorf("expected ';' but found %s", p.peek())
+		}
+	}
+
+	return nodes, semicolon, nil
+}
+
+// parseExpr parses an expression: a primitive applied to its arguments or an atom.
+func (p *michelsonParser) parseExpr() (Micheline, error) {
+	if p.peek().kind != michelsonIdent {
+		return p.parseAtom()
+	}
+
+	node, err := p.parsePrim()
+	if err != nil {
+		return Micheline{}, err
+	}
+
+	for {
+		switch p.peek().kind {
+		case michelsonIdent, michelsonInt, michelsonString, michelsonBytes, michelsonOpenParen, michelsonOpenBrace:
+			arg, err := p.parseAtom()
+			if err != nil {
+				return Micheline{}, err
+			}
+			node.Args = append(node.Args, arg)
+		default:
+			return node, nil
+		}
+	}
+}
+
+// parseAtom parses a literal, a sequence, a parenthesized expression or a primitive without arguments.
+func (p *michelsonParser) parseAtom() (Micheline, error) {
+	if p.peek().kind == michelsonIdent {
+		return p.parsePrim()
+	}
+
+	t := p.next()
+	switch t.kind {
+	case michelsonInt:
+		i, ok := new(big.Int).SetString(t.text, 10)
+		if !ok {
+			return Micheline{}, errors.Errorf("invalid int %s", t)
+		}
+		return Micheline{Kind: MichelineKindInt, Int: i}, nil
+	case michelsonString:
+		return NewMichelineString(t.text), nil
+	case michelsonBytes:
+		b, err := hex.DecodeString(t.text[2:])
+		if err != nil {
+			return Micheline{}, errors.Errorf("invalid bytes %s", t)
+		}
+		return NewMichelineBytes(b), nil
+	case michelsonOpenParen:
+		node, err := p.parseExpr()
+		if err != nil {
+			return Micheline{}, err
+		}
+		if closing := p.next(); closing.kind != michelsonCloseParen {
+			return Micheline{}, errors.Errorf("expected ')' but found %s", closing)
+		}
+		return node, nil
+	case michelsonOpenBrace:
+		nodes, _, err := p.parseSequence(michelsonCloseBrace)
+		if err != nil {
+			return Micheline{}, err
+		}
+		if closing := p.next(); closing.kind != michelsonCloseBrace {
+			return Micheline{}, errors.Errorf("expected '}' but found %s", closing)
+		}
+		return NewMichelineSeq(nodes...), nil
+	default:
+		return Micheline{}, errors.Errorf("unexpected %s", t)
+	}
+}
+
+// parsePrim parses a primitive and its annotations.
+func (p *michelsonParser) parsePrim() (Micheline, error) {
+	t := p.next()
+	if _, ok := michelinePrimitiveCodes[t.text]; !ok {
+		return Micheline{}, errors.Errorf("unknown primitive %s", t)
+	}
+
+	node := NewMichelinePrim(t.text)
+	for p.peek().kind == michelsonAnnot {
+		node.Annots = append(node.Annots, p.next().text)
+	}
+	return node, nil
+}
+
+func tokenizeMichelson(source string) ([]michelsonToken, error) {
+	var tokens []michelsonToken
+	line, column := 1, 1
+	i := 0
+
+	advance := func(n int) {
+		for ; n > 0 && i < len(source); n-- {
+			if source[i] == '\n' {
+				line++
+				column = 1
+			} else {
+				column++
+			}
+			i++
+		}
+	}
+
+	for i < len(source) {
+		c := source[i]
+		start := michelsonToken{line: line, column: column}
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			advance(1)
+		case c == '#':
+			for i < len(source) && source[i] != '\n' {
+				advance(1)
+			}
+		case strings.HasPrefix(source[i:], "/*"):
+			end := strings.Index(source[i+2:], "*/")
+			if end < 0 {
+				return nil, errors.Errorf("unterminated comment at line %d, column %d", start.line, start.column)
+			}
+			advance(end + 4)
+		case c == '{' || c == '}' || c == '(' || c == ')' || c == ';':
+			start.kind = map[byte]michelsonTokenKind{
+				'{': michelsonOpenBrace,
+				'}': michelsonCloseBrace,
+				'(': michelsonOpenParen,
+				')': michelsonCloseParen,
+				';': michelsonSemicolon,
+			}[c]
+			start.text = string(c)
+			tokens = append(tokens, start)
+			advance(1)
+		case c == '"':
+			s, n, err := readMichelsonString(source[i:])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid string at line %d, column %d", start.line, start.column)
+			}
+			start.kind, start.text = michelsonString, s
+			tokens = append(tokens, start)
+			advance(n)
+		case strings.HasPrefix(source[i:], "0x"):
+			n := 2 + michelsonSpan(source[i+2:], isHexDigit)
+			start.kind, start.text = michelsonBytes, source[i:i+n]
+			tokens = append(tokens, start)
+			advance(n)
+		case isDigit(c) || (c == '-' && i+1 < len(source) && isDigit(source[i+1])):
+			n := 1 + michelsonSpan(source[i+1:], isDigit)
+			start.kind, start.text = michelsonInt, source[i:i+n]
+			tokens = append(tokens, start)
+			advance(n)
+		case c == '@' || c == ':' || c == '%':
+			n := 1 + michelsonSpan(source[i+1:], isAnnotChar)
+			start.kind, start.text = michelsonAnnot, source[i:i+n]
+			tokens = append(tokens, start)
+			advance(n)
+		case isIdentChar(c) && !isDigit(c):
+			n := michelsonSpan(source[i:], isIdentChar)
+			start.kind, start.text = michelsonIdent, source[i:i+n]
+			tokens = append(tokens, start)
+			advance(n)
+		default:
+			return nil, errors.Errorf("unexpected character '%c' at line %d, column %d", c, start.line, start.column)
+		}
+	}
+
+	return append(tokens, michelsonToken{kind: michelsonEOF, line: line, column: column}), nil
+}
+
+// readMichelsonString reads a quoted string with its escapes, returning its value and its length in the source.
+func readMichelsonString(source string) (string, int, error) {
+	var sb strings.Builder
+	for i := 1; i < len(source); i++ {
+		switch source[i] {
+		case '"':
+			return sb.String(), i + 1, nil
+		case '\n':
+			return "", 0, errors.New("unterminated string")
+		case '\\':
+			i++
+			if i == len(source) {
+				return "", 0, errors.New("unterminated string")
+			}
+			escaped, ok := map[byte]byte{'n': '\n', 't': '\t', 'b': '\b', 'r': '\r', '\\': '\\', '"': '"'}[source[i]]
+			if !ok {
+				return "", 0, errors.Errorf("unknown escape sequence '\\%c'", source[i])
+			}
+			sb.WriteByte(escaped)
+		default:
+			sb.WriteByte(source[i])
+		}
+	}
+
+	return "", 0, errors.New("unterminated string")
+}
+
+func michelsonSpan(s string, accept func(byte) bool) int {
+	n := 0
+	for n < len(s) && accept(s[n]) {
+		n++
+	}
+	return n
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isIdentChar(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_'
+}
+
+func isAnnotChar(c byte) bool {
+	return isIdentChar(c) || c == '.' || c == '%' || c == '@'
+}
+
+// michelsonWidth is the line width Michelson rendered by Micheline.Michelson is wrapped at.
+const michelsonWidth = 80
+
+/*
+Michelson Function
+Description: Renders the expression as Michelson source, as tezos-client does: expressions fitting on a
+line are kept on one line, longer sequences are broken into one instruction per line and longer primitive
+applications into one argument per line, indented by two spaces. A single sequence argument is kept on the line
+of its primitive (e.g. code { ... }). The result can be parsed back with
+ParseMichelson.
+*/
+func (m Micheline) Michelson() string {
+	return m.renderMichelson(0)
+}
+
+/*
+CompactMichelson Function
+Description: Renders the expression as Michelson source on a single line, e.g. for logs.
+*/
+func (m Micheline) CompactMichelson() string {
+	var sb strings.Builder
+	m.writeMichelson(&sb)
+	return sb.String()
+}
+
+// renderMichelson renders the expression starting at the given column, wrapping what exceeds michelsonWidth.
+func (m Micheline) renderMichelson(column int) string {
+	compact := m.CompactMichelson()
+	if column+len(compact) <= michelsonWidth {
+		return compact
+	}
+
+	indent := strings.Repeat(" ", column+2)
+	switch m.Kind {
+	case MichelineKindSeq:
+		items := make([]string, len(m.Seq))
+		for i, node := range m.Seq {
+			items[i] = node.renderMichelson(column + 2)
+		}
+		return "{ " + strings.Join(items, " ;\n"+indent) + " }"
+	case MichelineKindPrim:
+		if len(m.Args) == 0 {
+			return compact
+		}
+
+		// A single sequence argument (e.g. code { ... } or DIP 2 { ... }) is kept on the line of the primitive.
+		head := m
+		head.Args = m.Args[:len(m.Args)-1]
+		if last := m.Args[len(m.Args)-1]; last.Kind == MichelineKindSeq && !head.hasSeqArg() {
+			if line := head.CompactMichelson() + " "; column+len(line) < michelsonWidth {
+				return line + last.renderMichelson(column+len(line))
+			}
+		}
+
+		var sb strings.Builder
+		sb.WriteString(strings.Join(append([]string{m.Prim}, m.Annots...), " "))
+		for _, arg := range m.Args {
+			sb.WriteString("\n")
+			sb.WriteString(indent)
+			if arg.needsParens() {
+				sb.WriteString("(" + arg.renderMichelson(column+3) + ")")
+			} else {
+				sb.WriteString(arg.renderMichelson(column + 2))
+			}
+		}
+		return sb.String()
+	default:
+		return compact
+	}
+}
+
+func (m Micheline) writeMichelson(sb *strings.Builder) {
+	switch m.Kind {
+	case MichelineKindInt:
+		if m.Int == nil {
+			sb.WriteString("0")
+		} else {
+			sb.WriteString(m.Int.String())
+		}
+	case MichelineKindString:
+		sb.WriteString(quoteMichelsonString(m.String))
+	case MichelineKindBytes:
+		sb.WriteString("0x")
+		sb.WriteString(hex.EncodeToString(m.Bytes))
+	case MichelineKindSeq:
+		if len(m.Seq) == 0 {
+			sb.WriteString("{}")
+			return
+		}
+		sb.WriteString("{ ")
+		for i, node := range m.Seq {
+			if i > 0 {
+				sb.WriteString(" ; ")
+			}
+			node.writeMichelson(sb)
+		}
+		sb.WriteString(" }")
+	case MichelineKindPrim:
+		sb.WriteString(m.Prim)
+		for _, annot := range m.Annots {
+			sb.WriteString(" ")
+			sb.WriteString(annot)
+		}
+		for _, arg := range m.Args {
+			sb.WriteString(" ")
+			if arg.needsParens() {
+				sb.WriteString("(")
+				arg.writeMichelson(sb)
+				sb.WriteString(")")
+			} else {
+				arg.writeMichelson(sb)
+			}
+		}
+	}
+}
+
+func (m Micheline) hasSeqArg() bool {
+	for _, arg := range m.Args {
+		if arg.Kind == MichelineKindSeq {
+			return true
+		}
+	}
+	return false
+}
+
+// needsParens returns whether the expression is wrapped in parentheses as the argument of a primitive.
+func (m Micheline) needsParens() bool {
+	return m.Kind == MichelineKindPrim && (len(m.Args) > 0 || len(m.Annots) > 0)
+}
+
+func quoteMichelsonString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\b':
+			sb.WriteString(`\b`)
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
@@ -0,0 +1,221 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseMichelson(t *testing.T) {
+	type want struct {
+		err         bool
+		containsErr string
+		json        string
+	}
+
+	cases := []struct {
+		name  string
+		input string
+		want
+	}{
+		{
+			"is successful with a script",
+			`# a counter
+			parameter (or (int %increment) (unit %reset)) ;
+			storage int ;
+			code { UNPAIR ;
+			       IF_LEFT { ADD } { DROP 2 ; PUSH int 0 } ; /* reset */
+			       NIL operation ;
+			       PAIR }`,
+			want{
+				false,
+				"",
+				`[
+					{"prim":"parameter","args":[{"prim":"or","args":[{"prim":"int","annots":["%increment"]},{"prim":"unit","annots":["%reset"]}]}]},
+					{"prim":"storage","args":[{"prim":"int"}]},
+					{"prim":"code","args":[[
+						{"prim":"UNPAIR"},
+						{"prim":"IF_LEFT","args":[[{"prim":"ADD"}],[{"prim":"DROP","args":[{"int":"2"}]},{"prim":"PUSH","args":[{"prim":"int"},{"int":"0"}]}]]},
+						{"prim":"NIL","args":[{"prim":"operation"}]},
+						{"prim":"PAIR"}
+					]]}
+				]`,
+			},
+		},
+		{
+			"is successful with data",
+			`Pair (Some "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK") -42 0x0aFF { Elt "a\"b\n" Unit }`,
+			want{
+				false,
+				"",
+				`{"prim":"Pair","args":[
+					{"prim":"Some","args":[{"string":"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"}]},
+					{"int":"-42"},
+					{"bytes":"0aff"},
+					[{"prim":"Elt","args":[{"string":"a\"b\n"},{"prim":"Unit"}]}]
+				]}`,
+			},
+		},
+		{
+			"is successful with annotations on instructions",
+			`{ CAR @amount %from ; DIP { DROP } ; }`,
+			want{
+				false,
+				"",
+				`[{"prim":"CAR","annots":["@amount","%from"]},{"prim":"DIP","args":[[{"prim":"DROP"}]]}]`,
+			},
+		},
+		{
+			"is successful with empty sequences",
+			`{}`,
+			want{
+				false,
+				"",
+				`[]`,
+			},
+		},
+		{
+			"returns empty source",
+			` # nothing`,
+			want{
+				true,
+				"empty source",
+				"",
+			},
+		},
+		{
+			"returns unknown primitive",
+			`{ DROP ;
+			   FOO }`,
+			want{
+				true,
+				"unknown primitive 'FOO' at line 2, column 7",
+				"",
+			},
+		},
+		{
+			"returns unbalanced braces",
+			`{ DROP`,
+			want{
+				true,
+				"expected ';' but found end of source",
+				"",
+			},
+		},
+		{
+			"returns unbalanced parentheses",
+			`Pair (Some 1 2`,
+			want{
+				true,
+				"expected ')' but found end of source",
+				"",
+			},
+		},
+		{
+			"returns unterminated string",
+			`"tz1`,
+			want{
+				true,
+				"unterminated string",
+				"",
+			},
+		},
+		{
+			"returns unterminated comment",
+			`Unit /* `,
+			want{
+				true,
+				"unterminated comment",
+				"",
+			},
+		},
+		{
+			"returns unexpected character",
+			`Pair 1 $`,
+			want{
+				true,
+				"unexpected character '$' at line 1, column 8",
+				"",
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := ParseMichelson(tt.input)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			if tt.want.err {
+				return
+			}
+
+			v, err := json.Marshal(m)
+			assert.Nil(t, err)
+			assert.JSONEq(t, tt.want.json, string(v))
+		})
+	}
+}
+
+func Test_MichelineMichelson(t *testing.T) {
+	script := `parameter (or (int %increment) (unit %reset)) ;
+storage (pair (int %counter) (address %owner)) ;
+code { UNPAIR ; IF_LEFT { DIP { UNPAIR } ; ADD ; PAIR } { DROP ; CDR ; PUSH int 0 ; PAIR } ; NIL operation ; PAIR }`
+
+	cases := []struct {
+		name    string
+		input   Micheline
+		compact string
+		want    string
+	}{
+		{
+			"literals",
+			NewMichelineSeq(NewMichelineInt(-42), NewMichelineString("a\"b\\\n"), NewMichelineBytes([]byte{0x0a, 0xff}), NewMichelineSeq()),
+			`{ -42 ; "a\"b\\\n" ; 0x0aff ; {} }`,
+			`{ -42 ; "a\"b\\\n" ; 0x0aff ; {} }`,
+		},
+		{
+			"annotations and nested arguments",
+			NewMichelinePrim("pair", NewMichelinePrim("nat").WithAnnots("%counter"), NewMichelinePrim("option", NewMichelinePrim("address"))).WithAnnots(":storage"),
+			`pair :storage (nat %counter) (option address)`,
+			`pair :storage (nat %counter) (option address)`,
+		},
+		{
+			"script",
+			mustParseMichelson(t, script),
+			`{ parameter (or (int %increment) (unit %reset)) ; storage (pair (int %counter) (address %owner)) ; code { UNPAIR ; IF_LEFT { DIP { UNPAIR } ; ADD ; PAIR } { DROP ; CDR ; PUSH int 0 ; PAIR } ; NIL operation ; PAIR } }`,
+			`{ parameter (or (int %increment) (unit %reset)) ;
+  storage (pair (int %counter) (address %owner)) ;
+  code { UNPAIR ;
+         IF_LEFT
+           { DIP { UNPAIR } ; ADD ; PAIR }
+           { DROP ; CDR ; PUSH int 0 ; PAIR } ;
+         NIL operation ;
+         PAIR } }`,
+		},
+		{
+			"long primitive application",
+			mustParseMichelson(t, `IF_LEFT { DIP { UNPAIR } ; ADD ; PAIR ; NIL operation ; PAIR } { DROP ; CDR ; PUSH int 0 ; PAIR ; NIL operation ; PAIR }`),
+			`IF_LEFT { DIP { UNPAIR } ; ADD ; PAIR ; NIL operation ; PAIR } { DROP ; CDR ; PUSH int 0 ; PAIR ; NIL operation ; PAIR }`,
+			`IF_LEFT
+  { DIP { UNPAIR } ; ADD ; PAIR ; NIL operation ; PAIR }
+  { DROP ; CDR ; PUSH int 0 ; PAIR ; NIL operation ; PAIR }`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.compact, tt.input.CompactMichelson())
+			assert.Equal(t, tt.want, tt.input.Michelson())
+
+			parsed, err := ParseMichelson(tt.input.Michelson())
+			assert.Nil(t, err)
+			assert.Equal(t, tt.input, parsed)
+		})
+	}
+}
+
+func mustParseMichelson(t *testing.T, source string) Micheline {
+	m, err := ParseMichelson(source)
+	assert.Nil(t, err)
+	return m
+}
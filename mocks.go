@@ -4,6 +4,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"regexp"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -31,6 +33,8 @@ var (
 	mockRPCErrorResp        = []byte(`[{"kind":"somekind","Error":"someerror"}]`)
 	mockStakingBalanceResp  = []byte(`"1216660108948"`)
 	mockVersionResp         = []byte(`{"chain_name":"TEZOS_MAINNET","distributed_db_version":0,"p2p_version":0}`)
+	mockNetworkVersionsResp = []byte(`[{"chain_name":"TEZOS_MAINNET","distributed_db_version":2,"p2p_version":0},{"chain_name":"TEZOS_MAINNET","distributed_db_version":2,"p2p_version":1}]`)
+	mockNodeVersionResp     = []byte(`{"version":{"major":17,"minor":3,"additional_info":"release"},"network_version":{"chain_name":"TEZOS_MAINNET","distributed_db_version":2,"p2p_version":1},"commit_info":{"commit_hash":"4ca33194c7e1ad4d8e9b3e3c2f1b8a0c6b8b3f4c","commit_date":"2023-08-04 10:15:40 +0000"}}`)
 )
 
 // The below variables contain mocks that are unmarshaled.
@@ -41,26 +45,37 @@ var (
 
 // Regexes to allow the capture of custom handlers for unit testing.
 var (
-	regBakingRights       = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/helpers\/baking_rights`)
-	regBalance            = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/contracts\/[A-z0-9]+\/balance`)
-	regBlock              = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+`)
-	regBlocks             = regexp.MustCompile(`\/chains\/main\/blocks`)
-	regBoostrap           = regexp.MustCompile(`\/monitor\/bootstrapped`)
-	regChainID            = regexp.MustCompile(`\/chains\/main\/chain_id`)
-	regCheckpoint         = regexp.MustCompile(`\/chains\/main\/checkpoint`)
-	regCommit             = regexp.MustCompile(`\/monitor\/commit_hash`)
-	regConnections        = regexp.MustCompile(`\/network\/connections`)
-	regConstants          = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/constants`)
-	regCounter            = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/contracts\/[A-z0-9]+\/counter`)
-	regCycle              = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/raw\/json\/cycle\/[0-9]+`)
-	regDelegate           = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/delegates\/[A-z0-9]+`)
-	regDelegatedContracts = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/delegates\/[A-z0-9]+\/delegated_contracts`)
-	regFrozenBalance      = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/raw\/json\/contracts\/index\/[A-z0-9]+\/frozen_balance\/[0-9]+`)
-	regInvalidBlocks      = regexp.MustCompile(`\/chains\/main\/invalid_blocks`)
-	regOperationHashes    = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/operation_hashes`)
-	regStakingBalance     = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/delegates\/[A-z0-9]+\/staking_balance`)
-	regStorage            = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/contracts\/[A-z0-9]+\/storage`)
-	regVersions           = regexp.MustCompile(`\/network\/version`)
+	regBakingRights        = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/helpers\/baking_rights`)
+	regBalance             = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/contracts\/[A-z0-9]+\/balance`)
+	regBlock               = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+`)
+	regBlocks              = regexp.MustCompile(`\/chains\/main\/blocks`)
+	regBoostrap            = regexp.MustCompile(`\/monitor\/bootstrapped`)
+	regChainID             = regexp.MustCompile(`\/chains\/main\/chain_id`)
+	regCheckpoint          = regexp.MustCompile(`\/chains\/main\/checkpoint`)
+	regCommit              = regexp.MustCompile(`\/monitor\/commit_hash`)
+	regConnections         = regexp.MustCompile(`\/network\/connections`)
+	regConstants           = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/constants`)
+	regCounter             = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/contracts\/[A-z0-9]+\/counter`)
+	regCycle               = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/raw\/json\/cycle\/[0-9]+`)
+	regDelegate            = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/delegates\/[A-z0-9]+`)
+	regDelegatedContracts  = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/delegates\/[A-z0-9]+\/delegated_contracts`)
+	regEndorsingRights     = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/helpers\/endorsing_rights`)
+	regFrozenBalance       = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/raw\/json\/contracts\/index\/[A-z0-9]+\/frozen_balance\/[0-9]+`)
+	regInvalidBlocks       = regexp.MustCompile(`\/chains\/main\/invalid_blocks`)
+	regLiquidityBakingCPMM = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/liquidity_baking\/cpmm_address`)
+	regManagerKey          = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/contracts\/[A-z0-9]+\/manager_key`)
+	regNormalizeData       = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/helpers\/scripts\/normalize_data`)
+	regOperationHashes     = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/operation_hashes`)
+	regRunCode             = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/helpers\/scripts\/run_code`)
+	regRunOperation        = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/helpers\/scripts\/run_operation`)
+	regScript              = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/contracts\/[A-z0-9]+\/script`)
+	regStakingBalance      = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/delegates\/[A-z0-9]+\/staking_balance`)
+	regStorage             = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/contracts\/[A-z0-9]+\/storage`)
+	regTraceCode           = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/helpers\/scripts\/trace_code`)
+	regTypecheckCode       = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/helpers\/scripts\/typecheck_code`)
+	regTypecheckData       = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/helpers\/scripts\/typecheck_data`)
+	regVersions            = regexp.MustCompile(`\/network\/version$`)
+	regNodeVersion         = regexp.MustCompile(`^\/version`)
 )
 
 // blankHandler handles the end of a http test handler chain
@@ -265,6 +280,39 @@ func invalidBlocksHandlerMock(resp []byte, next http.Handler) http.Handler {
 	})
 }
 
+func liquidityBakingCPMMHandlerMock(resp []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if regLiquidityBakingCPMM.MatchString(r.URL.String()) {
+			w.Write(resp)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func managerKeyHandlerMock(resp []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if regManagerKey.MatchString(r.URL.String()) {
+			w.Write(resp)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func normalizeDataHandlerMock(resp []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if regNormalizeData.MatchString(r.URL.String()) {
+			w.Write(resp)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 func operationHashesHandlerMock(resp []byte, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if regOperationHashes.MatchString(r.URL.String()) {
@@ -276,6 +324,39 @@ func operationHashesHandlerMock(resp []byte, next http.Handler) http.Handler {
 	})
 }
 
+func runCodeHandlerMock(resp []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if regRunCode.MatchString(r.URL.String()) {
+			w.Write(resp)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func runOperationHandlerMock(resp []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if regRunOperation.MatchString(r.URL.String()) {
+			w.Write(resp)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func scriptHandlerMock(resp []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if regScript.MatchString(r.URL.String()) {
+			w.Write(resp)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 func stakingBalanceHandlerMock(resp []byte, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if regStakingBalance.MatchString(r.URL.String()) {
@@ -298,6 +379,39 @@ func storageHandlerMock(resp []byte, next http.Handler) http.Handler {
 	})
 }
 
+func traceCodeHandlerMock(resp []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if regTraceCode.MatchString(r.URL.String()) {
+			w.Write(resp)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func typecheckCodeHandlerMock(resp []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if regTypecheckCode.MatchString(r.URL.String()) {
+			w.Write(resp)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func typecheckDataHandlerMock(resp []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if regTypecheckData.MatchString(r.URL.String()) {
+			w.Write(resp)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 func versionsHandlerMock(resp []byte, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if regVersions.MatchString(r.URL.String()) {
@@ -309,6 +423,42 @@ func versionsHandlerMock(resp []byte, next http.Handler) http.Handler {
 	})
 }
 
+func nodeVersionHandlerMock(resp []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if regNodeVersion.MatchString(r.URL.String()) {
+			w.Write(resp)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// routesHandlerMock serves the response of the longest suffix of the path among routes, or calls next.
+func routesHandlerMock(routes map[string][]byte, next http.Handler) http.Handler {
+	suffixes := make([]string, 0, len(routes))
+	for suffix := range routes {
+		suffixes = append(suffixes, suffix)
+	}
+	sort.Slice(suffixes, func(i, j int) bool {
+		if len(suffixes[i]) != len(suffixes[j]) {
+			return len(suffixes[i]) > len(suffixes[j])
+		}
+		return suffixes[i] < suffixes[j]
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, suffix := range suffixes {
+			if strings.HasSuffix(r.URL.Path, suffix) {
+				w.Write(routes[suffix])
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 func checkErr(t *testing.T, wantErr bool, errContains string, err error) {
 	if wantErr {
 		assert.NotNil(t, err)
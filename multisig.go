@@ -0,0 +1,375 @@
+package gotezos
+
+import (
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+/*
+MultisigStorage Result
+RPC: ../<block_id>/context/contracts/<contract_id>/storage (GET)
+Link: https://gitlab.com/tezos/tezos/-/blob/master/michelson_test_scripts/mini_scenarios/generic_multisig.tz
+Description: The storage of the generic multisig contract.
+*/
+type MultisigStorage struct {
+	Counter   int
+	Threshold int
+	Keys      []string
+}
+
+/*
+MultisigPayload -
+Description: The payload the keys of a generic multisig contract sign off-chain before the
+main entrypoint may be called. Action is built with one of the Multisig*Action functions.
+*/
+type MultisigPayload struct {
+	// The chain id of the chain the multisig contract lives on.
+	ChainID string `validate:"required"`
+
+	// The KT1 address of the multisig contract.
+	Contract string `validate:"required"`
+
+	// The current stored counter of the multisig contract (see MultisigStorage).
+	Counter int
+
+	// The action the contract is asked to perform.
+	Action Micheline
+}
+
+/*
+MultisigMainInput -
+Description: The input for building a call to the main entrypoint of a generic multisig contract.
+Function: func MultisigMainContents(input *MultisigMainInput) (*Contents, error) {}
+*/
+type MultisigMainInput struct {
+	// The signed payload.
+	Payload *MultisigPayload `validate:"required"`
+
+	// The public keys of the contract in the order they are stored (see MultisigStorage).
+	Keys []string `validate:"required"`
+
+	// The collected signatures keyed by the public key that produced them.
+	Signatures map[string]string `validate:"required"`
+
+	// The manager fields of the transaction calling the contract.
+	Source       string `validate:"required"`
+	Fee          BigInt
+	Counter      BigInt
+	GasLimit     BigInt
+	StorageLimit BigInt
+}
+
+/*
+MultisigStorage RPC
+Path: ../<block_id>/context/contracts/<contract_id>/storage (GET)
+Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-contracts-contract-id-storage
+Description: Gets and decodes the storage (counter, threshold and keys) of a generic multisig contract.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+	contract:
+		The KT1 address of the multisig contract.
+*/
+func (t *GoTezos) MultisigStorage(blockID BlockID, contract string) (*MultisigStorage, error) {
+	storage, err := t.ContractStorage(&ContractStorageInput{
+		BlockID:  blockID,
+		Contract: contract,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get multisig storage for '%s'", contract)
+	}
+
+	fields := unpairComb(*storage)
+	if len(fields) != 3 || fields[0].Kind != MichelineKindInt || fields[1].Kind != MichelineKindInt || fields[2].Kind != MichelineKindSeq {
+		return nil, errors.Errorf("storage of '%s' is not a generic multisig storage", contract)
+	}
+
+	multisig := MultisigStorage{
+		Counter:   int(fields[0].Int.Int64()),
+		Threshold: int(fields[1].Int.Int64()),
+	}
+	for _, key := range fields[2].Seq {
+		switch key.Kind {
+		case MichelineKindString:
+			multisig.Keys = append(multisig.Keys, key.String)
+		case MichelineKindBytes:
+			pk, err := bytesToPublicKey(key.Bytes)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not decode multisig key for '%s'", contract)
+			}
+			multisig.Keys = append(multisig.Keys, pk)
+		default:
+			return nil, errors.Errorf("storage of '%s' is not a generic multisig storage", contract)
+		}
+	}
+
+	return &multisig, nil
+}
+
+/*
+MultisigLambdaAction Function
+Description: Returns an action that has the multisig contract execute an arbitrary lambda of type
+(lambda unit (list operation)).
+
+Parameters:
+	lambda:
+		The code of the lambda.
+*/
+func MultisigLambdaAction(lambda Micheline) Micheline {
+	return NewMichelinePrim("Left", lambda)
+}
+
+/*
+MultisigTransferAction Function
+Description: Returns an action that has the multisig contract transfer tez to an implicit
+account or to the default entrypoint of an originated contract.
+
+Parameters:
+	destination:
+		The tz1, tz2, tz3 or KT1 address receiving the transfer.
+	amount:
+		The amount in mutez.
+*/
+func MultisigTransferAction(destination string, amount BigInt) (Micheline, error) {
+	transfer := []Micheline{
+		NewMichelinePrim("PUSH", NewMichelinePrim("mutez"), NewMichelineBigInt(&amount.Int)),
+		NewMichelinePrim("UNIT"),
+		NewMichelinePrim("TRANSFER_TOKENS"),
+		NewMichelinePrim("CONS"),
+	}
+
+	var lambda []Micheline
+	if strings.HasPrefix(destination, "KT1") {
+		address, err := addressToBytes(destination)
+		if err != nil {
+			return Micheline{}, errors.Wrap(err, "could not build multisig transfer action")
+		}
+		lambda = []Micheline{
+			NewMichelinePrim("DROP"),
+			NewMichelinePrim("NIL", NewMichelinePrim("operation")),
+			NewMichelinePrim("PUSH", NewMichelinePrim("address"), NewMichelineBytes(address)),
+			NewMichelinePrim("CONTRACT", NewMichelinePrim("unit")),
+			NewMichelinePrim("IF_NONE",
+				NewMichelineSeq(NewMichelineSeq(NewMichelinePrim("UNIT"), NewMichelinePrim("FAILWITH"))),
+				NewMichelineSeq(),
+			),
+		}
+	} else {
+		keyHash, err := keyHashToBytes(destination)
+		if err != nil {
+			return Micheline{}, errors.Wrap(err, "could not build multisig transfer action")
+		}
+		lambda = []Micheline{
+			NewMichelinePrim("DROP"),
+			NewMichelinePrim("NIL", NewMichelinePrim("operation")),
+			NewMichelinePrim("PUSH", NewMichelinePrim("key_hash"), NewMichelineBytes(keyHash)),
+			NewMichelinePrim("IMPLICIT_ACCOUNT"),
+		}
+	}
+
+	return MultisigLambdaAction(NewMichelineSeq(append(lambda, transfer...)...)), nil
+}
+
+/*
+MultisigDelegateAction Function
+Description: Returns an action that has the multisig contract set (or withdraw) its delegate.
+
+Parameters:
+	delegate:
+		The tz1, tz2 or tz3 address of the new delegate. An empty string withdraws the delegate.
+*/
+func MultisigDelegateAction(delegate string) (Micheline, error) {
+	lambda := []Micheline{
+		NewMichelinePrim("DROP"),
+		NewMichelinePrim("NIL", NewMichelinePrim("operation")),
+	}
+
+	if delegate == "" {
+		lambda = append(lambda, NewMichelinePrim("NONE", NewMichelinePrim("key_hash")))
+	} else {
+		keyHash, err := keyHashToBytes(delegate)
+		if err != nil {
+			return Micheline{}, errors.Wrap(err, "could not build multisig delegate action")
+		}
+		lambda = append(lambda,
+			NewMichelinePrim("PUSH", NewMichelinePrim("key_hash"), NewMichelineBytes(keyHash)),
+			NewMichelinePrim("SOME"),
+		)
+	}
+
+	lambda = append(lambda, NewMichelinePrim("SET_DELEGATE"), NewMichelinePrim("CONS"))
+
+	return MultisigLambdaAction(NewMichelineSeq(lambda...)), nil
+}
+
+/*
+MultisigChangeKeysAction Function
+Description: Returns an action that replaces the threshold and keys of the multisig contract.
+
+Parameters:
+	threshold:
+		The number of signatures required by the contract.
+	keys:
+		The new public keys controlling the contract.
+*/
+func MultisigChangeKeysAction(threshold int, keys []string) (Micheline, error) {
+	if threshold > len(keys) {
+		return Micheline{}, errors.Errorf("threshold '%d' is greater than the number of keys '%d'", threshold, len(keys))
+	}
+
+	var keyNodes []Micheline
+	for _, key := range keys {
+		k, err := publicKeyToBytes(key)
+		if err != nil {
+			return Micheline{}, errors.Wrap(err, "could not build multisig change keys action")
+		}
+		keyNodes = append(keyNodes, NewMichelineBytes(k))
+	}
+
+	return NewMichelinePrim("Right",
+		NewMichelinePrim("Pair", NewMichelineInt(int64(threshold)), NewMichelineSeq(keyNodes...)),
+	), nil
+}
+
+/*
+Micheline Function
+Description: Returns the Micheline data the multisig contract checks signatures against:
+(Pair (Pair chain_id self_address) (Pair counter action)).
+*/
+func (m *MultisigPayload) Micheline() (Micheline, error) {
+	err := validator.New().Struct(m)
+	if err != nil {
+		return Micheline{}, errors.Wrap(err, "invalid input")
+	}
+
+	chainID, err := chainIDToBytes(m.ChainID)
+	if err != nil {
+		return Micheline{}, errors.Wrap(err, "invalid multisig payload")
+	}
+
+	contract, err := addressToBytes(m.Contract)
+	if err != nil {
+		return Micheline{}, errors.Wrap(err, "invalid multisig payload")
+	}
+
+	return NewMichelinePrim("Pair",
+		NewMichelinePrim("Pair", NewMichelineBytes(chainID), NewMichelineBytes(contract)),
+		NewMichelinePrim("Pair", NewMichelineInt(int64(m.Counter)), m.Action),
+	), nil
+}
+
+/*
+Bytes Function
+Description: Returns the packed payload, which is what each key of the multisig contract signs.
+*/
+func (m *MultisigPayload) Bytes() ([]byte, error) {
+	payload, err := m.Micheline()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to pack multisig payload")
+	}
+
+	return payload.Pack()
+}
+
+/*
+Sign Function
+Description: Signs the packed payload with a wallet, producing one of the signatures collected
+for MultisigMainInput.
+
+Parameters:
+	wallet:
+		The wallet holding one of the multisig contract's keys.
+*/
+func (m *MultisigPayload) Sign(wallet *Wallet) (string, error) {
+	payload, err := m.Bytes()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign multisig payload")
+	}
+
+	signature, err := wallet.Sign(payload)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign multisig payload")
+	}
+
+	return signature, nil
+}
+
+/*
+MultisigMainContents Function
+Description: Builds the transaction calling the main entrypoint of a generic multisig contract with
+the payload and the collected signatures, ordered as the contract's keys.
+
+Parameters:
+	input:
+		The payload, keys, signatures and manager fields of the call.
+*/
+func MultisigMainContents(input *MultisigMainInput) (*Contents, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	if _, err := input.Payload.Micheline(); err != nil {
+		return nil, errors.Wrap(err, "failed to build multisig call")
+	}
+
+	var sigs []Micheline
+	var signed int
+	for _, key := range input.Keys {
+		signature, ok := input.Signatures[key]
+		if !ok {
+			sigs = append(sigs, NewMichelinePrim("None"))
+			continue
+		}
+
+		sig, err := signatureToBytes(signature)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to build multisig call: invalid signature for '%s'", key)
+		}
+		sigs = append(sigs, NewMichelinePrim("Some", NewMichelineBytes(sig)))
+		signed++
+	}
+
+	if signed != len(input.Signatures) {
+		return nil, errors.New("failed to build multisig call: signatures provided for keys not in the contract")
+	}
+
+	return &Contents{
+		Kind:         TRANSACTIONOP,
+		Source:       input.Source,
+		Fee:          input.Fee,
+		Counter:      input.Counter,
+		GasLimit:     input.GasLimit,
+		StorageLimit: input.StorageLimit,
+		Destination:  input.Payload.Contract,
+		Parameters: &Parameters{
+			Entrypoint: "main",
+			Value: NewMichelinePrim("Pair",
+				NewMichelinePrim("Pair", NewMichelineInt(int64(input.Payload.Counter)), input.Payload.Action),
+				NewMichelineSeq(sigs...),
+			),
+		},
+	}, nil
+}
+
+/*
+ForgeMultisigMainOperation Function
+Description: Forges a call to the main entrypoint of a generic multisig contract.
+
+Parameters:
+	branch:
+		The branch to forge the operation on.
+	input:
+		The payload, keys, signatures and manager fields of the call.
+*/
+func (t *GoTezos) ForgeMultisigMainOperation(branch string, input *MultisigMainInput) (*string, error) {
+	contents, err := MultisigMainContents(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to forge multisig operation")
+	}
+
+	return t.ForgeOperation(branch, *contents)
+}
@@ -0,0 +1,237 @@
+package gotezos
+
+import (
+	"encoding/hex"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/ed25519"
+)
+
+var (
+	mockMultisigContract = "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"
+	mockMultisigStorage  = []byte(`{"prim":"Pair","args":[{"int":"3"},{"prim":"Pair","args":[{"int":"1"},[{"string":"edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G"},{"bytes":"00727e2a62e0e00d6bd03b3fe91d4d7b1bd1a6c4e9c4f1bba3d2ee4bb0ec4ccf61"}]]}]}`)
+)
+
+func Test_MultisigStorage(t *testing.T) {
+	bytesKey, _ := hex.DecodeString("727e2a62e0e00d6bd03b3fe91d4d7b1bd1a6c4e9c4f1bba3d2ee4bb0ec4ccf61")
+
+	type want struct {
+		err         bool
+		containsErr string
+		storage     *MultisigStorage
+	}
+
+	cases := []struct {
+		name        string
+		inputHanler http.Handler
+		want
+	}{
+		{
+			"returns rpc error",
+			gtGoldenHTTPMock(storageHandlerMock(mockRPCErrorResp, blankHandler)),
+			want{
+				true,
+				"could not get multisig storage",
+				nil,
+			},
+		},
+		{
+			"is not a multisig storage",
+			gtGoldenHTTPMock(storageHandlerMock([]byte(`{"string":"Hello Tezos!"}`), blankHandler)),
+			want{
+				true,
+				"is not a generic multisig storage",
+				nil,
+			},
+		},
+		{
+			"is successful",
+			gtGoldenHTTPMock(storageHandlerMock(mockMultisigStorage, blankHandler)),
+			want{
+				false,
+				"",
+				&MultisigStorage{
+					Counter:   3,
+					Threshold: 1,
+					Keys: []string{
+						"edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G",
+						b58cencode(bytesKey, prefix_edpk),
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.inputHanler)
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			storage, err := gt.MultisigStorage(BlockIDHash(mockBlockHash), mockMultisigContract)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			assert.Equal(t, tt.want.storage, storage)
+		})
+	}
+}
+
+func Test_MultisigPayload(t *testing.T) {
+	wallet, err := ImportWallet(
+		"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK",
+		"edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G",
+		"edskSA4oADtx6DTT6eXdBc6Pv5MoVBGXUzy8bBryi6D96RQNQYcRfVEXd2nuE2ZZPxs4YLZeM7KazUULFT1SfMDNyKFCUgk6vR",
+	)
+	assert.Nil(t, err)
+
+	transfer, err := MultisigTransferAction(mockAddressTz1, BigInt{*big.NewInt(1000000)})
+	assert.Nil(t, err)
+	delegate, err := MultisigDelegateAction("")
+	assert.Nil(t, err)
+	changeKeys, err := MultisigChangeKeysAction(1, []string{wallet.Pk})
+	assert.Nil(t, err)
+
+	_, err = MultisigTransferAction("tz1notanaddress", BigInt{})
+	assert.NotNil(t, err)
+	_, err = MultisigChangeKeysAction(2, []string{wallet.Pk})
+	assert.NotNil(t, err)
+
+	for _, action := range []Micheline{transfer, delegate, changeKeys} {
+		payload := &MultisigPayload{
+			ChainID:  "NetXdQprcVkpaWU",
+			Contract: mockMultisigContract,
+			Counter:  3,
+			Action:   action,
+		}
+
+		packed, err := payload.Bytes()
+		assert.Nil(t, err)
+		assert.Equal(t, byte(0x05), packed[0])
+
+		signature, err := payload.Sign(wallet)
+		assert.Nil(t, err)
+
+		sig, err := signatureToBytes(signature)
+		assert.Nil(t, err)
+		digest := blake2b.Sum256(packed)
+		assert.True(t, ed25519.Verify(ed25519.PublicKey(wallet.Kp.PubKey), digest[:], sig))
+	}
+
+	_, err = (&MultisigPayload{ChainID: "NetXdQprcVkpaWU", Contract: "KT1bad", Action: transfer}).Bytes()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid multisig payload")
+}
+
+func Test_ForgeMultisigMainOperation(t *testing.T) {
+	wallet, err := ImportWallet(
+		"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK",
+		"edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G",
+		"edskSA4oADtx6DTT6eXdBc6Pv5MoVBGXUzy8bBryi6D96RQNQYcRfVEXd2nuE2ZZPxs4YLZeM7KazUULFT1SfMDNyKFCUgk6vR",
+	)
+	assert.Nil(t, err)
+
+	action, err := MultisigDelegateAction(mockAddressTz1)
+	assert.Nil(t, err)
+
+	payload := &MultisigPayload{
+		ChainID:  "NetXdQprcVkpaWU",
+		Contract: mockMultisigContract,
+		Counter:  3,
+		Action:   action,
+	}
+	signature, err := payload.Sign(wallet)
+	assert.Nil(t, err)
+	otherKey := b58cencode(make([]byte, 32), prefix_edpk)
+
+	type want struct {
+		err         bool
+		containsErr string
+		sigs        []string
+	}
+
+	cases := []struct {
+		name  string
+		input *MultisigMainInput
+		want  want
+	}{
+		{
+			"orders signatures by contract keys",
+			&MultisigMainInput{
+				Payload:    payload,
+				Keys:       []string{otherKey, wallet.Pk},
+				Signatures: map[string]string{wallet.Pk: signature},
+				Source:     mockAddressTz1,
+				Fee:        BigInt{*big.NewInt(10000)},
+				Counter:    BigInt{*big.NewInt(11)},
+				GasLimit:   BigInt{*big.NewInt(50000)},
+			},
+			want{
+				false,
+				"",
+				[]string{"None", "Some"},
+			},
+		},
+		{
+			"rejects signatures of unknown keys",
+			&MultisigMainInput{
+				Payload:    payload,
+				Keys:       []string{otherKey},
+				Signatures: map[string]string{wallet.Pk: signature},
+				Source:     mockAddressTz1,
+			},
+			want{
+				true,
+				"signatures provided for keys not in the contract",
+				nil,
+			},
+		},
+		{
+			"is missing payload",
+			&MultisigMainInput{
+				Keys:       []string{otherKey},
+				Signatures: map[string]string{},
+				Source:     mockAddressTz1,
+			},
+			want{
+				true,
+				"invalid input",
+				nil,
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			gt := &GoTezos{}
+			forge, err := gt.ForgeMultisigMainOperation(mockBlockHash, tt.input)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			if tt.want.err {
+				return
+			}
+
+			_, contents, err := gt.UnforgeOperation(*forge, false)
+			assert.Nil(t, err)
+			assert.Len(t, *contents, 1)
+
+			c := (*contents)[0]
+			assert.Equal(t, mockMultisigContract, c.Destination)
+			assert.Equal(t, "main", c.Parameters.Entrypoint)
+
+			var sigs []string
+			for _, sig := range c.Parameters.Value.Args[1].Seq {
+				sigs = append(sigs, sig.Prim)
+			}
+			assert.Equal(t, tt.want.sigs, sigs)
+
+			expected, err := MultisigMainContents(tt.input)
+			assert.Nil(t, err)
+			assert.Equal(t, expected.Parameters, c.Parameters)
+		})
+	}
+}
@@ -3,53 +3,360 @@ package gotezos
 import (
 	"encoding/json"
 	"fmt"
-	"time"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 )
 
 /*
 Version Result
-RPC: /network/version (GET)
-Link: https://tezos.gitlab.io/api/rpc.html#get-network-version
+RPC: /version (GET), /network/version (GET), /network/versions (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-version
+Description: The versions of the node and of its network layer. Node and Commit are nil for nodes older than
+Octez v8, which only have /network/version. SupportedVersions is nil for nodes that do not expose
+/network/versions.
 */
 type Version struct {
+	ChainName            string `json:"chain_name"`
+	DistributedDbVersion int    `json:"distributed_db_version"`
+	// The version of the peer-to-peer protocol announced to peers.
+	P2PVersion int          `json:"p2p_version"`
+	Node       *NodeVersion `json:"-"`
+	Commit     *CommitInfo  `json:"-"`
+	// The network versions the node accepts from peers.
+	SupportedVersions []NetworkVersion `json:"-"`
+}
+
+/*
+NetworkVersion Result
+RPC: /network/versions (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-network-versions
+*/
+type NetworkVersion struct {
 	ChainName            string `json:"chain_name"`
 	DistributedDbVersion int    `json:"distributed_db_version"`
 	P2PVersion           int    `json:"p2p_version"`
 }
 
+/*
+NodeVersion Result
+RPC: /version (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-version
+Description: The release of Octez a node runs, e.g. 17.3 or 18.0~rc1.
+*/
+type NodeVersion struct {
+	Major int
+	Minor int
+	// "release", "dev", or the release candidate or beta and its number (e.g. "rc1", "beta2"), suffixed with
+	// "+dev" for their development versions.
+	AdditionalInfo string
+}
+
+/*
+CommitInfo Result
+RPC: /version (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-version
+*/
+type CommitInfo struct {
+	CommitHash string `json:"commit_hash"`
+	CommitDate string `json:"commit_date"`
+}
+
+/*
+UnmarshalJSON Function
+Description: Implements the json.Unmarshaler interface for Version. Both the response of /version and the
+network version of /network/version are accepted.
+
+Parameters:
+	b:
+		The JSON representation of a version.
+*/
+func (v *Version) UnmarshalJSON(b []byte) error {
+	type version Version
+	var node struct {
+		Version        *NodeVersion `json:"version"`
+		NetworkVersion *version     `json:"network_version"`
+		CommitInfo     *CommitInfo  `json:"commit_info"`
+	}
+	if err := json.Unmarshal(b, &node); err != nil {
+		return err
+	}
+
+	if node.NetworkVersion == nil {
+		return json.Unmarshal(b, (*version)(v))
+	}
+
+	*v = Version(*node.NetworkVersion)
+	v.Node, v.Commit = node.Version, node.CommitInfo
+
+	return nil
+}
+
+/*
+UnmarshalJSON Function
+Description: Implements the json.Unmarshaler interface for NodeVersion. The additional info of a release
+candidate or beta is an object with its number (e.g. {"rc": 1}), flattened into AdditionalInfo (e.g. "rc1").
+
+Parameters:
+	b:
+		The JSON representation of a node version.
+*/
+func (n *NodeVersion) UnmarshalJSON(b []byte) error {
+	var version struct {
+		Major          int             `json:"major"`
+		Minor          int             `json:"minor"`
+		AdditionalInfo json.RawMessage `json:"additional_info"`
+	}
+	if err := json.Unmarshal(b, &version); err != nil {
+		return err
+	}
+
+	n.Major, n.Minor, n.AdditionalInfo = version.Major, version.Minor, ""
+	if len(version.AdditionalInfo) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(version.AdditionalInfo, &n.AdditionalInfo); err == nil {
+		return nil
+	}
+
+	var info map[string]int
+	if err := json.Unmarshal(version.AdditionalInfo, &info); err != nil {
+		return errors.Wrap(err, "invalid additional info")
+	}
+	for kind, number := range info {
+		n.AdditionalInfo = fmt.Sprintf("%s%d", strings.TrimSuffix(kind, "_dev"), number)
+		if strings.HasSuffix(kind, "_dev") {
+			n.AdditionalInfo += "+dev"
+		}
+	}
+
+	return nil
+}
+
+/*
+String Function
+Description: Returns the version as Octez prints it, e.g. 17.3, 18.0~rc1 or 19.0+dev.
+*/
+func (n NodeVersion) String() string {
+	version := fmt.Sprintf("%d.%d", n.Major, n.Minor)
+	switch n.AdditionalInfo {
+	case "", "release":
+		return version
+	case "dev":
+		return version + "+dev"
+	default:
+		return version + "~" + n.AdditionalInfo
+	}
+}
+
+/*
+AtLeast Function
+Description: Returns whether the node is at least of a release, e.g. to use a RPC only newer nodes have. Release
+candidates, betas and development versions count as the release they lead to.
+
+Parameters:
+	major:
+		The major version of the release, e.g. 17.
+	minor:
+		The minor version of the release, e.g. 3.
+*/
+func (n NodeVersion) AtLeast(major, minor int) bool {
+	return n.Major > major || (n.Major == major && n.Minor >= minor)
+}
+
 /*
 Constants Result
 RPC: ../<block_id>/context/constants (GET)
 Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-constants
+Description: Constants are added, removed and change type between protocols. Fields missing from the
+node's response are left empty and fields of an unexpected type are skipped rather than failing the
+unmarshal; every constant remains available through Raw and Get.
 */
 type Constants struct {
-	ProofOfWorkNonceSize         int      `json:"proof_of_work_nonce_size"`
-	NonceLength                  int      `json:"nonce_length"`
-	MaxRevelationsPerBlock       int      `json:"max_revelations_per_block"`
-	MaxOperationDataLength       int      `json:"max_operation_data_length"`
-	MaxProposalsPerDelegate      int      `json:"max_proposals_per_delegate"`
-	PreservedCycles              int      `json:"preserved_cycles"`
-	BlocksPerCycle               int      `json:"blocks_per_cycle"`
-	BlocksPerCommitment          int      `json:"blocks_per_commitment"`
-	BlocksPerRollSnapshot        int      `json:"blocks_per_roll_snapshot"`
-	BlocksPerVotingPeriod        int      `json:"blocks_per_voting_period"`
-	TimeBetweenBlocks            []string `json:"time_between_blocks"`
-	EndorsersPerBlock            int      `json:"endorsers_per_block"`
-	HardGasLimitPerOperation     string   `json:"hard_gas_limit_per_operation"`
-	HardGasLimitPerBlock         string   `json:"hard_gas_limit_per_block"`
-	ProofOfWorkThreshold         string   `json:"proof_of_work_threshold"`
-	TokensPerRoll                string   `json:"tokens_per_roll"`
-	MichelsonMaximumTypeSize     int      `json:"michelson_maximum_type_size"`
-	SeedNonceRevelationTip       string   `json:"seed_nonce_revelation_tip"`
-	OriginationSize              int      `json:"origination_size"`
-	BlockSecurityDeposit         string   `json:"block_security_deposit"`
-	EndorsementSecurityDeposit   string   `json:"endorsement_security_deposit"`
-	BlockReward                  string   `json:"block_reward"`
-	EndorsementReward            string   `json:"endorsement_reward"`
-	CostPerByte                  string   `json:"cost_per_byte"`
-	HardStorageLimitPerOperation string   `json:"hard_storage_limit_per_operation"`
+	ProofOfWorkNonceSize              int      `json:"proof_of_work_nonce_size"`
+	NonceLength                       int      `json:"nonce_length"`
+	MaxRevelationsPerBlock            int      `json:"max_revelations_per_block"`
+	MaxOperationDataLength            int      `json:"max_operation_data_length"`
+	MaxProposalsPerDelegate           int      `json:"max_proposals_per_delegate"`
+	PreservedCycles                   int      `json:"preserved_cycles"`
+	BlocksPerCycle                    int      `json:"blocks_per_cycle"`
+	BlocksPerCommitment               int      `json:"blocks_per_commitment"`
+	BlocksPerRollSnapshot             int      `json:"blocks_per_roll_snapshot"`
+	BlocksPerVotingPeriod             int      `json:"blocks_per_voting_period"`
+	CyclesPerVotingPeriod             int      `json:"cycles_per_voting_period,omitempty"`
+	TimeBetweenBlocks                 []string `json:"time_between_blocks"`
+	EndorsersPerBlock                 int      `json:"endorsers_per_block"`
+	HardGasLimitPerOperation          string   `json:"hard_gas_limit_per_operation"`
+	HardGasLimitPerBlock              string   `json:"hard_gas_limit_per_block"`
+	ProofOfWorkThreshold              string   `json:"proof_of_work_threshold"`
+	TokensPerRoll                     string   `json:"tokens_per_roll"`
+	MichelsonMaximumTypeSize          int      `json:"michelson_maximum_type_size"`
+	SeedNonceRevelationTip            string   `json:"seed_nonce_revelation_tip"`
+	OriginationSize                   int      `json:"origination_size"`
+	BlockSecurityDeposit              string   `json:"block_security_deposit"`
+	EndorsementSecurityDeposit        string   `json:"endorsement_security_deposit"`
+	BlockReward                       string   `json:"block_reward"`
+	EndorsementReward                 string   `json:"endorsement_reward"`
+	CostPerByte                       string   `json:"cost_per_byte"`
+	HardStorageLimitPerOperation      string   `json:"hard_storage_limit_per_operation"`
+	QuorumMin                         int      `json:"quorum_min"`
+	QuorumMax                         int      `json:"quorum_max"`
+	MinProposalQuorum                 int      `json:"min_proposal_quorum"`
+	LiquidityBakingSubsidy            string   `json:"liquidity_baking_subsidy,omitempty"`
+	LiquidityBakingSunsetLevel        int      `json:"liquidity_baking_sunset_level,omitempty"`
+	LiquidityBakingEscapeEmaThreshold int      `json:"liquidity_baking_escape_ema_threshold,omitempty"`
+	LiquidityBakingToggleEmaThreshold int      `json:"liquidity_baking_toggle_ema_threshold,omitempty"`
+
+	// The Tenderbake constants, nil for protocols before Ithaca.
+	Tenderbake *TenderbakeConstants `json:"-"`
+	// The Data Availability Layer constants, nil for protocols before Oxford.
+	DAL *DALConstants `json:"-"`
+	// The constants exactly as returned by the node.
+	Raw json.RawMessage `json:"-"`
+}
+
+/*
+TenderbakeConstants Result
+RPC: ../<block_id>/context/constants (GET)
+Description: The constants introduced with Tenderbake consensus (Ithaca and later).
+*/
+type TenderbakeConstants struct {
+	ConsensusCommitteeSize   int    `json:"consensus_committee_size"`
+	ConsensusThreshold       int    `json:"consensus_threshold"`
+	MinimalBlockDelay        string `json:"minimal_block_delay"`
+	DelayIncrementPerRound   string `json:"delay_increment_per_round"`
+	BlocksPerStakeSnapshot   int    `json:"blocks_per_stake_snapshot"`
+	ConsensusRightsDelay     int    `json:"consensus_rights_delay,omitempty"`
+	MaxSlashingPeriod        int    `json:"max_slashing_period"`
+	FrozenDepositsPercentage int    `json:"frozen_deposits_percentage,omitempty"`
+	MinimalStake             string `json:"minimal_stake,omitempty"`
+	BakingRewardFixedPortion string `json:"baking_reward_fixed_portion,omitempty"`
+	BakingRewardBonusPerSlot string `json:"baking_reward_bonus_per_slot,omitempty"`
+	EndorsingRewardPerSlot   string `json:"endorsing_reward_per_slot,omitempty"`
+}
+
+/*
+UnmarshalJSON Function
+Description: Implements the json.Unmarshaler interface for Constants. Integers returned as strings
+(and the other way around) are converted, fields of any other unexpected type are skipped.
+
+Parameters:
+	b:
+		The JSON representation of the constants.
+*/
+func (c *Constants) UnmarshalJSON(b []byte) error {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(b, &fields)
+	if err != nil {
+		return err
+	}
+
+	*c = Constants{
+		Raw: append(json.RawMessage{}, b...),
+	}
+	unmarshalConstantFields(fields, reflect.ValueOf(c).Elem())
+
+	if _, ok := fields["consensus_committee_size"]; ok {
+		c.Tenderbake = &TenderbakeConstants{}
+		unmarshalConstantFields(fields, reflect.ValueOf(c.Tenderbake).Elem())
+
+		// preserved_cycles was replaced by consensus_rights_delay in Paris.
+		if _, ok := fields["preserved_cycles"]; !ok {
+			c.PreservedCycles = c.Tenderbake.ConsensusRightsDelay
+		}
+	}
+
+	var dal map[string]json.RawMessage
+	if json.Unmarshal(fields["dal_parametric"], &dal) == nil && dal != nil {
+		c.DAL = &DALConstants{}
+		unmarshalConstantFields(dal, reflect.ValueOf(c.DAL).Elem())
+	}
+
+	return nil
+}
+
+/*
+Has Function
+Description: Returns whether the node returned a constant.
+
+Parameters:
+	name:
+		The name of the constant (e.g. "blocks_per_cycle").
+*/
+func (c *Constants) Has(name string) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(c.Raw, &fields); err != nil {
+		return false
+	}
+
+	_, ok := fields[name]
+	return ok
+}
+
+/*
+Get Function
+Description: Unmarshals any constant returned by the node, including those without a field in Constants.
+
+Parameters:
+	name:
+		The name of the constant (e.g. "blocks_per_cycle").
+	v:
+		A pointer to unmarshal the constant into.
+*/
+func (c *Constants) Get(name string, v interface{}) error {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(c.Raw, &fields)
+	if err != nil {
+		return errors.Wrap(err, "could not unmarshal network constants")
+	}
+
+	field, ok := fields[name]
+	if !ok {
+		return errors.Errorf("constant '%s' not found", name)
+	}
+
+	err = json.Unmarshal(field, v)
+	if err != nil {
+		return errors.Wrapf(err, "could not unmarshal constant '%s'", name)
+	}
+
+	return nil
+}
+
+// unmarshalConstantFields sets every field of the struct v tagged with a constant name present in fields.
+func unmarshalConstantFields(fields map[string]json.RawMessage, v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		name := strings.Split(v.Type().Field(i).Tag.Get("json"), ",")[0]
+		field, ok := fields[name]
+		if name == "" || name == "-" || !ok {
+			continue
+		}
+
+		value := v.Field(i)
+		if json.Unmarshal(field, value.Addr().Interface()) == nil {
+			continue
+		}
+		value.Set(reflect.Zero(value.Type()))
+
+		switch value.Kind() {
+		case reflect.Int:
+			var str string
+			if json.Unmarshal(field, &str) == nil {
+				if n, err := strconv.Atoi(str); err == nil {
+					value.SetInt(int64(n))
+				}
+			}
+		case reflect.String:
+			var n json.Number
+			if json.Unmarshal(field, &n) == nil {
+				value.SetString(n.String())
+			}
+		}
+	}
 }
 
 // Cycle is a Snapshot returned by the Tezos RPC API.
@@ -95,25 +402,48 @@ Link: https://tezos.gitlab.io/api/rpc.html#get-monitor-bootstrapped
 */
 type Bootstrap struct {
 	Block     string    `json:"block"`
-	Timestamp time.Time `json:"timestamp"`
+	Timestamp Timestamp `json:"timestamp"`
 }
 
 /*
 Version RPC
-Path: /network/version (GET)
-Link: https://tezos.gitlab.io/api/rpc.html#get-network-version
-Description: Supported network layer version.
+Path: /version (GET), /network/version (GET), /network/versions (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-version
+Description: The version of the node, the commit it was built from, the version of its network layer and the
+network versions it supports. Nodes without /version (older than Octez v8) are asked for the version of their
+network layer only.
 */
 func (t *GoTezos) Version() (*Version, error) {
-	resp, err := t.get("/network/version")
+	resp, err := t.get("/version")
+	if requestErr, ok := AsRequestError(err); ok && requestErr.StatusCode == http.StatusNotFound {
+		resp, err = t.get("/network/version")
+		if err != nil {
+			return &Version{}, errors.Wrap(err, "could not get network version")
+		}
+	}
 	if err != nil {
-		return &Version{}, errors.Wrap(err, "could not get network version")
+		return &Version{}, errors.Wrap(err, "could not get version")
 	}
 
 	var version Version
 	err = json.Unmarshal(resp, &version)
 	if err != nil {
-		return &Version{}, errors.Wrap(err, "could not unmarshal network version")
+		return &Version{}, errors.Wrap(err, "could not unmarshal version")
+	}
+
+	// /network/versions is often denied by the ACL of public nodes, which doesn't make the rest of the version
+	// unusable.
+	resp, err = t.get("/network/versions")
+	if requestErr, ok := AsRequestError(err); ok && (requestErr.StatusCode == http.StatusNotFound || requestErr.StatusCode == http.StatusForbidden) {
+		return &version, nil
+	}
+	if err != nil {
+		return &Version{}, errors.Wrap(err, "could not get supported network versions")
+	}
+
+	err = json.Unmarshal(resp, &version.SupportedVersions)
+	if err != nil {
+		return &Version{}, errors.Wrap(err, "could not unmarshal supported network versions")
 	}
 
 	return &version, nil
@@ -123,10 +453,15 @@ func (t *GoTezos) Version() (*Version, error) {
 Version RPC
 Path: ../<block_id>/context/constants (GET)
 Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-constants
-Description: All constants.
+Description: All constants. Offline (see NewOffline), the constants GoTezos was created with.
 */
-func (t *GoTezos) Constants(blockhash string) (*Constants, error) {
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/constants", blockhash))
+func (t *GoTezos) Constants(blockID BlockID) (*Constants, error) {
+	if t.offline {
+		constants := *t.cachedConstants()
+		return &constants, nil
+	}
+
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/constants", blockID.ID()))
 	if err != nil {
 		return &Constants{}, errors.Wrapf(err, "could not get network constants")
 	}
@@ -217,13 +552,14 @@ func (t *GoTezos) Cycle(cycle int) (*Cycle, error) {
 		return &Cycle{}, errors.Wrapf(err, "could not get cycle '%d'", cycle)
 	}
 
-	if cycle > head.Metadata.Level.Cycle+t.networkConstants.PreservedCycles-1 {
+	constants := t.cachedConstants()
+	if cycle > head.Metadata.Level.Cycle+constants.PreservedCycles-1 {
 		return &Cycle{}, errors.Errorf("could not get cycle '%d': request is in the future", cycle)
 	}
 
 	var c Cycle
 	if cycle < head.Metadata.Level.Cycle {
-		block, err := t.Block(cycle*t.networkConstants.BlocksPerCycle + 1)
+		block, err := t.Block(BlockIDLevel(cycle*constants.BlocksPerCycle + 1))
 		if err != nil {
 			return &Cycle{}, errors.Wrapf(err, "could not get cycle '%d'", cycle)
 		}
@@ -240,12 +576,12 @@ func (t *GoTezos) Cycle(cycle int) (*Cycle, error) {
 		}
 	}
 
-	level := ((cycle - t.networkConstants.PreservedCycles - 2) * t.networkConstants.BlocksPerCycle) + (c.RollSnapshot+1)*t.networkConstants.BlocksPerRollSnapshot
+	level := ((cycle - constants.PreservedCycles - 2) * constants.BlocksPerCycle) + (c.RollSnapshot+1)*constants.BlocksPerRollSnapshot
 	if level < 1 {
 		level = 1
 	}
 
-	block, err := t.Block(level)
+	block, err := t.Block(BlockIDLevel(level))
 	if err != nil {
 		return &c, errors.Wrapf(err, "could not get cycle '%d'", cycle)
 	}
@@ -12,7 +12,44 @@ import (
 func Test_Version(t *testing.T) {
 
 	var goldenVersion Version
-	json.Unmarshal(mockVersionResp, &goldenVersion)
+	json.Unmarshal(mockNodeVersionResp, &goldenVersion)
+
+	var goldenNetworkVersion Version
+	json.Unmarshal(mockVersionResp, &goldenNetworkVersion)
+
+	var goldenSupportedVersions []NetworkVersion
+	json.Unmarshal(mockNetworkVersionsResp, &goldenSupportedVersions)
+
+	withSupportedVersions := func(version Version) *Version {
+		version.SupportedVersions = goldenSupportedVersions
+		return &version
+	}
+
+	supportedVersions := func(resp []byte, next http.Handler) http.Handler {
+		return routesHandlerMock(map[string][]byte{"/network/versions": resp}, next)
+	}
+
+	denied := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/network/versions" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	notFound := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/version" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
 
 	type want struct {
 		wantErr     bool
@@ -27,31 +64,76 @@ func Test_Version(t *testing.T) {
 	}{
 		{
 			"returns rpc error",
-			gtGoldenHTTPMock(versionsHandlerMock(mockRPCErrorResp, blankHandler)),
+			gtGoldenHTTPMock(nodeVersionHandlerMock(mockRPCErrorResp, blankHandler)),
 			want{
 				true,
-				"could not get network version",
+				"could not get version",
 				&Version{},
 			},
 		},
 		{
 			"fails to unmarshal",
-			gtGoldenHTTPMock(versionsHandlerMock([]byte(`junk`), blankHandler)),
+			gtGoldenHTTPMock(nodeVersionHandlerMock([]byte(`junk`), blankHandler)),
 			want{
 				true,
-				"could not unmarshal network version",
+				"could not unmarshal version",
 				&Version{},
 			},
 		},
 		{
 			"is successful",
-			gtGoldenHTTPMock(versionsHandlerMock(mockVersionResp, blankHandler)),
+			gtGoldenHTTPMock(supportedVersions(mockNetworkVersionsResp, nodeVersionHandlerMock(mockNodeVersionResp, blankHandler))),
+			want{
+				false,
+				"",
+				withSupportedVersions(goldenVersion),
+			},
+		},
+		{
+			"falls back to network version",
+			gtGoldenHTTPMock(supportedVersions(mockNetworkVersionsResp, notFound(versionsHandlerMock(mockVersionResp, blankHandler)))),
+			want{
+				false,
+				"",
+				withSupportedVersions(goldenNetworkVersion),
+			},
+		},
+		{
+			"returns supported versions rpc error",
+			gtGoldenHTTPMock(supportedVersions(mockRPCErrorResp, nodeVersionHandlerMock(mockNodeVersionResp, blankHandler))),
+			want{
+				true,
+				"could not get supported network versions",
+				&Version{},
+			},
+		},
+		{
+			"fails to unmarshal supported versions",
+			gtGoldenHTTPMock(supportedVersions([]byte(`junk`), nodeVersionHandlerMock(mockNodeVersionResp, blankHandler))),
+			want{
+				true,
+				"could not unmarshal supported network versions",
+				&Version{},
+			},
+		},
+		{
+			"ignores denied supported versions",
+			gtGoldenHTTPMock(denied(nodeVersionHandlerMock(mockNodeVersionResp, blankHandler))),
 			want{
 				false,
 				"",
 				&goldenVersion,
 			},
 		},
+		{
+			"returns network version rpc error",
+			gtGoldenHTTPMock(notFound(versionsHandlerMock(mockRPCErrorResp, blankHandler))),
+			want{
+				true,
+				"could not get network version",
+				&Version{},
+			},
+		},
 	}
 
 	for _, tt := range cases {
@@ -73,6 +155,45 @@ func Test_Version(t *testing.T) {
 			assert.Equal(t, tt.want.wantVersion, version)
 		})
 	}
+
+	assert.Equal(t, &Version{
+		ChainName:            "TEZOS_MAINNET",
+		DistributedDbVersion: 2,
+		P2PVersion:           1,
+		Node:                 &NodeVersion{Major: 17, Minor: 3, AdditionalInfo: "release"},
+		Commit:               &CommitInfo{CommitHash: "4ca33194c7e1ad4d8e9b3e3c2f1b8a0c6b8b3f4c", CommitDate: "2023-08-04 10:15:40 +0000"},
+	}, &goldenVersion)
+	assert.Nil(t, goldenNetworkVersion.Node)
+	assert.Equal(t, []NetworkVersion{
+		{ChainName: "TEZOS_MAINNET", DistributedDbVersion: 2, P2PVersion: 0},
+		{ChainName: "TEZOS_MAINNET", DistributedDbVersion: 2, P2PVersion: 1},
+	}, goldenSupportedVersions)
+}
+
+func Test_NodeVersion(t *testing.T) {
+	cases := []struct {
+		input   string
+		version string
+	}{
+		{`{"major":17,"minor":3,"additional_info":"release"}`, "17.3"},
+		{`{"major":19,"minor":0,"additional_info":"dev"}`, "19.0+dev"},
+		{`{"major":18,"minor":0,"additional_info":{"rc":1}}`, "18.0~rc1"},
+		{`{"major":18,"minor":0,"additional_info":{"beta_dev":2}}`, "18.0~beta2+dev"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.version, func(t *testing.T) {
+			var version NodeVersion
+			assert.Nil(t, json.Unmarshal([]byte(tt.input), &version))
+			assert.Equal(t, tt.version, version.String())
+		})
+	}
+
+	version := NodeVersion{Major: 17, Minor: 3}
+	assert.True(t, version.AtLeast(17, 3))
+	assert.True(t, version.AtLeast(16, 9))
+	assert.False(t, version.AtLeast(17, 4))
+	assert.False(t, version.AtLeast(18, 0))
 }
 
 func Test_Constants(t *testing.T) {
@@ -128,7 +249,7 @@ func Test_Constants(t *testing.T) {
 			gt, err := New(server.URL)
 			assert.Nil(t, err)
 
-			constants, err := gt.Constants("BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1")
+			constants, err := gt.Constants(BlockIDHash("BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1"))
 			if tt.wantErr {
 				assert.NotNil(t, err)
 				assert.Contains(t, err.Error(), tt.want.containsErr)
@@ -141,6 +262,56 @@ func Test_Constants(t *testing.T) {
 	}
 }
 
+func Test_ConstantsAcrossProtocols(t *testing.T) {
+	carthage := []byte(`{"preserved_cycles":5,"blocks_per_cycle":4096,"blocks_per_roll_snapshot":256,"time_between_blocks":["60","40"],"endorsers_per_block":32,"block_reward":["1250000","187500"],"endorsement_reward":["1250000","833333"],"cost_per_byte":"250","origination_size":257}`)
+	paris := []byte(`{"consensus_rights_delay":2,"blocks_per_cycle":30720,"blocks_per_stake_snapshot":"1920","consensus_committee_size":7000,"consensus_threshold":4667,"minimal_block_delay":"10","delay_increment_per_round":"5","max_slashing_period":2,"minimal_stake":"6000000000","cost_per_byte":250,"origination_size":257,"dal_parametric":{"feature_enable":true,"number_of_slots":32}}`)
+
+	var constants Constants
+	err := json.Unmarshal(carthage, &constants)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, constants.PreservedCycles)
+	assert.Equal(t, []string{"60", "40"}, constants.TimeBetweenBlocks)
+	assert.Equal(t, "", constants.EndorsementReward)
+	assert.Equal(t, "250", constants.CostPerByte)
+	assert.Nil(t, constants.Tenderbake)
+
+	var rewards []string
+	err = constants.Get("endorsement_reward", &rewards)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1250000", "833333"}, rewards)
+
+	constants = Constants{}
+	err = json.Unmarshal(paris, &constants)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, constants.PreservedCycles)
+	assert.Equal(t, 30720, constants.BlocksPerCycle)
+	assert.Equal(t, "250", constants.CostPerByte)
+	assert.Nil(t, constants.TimeBetweenBlocks)
+	assert.Equal(t, &TenderbakeConstants{
+		ConsensusCommitteeSize: 7000,
+		ConsensusThreshold:     4667,
+		MinimalBlockDelay:      "10",
+		DelayIncrementPerRound: "5",
+		BlocksPerStakeSnapshot: 1920,
+		ConsensusRightsDelay:   2,
+		MaxSlashingPeriod:      2,
+		MinimalStake:           "6000000000",
+	}, constants.Tenderbake)
+
+	assert.True(t, constants.Has("dal_parametric"))
+	assert.False(t, constants.Has("preserved_cycles"))
+
+	var dal struct {
+		NumberOfSlots int `json:"number_of_slots"`
+	}
+	err = constants.Get("dal_parametric", &dal)
+	assert.Nil(t, err)
+	assert.Equal(t, 32, dal.NumberOfSlots)
+
+	err = constants.Get("tokens_per_roll", &rewards)
+	checkErr(t, true, "constant 'tokens_per_roll' not found", err)
+}
+
 func Test_Connections(t *testing.T) {
 
 	var goldenConnections Connections
@@ -0,0 +1,103 @@
+package gotezos
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+/*
+Network -
+Description: A Tezos network and the public nodes serving it, see NewWithNetwork. Public nodes are rate limited
+and come and go, run a node for anything beyond examples and tests.
+*/
+type Network struct {
+	// The name of the network, e.g. ghostnet.
+	Name string
+	// The chain ID of the network, checked against the node. Empty for testnets whose chain ID changes when
+	// they are reset.
+	ChainID string
+	// The public RPC endpoints of the network, in order of preference.
+	RPCEndpoints []string
+	// Where test tez are given away, empty for mainnet.
+	Faucet string
+}
+
+// Presets of the public Tezos networks, see NewWithNetwork.
+var (
+	Mainnet = Network{
+		Name:         "mainnet",
+		ChainID:      MainnetChainID,
+		RPCEndpoints: []string{"https://mainnet.api.tez.ie", "https://rpc.tzbeta.net"},
+	}
+
+	Ghostnet = Network{
+		Name:         "ghostnet",
+		ChainID:      GhostnetChainID,
+		RPCEndpoints: []string{"https://rpc.ghostnet.teztnets.com", "https://ghostnet.ecadinfra.com"},
+		Faucet:       "https://faucet.ghostnet.teztnets.com",
+	}
+
+	// Rionet is the testnet of the Rio protocol. It is reset with each protocol, so its chain ID is not checked.
+	Rionet = Network{
+		Name:         "rionet",
+		RPCEndpoints: []string{"https://rpc.rionet.teztnets.com"},
+		Faucet:       "https://faucet.rionet.teztnets.com",
+	}
+)
+
+// networks are the network presets, see NetworkByName.
+var networks = []*Network{&Mainnet, &Ghostnet, &Rionet}
+
+/*
+NetworkByName Function
+Description: Returns the preset of a network by its name, e.g. from a flag or an environment variable.
+
+Parameters:
+	name:
+		The name of the network, case insensitive, e.g. ghostnet.
+*/
+func NetworkByName(name string) (Network, bool) {
+	for _, network := range networks {
+		if strings.EqualFold(network.Name, name) {
+			return *network, true
+		}
+	}
+
+	return Network{}, false
+}
+
+/*
+NewWithNetwork Func
+Description: Returns a GoTezos connected to the first RPC endpoint of a network that can be reached, checking the
+node is on the chain of the network, see New.
+
+	gt, err := gotezos.NewWithNetwork(gotezos.Ghostnet)
+
+Parameters:
+	network:
+		The network, e.g. Ghostnet.
+
+	opts:
+		Optional checks of the node and tuning of the connections to it, see Option.
+*/
+func NewWithNetwork(network Network, opts ...Option) (*GoTezos, error) {
+	if len(network.RPCEndpoints) == 0 {
+		return nil, errors.Errorf("failed to connect to %s: no rpc endpoints", network.Name)
+	}
+
+	if network.ChainID != "" {
+		opts = append([]Option{WithChainID(network.ChainID)}, opts...)
+	}
+
+	var err error
+	for _, endpoint := range network.RPCEndpoints {
+		var gt *GoTezos
+		gt, err = New(endpoint, opts...)
+		if err == nil {
+			return gt, nil
+		}
+	}
+
+	return nil, errors.Wrapf(err, "failed to connect to %s", network.Name)
+}
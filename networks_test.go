@@ -0,0 +1,92 @@
+package gotezos
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewWithNetwork(t *testing.T) {
+	down := httptest.NewServer(blankHandler)
+	down.Close()
+
+	type want struct {
+		err         bool
+		containsErr string
+	}
+
+	cases := []struct {
+		name    string
+		network func(url string) Network
+		want
+	}{
+		{
+			"is successful",
+			func(url string) Network {
+				return Network{Name: "mainnet", ChainID: MainnetChainID, RPCEndpoints: []string{url}}
+			},
+			want{false, ""},
+		},
+		{
+			"falls back to the next endpoint",
+			func(url string) Network {
+				return Network{Name: "mainnet", ChainID: MainnetChainID, RPCEndpoints: []string{down.URL, url}}
+			},
+			want{false, ""},
+		},
+		{
+			"does not check the chain of networks without chain id",
+			func(url string) Network {
+				return Network{Name: "rionet", RPCEndpoints: []string{url}}
+			},
+			want{false, ""},
+		},
+		{
+			"handles node on another chain",
+			func(url string) Network {
+				return Network{Name: "ghostnet", ChainID: GhostnetChainID, RPCEndpoints: []string{url}}
+			},
+			want{true, "failed to connect to ghostnet: node is on chain 'NetXdQprcVkpaWU', expected 'NetXnHfVqm9iesp'"},
+		},
+		{
+			"handles unreachable endpoints",
+			func(url string) Network {
+				return Network{Name: "mainnet", RPCEndpoints: []string{down.URL}}
+			},
+			want{true, "failed to connect to mainnet: could not initialize library with network constants"},
+		},
+		{
+			"handles no endpoints",
+			func(url string) Network {
+				return Network{Name: "mainnet"}
+			},
+			want{true, "failed to connect to mainnet: no rpc endpoints"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(gtGoldenHTTPMock(blankHandler))
+			defer server.Close()
+
+			gt, err := NewWithNetwork(tt.network(server.URL))
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			assert.Equal(t, tt.want.err, gt == nil)
+		})
+	}
+}
+
+func Test_NetworkByName(t *testing.T) {
+	network, ok := NetworkByName("Ghostnet")
+	assert.True(t, ok)
+	assert.Equal(t, Ghostnet, network)
+	assert.Equal(t, GhostnetChainID, network.ChainID)
+
+	for _, network := range networks {
+		assert.NotEmpty(t, network.RPCEndpoints, network.Name)
+	}
+
+	_, ok = NetworkByName("carthagenet")
+	assert.False(t, ok)
+}
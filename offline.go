@@ -0,0 +1,49 @@
+package gotezos
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// ErrOffline is the cause of the errors of network calls made with a GoTezos created by NewOffline.
+var ErrOffline = errors.New("network calls are disabled offline")
+
+/*
+NewOffline Func
+Description: Returns a GoTezos that never connects to a node, for air-gapped signing machines. Only local
+operations work: forging and unforging operations for the protocol given (e.g. ForgeOperation), the fee and burn
+math with the constants given (e.g. MinimalFees and Constants.MaxCost), which Constants returns for any block.
+Any other call fails with ErrOffline as the cause, see errors.Cause. Signing (Wallet.SignOperation), packing
+(Micheline.Pack) and address derivation (PublicKeyAddress) need no GoTezos.
+
+	gt := gotezos.NewOffline(head.Protocol, constants)
+	forge, err := gt.ForgeOperation(branch, contents...)
+	signed, err := wallet.SignOperation(*forge)
+
+Parameters:
+	protocol:
+		The hash of the protocol operations are forged for, i.e. of the head of the chain they are injected on.
+	constants:
+		The constants of the chain, e.g. saved from Constants on a machine connected to a node.
+*/
+func NewOffline(protocol string, constants Constants) *GoTezos {
+	gt := &GoTezos{
+		client:        offlineClient{},
+		correlationID: newCorrelationID,
+		offline:       true,
+	}
+	gt.SetProtocol(protocol)
+	gt.SetConstants(constants)
+
+	return gt
+}
+
+// offlineClient refuses all requests, in case one is sent past the check of send.
+type offlineClient struct{}
+
+func (offlineClient) Do(*http.Request) (*http.Response, error) {
+	return nil, ErrOffline
+}
+
+func (offlineClient) CloseIdleConnections() {}
@@ -0,0 +1,58 @@
+package gotezos
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewOffline(t *testing.T) {
+	constants := expectedConstants(t)
+	gt := NewOffline("PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS", *constants)
+
+	assert.Equal(t, "PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS", gt.Protocol().Hash)
+
+	c, err := gt.Constants(BlockIDHead{})
+	assert.Nil(t, err)
+	assert.Equal(t, constants, c)
+
+	transfer := Contents{Kind: TRANSACTIONOP, Source: "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", Destination: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}
+	transfer.Fee.SetInt64(1283)
+	transfer.Counter.SetInt64(2)
+	transfer.GasLimit.SetInt64(10307)
+	transfer.Amount.SetInt64(1)
+
+	forge, err := gt.ForgeOperation("BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1", transfer)
+	assert.Nil(t, err)
+
+	branch, contents, err := gt.UnforgeOperation(*forge, false)
+	assert.Nil(t, err)
+	reforge, err := gt.ForgeOperation(*branch, *contents...)
+	assert.Nil(t, err)
+	assert.Equal(t, *forge, *reforge)
+
+	_, err = gt.Head()
+	checkErr(t, true, "network calls are disabled offline", err)
+	assert.Equal(t, ErrOffline, errors.Cause(err))
+
+	_, err = gt.Balance(BlockIDHead{}, "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK")
+	assert.Equal(t, ErrOffline, errors.Cause(err))
+}
+
+func Test_MinimalFees(t *testing.T) {
+	gt := NewOffline("PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS", *expectedConstants(t))
+
+	transfer := Contents{Kind: TRANSACTIONOP, Source: "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", Destination: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}
+	transfer.Counter.SetInt64(2)
+	transfer.GasLimit.SetInt64(10307)
+	transfer.Amount.SetInt64(1)
+
+	// 32 byte branch, 51 byte transfers once forged, 64 byte signature and 4 bytes per fee.
+	fees, err := gt.MinimalFees(transfer, transfer)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{100 + 206 + 1030 + 1, 1030 + 1}, fees)
+
+	_, err = gt.MinimalFees(Contents{Kind: TRANSACTIONOP, Source: "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"})
+	checkErr(t, true, "failed to compute minimal fees", err)
+}
@@ -0,0 +1,130 @@
+package gotezos
+
+/*
+OperationFilter -
+Description: Selects operation contents by kind, source, destination and entrypoint. Empty fields match
+anything; set fields must all match.
+Function: func (b *Block) FilterOperations(filter OperationFilter) []OperationContents {}
+*/
+type OperationFilter struct {
+	// The kind of the contents, see the OP constants (e.g. TRANSACTIONOP).
+	Kind string
+	// The source of the contents.
+	Source string
+	// The destination of transactions (or drain_delegate operations).
+	Destination string
+	// The entrypoint transactions call. Transactions without parameters call "default".
+	Entrypoint string
+}
+
+func (f OperationFilter) matches(contents *Contents) bool {
+	if f.Kind != "" && contents.Kind != f.Kind {
+		return false
+	}
+	if f.Source != "" && contents.Source != f.Source {
+		return false
+	}
+	if f.Destination != "" && contents.Destination != f.Destination {
+		return false
+	}
+	if f.Entrypoint != "" {
+		if contents.Kind != TRANSACTIONOP {
+			return false
+		}
+
+		entrypoint := "default"
+		if contents.Parameters != nil && contents.Parameters.Entrypoint != "" {
+			entrypoint = contents.Parameters.Entrypoint
+		}
+		if entrypoint != f.Entrypoint {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+OperationContents -
+Description: Operation contents along with the operation they are part of, as returned by FilterOperations.
+*/
+type OperationContents struct {
+	// The hash of the operation.
+	OperationHash string
+	// The validation pass of the operation, e.g. 3 for manager operations.
+	ValidationPass int
+	// The index of the contents in the operation, which batches several contents.
+	Index int
+	// The contents, with their metadata.
+	Contents Contents
+}
+
+/*
+FilterOperations Function
+Description: Returns the contents of the operations of the block matching the filter, in order. Only the
+contents signed by their source are filtered; internal operations (see Operations.InternalOperationResults) are not.
+
+Parameters:
+	filter:
+		The kind, source, destination and entrypoint of the contents to return.
+*/
+func (b *Block) FilterOperations(filter OperationFilter) []OperationContents {
+	contents := []OperationContents{}
+	for pass := range b.Operations {
+		for i := range b.Operations[pass] {
+			for _, c := range b.Operations[pass][i].FilterOperations(filter) {
+				c.ValidationPass = pass
+				contents = append(contents, c)
+			}
+		}
+	}
+
+	return contents
+}
+
+/*
+FilterOperations Function
+Description: Returns the contents of the operation matching the filter, in order. ValidationPass is not known
+from the operation alone and is left 0.
+
+Parameters:
+	filter:
+		The kind, source, destination and entrypoint of the contents to return.
+*/
+func (o *Operations) FilterOperations(filter OperationFilter) []OperationContents {
+	contents := []OperationContents{}
+	for i := range o.Contents {
+		if filter.matches(&o.Contents[i]) {
+			contents = append(contents, OperationContents{
+				OperationHash: o.Hash,
+				Index:         i,
+				Contents:      o.Contents[i],
+			})
+		}
+	}
+
+	return contents
+}
+
+/*
+Transactions Function
+Description: Returns the transactions of the block, in order.
+*/
+func (b *Block) Transactions() []OperationContents {
+	return b.FilterOperations(OperationFilter{Kind: TRANSACTIONOP})
+}
+
+/*
+CallsTo Function
+Description: Returns the transactions of the block to a contract, in order, e.g. the transfers of a token with
+CallsTo("KT1...", "transfer").
+
+Parameters:
+	contract:
+		The contract called.
+	entrypoint:
+		The entrypoint called, empty for any.
+*/
+func (b *Block) CallsTo(contract, entrypoint string) []OperationContents {
+	return b.FilterOperations(OperationFilter{Kind: TRANSACTIONOP, Destination: contract, Entrypoint: entrypoint})
+}
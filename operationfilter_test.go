@@ -0,0 +1,55 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FilterOperations(t *testing.T) {
+	var block Block
+	err := json.Unmarshal(mockBlockResp, &block)
+	assert.Nil(t, err)
+
+	transactions := block.Transactions()
+	assert.Len(t, transactions, 2)
+	assert.Equal(t, "ooGypsBLe5Rk3zWVWj67JBYwwCxFTAWhM1YuAvN94K9oGP1Xeyz", transactions[0].OperationHash)
+	assert.Equal(t, 3, transactions[0].ValidationPass)
+	assert.Equal(t, "601000000", transactions[0].Contents.Amount.String())
+
+	assert.Len(t, block.FilterOperations(OperationFilter{Kind: ENDORSEMENTOP}), 22)
+	assert.Len(t, block.FilterOperations(OperationFilter{}), 24)
+
+	bySource := block.FilterOperations(OperationFilter{Source: "tz1MnyYPixeTxv339nNotoHzgXhBTG7ERHqE"})
+	assert.Len(t, bySource, 1)
+	assert.Equal(t, "onyxb5CSqYoosmYtQzhAy2nw5164PTJqGEjMZ7PH4n8yDQmKLrn", bySource[0].OperationHash)
+
+	assert.Len(t, block.CallsTo("KT1MFnMZGpC13cEZP51X2YaBi13Htf4MeRai", ""), 1)
+	assert.Len(t, block.CallsTo("KT1MFnMZGpC13cEZP51X2YaBi13Htf4MeRai", "default"), 1)
+	assert.Empty(t, block.CallsTo("KT1MFnMZGpC13cEZP51X2YaBi13Htf4MeRai", "transfer"))
+
+	t.Run("filters by entrypoint", func(t *testing.T) {
+		var operation Operations
+		err := json.Unmarshal([]byte(`{
+			"hash": "opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A",
+			"contents": [
+				{"kind": "reveal", "source": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "public_key": "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G"},
+				{"kind": "transaction", "source": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "destination": "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", "parameters": {"entrypoint": "transfer", "value": {"int": "1"}}},
+				{"kind": "transaction", "source": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "destination": "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", "parameters": {"entrypoint": "update_operators", "value": {"int": "1"}}}
+			]
+		}`), &operation)
+		assert.Nil(t, err)
+
+		calls := operation.FilterOperations(OperationFilter{Destination: "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", Entrypoint: "transfer"})
+		assert.Len(t, calls, 1)
+		assert.Equal(t, 1, calls[0].Index)
+		assert.Equal(t, "transfer", calls[0].Contents.Parameters.Entrypoint)
+
+		block := Block{Operations: [][]Operations{{}, {}, {}, {operation}}}
+		assert.Len(t, block.CallsTo("KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", ""), 2)
+		assert.Len(t, block.CallsTo("KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", "update_operators"), 1)
+		assert.Empty(t, block.FilterOperations(OperationFilter{Kind: REVEALOP, Entrypoint: "transfer"}))
+		assert.Len(t, block.FilterOperations(OperationFilter{Source: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}), 3)
+	})
+}
@@ -0,0 +1,127 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// builtinOperationKinds are the kinds of operation contents Contents has fields for.
+var builtinOperationKinds = map[string]bool{
+	TRANSACTIONOP:            true,
+	REVEALOP:                 true,
+	ORIGINATIONOP:            true,
+	DELEGATIONOP:             true,
+	REGISTERGLOBALCONSTANTOP: true,
+	SMARTROLLUPORIGINATEOP:   true,
+	SMARTROLLUPADDMESSAGESOP: true,
+	SMARTROLLUPCEMENTOP:      true,
+	SMARTROLLUPPUBLISHOP:     true,
+	ENDORSEMENTOP:            true,
+	ENDORSEMENTWITHSLOTOP:    true,
+	ATTESTATIONOP:            true,
+	PREENDORSEMENTOP:         true,
+	PREATTESTATIONOP:         true,
+	ATTESTATIONWITHDALOP:     true,
+	DALATTESTATIONOP:         true,
+	DALPUBLISHCOMMITMENTOP:   true,
+	UPDATECONSENSUSKEYOP:     true,
+	DRAINDELEGATEOP:          true,
+
+	"activate_account":               true,
+	"ballot":                         true,
+	"proposals":                      true,
+	"seed_nonce_revelation":          true,
+	"double_baking_evidence":         true,
+	"double_endorsement_evidence":    true,
+	"double_preendorsement_evidence": true,
+	"double_attestation_evidence":    true,
+	"double_preattestation_evidence": true,
+}
+
+var (
+	contentsDecodersMu sync.RWMutex
+	contentsDecoders   = map[string]ContentsDecoder{}
+)
+
+/*
+ContentsDecoder -
+Description: Decodes operation contents of a kind the library does not support yet, see RegisterContentsDecoder.
+The value returned is set as the Decoded field of the Contents.
+*/
+type ContentsDecoder func(raw json.RawMessage) (interface{}, error)
+
+/*
+UnknownContents -
+Description: The contents of an operation of a kind the library does not support and no decoder is registered
+for, set as the Decoded field of the Contents.
+*/
+type UnknownContents struct {
+	Kind string
+	// The fields of the contents, kind included, as returned by the node.
+	Fields map[string]json.RawMessage
+}
+
+/*
+RegisterContentsDecoder Function
+Description: Registers the decoder of operation contents of a new kind, e.g. to decode the operations a protocol
+introduces before the library supports them. Registering a kind again replaces its decoder. Kinds supported
+by the library can not be registered.
+
+Parameters:
+	kind:
+		The kind of the contents, e.g. "dal_entrapment_evidence".
+	decoder:
+		Decodes the contents, given their JSON as returned by the node.
+*/
+func RegisterContentsDecoder(kind string, decoder ContentsDecoder) error {
+	if builtinOperationKinds[kind] {
+		return errors.Errorf("failed to register decoder: '%s' is a supported operation kind", kind)
+	}
+	if decoder == nil {
+		return errors.New("failed to register decoder: decoder is nil")
+	}
+
+	contentsDecodersMu.Lock()
+	defer contentsDecodersMu.Unlock()
+	contentsDecoders[kind] = decoder
+
+	return nil
+}
+
+/*
+IsSupportedOperationKind Function
+Description: Returns whether Contents has fields for the kind of operation contents. Contents of other kinds
+are decoded into their Decoded field.
+
+Parameters:
+	kind:
+		The kind of the contents.
+*/
+func IsSupportedOperationKind(kind string) bool {
+	return builtinOperationKinds[kind]
+}
+
+// decodeUnsupported decodes contents of a kind the library does not support, with the decoder registered for
+// their kind if any, or else into UnknownContents.
+func decodeUnsupported(kind string, b []byte) (interface{}, error) {
+	contentsDecodersMu.RLock()
+	decoder, ok := contentsDecoders[kind]
+	contentsDecodersMu.RUnlock()
+	if ok {
+		decoded, err := decoder(append(json.RawMessage{}, b...))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode '%s' contents", kind)
+		}
+		return decoded, nil
+	}
+
+	unknown := &UnknownContents{Kind: kind}
+	err := json.Unmarshal(b, &unknown.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return unknown, nil
+}
@@ -0,0 +1,77 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnsupportedContents(t *testing.T) {
+	var operation Operations
+	err := json.Unmarshal([]byte(`{
+		"hash": "opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A",
+		"contents": [
+			{"kind": "transaction", "source": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "amount": "10"},
+			{"kind": "set_deposits_limit", "source": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "limit": "1000"},
+			{"kind": "dal_entrapment_evidence", "attestation": {"branch": "BLTbZ3U7kHwGqSUdq3e5QMyn2aJu9uZkMdQAtRHGmFf46TKdcfM"}, "slot_index": 3}
+		]
+	}`), &operation)
+	assert.Nil(t, err)
+	assert.Len(t, operation.Contents, 3)
+
+	assert.Nil(t, operation.Contents[0].Decoded)
+	assert.True(t, IsSupportedOperationKind(operation.Contents[0].Kind))
+
+	assert.Equal(t, "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", operation.Contents[1].Source)
+	limit, ok := operation.Contents[1].Decoded.(*UnknownContents)
+	assert.True(t, ok)
+	assert.Equal(t, "set_deposits_limit", limit.Kind)
+	assert.Equal(t, json.RawMessage(`"1000"`), limit.Fields["limit"])
+
+	// The attestation of the evidence is not a BigInt.
+	assert.Equal(t, "dal_entrapment_evidence", operation.Contents[2].Kind)
+	evidence, ok := operation.Contents[2].Decoded.(*UnknownContents)
+	assert.True(t, ok)
+	assert.Equal(t, json.RawMessage(`3`), evidence.Fields["slot_index"])
+
+	v, err := json.Marshal(operation.Contents[2])
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"kind": "dal_entrapment_evidence", "attestation": {"branch": "BLTbZ3U7kHwGqSUdq3e5QMyn2aJu9uZkMdQAtRHGmFf46TKdcfM"}, "slot_index": 3}`, string(v))
+}
+
+func Test_RegisterContentsDecoder(t *testing.T) {
+	type entrapmentEvidence struct {
+		SlotIndex int `json:"slot_index"`
+	}
+
+	err := RegisterContentsDecoder("dal_entrapment_evidence", func(raw json.RawMessage) (interface{}, error) {
+		var evidence entrapmentEvidence
+		err := json.Unmarshal(raw, &evidence)
+		if evidence.SlotIndex < 0 {
+			return nil, errors.New("invalid slot index")
+		}
+		return &evidence, err
+	})
+	assert.Nil(t, err)
+	defer func() {
+		contentsDecodersMu.Lock()
+		delete(contentsDecoders, "dal_entrapment_evidence")
+		contentsDecodersMu.Unlock()
+	}()
+
+	var contents Contents
+	err = json.Unmarshal([]byte(`{"kind": "dal_entrapment_evidence", "slot_index": 3}`), &contents)
+	assert.Nil(t, err)
+	assert.Equal(t, &entrapmentEvidence{SlotIndex: 3}, contents.Decoded)
+
+	err = json.Unmarshal([]byte(`{"kind": "dal_entrapment_evidence", "slot_index": -1}`), &contents)
+	checkErr(t, true, "failed to decode 'dal_entrapment_evidence' contents: invalid slot index", err)
+
+	err = RegisterContentsDecoder(TRANSACTIONOP, func(raw json.RawMessage) (interface{}, error) { return nil, nil })
+	checkErr(t, true, "'transaction' is a supported operation kind", err)
+
+	err = RegisterContentsDecoder("dal_entrapment_evidence", nil)
+	checkErr(t, true, "decoder is nil", err)
+}
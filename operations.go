@@ -1,6 +1,7 @@
 package gotezos
 
 import (
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -21,6 +22,38 @@ const (
 	ORIGINATIONOP = "origination"
 	// DELEGATIONOP is a kind of operation
 	DELEGATIONOP = "delegation"
+	// REGISTERGLOBALCONSTANTOP is a kind of operation
+	REGISTERGLOBALCONSTANTOP = "register_global_constant"
+	// SMARTROLLUPORIGINATEOP is a kind of operation
+	SMARTROLLUPORIGINATEOP = "smart_rollup_originate"
+	// SMARTROLLUPADDMESSAGESOP is a kind of operation
+	SMARTROLLUPADDMESSAGESOP = "smart_rollup_add_messages"
+	// SMARTROLLUPCEMENTOP is a kind of operation
+	SMARTROLLUPCEMENTOP = "smart_rollup_cement"
+	// SMARTROLLUPPUBLISHOP is a kind of operation
+	SMARTROLLUPPUBLISHOP = "smart_rollup_publish"
+	// ENDORSEMENTOP is a kind of operation
+	ENDORSEMENTOP = "endorsement"
+	// ENDORSEMENTWITHSLOTOP is a kind of operation (Edo to Hangzhou)
+	ENDORSEMENTWITHSLOTOP = "endorsement_with_slot"
+	// ATTESTATIONOP is a kind of operation (Oxford and later)
+	ATTESTATIONOP = "attestation"
+	// PREENDORSEMENTOP is a kind of operation (Ithaca to Nairobi)
+	PREENDORSEMENTOP = "preendorsement"
+	// PREATTESTATIONOP is a kind of operation (Oxford and later)
+	PREATTESTATIONOP = "preattestation"
+	// ATTESTATIONWITHDALOP is a kind of operation (Quebec and later)
+	ATTESTATIONWITHDALOP = "attestation_with_dal"
+	// DALATTESTATIONOP is a kind of operation (Oxford and Paris)
+	DALATTESTATIONOP = "dal_attestation"
+	// DALPUBLISHCOMMITMENTOP is a kind of operation
+	DALPUBLISHCOMMITMENTOP = "dal_publish_commitment"
+	// UPDATECONSENSUSKEYOP is a kind of operation (Lima and later)
+	UPDATECONSENSUSKEYOP = "update_consensus_key"
+	// DRAINDELEGATEOP is a kind of operation (Lima and later)
+	DRAINDELEGATEOP = "drain_delegate"
+	// EVENTOP is a kind of internal operation
+	EVENTOP = "event"
 )
 
 /*
@@ -46,14 +79,14 @@ Link: https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-preapply-operat
 Description: Simulate the validation of an operation.
 
 Parameters:
-	blockhash:
-		The hash of block (height) of which you want to make the query.
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
 	contents:
 		The contents of the of the operation.
 	signature:
 		The operation signature.
 */
-func (t *GoTezos) PreapplyOperations(blockhash string, contents []Contents, signature string) (*[]byte, error) {
+func (t *GoTezos) PreapplyOperations(blockID BlockID, contents []Contents, signature string) (*[]byte, error) {
 	head, err := t.Head()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to preapply operation")
@@ -73,7 +106,7 @@ func (t *GoTezos) PreapplyOperations(blockhash string, contents []Contents, sign
 		return nil, errors.Wrap(err, "failed to preapply operation")
 	}
 
-	resp, err := t.post(fmt.Sprintf("/chains/main/blocks/%s/helpers/preapply/operations", blockhash), op)
+	resp, err := t.post(fmt.Sprintf("/chains/main/blocks/%s/helpers/preapply/operations", blockID.ID()), op)
 	if err != nil {
 		return &resp, errors.Wrap(err, "failed to preapply operation")
 	}
@@ -81,6 +114,55 @@ func (t *GoTezos) PreapplyOperations(blockhash string, contents []Contents, sign
 	return &resp, nil
 }
 
+/*
+RunOperation RPC
+Path: ../<block_id>/helpers/scripts/run_operation (POST)
+Link: https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-scripts-run-operation
+Description: Run an operation without signature checks. The operation is not injected and
+the returned contents carry the metadata (status, consumed gas, storage diffs) the node computed.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+	operation:
+		The operation to run. Branch, Contents, Signature and ChainID are used.
+*/
+func (t *GoTezos) RunOperation(blockID BlockID, operation Operations) (*Operations, error) {
+	type runOperation struct {
+		Branch    string     `json:"branch"`
+		Contents  []Contents `json:"contents"`
+		Signature string     `json:"signature"`
+	}
+
+	v, err := json.Marshal(struct {
+		Operation runOperation `json:"operation"`
+		ChainID   string       `json:"chain_id"`
+	}{
+		Operation: runOperation{
+			Branch:    operation.Branch,
+			Contents:  operation.Contents,
+			Signature: operation.Signature,
+		},
+		ChainID: operation.ChainID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to run operation")
+	}
+
+	resp, err := t.post(fmt.Sprintf("/chains/main/blocks/%s/helpers/scripts/run_operation", blockID.ID()), v)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to run operation")
+	}
+
+	var result Operations
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal operation result")
+	}
+
+	return &result, nil
+}
+
 /*
 InjectionOperation RPC
 Path: /injection/operation (POST)
@@ -114,19 +196,19 @@ func (t *GoTezos) InjectionOperation(input *InjectionOperationInput) (*[]byte, e
 	return &resp, nil
 }
 
-func (i *InjectionOperationInput) contructRPCOptions() []rpcOptions {
-	var opts []rpcOptions
+func (i *InjectionOperationInput) contructRPCOptions() []RPCOption {
+	var opts []RPCOption
 	if i.Async == true {
-		opts = append(opts, rpcOptions{
-			"async",
-			"true",
+		opts = append(opts, RPCOption{
+			Key:   "async",
+			Value: "true",
 		})
 	}
 
 	if i.ChainID != nil {
-		opts = append(opts, rpcOptions{
-			"chain_id",
-			*i.ChainID,
+		opts = append(opts, RPCOption{
+			Key:   "chain_id",
+			Value: *i.ChainID,
 		})
 	}
 	return opts
@@ -136,16 +218,17 @@ func (i *InjectionOperationInput) contructRPCOptions() []rpcOptions {
 Counter RPC
 Path: ../<block_id>/context/contracts/<contract_id>/counter (GET)
 Link: https://tezos.gitlab.io/api/rpc.html#get-block-id-context-contracts-contract-id-counter
-Description: Access the counter of a contract, if any.
+Description: Access the counter of a contract, if any. Fails at blocks below the savepoint of the node, see
+Savepoint.
 
 Parameters:
-	blockhash:
-		The hash of block (height) of which you want to make the query.
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
 	pkh:
 		The pkh (address) of the contract for the query.
 */
-func (t *GoTezos) Counter(blockhash, pkh string) (*int, error) {
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/contracts/%s/counter", blockhash, pkh))
+func (t *GoTezos) Counter(blockID BlockID, pkh string) (*int, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/contracts/%s/counter", blockID.ID(), pkh))
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get counter")
 	}
@@ -166,7 +249,8 @@ func (t *GoTezos) Counter(blockhash, pkh string) (*int, error) {
 ForgeOperation -
 Description: GoTezos does not use the RPC or a trusted source to forge operations. All operations
 are formed and encoded locally. Current supported operations include transfer, reveal, delegation,
-and origination.
+and origination. Endorsements are encoded for the protocol GoTezos was initialized for (see Protocol),
+as endorsement, endorsement_with_slot or attestation.
 
 Parameters:
 	branch:
@@ -213,6 +297,54 @@ func (t *GoTezos) ForgeOperation(branch string, contents ...Contents) (*string,
 				return nil, errors.Wrap(err, "failed to forge operation")
 			}
 			sb.WriteString(forge)
+		case REGISTERGLOBALCONSTANTOP:
+			forge, err := t.forgeRegisterGlobalConstantOperation(c)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to forge operation")
+			}
+			sb.WriteString(forge)
+		case ENDORSEMENTOP, ENDORSEMENTWITHSLOTOP, ATTESTATIONOP, PREENDORSEMENTOP, PREATTESTATIONOP:
+			forge, err := t.forgeEndorsementOperation(c)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to forge operation")
+			}
+			sb.WriteString(forge)
+		case SMARTROLLUPORIGINATEOP:
+			forge, err := t.forgeSmartRollupOriginateOperation(c)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to forge operation")
+			}
+			sb.WriteString(forge)
+		case SMARTROLLUPADDMESSAGESOP:
+			forge, err := t.forgeSmartRollupAddMessagesOperation(c)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to forge operation")
+			}
+			sb.WriteString(forge)
+		case SMARTROLLUPCEMENTOP:
+			forge, err := t.forgeSmartRollupCementOperation(c)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to forge operation")
+			}
+			sb.WriteString(forge)
+		case SMARTROLLUPPUBLISHOP:
+			forge, err := t.forgeSmartRollupPublishOperation(c)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to forge operation")
+			}
+			sb.WriteString(forge)
+		case UPDATECONSENSUSKEYOP:
+			forge, err := t.forgeUpdateConsensusKeyOperation(c)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to forge operation")
+			}
+			sb.WriteString(forge)
+		case DRAINDELEGATEOP:
+			forge, err := t.forgeDrainDelegateOperation(c)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to forge operation")
+			}
+			sb.WriteString(forge)
 		default:
 			return nil, fmt.Errorf("failed to forge operation: unsupported kind %s", c.Kind)
 		}
@@ -254,9 +386,130 @@ func (t *GoTezos) forgeTransactionOperation(contents Contents) (string, error) {
 		cleanDestination = fmt.Sprintf("0%s", cleanDestination)
 	}
 
-	// TODO account for code
 	sb.WriteString(cleanDestination)
-	sb.WriteString("00")
+
+	parameters, err := forgeParameters(contents.Parameters)
+	if err != nil {
+		return "", errors.Wrap(err, "could not forge transaction")
+	}
+	sb.WriteString(parameters)
+
+	return sb.String(), nil
+}
+
+// entrypointTags are the entrypoints with a reserved one byte encoding.
+var entrypointTags = map[string]string{
+	"default":         "00",
+	"root":            "01",
+	"do":              "02",
+	"set_delegate":    "03",
+	"remove_delegate": "04",
+	"deposit":         "05",
+	// The staking pseudo-entrypoints (Oxford and later).
+	"stake":                   "06",
+	"unstake":                 "07",
+	"finalize_unstake":        "08",
+	"set_delegate_parameters": "09",
+}
+
+func forgeParameters(parameters *Parameters) (string, error) {
+	if parameters == nil {
+		return "00", nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("ff")
+
+	entrypoint := parameters.Entrypoint
+	if entrypoint == "" {
+		entrypoint = "default"
+	}
+
+	if tag, ok := entrypointTags[entrypoint]; ok {
+		sb.WriteString(tag)
+	} else {
+		if len(entrypoint) > 31 {
+			return "", errors.Errorf("entrypoint '%s' is too long", entrypoint)
+		}
+		sb.WriteString("ff")
+		sb.WriteString(fmt.Sprintf("%02x", len(entrypoint)))
+		sb.WriteString(hex.EncodeToString([]byte(entrypoint)))
+	}
+
+	value, err := parameters.Value.MarshalBinary()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to forge parameters")
+	}
+	sb.WriteString(fmt.Sprintf("%08x", len(value)))
+	sb.WriteString(hex.EncodeToString(value))
+
+	return sb.String(), nil
+}
+
+func unforgeParameters(hexString string) (*Parameters, string, error) {
+	result, rest := splitAndReturnRest(hexString, 2)
+	hasParameters, err := checkBoolean(result)
+	if err != nil {
+		return nil, rest, errors.Wrap(err, "failed to unforge parameters")
+	}
+	if !hasParameters {
+		return nil, rest, nil
+	}
+
+	var parameters Parameters
+	result, rest = splitAndReturnRest(rest, 2)
+	if result == "ff" {
+		result, rest = splitAndReturnRest(rest, 2)
+		length, err := strconv.ParseUint(result, 16, 8)
+		if err != nil {
+			return nil, rest, errors.Wrap(err, "failed to unforge parameters: invalid entrypoint length")
+		}
+		result, rest = splitAndReturnRest(rest, int(length)*2)
+		entrypoint, err := hex.DecodeString(result)
+		if err != nil {
+			return nil, rest, errors.Wrap(err, "failed to unforge parameters: invalid entrypoint")
+		}
+		parameters.Entrypoint = string(entrypoint)
+	} else {
+		for name, tag := range entrypointTags {
+			if tag == result {
+				parameters.Entrypoint = name
+			}
+		}
+		if parameters.Entrypoint == "" {
+			return nil, rest, errors.Errorf("failed to unforge parameters: unknown entrypoint tag %s", result)
+		}
+	}
+
+	result, rest = splitAndReturnRest(rest, 8)
+	length, err := strconv.ParseUint(result, 16, 32)
+	if err != nil {
+		return nil, rest, errors.Wrap(err, "failed to unforge parameters: invalid value length")
+	}
+	result, rest = splitAndReturnRest(rest, int(length)*2)
+	value, err := hex.DecodeString(result)
+	if err != nil {
+		return nil, rest, errors.Wrap(err, "failed to unforge parameters: invalid value")
+	}
+	err = parameters.Value.UnmarshalBinary(value)
+	if err != nil {
+		return nil, rest, errors.Wrap(err, "failed to unforge parameters")
+	}
+
+	return &parameters, rest, nil
+}
+
+// forgeScript forges the code and the initial storage of an origination, each prefixed by its length.
+func forgeScript(script *Script) (string, error) {
+	var sb strings.Builder
+	for _, expr := range []Micheline{script.Code, script.Storage} {
+		v, err := expr.MarshalBinary()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to forge script")
+		}
+		sb.WriteString(fmt.Sprintf("%08x", len(v)))
+		sb.WriteString(hex.EncodeToString(v))
+	}
 
 	return sb.String(), nil
 }
@@ -330,6 +583,17 @@ func (t *GoTezos) forgeOriginationOperation(contents Contents) (string, error) {
 		sb.WriteString("00")
 	}
 
+	if contents.Script != nil {
+		script, err := forgeScript(contents.Script)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to forge origination operation")
+		}
+		sb.WriteString(script)
+
+		return sb.String(), nil
+	}
+
+	// Without a script, the manager.tz contract is originated with the source as manager.
 	sb.WriteString("000000c602000000c105000764085e036c055f036d0000000325646f046c000000082564656661756c740501035d050202000000950200000012020000000d03210316051f02000000020317072e020000006a0743036a00000313020000001e020000000403190325072c020000000002000000090200000004034f0327020000000b051f02000000020321034c031e03540348020000001e020000000403190325072c020000000002000000090200000004034f0327034f0326034202000000080320053d036d0342")
 	sb.WriteString("0000001a")
 	sb.WriteString("0a")
@@ -381,6 +645,268 @@ func (t *GoTezos) forgeDelegationOperation(contents Contents) (string, error) {
 	return sb.String(), nil
 }
 
+func (t *GoTezos) forgeRegisterGlobalConstantOperation(contents Contents) (string, error) {
+	if contents.Value == nil {
+		return "", errors.New("failed to forge register global constant operation: value is required")
+	}
+
+	common, err := t.forgeCommonFields(contents)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to forge register global constant operation")
+	}
+
+	value, err := contents.Value.MarshalBinary()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to forge register global constant operation")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("6f")
+	sb.WriteString(common)
+	sb.WriteString(fmt.Sprintf("%08x", len(value)))
+	sb.WriteString(hex.EncodeToString(value))
+
+	return sb.String(), nil
+}
+
+// forgeEndorsementOperation forges an endorsement, or a preendorsement, with the encoding of the protocol
+// GoTezos was initialized for, regardless of which of the consensus kinds the contents were given as.
+func (t *GoTezos) forgeEndorsementOperation(contents Contents) (string, error) {
+	protocol := t.Protocol()
+
+	kind, tag := protocol.EndorsementKind, "15"
+	if contents.Kind == PREENDORSEMENTOP || contents.Kind == PREATTESTATIONOP {
+		if !protocol.Tenderbake {
+			return "", errors.Errorf("failed to forge %s operation: protocol %s has no preendorsements", contents.Kind, protocol.Hash)
+		}
+		kind, tag = protocol.PreendorsementKind(), "14"
+	}
+
+	var sb strings.Builder
+	switch {
+	case protocol.Tenderbake:
+		payloadHash, err := b58cdecodeChecked(contents.BlockPayloadHash, prefix_vh, 32)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to forge %s operation: invalid block payload hash '%s'", kind, contents.BlockPayloadHash)
+		}
+
+		sb.WriteString(tag)
+		sb.WriteString(fmt.Sprintf("%04x", contents.Slot))
+		sb.WriteString(fmt.Sprintf("%08x", contents.Level))
+		sb.WriteString(fmt.Sprintf("%08x", contents.Round))
+		sb.WriteString(hex.EncodeToString(payloadHash))
+	case protocol.EndorsementKind == ENDORSEMENTWITHSLOTOP:
+		if contents.Endorsement == nil {
+			return "", errors.New("failed to forge endorsement_with_slot operation: endorsement is required")
+		}
+
+		branch, err := b58cdecodeChecked(contents.Endorsement.Branch, prefix_branch, 32)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to forge endorsement_with_slot operation: invalid branch '%s'", contents.Endorsement.Branch)
+		}
+
+		signature, err := signatureToBytes(contents.Endorsement.Signature)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to forge endorsement_with_slot operation")
+		}
+
+		var endorsement strings.Builder
+		endorsement.WriteString(hex.EncodeToString(branch))
+		endorsement.WriteString("00")
+		endorsement.WriteString(fmt.Sprintf("%08x", contents.Endorsement.Operations.Level))
+		endorsement.WriteString(hex.EncodeToString(signature))
+
+		sb.WriteString("0a")
+		sb.WriteString(fmt.Sprintf("%08x", endorsement.Len()/2))
+		sb.WriteString(endorsement.String())
+		sb.WriteString(fmt.Sprintf("%04x", contents.Slot))
+	default:
+		sb.WriteString("00")
+		sb.WriteString(fmt.Sprintf("%08x", contents.Level))
+	}
+
+	return sb.String(), nil
+}
+
+func (t *GoTezos) forgeSmartRollupOriginateOperation(contents Contents) (string, error) {
+	pvmKind := -1
+	for i, kind := range pvmKinds {
+		if contents.PvmKind == kind {
+			pvmKind = i
+		}
+	}
+	if pvmKind < 0 {
+		return "", fmt.Errorf("failed to forge smart rollup originate operation: unsupported pvm kind '%s'", contents.PvmKind)
+	}
+
+	if contents.ParametersTy == nil {
+		return "", errors.New("failed to forge smart rollup originate operation: parameters type is required")
+	}
+
+	common, err := t.forgeCommonFields(contents)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to forge smart rollup originate operation")
+	}
+
+	kernel, err := hex.DecodeString(contents.Kernel)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to forge smart rollup originate operation: kernel must be hex encoded")
+	}
+
+	parametersTy, err := contents.ParametersTy.MarshalBinary()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to forge smart rollup originate operation")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("c8")
+	sb.WriteString(common)
+	sb.WriteString(fmt.Sprintf("%02x", pvmKind))
+	sb.WriteString(fmt.Sprintf("%08x", len(kernel)))
+	sb.WriteString(hex.EncodeToString(kernel))
+	sb.WriteString(fmt.Sprintf("%08x", len(parametersTy)))
+	sb.WriteString(hex.EncodeToString(parametersTy))
+
+	if contents.Whitelist == nil {
+		sb.WriteString("00")
+	} else {
+		var whitelist []byte
+		for _, pkh := range contents.Whitelist {
+			keyHash, err := keyHashToBytes(pkh)
+			if err != nil {
+				return "", errors.Wrap(err, "failed to forge smart rollup originate operation")
+			}
+			whitelist = append(whitelist, keyHash...)
+		}
+
+		sb.WriteString("ff")
+		sb.WriteString(fmt.Sprintf("%08x", len(whitelist)))
+		sb.WriteString(hex.EncodeToString(whitelist))
+	}
+
+	return sb.String(), nil
+}
+
+func (t *GoTezos) forgeSmartRollupAddMessagesOperation(contents Contents) (string, error) {
+	common, err := t.forgeCommonFields(contents)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to forge smart rollup add messages operation")
+	}
+
+	var messages strings.Builder
+	for _, m := range contents.Message {
+		message, err := hex.DecodeString(m)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to forge smart rollup add messages operation: messages must be hex encoded")
+		}
+		messages.WriteString(fmt.Sprintf("%08x", len(message)))
+		messages.WriteString(hex.EncodeToString(message))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("c9")
+	sb.WriteString(common)
+	sb.WriteString(fmt.Sprintf("%08x", messages.Len()/2))
+	sb.WriteString(messages.String())
+
+	return sb.String(), nil
+}
+
+func (t *GoTezos) forgeSmartRollupCementOperation(contents Contents) (string, error) {
+	common, err := t.forgeCommonFields(contents)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to forge smart rollup cement operation")
+	}
+
+	rollup, err := b58cdecodeChecked(contents.Rollup, prefix_sr1, 20)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to forge smart rollup cement operation: invalid rollup '%s'", contents.Rollup)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("ca")
+	sb.WriteString(common)
+	sb.WriteString(hex.EncodeToString(rollup))
+
+	return sb.String(), nil
+}
+
+func (t *GoTezos) forgeSmartRollupPublishOperation(contents Contents) (string, error) {
+	if contents.Commitment == nil {
+		return "", errors.New("failed to forge smart rollup publish operation: commitment is required")
+	}
+
+	common, err := t.forgeCommonFields(contents)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to forge smart rollup publish operation")
+	}
+
+	rollup, err := b58cdecodeChecked(contents.Rollup, prefix_sr1, 20)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to forge smart rollup publish operation: invalid rollup '%s'", contents.Rollup)
+	}
+
+	state, err := b58cdecodeChecked(contents.Commitment.CompressedState, prefix_srs1, 32)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to forge smart rollup publish operation: invalid compressed state '%s'", contents.Commitment.CompressedState)
+	}
+
+	predecessor, err := b58cdecodeChecked(contents.Commitment.Predecessor, prefix_src1, 32)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to forge smart rollup publish operation: invalid predecessor '%s'", contents.Commitment.Predecessor)
+	}
+
+	ticks := contents.Commitment.NumberOfTicks
+	if !ticks.IsInt64() || ticks.Sign() < 0 {
+		return "", errors.New("failed to forge smart rollup publish operation: number of ticks out of range")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("cb")
+	sb.WriteString(common)
+	sb.WriteString(hex.EncodeToString(rollup))
+	sb.WriteString(hex.EncodeToString(state))
+	sb.WriteString(fmt.Sprintf("%08x", contents.Commitment.InboxLevel))
+	sb.WriteString(hex.EncodeToString(predecessor))
+	sb.WriteString(fmt.Sprintf("%016x", ticks.Int64()))
+
+	return sb.String(), nil
+}
+
+func (t *GoTezos) forgeUpdateConsensusKeyOperation(contents Contents) (string, error) {
+	common, err := t.forgeCommonFields(contents)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to forge update consensus key operation")
+	}
+
+	pk, err := publicKeyToBytes(contents.Pk)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to forge update consensus key operation")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("72")
+	sb.WriteString(common)
+	sb.WriteString(hex.EncodeToString(pk))
+
+	return sb.String(), nil
+}
+
+func (t *GoTezos) forgeDrainDelegateOperation(contents Contents) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("09")
+
+	for _, keyHash := range []string{contents.ConsensusKey, contents.Delegate, contents.Destination} {
+		v, err := keyHashToBytes(keyHash)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to forge drain delegate operation")
+		}
+		sb.WriteString(hex.EncodeToString(v))
+	}
+
+	return sb.String(), nil
+}
+
 func (t *GoTezos) forgeCommonFields(contents Contents) (string, error) {
 	source, err := removeHexPrefix(contents.Source, prefix_tz1)
 	if err != nil {
@@ -433,11 +959,57 @@ func (t *GoTezos) UnforgeOperation(operation string, signed bool) (*string, *[]C
 	var contents []Contents
 	for len(rest) > 0 {
 		result, rest = splitAndReturnRest(rest, 2)
-		if result == "00" || len(result) < 2 {
-			break
-		}
-
-		switch result {
+		if result == "00" && !t.Protocol().Tenderbake && len(rest) == 8 {
+			c, r, err := t.unforgeEndorsementOperation(rest)
+			if err != nil {
+				return &branch, &contents, errors.Wrap(err, "failed to unforge operation")
+			}
+			rest = r
+			contents = append(contents, c)
+			continue
+		}
+
+		if result == "00" || len(result) < 2 {
+			break
+		}
+
+		switch result {
+		case "0a":
+			c, r, err := t.unforgeEndorsementWithSlotOperation(rest)
+			if err != nil {
+				return &branch, &contents, errors.Wrap(err, "failed to unforge operation")
+			}
+			rest = r
+			contents = append(contents, c)
+		case "09":
+			c, r, err := t.unforgeDrainDelegateOperation(rest)
+			if err != nil {
+				return &branch, &contents, errors.Wrap(err, "failed to unforge operation")
+			}
+			rest = r
+			contents = append(contents, c)
+		case "14":
+			kind := PREENDORSEMENTOP
+			if t.Protocol().EndorsementKind == ATTESTATIONOP {
+				kind = PREATTESTATIONOP
+			}
+			c, r, err := t.unforgeTenderbakeEndorsementOperation(kind, rest)
+			if err != nil {
+				return &branch, &contents, errors.Wrap(err, "failed to unforge operation")
+			}
+			rest = r
+			contents = append(contents, c)
+		case "15":
+			kind := ENDORSEMENTOP
+			if t.Protocol().EndorsementKind == ATTESTATIONOP {
+				kind = ATTESTATIONOP
+			}
+			c, r, err := t.unforgeTenderbakeEndorsementOperation(kind, rest)
+			if err != nil {
+				return &branch, &contents, errors.Wrap(err, "failed to unforge operation")
+			}
+			rest = r
+			contents = append(contents, c)
 		case "6b":
 			c, r, err := t.unforgeRevealOperation(rest)
 			if err != nil {
@@ -466,6 +1038,48 @@ func (t *GoTezos) UnforgeOperation(operation string, signed bool) (*string, *[]C
 			}
 			rest = r
 			contents = append(contents, c)
+		case "6f":
+			c, r, err := t.unforgeRegisterGlobalConstantOperation(rest)
+			if err != nil {
+				return &branch, &contents, errors.Wrap(err, "failed to unforge operation")
+			}
+			rest = r
+			contents = append(contents, c)
+		case "c8":
+			c, r, err := t.unforgeSmartRollupOriginateOperation(rest)
+			if err != nil {
+				return &branch, &contents, errors.Wrap(err, "failed to unforge operation")
+			}
+			rest = r
+			contents = append(contents, c)
+		case "c9":
+			c, r, err := t.unforgeSmartRollupAddMessagesOperation(rest)
+			if err != nil {
+				return &branch, &contents, errors.Wrap(err, "failed to unforge operation")
+			}
+			rest = r
+			contents = append(contents, c)
+		case "ca":
+			c, r, err := t.unforgeSmartRollupCementOperation(rest)
+			if err != nil {
+				return &branch, &contents, errors.Wrap(err, "failed to unforge operation")
+			}
+			rest = r
+			contents = append(contents, c)
+		case "cb":
+			c, r, err := t.unforgeSmartRollupPublishOperation(rest)
+			if err != nil {
+				return &branch, &contents, errors.Wrap(err, "failed to unforge operation")
+			}
+			rest = r
+			contents = append(contents, c)
+		case "72":
+			c, r, err := t.unforgeUpdateConsensusKeyOperation(rest)
+			if err != nil {
+				return &branch, &contents, errors.Wrap(err, "failed to unforge operation")
+			}
+			rest = r
+			contents = append(contents, c)
 		default:
 			return &branch, &contents, fmt.Errorf("failed to unforge operation: transaction operation unkown %s", result)
 		}
@@ -474,6 +1088,88 @@ func (t *GoTezos) UnforgeOperation(operation string, signed bool) (*string, *[]C
 	return &branch, &contents, nil
 }
 
+/*
+VerifyForgedOperation Function
+Description: Checks that a forged operation decodes back to the branch and contents it was forged from, so that
+the bytes signed and injected are exactly the operation meant. The kind, source, fee, counter and limits of
+each content are compared, and the amount, destination and parameters of transactions, the balance and
+delegate of originations, the delegate of delegations and the public key of reveals.
+
+Parameters:
+	forged:
+		The forged operation, see ForgeOperation.
+	branch:
+		The branch the operation was forged on.
+	contents:
+		The contents the operation was forged from.
+*/
+func (t *GoTezos) VerifyForgedOperation(forged, branch string, contents ...Contents) error {
+	unforgedBranch, unforged, err := t.UnforgeOperation(forged, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify forged operation")
+	}
+
+	if *unforgedBranch != branch {
+		return errors.Errorf("failed to verify forged operation: branch %s, expected %s", *unforgedBranch, branch)
+	}
+
+	if len(*unforged) != len(contents) {
+		return errors.Errorf("failed to verify forged operation: %d contents, expected %d", len(*unforged), len(contents))
+	}
+
+	for i := range contents {
+		want, err := json.Marshal(forgedFields(contents[i]))
+		if err != nil {
+			return errors.Wrap(err, "failed to verify forged operation")
+		}
+
+		got, err := json.Marshal(forgedFields((*unforged)[i]))
+		if err != nil {
+			return errors.Wrap(err, "failed to verify forged operation")
+		}
+
+		if string(got) != string(want) {
+			return errors.Errorf("failed to verify forged operation: contents %d forged as %s, expected %s", i, got, want)
+		}
+	}
+
+	return nil
+}
+
+// forgedFields returns the fields of contents compared by VerifyForgedOperation.
+func forgedFields(contents Contents) map[string]interface{} {
+	fields := map[string]interface{}{
+		"kind":          contents.Kind,
+		"source":        contents.Source,
+		"fee":           contents.Fee.String(),
+		"counter":       contents.Counter.String(),
+		"gas_limit":     contents.GasLimit.String(),
+		"storage_limit": contents.StorageLimit.String(),
+	}
+
+	switch contents.Kind {
+	case TRANSACTIONOP:
+		fields["amount"] = contents.Amount.String()
+		fields["destination"] = contents.Destination
+		if contents.Parameters != nil {
+			parameters := *contents.Parameters
+			if parameters.Entrypoint == "" {
+				parameters.Entrypoint = "default"
+			}
+			fields["parameters"] = parameters
+		}
+	case ORIGINATIONOP:
+		fields["balance"] = contents.Balance.String()
+		fields["delegate"] = contents.Delegate
+	case DELEGATIONOP:
+		fields["delegate"] = contents.Delegate
+	case REVEALOP:
+		fields["public_key"] = contents.Phk
+	}
+
+	return fields
+}
+
 func (t *GoTezos) unforgeRevealOperation(hexString string) (Contents, string, error) {
 	result, rest := splitAndReturnRest(hexString, 42)
 	source, err := parseTzAddress(result)
@@ -613,16 +1309,11 @@ func (t *GoTezos) unforgeTransactionOperation(hexString string) (Contents, strin
 	}
 	contents.Destination = address
 
-	// TODO Handle Contracts
-	// hasParameters, err := checkBoolean(result)
-	// if err != nil {
-	// 	return Contents{}, "", errors.Wrap(err, "failed to unforge transaction operation: could not check for parameters")
-	// }
-
-	// Temporary: Trim 00
-	if len(rest) > 2 {
-		rest = rest[2:]
+	parameters, rest, err := unforgeParameters(rest)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge transaction operation")
 	}
+	contents.Parameters = parameters
 
 	return contents, rest, nil
 }
@@ -712,6 +1403,373 @@ func (t *GoTezos) unforgeOriginationOperation(hexString string) (Contents, strin
 	return contents, rest, nil
 }
 
+func (t *GoTezos) unforgeRegisterGlobalConstantOperation(hexString string) (Contents, string, error) {
+	contents, rest, err := unforgeCommonFields(hexString)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge register global constant operation")
+	}
+	contents.Kind = REGISTERGLOBALCONSTANTOP
+
+	if len(rest) < 8 {
+		return Contents{}, "", errors.New("failed to unforge register global constant operation: value is missing")
+	}
+	result, rest := splitAndReturnRest(rest, 8)
+	length, err := strconv.ParseInt(result, 16, 64)
+	if err != nil || int64(len(rest)) < length*2 {
+		return Contents{}, "", errors.New("failed to unforge register global constant operation: invalid value length")
+	}
+
+	result, rest = splitAndReturnRest(rest, int(length*2))
+	v, err := hex.DecodeString(result)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge register global constant operation")
+	}
+
+	var value Micheline
+	err = value.UnmarshalBinary(v)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge register global constant operation")
+	}
+	contents.Value = &value
+
+	return contents, rest, nil
+}
+
+func (t *GoTezos) unforgeEndorsementOperation(hexString string) (Contents, string, error) {
+	if len(hexString) < 8 {
+		return Contents{}, "", errors.New("failed to unforge endorsement operation: level is missing")
+	}
+
+	result, rest := splitAndReturnRest(hexString, 8)
+	level, err := strconv.ParseInt(result, 16, 64)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge endorsement operation: invalid level")
+	}
+
+	return Contents{Kind: ENDORSEMENTOP, Level: int(level)}, rest, nil
+}
+
+func (t *GoTezos) unforgeEndorsementWithSlotOperation(hexString string) (Contents, string, error) {
+	endorsement, rest, err := unforgeDynamicBytes(hexString)
+	if err != nil || len(endorsement) != 32+1+4+64 || endorsement[32] != 0 {
+		return Contents{}, "", errors.New("failed to unforge endorsement_with_slot operation: invalid endorsement")
+	}
+
+	if len(rest) < 4 {
+		return Contents{}, "", errors.New("failed to unforge endorsement_with_slot operation: slot is missing")
+	}
+	result, rest := splitAndReturnRest(rest, 4)
+	slot, err := strconv.ParseInt(result, 16, 64)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge endorsement_with_slot operation: invalid slot")
+	}
+
+	contents := Contents{
+		Kind: ENDORSEMENTWITHSLOTOP,
+		Slot: int(slot),
+		Endorsement: &InlinedEndorsement{
+			Branch: b58cencode(endorsement[:32], prefix_branch),
+			Operations: Contents{
+				Kind:  ENDORSEMENTOP,
+				Level: int(binary.BigEndian.Uint32(endorsement[33:37])),
+			},
+			Signature: b58cencode(endorsement[37:], prefix_sig),
+		},
+	}
+
+	return contents, rest, nil
+}
+
+// unforgeTenderbakeEndorsementOperation unforges a Tenderbake endorsement or preendorsement, which share their
+// encoding, as contents of the kind given.
+func (t *GoTezos) unforgeTenderbakeEndorsementOperation(kind, hexString string) (Contents, string, error) {
+	if len(hexString) < 4+8+8+64 {
+		return Contents{}, "", fmt.Errorf("failed to unforge %s operation: invalid length", kind)
+	}
+
+	contents := Contents{
+		Kind: kind,
+	}
+
+	rest := hexString
+	for _, field := range []struct {
+		value  *int
+		length int
+	}{
+		{&contents.Slot, 4},
+		{&contents.Level, 8},
+		{&contents.Round, 8},
+	} {
+		var result string
+		result, rest = splitAndReturnRest(rest, field.length)
+		v, err := strconv.ParseInt(result, 16, 64)
+		if err != nil {
+			return Contents{}, "", errors.Wrapf(err, "failed to unforge %s operation", kind)
+		}
+		*field.value = int(v)
+	}
+
+	result, rest := splitAndReturnRest(rest, 64)
+	payloadHash, err := prefixAndBase58Encode(result, prefix_vh)
+	if err != nil {
+		return Contents{}, "", errors.Wrapf(err, "failed to unforge %s operation", kind)
+	}
+	contents.BlockPayloadHash = payloadHash
+
+	return contents, rest, nil
+}
+
+func (t *GoTezos) unforgeSmartRollupOriginateOperation(hexString string) (Contents, string, error) {
+	contents, rest, err := unforgeCommonFields(hexString)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge smart rollup originate operation")
+	}
+	contents.Kind = SMARTROLLUPORIGINATEOP
+
+	result, rest := splitAndReturnRest(rest, 2)
+	pvmKind, err := strconv.ParseInt(result, 16, 64)
+	if err != nil || pvmKind >= int64(len(pvmKinds)) {
+		return Contents{}, "", fmt.Errorf("failed to unforge smart rollup originate operation: unsupported pvm kind %s", result)
+	}
+	contents.PvmKind = pvmKinds[pvmKind]
+
+	kernel, rest, err := unforgeDynamicBytes(rest)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge smart rollup originate operation: invalid kernel")
+	}
+	contents.Kernel = hex.EncodeToString(kernel)
+
+	v, rest, err := unforgeDynamicBytes(rest)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge smart rollup originate operation: invalid parameters type")
+	}
+
+	var parametersTy Micheline
+	err = parametersTy.UnmarshalBinary(v)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge smart rollup originate operation")
+	}
+	contents.ParametersTy = &parametersTy
+
+	result, rest = splitAndReturnRest(rest, 2)
+	if result == "ff" {
+		whitelist, r, err := unforgeDynamicBytes(rest)
+		if err != nil || len(whitelist)%21 != 0 {
+			return Contents{}, "", errors.New("failed to unforge smart rollup originate operation: invalid whitelist")
+		}
+		rest = r
+
+		contents.Whitelist = []string{}
+		for i := 0; i < len(whitelist); i += 21 {
+			pkh, err := bytesToKeyHash(whitelist[i : i+21])
+			if err != nil {
+				return Contents{}, "", errors.Wrap(err, "failed to unforge smart rollup originate operation")
+			}
+			contents.Whitelist = append(contents.Whitelist, pkh)
+		}
+	} else if result != "00" {
+		return Contents{}, "", errors.New("failed to unforge smart rollup originate operation: invalid whitelist")
+	}
+
+	return contents, rest, nil
+}
+
+func (t *GoTezos) unforgeSmartRollupAddMessagesOperation(hexString string) (Contents, string, error) {
+	contents, rest, err := unforgeCommonFields(hexString)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge smart rollup add messages operation")
+	}
+	contents.Kind = SMARTROLLUPADDMESSAGESOP
+
+	messages, rest, err := unforgeDynamicBytes(rest)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge smart rollup add messages operation: invalid messages")
+	}
+
+	m := hex.EncodeToString(messages)
+	contents.Message = []string{}
+	for len(m) > 0 {
+		message, r, err := unforgeDynamicBytes(m)
+		if err != nil {
+			return Contents{}, "", errors.Wrap(err, "failed to unforge smart rollup add messages operation: invalid message")
+		}
+		m = r
+		contents.Message = append(contents.Message, hex.EncodeToString(message))
+	}
+
+	return contents, rest, nil
+}
+
+func (t *GoTezos) unforgeSmartRollupCementOperation(hexString string) (Contents, string, error) {
+	contents, rest, err := unforgeCommonFields(hexString)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge smart rollup cement operation")
+	}
+	contents.Kind = SMARTROLLUPCEMENTOP
+
+	if len(rest) < 40 {
+		return Contents{}, "", errors.New("failed to unforge smart rollup cement operation: rollup is missing")
+	}
+	result, rest := splitAndReturnRest(rest, 40)
+	contents.Rollup, err = prefixAndBase58Encode(result, prefix_sr1)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge smart rollup cement operation")
+	}
+
+	return contents, rest, nil
+}
+
+func (t *GoTezos) unforgeSmartRollupPublishOperation(hexString string) (Contents, string, error) {
+	contents, rest, err := unforgeCommonFields(hexString)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge smart rollup publish operation")
+	}
+	contents.Kind = SMARTROLLUPPUBLISHOP
+
+	if len(rest) < 40+64+8+64+16 {
+		return Contents{}, "", errors.New("failed to unforge smart rollup publish operation: commitment is missing")
+	}
+
+	result, rest := splitAndReturnRest(rest, 40)
+	contents.Rollup, err = prefixAndBase58Encode(result, prefix_sr1)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge smart rollup publish operation")
+	}
+
+	commitment := SmartRollupCommitment{}
+	result, rest = splitAndReturnRest(rest, 64)
+	commitment.CompressedState, err = prefixAndBase58Encode(result, prefix_srs1)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge smart rollup publish operation")
+	}
+
+	result, rest = splitAndReturnRest(rest, 8)
+	inboxLevel, err := strconv.ParseInt(result, 16, 64)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge smart rollup publish operation: invalid inbox level")
+	}
+	commitment.InboxLevel = int(inboxLevel)
+
+	result, rest = splitAndReturnRest(rest, 64)
+	commitment.Predecessor, err = prefixAndBase58Encode(result, prefix_src1)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge smart rollup publish operation")
+	}
+
+	result, rest = splitAndReturnRest(rest, 16)
+	ticks, err := strconv.ParseInt(result, 16, 64)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge smart rollup publish operation: invalid number of ticks")
+	}
+	commitment.NumberOfTicks = BigInt{*big.NewInt(ticks)}
+	contents.Commitment = &commitment
+
+	return contents, rest, nil
+}
+
+func (t *GoTezos) unforgeUpdateConsensusKeyOperation(hexString string) (Contents, string, error) {
+	contents, rest, err := unforgeCommonFields(hexString)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge update consensus key operation")
+	}
+	contents.Kind = UPDATECONSENSUSKEYOP
+
+	// An ed25519 key is 32 bytes, a secp256k1 or p256 key 33 bytes.
+	length := 66
+	if strings.HasPrefix(rest, "00") {
+		length = 64
+	}
+	if len(rest) < 2+length {
+		return Contents{}, "", errors.New("failed to unforge update consensus key operation: public key is missing")
+	}
+
+	result, rest := splitAndReturnRest(rest, 2+length)
+	pk, err := hex.DecodeString(result)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge update consensus key operation")
+	}
+
+	contents.Pk, err = bytesToPublicKey(pk)
+	if err != nil {
+		return Contents{}, "", errors.Wrap(err, "failed to unforge update consensus key operation")
+	}
+
+	return contents, rest, nil
+}
+
+func (t *GoTezos) unforgeDrainDelegateOperation(hexString string) (Contents, string, error) {
+	if len(hexString) < 3*42 {
+		return Contents{}, "", errors.New("failed to unforge drain delegate operation: key hashes are missing")
+	}
+
+	contents := Contents{Kind: DRAINDELEGATEOP}
+	rest := hexString
+	for _, field := range []*string{&contents.ConsensusKey, &contents.Delegate, &contents.Destination} {
+		var result string
+		result, rest = splitAndReturnRest(rest, 42)
+
+		keyHash, err := hex.DecodeString(result)
+		if err != nil {
+			return Contents{}, "", errors.Wrap(err, "failed to unforge drain delegate operation")
+		}
+
+		*field, err = bytesToKeyHash(keyHash)
+		if err != nil {
+			return Contents{}, "", errors.Wrap(err, "failed to unforge drain delegate operation")
+		}
+	}
+
+	return contents, rest, nil
+}
+
+// unforgeDynamicBytes decodes a field prefixed with its 4 byte length.
+func unforgeDynamicBytes(hexString string) ([]byte, string, error) {
+	if len(hexString) < 8 {
+		return nil, "", errors.New("length is missing")
+	}
+	result, rest := splitAndReturnRest(hexString, 8)
+	length, err := strconv.ParseInt(result, 16, 64)
+	if err != nil || int64(len(rest)) < length*2 {
+		return nil, "", errors.New("invalid length")
+	}
+
+	result, rest = splitAndReturnRest(rest, int(length*2))
+	v, err := hex.DecodeString(result)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return v, rest, nil
+}
+
+// unforgeCommonFields decodes the source, fee, counter, gas limit and storage limit every manager operation starts with.
+func unforgeCommonFields(hexString string) (Contents, string, error) {
+	result, rest := splitAndReturnRest(hexString, 42)
+	source, err := parseTzAddress(result)
+	if err != nil {
+		return Contents{}, rest, err
+	}
+
+	contents := Contents{
+		Source: source,
+	}
+
+	for _, field := range []*BigInt{&contents.Fee, &contents.Counter, &contents.GasLimit, &contents.StorageLimit} {
+		zEndIndex, err := findZarithEndIndex(rest)
+		if err != nil {
+			return Contents{}, "", err
+		}
+		result, rest = splitAndReturnRest(rest, zEndIndex)
+		zBigNum, err := zarithToBigNumber(result)
+		if err != nil {
+			return Contents{}, "", err
+		}
+		*field = zBigNum
+	}
+
+	return contents, rest, nil
+}
+
 func (t *GoTezos) unforgeDelegationOperation(hexString string) (Contents, string, error) {
 	result, rest := splitAndReturnRest(hexString, 42)
 	source, err := parseTzAddress(result)
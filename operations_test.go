@@ -88,7 +88,7 @@ func Test_Counter(t *testing.T) {
 			gt, err := New(server.URL)
 			assert.Nil(t, err)
 
-			counter, err := gt.Counter(mockBlockHash, mockAddressTz1)
+			counter, err := gt.Counter(BlockIDHash(mockBlockHash), mockAddressTz1)
 			checkErr(t, tt.want.err, tt.want.errContains, err)
 			assert.Equal(t, tt.want.counter, counter)
 		})
@@ -100,8 +100,22 @@ func Test_ForgeOperation(t *testing.T) {
 		transactionOp = "a732d3520eeaa3de98d78e5e5cb6c85f72204fd46feb9f76853841d4a701add36c0008ba0cb2fad622697145cf1665124096d25bc31ef44e0af44e00b960000008ba0cb2fad622697145cf1665124096d25bc31e006c0008ba0cb2fad622697145cf1665124096d25bc31ed3e7bd1008d3bb0300b1a803000008ba0cb2fad622697145cf1665124096d25bc31e00"
 		revealOp      = "a732d3520eeaa3de98d78e5e5cb6c85f72204fd46feb9f76853841d4a701add36b0008ba0cb2fad622697145cf1665124096d25bc31ef44e0af44e0000136083897bc97879c53e3e7855838fbbc87303ddd376080fc3d3e136b55d028b6b0008ba0cb2fad622697145cf1665124096d25bc31ed3e7bd1008d3bb030000136083897bc97879c53e3e7855838fbbc87303ddd376080fc3d3e136b55d028b"
 		originationOp = "a732d3520eeaa3de98d78e5e5cb6c85f72204fd46feb9f76853841d4a701add36d0008ba0cb2fad622697145cf1665124096d25bc31ef44e0af44e00928fe29c01ff0008ba0cb2fad622697145cf1665124096d25bc31e000000c602000000c105000764085e036c055f036d0000000325646f046c000000082564656661756c740501035d050202000000950200000012020000000d03210316051f02000000020317072e020000006a0743036a00000313020000001e020000000403190325072c020000000002000000090200000004034f0327020000000b051f02000000020321034c031e03540348020000001e020000000403190325072c020000000002000000090200000004034f0327034f0326034202000000080320053d036d03420000001a0a000000150008ba0cb2fad622697145cf1665124096d25bc31e"
+		scriptOp      = "a732d3520eeaa3de98d78e5e5cb6c85f72204fd46feb9f76853841d4a701add36d0008ba0cb2fad622697145cf1665124096d25bc31ef44e0af44e00928fe29c01000000001c02000000170500036c0501036c050202000000080317053d036d034200000002030b"
 		delegationOp  = "a732d3520eeaa3de98d78e5e5cb6c85f72204fd46feb9f76853841d4a701add36e0008ba0cb2fad622697145cf1665124096d25bc31ef44e0af44e00ff0008ba0cb2fad622697145cf1665124096d25bc31e"
 	)
+	script := &Script{
+		Code: NewMichelineSeq(
+			NewMichelinePrim("parameter", NewMichelinePrim("unit")),
+			NewMichelinePrim("storage", NewMichelinePrim("unit")),
+			NewMichelinePrim("code", NewMichelineSeq(
+				NewMichelinePrim("CDR"),
+				NewMichelinePrim("NIL", NewMichelinePrim("operation")),
+				NewMichelinePrim("PAIR"),
+			)),
+		),
+		Storage: NewMichelinePrim("Unit"),
+	}
+
 	type input struct {
 		handler  http.Handler
 		contents []Contents
@@ -208,6 +222,30 @@ func Test_ForgeOperation(t *testing.T) {
 				&originationOp,
 			},
 		},
+		{
+			"is successful origination of a script",
+			input{
+				gtGoldenHTTPMock(blankHandler),
+				[]Contents{
+					Contents{
+						Source:       "tz1LSAycAVcNdYnXCy18bwVksXci8gUC2YpA",
+						Fee:          BigInt{*big.NewInt(10100)},
+						Counter:      BigInt{*big.NewInt(10)},
+						GasLimit:     BigInt{*big.NewInt(10100)},
+						StorageLimit: BigInt{big.Int{}},
+						Kind:         ORIGINATIONOP,
+						Balance:      BigInt{*big.NewInt(328763282)},
+						Script:       script,
+					},
+				},
+				"BLyvCRkxuTXkx1KeGvrcEXiPYj4p1tFxzvFDhoHE7SFKtmP1rbk",
+			},
+			want{
+				false,
+				"",
+				&scriptOp,
+			},
+		},
 		{
 			"is successful delegation",
 			input{
@@ -392,3 +430,112 @@ func Test_UnforgeOperation(t *testing.T) {
 		})
 	}
 }
+
+func Test_VerifyForgedOperation(t *testing.T) {
+	branch := "BLyvCRkxuTXkx1KeGvrcEXiPYj4p1tFxzvFDhoHE7SFKtmP1rbk"
+	transaction := Contents{
+		Source:       "tz1LSAycAVcNdYnXCy18bwVksXci8gUC2YpA",
+		Fee:          BigInt{*big.NewInt(10100)},
+		Counter:      BigInt{*big.NewInt(10)},
+		GasLimit:     BigInt{*big.NewInt(10100)},
+		StorageLimit: BigInt{big.Int{}},
+		Amount:       BigInt{*big.NewInt(12345)},
+		Destination:  "tz1LSAycAVcNdYnXCy18bwVksXci8gUC2YpA",
+		Kind:         TRANSACTIONOP,
+	}
+	call := transaction
+	call.Counter = BigInt{*big.NewInt(11)}
+	call.Destination = "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"
+	call.Parameters = &Parameters{Value: NewMichelinePrim("Unit")}
+	delegation := Contents{
+		Source:       "tz1LSAycAVcNdYnXCy18bwVksXci8gUC2YpA",
+		Fee:          BigInt{*big.NewInt(10100)},
+		Counter:      BigInt{*big.NewInt(12)},
+		GasLimit:     BigInt{*big.NewInt(10100)},
+		StorageLimit: BigInt{big.Int{}},
+		Delegate:     "tz1LSAycAVcNdYnXCy18bwVksXci8gUC2YpA",
+		Kind:         DELEGATIONOP,
+	}
+
+	gt := testGoTezos(t, gtGoldenHTTPMock(blankHandler))
+	forged, err := gt.ForgeOperation(branch, transaction, call, delegation)
+	assert.Nil(t, err)
+
+	tamperedFee := transaction
+	tamperedFee.Fee = BigInt{*big.NewInt(1)}
+	tamperedDestination := transaction
+	tamperedDestination.Destination = "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc"
+	tamperedCall := call
+	tamperedCall.Parameters = &Parameters{Entrypoint: "withdraw", Value: NewMichelinePrim("Unit")}
+	tamperedDelegate := delegation
+	tamperedDelegate.Delegate = "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc"
+
+	type input struct {
+		forged   string
+		branch   string
+		contents []Contents
+	}
+
+	cases := []struct {
+		name        string
+		input       input
+		err         bool
+		errContains string
+	}{
+		{
+			"is successful",
+			input{*forged, branch, []Contents{transaction, call, delegation}},
+			false,
+			"",
+		},
+		{
+			"handles other branch",
+			input{*forged, mockBlockHash, []Contents{transaction, call, delegation}},
+			true,
+			"failed to verify forged operation: branch BLyvCRkxuTXkx1KeGvrcEXiPYj4p1tFxzvFDhoHE7SFKtmP1rbk",
+		},
+		{
+			"handles missing contents",
+			input{*forged, branch, []Contents{transaction}},
+			true,
+			"failed to verify forged operation: 3 contents, expected 1",
+		},
+		{
+			"handles other fee",
+			input{*forged, branch, []Contents{tamperedFee, call, delegation}},
+			true,
+			"failed to verify forged operation: contents 0",
+		},
+		{
+			"handles other destination",
+			input{*forged, branch, []Contents{tamperedDestination, call, delegation}},
+			true,
+			"failed to verify forged operation: contents 0",
+		},
+		{
+			"handles other entrypoint",
+			input{*forged, branch, []Contents{transaction, tamperedCall, delegation}},
+			true,
+			"failed to verify forged operation: contents 1",
+		},
+		{
+			"handles other delegate",
+			input{*forged, branch, []Contents{transaction, call, tamperedDelegate}},
+			true,
+			"failed to verify forged operation: contents 2",
+		},
+		{
+			"handles invalid operation",
+			input{"not hex", branch, []Contents{transaction, call, delegation}},
+			true,
+			"failed to verify forged operation",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := gt.VerifyForgedOperation(tt.input.forged, tt.input.branch, tt.input.contents...)
+			checkErr(t, tt.err, tt.errContains, err)
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package gotezos
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+/*
+Payout -
+Description: An amount of tez paid to an account, e.g. the share of a delegator in the rewards of a cycle.
+*/
+type Payout struct {
+	// The account paid.
+	Address string `json:"address" validate:"required"`
+	// The amount paid, in mutez.
+	Amount int64 `json:"amount" validate:"gt=0"`
+}
+
+/*
+PayoutBatch -
+Description: Payouts paid by a single operation. Batches are JSON encodable, so that the progress of a payout
+run can be saved and resumed.
+*/
+type PayoutBatch struct {
+	Payouts []Payout `json:"payouts"`
+	// The idempotency key of the batch, see PayoutsInput.Key and Injector.Pay.
+	Key string `json:"key,omitempty"`
+	// The hash of the operation paying the batch, set once it is injected.
+	OperationHash string `json:"operation_hash,omitempty"`
+}
+
+/*
+PayoutsInput -
+Description: The input for planning payouts.
+Function: func (w *WalletClient) PlanPayouts(input *PayoutsInput) ([]PayoutBatch, error) {}
+*/
+type PayoutsInput struct {
+	// The payouts, e.g. the reward split of a cycle.
+	// Required.
+	Payouts []Payout `validate:"required,dive"`
+
+	// The max number of payouts per batch. Defaults to as many as fit in an operation.
+	MaxBatchSize int
+
+	// The idempotency key of the payout run, e.g. "payout/cycle-500". The key of each batch is the key of the
+	// run followed by a hash of its payouts, e.g. "payout/cycle-500/5f1c...", so that a batch planned again
+	// with the same payouts gets the same key. Required by Injector.Pay.
+	Key string
+}
+
+/*
+PlanPayouts Function
+Description: Splits payouts into batches that each fit in an operation: the forged operation is at most
+max_operation_data_length bytes and its gas limits sum to at most hard_gas_limit_per_block, see
+SplitOperation. Payouts are kept in order. See Pay to pay the batches.
+
+The batches are not signed: each one is signed when it is paid, with the counter and branch of that time, as
+an operation signed in advance would expire after max_operations_ttl blocks. How payouts are split depends on
+the constants and the operation sizes of the node, so planning again may split them differently, and a payout
+moved from a paid batch to another one would be paid again. Save the batches before paying them and resume a
+run from them rather than planning it again.
+
+Parameters:
+	input:
+		The payouts and the max size of a batch. Payouts is required.
+*/
+func (w *WalletClient) PlanPayouts(input *PayoutsInput) ([]PayoutBatch, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	groups, err := w.splitOperation(payoutContents(input.Payouts), input.MaxBatchSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to plan payouts")
+	}
+
+	batches := []PayoutBatch{}
+	keys := map[string]int{}
+	start := 0
+	for _, group := range groups {
+		batch := PayoutBatch{Payouts: input.Payouts[start : start+len(group)]}
+		if input.Key != "" {
+			batch.Key = fmt.Sprintf("%s/%s", input.Key, payoutsDigest(batch.Payouts))
+			// Batches with the same payouts are paid each, their keys are told apart by their occurrence.
+			if keys[batch.Key]++; keys[batch.Key] > 1 {
+				batch.Key = fmt.Sprintf("%s-%d", batch.Key, keys[batch.Key])
+			}
+		}
+		batches = append(batches, batch)
+		start += len(group)
+	}
+
+	return batches, nil
+}
+
+/*
+Pay Function
+Description: Pays the batches planned with PlanPayouts, in order, each in an operation waited for until it
+is included (or confirmed, see WalletClient.Confirmations) before the next one is injected. Batches with an
+operation hash are skipped, so that a run can be resumed with the batches it returned. If a batch fails,
+the batches are returned along with the error, with the hashes of the batches paid. A batch injected but not
+seen included (e.g. as the context was done) keeps its hash: check whether the operation was included before
+clearing its hash to pay it again, so that payouts are not paid twice.
+
+Pay is not crash-safe: the hash of a batch is only known to the caller once Pay returns, so a crash after a
+batch is injected pays it again when the saved batches are resumed. See Injector.Pay to pay crash-safely.
+
+Parameters:
+	ctx:
+		Cancels the payouts.
+	batches:
+		The batches to pay.
+*/
+func (w *WalletClient) Pay(ctx context.Context, batches []PayoutBatch) ([]PayoutBatch, error) {
+	batches = append([]PayoutBatch{}, batches...)
+
+	client := *w
+	client.Wait = true
+
+	for i := range batches {
+		if batches[i].OperationHash != "" {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			return batches, errors.Wrapf(err, "failed to pay batch %d", i)
+		}
+
+		hash, err := client.Send(ctx, payoutContents(batches[i].Payouts)...)
+		if hash != nil {
+			batches[i].OperationHash = *hash
+		}
+		if err != nil {
+			return batches, errors.Wrapf(err, "failed to pay batch %d", i)
+		}
+	}
+
+	return batches, nil
+}
+
+/*
+Pay Function
+Description: Pays the batches planned with PlanPayouts like WalletClient.Pay, but through the Injector, so that
+a run resumed after a crash does not pay a batch twice: each batch is sent with its idempotency key (see
+PayoutsInput.Key), and its signed operation and hash are recorded to the store before it is injected. Batches
+whose key was injected already get the hash of their record and are not paid again, and a batch left signed
+has its recorded operation injected again. Batches without a key are refused.
+
+Parameters:
+	ctx:
+		Cancels the payouts.
+	batches:
+		The batches to pay, planned with a key.
+*/
+func (i *Injector) Pay(ctx context.Context, batches []PayoutBatch) ([]PayoutBatch, error) {
+	batches = append([]PayoutBatch{}, batches...)
+
+	client := *i.Client
+	client.Wait = true
+	injector := NewInjector(&client, i.Store)
+
+	for n := range batches {
+		if batches[n].OperationHash != "" {
+			continue
+		}
+
+		if batches[n].Key == "" {
+			return batches, errors.Errorf("failed to pay batch %d: no idempotency key, see PayoutsInput.Key", n)
+		}
+
+		if err := ctx.Err(); err != nil {
+			return batches, errors.Wrapf(err, "failed to pay batch %d", n)
+		}
+
+		record, err := injector.Send(ctx, batches[n].Key, payoutContents(batches[n].Payouts)...)
+		if record != nil && (record.Status == InjectionStatusInjected || record.Status == InjectionStatusIncluded) {
+			batches[n].OperationHash = record.OperationHash
+		}
+		if err != nil && errors.Cause(err) != ErrAlreadyInjected {
+			return batches, errors.Wrapf(err, "failed to pay batch %d", n)
+		}
+	}
+
+	return batches, nil
+}
+
+// payoutsDigest returns a hash of payouts, in hex.
+func payoutsDigest(payouts []Payout) string {
+	var b strings.Builder
+	for _, payout := range payouts {
+		fmt.Fprintf(&b, "%s:%d;", payout.Address, payout.Amount)
+	}
+	digest := blake2b.Sum256([]byte(b.String()))
+
+	return hex.EncodeToString(digest[:16])
+}
+
+func payoutContents(payouts []Payout) []Contents {
+	contents := make([]Contents, len(payouts))
+	for i, payout := range payouts {
+		contents[i].Kind = TRANSACTIONOP
+		contents[i].Destination = payout.Address
+		contents[i].Amount.SetInt64(payout.Amount)
+	}
+
+	return contents
+}
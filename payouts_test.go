@@ -0,0 +1,196 @@
+package gotezos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type payoutsMock struct {
+	*walletClientMock
+	consumedGas int
+	failOn      int
+}
+
+func (p *payoutsMock) DryRun(contents ...Contents) (*DryRunResult, error) {
+	result := &DryRunResult{Status: APPLIEDSTATUS}
+	for range contents {
+		result.Contents = append(result.Contents, dryRunContents(fmt.Sprintf(`{"status":"applied","consumed_gas":"%d"}`, p.consumedGas)))
+	}
+	return result, nil
+}
+
+func (p *payoutsMock) InjectionOperation(input *InjectionOperationInput) (*[]byte, error) {
+	if len(p.injected)+1 == p.failOn {
+		p.failOn = 0
+		return nil, errors.New("injection failed")
+	}
+	return p.walletClientMock.InjectionOperation(input)
+}
+
+func testPayouts(n int) []Payout {
+	var payouts []Payout
+	for i := 1; i <= n; i++ {
+		payouts = append(payouts, Payout{Address: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", Amount: int64(i * 1000)})
+	}
+	return payouts
+}
+
+func Test_PlanPayouts(t *testing.T) {
+	type want struct {
+		err         bool
+		containsErr string
+		sizes       []int
+	}
+
+	cases := []struct {
+		name        string
+		input       *PayoutsInput
+		consumedGas int
+		want
+	}{
+		{
+			"fits payouts in a batch",
+			&PayoutsInput{Payouts: testPayouts(25)},
+			1000,
+			want{false, "", []int{25}},
+		},
+		{
+			"splits payouts by size",
+			&PayoutsInput{Payouts: testPayouts(500)},
+			1000,
+			want{false, "", []int{253, 247}},
+		},
+		{
+			"splits payouts by gas",
+			&PayoutsInput{Payouts: testPayouts(25)},
+			700000,
+			want{false, "", []int{11, 11, 3}},
+		},
+		{
+			"splits payouts by max batch size",
+			&PayoutsInput{Payouts: testPayouts(5), MaxBatchSize: 2},
+			1000,
+			want{false, "", []int{2, 2, 1}},
+		},
+		{
+			"handles invalid payouts",
+			&PayoutsInput{Payouts: []Payout{{Address: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}}},
+			1000,
+			want{true, "invalid input", nil},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := testWalletClient(t)
+			revealed := "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G"
+			mock.managerKey = &revealed
+			client.Client = &payoutsMock{walletClientMock: mock, consumedGas: tt.consumedGas}
+
+			batches, err := client.PlanPayouts(tt.input)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+
+			var sizes []int
+			var payouts []Payout
+			for _, batch := range batches {
+				sizes = append(sizes, len(batch.Payouts))
+				payouts = append(payouts, batch.Payouts...)
+			}
+			assert.Equal(t, tt.want.sizes, sizes)
+			if !tt.want.err {
+				assert.Equal(t, tt.input.Payouts, payouts)
+			}
+		})
+	}
+}
+
+func Test_Pay(t *testing.T) {
+	client, mock := testWalletClient(t)
+	mock.confirmations = []Confirmation{{Confirmations: 0}}
+	payouts := &payoutsMock{walletClientMock: mock, consumedGas: 1000, failOn: 2}
+	client.Client = payouts
+
+	batches := []PayoutBatch{
+		{Payouts: testPayouts(1), OperationHash: "ooPaid"},
+		{Payouts: testPayouts(2)},
+		{Payouts: testPayouts(3)},
+		{Payouts: testPayouts(1)},
+	}
+
+	paid, err := client.Pay(context.Background(), batches)
+	checkErr(t, true, "failed to pay batch 2: injection failed", err)
+	assert.Equal(t, []string{"ooPaid", mock.hash, "", ""}, payoutHashes(paid))
+	assert.Equal(t, "", batches[1].OperationHash)
+	assert.Len(t, mock.injected, 1)
+
+	paid, err = client.Pay(context.Background(), paid)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ooPaid", mock.hash, mock.hash, mock.hash}, payoutHashes(paid))
+	assert.Len(t, mock.injected, 3)
+}
+
+func Test_InjectorPay(t *testing.T) {
+	client, mock := testWalletClient(t)
+	revealed := "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G"
+	mock.managerKey = &revealed
+	mock.confirmations = []Confirmation{{Confirmations: 0}}
+	payouts := &payoutsMock{walletClientMock: mock, consumedGas: 1000, failOn: 2}
+	client.Client = payouts
+
+	planned, err := client.PlanPayouts(&PayoutsInput{Payouts: testPayouts(5), MaxBatchSize: 2, Key: "payout/cycle-500"})
+	assert.Nil(t, err)
+	assert.Len(t, planned, 3)
+	for _, batch := range planned {
+		assert.Equal(t, "payout/cycle-500/"+payoutsDigest(batch.Payouts), batch.Key)
+	}
+
+	// Keys follow the payouts of a batch rather than its index.
+	replanned, err := client.PlanPayouts(&PayoutsInput{Payouts: testPayouts(5)[2:], MaxBatchSize: 2, Key: "payout/cycle-500"})
+	assert.Nil(t, err)
+	assert.Equal(t, payoutKeys(planned[1:]), payoutKeys(replanned))
+	shifted, err := client.PlanPayouts(&PayoutsInput{Payouts: testPayouts(5)[1:], MaxBatchSize: 2, Key: "payout/cycle-500"})
+	assert.Nil(t, err)
+	for _, key := range payoutKeys(shifted) {
+		assert.NotContains(t, payoutKeys(planned), key)
+	}
+	same := testPayouts(1)
+	duplicated, err := client.PlanPayouts(&PayoutsInput{Payouts: append(same, same...), MaxBatchSize: 1, Key: "payout/cycle-500"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"payout/cycle-500/" + payoutsDigest(same), "payout/cycle-500/" + payoutsDigest(same) + "-2"}, payoutKeys(duplicated))
+
+	injector := NewInjector(client, NewMemoryInjectionStore())
+	paid, err := injector.Pay(context.Background(), planned)
+	checkErr(t, true, fmt.Sprintf("failed to pay batch 1: failed to send operation '%s': injection failed", planned[1].Key), err)
+	assert.Equal(t, []string{mock.hash, "", ""}, payoutHashes(paid))
+	assert.Len(t, mock.injected, 1)
+
+	// The progress is lost as by a crash: the batch paid is not paid again.
+	paid, err = injector.Pay(context.Background(), planned)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{mock.hash, mock.hash, mock.hash}, payoutHashes(paid))
+	assert.Len(t, mock.injected, 3)
+	assert.Equal(t, "", planned[0].OperationHash)
+
+	_, err = injector.Pay(context.Background(), []PayoutBatch{{Payouts: testPayouts(1)}})
+	checkErr(t, true, "failed to pay batch 0: no idempotency key", err)
+}
+
+func payoutKeys(batches []PayoutBatch) []string {
+	var keys []string
+	for _, batch := range batches {
+		keys = append(keys, batch.Key)
+	}
+	return keys
+}
+
+func payoutHashes(batches []PayoutBatch) []string {
+	var hashes []string
+	for _, batch := range batches {
+		hashes = append(hashes, batch.OperationHash)
+	}
+	return hashes
+}
@@ -0,0 +1,238 @@
+//go:build cgo
+// +build cgo
+
+package gotezos
+
+import (
+	"encoding/asn1"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+/*
+PKCS11Signer -
+Description: A Signer of which the key is a secp256k1 (tz2) or P-256 (tz3) key of a PKCS#11 token, e.g. a
+YubiHSM 2 (through yubihsm_pkcs11.so) or a Nitrokey HSM (through opensc-pkcs11.so), so that operations are
+signed on the device. The private and public keys must share a label. Needs cgo.
+
+Function: func NewPKCS11Signer(module, tokenLabel, pin, keyLabel string) (*PKCS11Signer, error) {}
+*/
+type PKCS11Signer struct {
+	// A session is not safe for concurrent use.
+	mu      sync.Mutex
+	ctx     pkcs11Ctx
+	session pkcs11.SessionHandle
+	handle  pkcs11.ObjectHandle
+	key     *ecdsaKey
+}
+
+// pkcs11Ctx is the part of a pkcs11.Ctx used once the signer is open.
+type pkcs11Ctx interface {
+	SignInit(sh pkcs11.SessionHandle, m []*pkcs11.Mechanism, o pkcs11.ObjectHandle) error
+	Sign(sh pkcs11.SessionHandle, message []byte) ([]byte, error)
+	Logout(sh pkcs11.SessionHandle) error
+	CloseSession(sh pkcs11.SessionHandle) error
+	Finalize() error
+	Destroy()
+}
+
+/*
+NewPKCS11Signer Function
+Description: Loads a PKCS#11 module, logs in to a token and finds a key to sign with. Close the signer once done
+to log out.
+
+Parameters:
+	module:
+		The path of the PKCS#11 module of the token (e.g. /usr/lib/pkcs11/yubihsm_pkcs11.so).
+	tokenLabel:
+		The label of the token.
+	pin:
+		The PIN of the user (for a YubiHSM 2, the id of the authentication key followed by its password,
+		e.g. 0001password).
+	keyLabel:
+		The label of the private and public key.
+*/
+func NewPKCS11Signer(module, tokenLabel, pin, keyLabel string) (*PKCS11Signer, error) {
+	ctx := pkcs11.New(module)
+	if ctx == nil {
+		return nil, errors.Errorf("failed to load pkcs11 module '%s'", module)
+	}
+
+	signer := &PKCS11Signer{ctx: ctx}
+	if err := signer.open(ctx, tokenLabel, pin, keyLabel); err != nil {
+		signer.Close()
+		return nil, errors.Wrap(err, "failed to open pkcs11 signer")
+	}
+
+	return signer, nil
+}
+
+func (p *PKCS11Signer) open(ctx *pkcs11.Ctx, tokenLabel, pin, keyLabel string) error {
+	if err := ctx.Initialize(); err != nil {
+		return errors.Wrap(err, "failed to initialize module")
+	}
+
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return errors.Wrap(err, "failed to list slots")
+	}
+
+	slot, found := uint(0), false
+	for _, s := range slots {
+		info, err := ctx.GetTokenInfo(s)
+		if err == nil && strings.TrimSpace(info.Label) == tokenLabel {
+			slot, found = s, true
+			break
+		}
+	}
+	if !found {
+		return errors.Errorf("no token '%s'", tokenLabel)
+	}
+
+	if p.session, err = ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION); err != nil {
+		return errors.Wrap(err, "failed to open session")
+	}
+	if err = ctx.Login(p.session, pkcs11.CKU_USER, pin); err != nil {
+		return errors.Wrap(err, "failed to log in")
+	}
+
+	if p.handle, err = p.findObject(ctx, pkcs11.CKO_PRIVATE_KEY, keyLabel); err != nil {
+		return err
+	}
+	public, err := p.findObject(ctx, pkcs11.CKO_PUBLIC_KEY, keyLabel)
+	if err != nil {
+		return err
+	}
+
+	attributes, err := ctx.GetAttributeValue(p.session, public, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get public key '%s'", keyLabel)
+	}
+
+	p.key, err = parsePKCS11PublicKey(attributes[0].Value, attributes[1].Value)
+	return errors.Wrapf(err, "failed to get public key '%s'", keyLabel)
+}
+
+func (p *PKCS11Signer) findObject(ctx *pkcs11.Ctx, class uint, label string) (pkcs11.ObjectHandle, error) {
+	err := ctx.FindObjectsInit(p.session, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to find key '%s'", label)
+	}
+	defer ctx.FindObjectsFinal(p.session)
+
+	objects, _, err := ctx.FindObjects(p.session, 1)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to find key '%s'", label)
+	}
+	if len(objects) == 0 {
+		return 0, errors.Errorf("no key '%s'", label)
+	}
+
+	return objects[0], nil
+}
+
+// parsePKCS11PublicKey parses the CKA_EC_PARAMS (the DER encoded curve) and CKA_EC_POINT (the DER encoded
+// uncompressed point, or the raw point for some tokens) of a public key.
+func parsePKCS11PublicKey(params, point []byte) (*ecdsaKey, error) {
+	var curve asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(params, &curve); err != nil {
+		return nil, errors.Wrap(err, "invalid public key: invalid curve")
+	}
+
+	// A raw point is 65 bytes, 67 once DER encoded.
+	if len(point) != 65 {
+		var raw []byte
+		if _, err := asn1.Unmarshal(point, &raw); err != nil {
+			return nil, errors.Wrap(err, "invalid public key: invalid point")
+		}
+		point = raw
+	}
+
+	return newECDSAKey(curve, point)
+}
+
+/*
+Sign Function
+Description: Signs the blake2b-256 digest of a message on the token. Returns the spsig1 or p2sig encoded
+signature, with a low s.
+
+Parameters:
+	message:
+		The bytes to sign, see SignOperationWith to sign operations.
+*/
+func (p *PKCS11Signer) Sign(message []byte) (string, error) {
+	digest := blake2b.Sum256(message)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.ctx.SignInit(p.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}, p.handle); err != nil {
+		return "", errors.Wrap(err, "failed to sign with pkcs11")
+	}
+
+	// CKM_ECDSA signs the digest as it is, and returns r and s concatenated.
+	sig, err := p.ctx.Sign(p.session, digest[:])
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign with pkcs11")
+	}
+	if len(sig) != 64 {
+		return "", errors.Errorf("failed to sign with pkcs11: invalid signature length %d", len(sig))
+	}
+
+	signature, err := p.key.signature(new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:]))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign with pkcs11")
+	}
+
+	return signature, nil
+}
+
+/*
+PublicKey Function
+Description: Returns the sppk or p2pk public key of the key.
+*/
+func (p *PKCS11Signer) PublicKey() string {
+	return p.key.pk
+}
+
+/*
+Address Function
+Description: Returns the tz2 or tz3 address of the key.
+*/
+func (p *PKCS11Signer) Address() string {
+	return p.key.address
+}
+
+/*
+Close Function
+Description: Logs out of the token and unloads the module.
+*/
+func (p *PKCS11Signer) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var err error
+	if p.session != 0 {
+		p.ctx.Logout(p.session)
+		err = p.ctx.CloseSession(p.session)
+		p.session = 0
+	}
+	if p.ctx != nil {
+		p.ctx.Finalize()
+		p.ctx.Destroy()
+		p.ctx = nil
+	}
+
+	return errors.Wrap(err, "failed to close pkcs11 signer")
+}
@@ -0,0 +1,111 @@
+//go:build cgo
+// +build cgo
+
+package gotezos
+
+import (
+	"encoding/asn1"
+	"math/big"
+	"testing"
+
+	"github.com/miekg/pkcs11"
+	"github.com/stretchr/testify/assert"
+)
+
+type pkcs11CtxMock struct {
+	t      *testing.T
+	key    *kmsTestKey
+	calls  []string
+	digest []byte
+}
+
+func (m *pkcs11CtxMock) SignInit(sh pkcs11.SessionHandle, mechanisms []*pkcs11.Mechanism, o pkcs11.ObjectHandle) error {
+	assert.Equal(m.t, pkcs11.SessionHandle(1), sh)
+	assert.Equal(m.t, pkcs11.ObjectHandle(2), o)
+	assert.Equal(m.t, uint(pkcs11.CKM_ECDSA), mechanisms[0].Mechanism)
+	m.calls = append(m.calls, "SignInit")
+	return nil
+}
+
+func (m *pkcs11CtxMock) Sign(sh pkcs11.SessionHandle, digest []byte) ([]byte, error) {
+	m.calls = append(m.calls, "Sign")
+	var sig struct{ R, S *big.Int }
+	_, err := asn1.Unmarshal(m.key.sign(m.t, digest), &sig)
+	assert.Nil(m.t, err)
+
+	raw := make([]byte, 64)
+	copy(raw[32-len(sig.R.Bytes()):], sig.R.Bytes())
+	copy(raw[64-len(sig.S.Bytes()):], sig.S.Bytes())
+	return raw, nil
+}
+
+func (m *pkcs11CtxMock) Logout(sh pkcs11.SessionHandle) error {
+	m.calls = append(m.calls, "Logout")
+	return nil
+}
+
+func (m *pkcs11CtxMock) CloseSession(sh pkcs11.SessionHandle) error {
+	m.calls = append(m.calls, "CloseSession")
+	return nil
+}
+
+func (m *pkcs11CtxMock) Finalize() error {
+	m.calls = append(m.calls, "Finalize")
+	return nil
+}
+
+func (m *pkcs11CtxMock) Destroy() {
+	m.calls = append(m.calls, "Destroy")
+}
+
+func Test_PKCS11Signer(t *testing.T) {
+	for _, curve := range []string{"secp256k1", "p256"} {
+		t.Run(curve, func(t *testing.T) {
+			key := newKMSTestKey(t, curve)
+
+			// Tokens return the SubjectPublicKeyInfo split in CKA_EC_PARAMS and CKA_EC_POINT.
+			var spki struct {
+				Algorithm struct {
+					Algorithm asn1.ObjectIdentifier
+					Curve     asn1.ObjectIdentifier
+				}
+				PublicKey asn1.BitString
+			}
+			_, err := asn1.Unmarshal(key.der, &spki)
+			assert.Nil(t, err)
+			params, err := asn1.Marshal(spki.Algorithm.Curve)
+			assert.Nil(t, err)
+			point, err := asn1.Marshal(spki.PublicKey.Bytes)
+			assert.Nil(t, err)
+
+			publicKey, err := parsePKCS11PublicKey(params, point)
+			assert.Nil(t, err)
+			raw, err := parsePKCS11PublicKey(params, spki.PublicKey.Bytes)
+			assert.Nil(t, err)
+			assert.Equal(t, publicKey, raw)
+
+			ctx := &pkcs11CtxMock{t: t, key: key}
+			signer := &PKCS11Signer{ctx: ctx, session: 1, handle: 2, key: publicKey}
+
+			checkKMSSigner(t, curve, signer,
+				func() (string, error) { return signer.PublicKey(), nil },
+				func() (string, error) { return signer.Address(), nil },
+			)
+
+			assert.Nil(t, signer.Close())
+			assert.Equal(t, []string{"Logout", "CloseSession", "Finalize", "Destroy"}, ctx.calls[len(ctx.calls)-4:])
+		})
+	}
+
+	t.Run("handles unsupported curve", func(t *testing.T) {
+		params, err := asn1.Marshal(asn1.ObjectIdentifier{1, 3, 132, 0, 34})
+		assert.Nil(t, err)
+		_, err = parsePKCS11PublicKey(params, make([]byte, 65))
+		checkErr(t, true, "invalid public key", err)
+	})
+
+	t.Run("handles missing module", func(t *testing.T) {
+		_, err := NewPKCS11Signer("/nonexistent/pkcs11.so", "token", "1234", "key")
+		checkErr(t, true, "failed to load pkcs11 module '/nonexistent/pkcs11.so'", err)
+	})
+}
@@ -0,0 +1,161 @@
+package gotezos
+
+/*
+Protocol -
+Description: The operation schema of a Tezos protocol. Manager operations are encoded the same way
+since Babylon, but consensus operations changed kind and fields across protocols:
+
+	Babylon to Delphi:  endorsement (level)
+	Edo to Hangzhou:    endorsement_with_slot (inlined signed endorsement, slot)
+	Ithaca to Nairobi:  endorsement (slot, level, round, block payload hash)
+	Oxford and later:   attestation (slot, level, round, block payload hash)
+
+Tenderbake protocols also have preendorsements (preattestations since Oxford), encoded as their endorsements.
+*/
+type Protocol struct {
+	// The protocol hash.
+	Hash string
+	// The protocol name.
+	Name string
+	// The kind endorsements are forged and unforged as.
+	EndorsementKind string
+	// Whether the protocol uses Tenderbake consensus operations.
+	Tenderbake bool
+}
+
+// protocols are the known protocols since Babylon, oldest first.
+var protocols = []Protocol{
+	{Hash: "PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS", Name: "Babylon", EndorsementKind: ENDORSEMENTOP},
+	{Hash: "PsCARTHAGazKbHtnKfLzQg3kms52kSRpgnDY982a9oYsSXRLQEb", Name: "Carthage", EndorsementKind: ENDORSEMENTOP},
+	{Hash: "PsDELPH1Kxsxt8f9eWbxQeRxkjfbxoqM52jvs5Y5fBxWWh4ifpo", Name: "Delphi", EndorsementKind: ENDORSEMENTOP},
+	{Hash: "PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA", Name: "Edo", EndorsementKind: ENDORSEMENTWITHSLOTOP},
+	{Hash: "PsFLorenaUUuikDWvMDr6fGBRG8kt3e3D3fHoXK1j1BFRxeSH4i", Name: "Florence", EndorsementKind: ENDORSEMENTWITHSLOTOP},
+	{Hash: "PtGRANADsDU8R9daYKAgWnQYAJ64omN1o3KMGVCykShA97vQbvV", Name: "Granada", EndorsementKind: ENDORSEMENTWITHSLOTOP},
+	{Hash: "PtHangz2aRngywmSRGGvrcTyMbbdpWdpFKuS4uMWxg2RaH9i1qx", Name: "Hangzhou", EndorsementKind: ENDORSEMENTWITHSLOTOP},
+	{Hash: "Psithaca2MLRFYargivpo7YvUr7wUDqyxrdhC5CQq78mRvimz6A", Name: "Ithaca", EndorsementKind: ENDORSEMENTOP, Tenderbake: true},
+	{Hash: "PtJakart2xVj7pYXJBXrqHgd82rdkLey5ZeeGwDgPp9rhQUbSqY", Name: "Jakarta", EndorsementKind: ENDORSEMENTOP, Tenderbake: true},
+	{Hash: "PtKathmankSpLLDALzWw7CGD2j2MtyveTwboEYokqUCP4a1LxMg", Name: "Kathmandu", EndorsementKind: ENDORSEMENTOP, Tenderbake: true},
+	{Hash: "PtLimaPtLMwfNinJi9rCfDPWea8dFgTZ1MeJ9f1m2SRic6ayiwW", Name: "Lima", EndorsementKind: ENDORSEMENTOP, Tenderbake: true},
+	{Hash: "PtMumbai2TmsJHNGRkD8v8YDbtao7BLUC3wjASn1inAKLFCjaH1", Name: "Mumbai", EndorsementKind: ENDORSEMENTOP, Tenderbake: true},
+	{Hash: "PtNairobiyssHuh87hEhfVBGCVrK3WnS8Z2FT4ymB5tAa4r1nQf", Name: "Nairobi", EndorsementKind: ENDORSEMENTOP, Tenderbake: true},
+	{Hash: "ProxfordYmVfjWnRcgjWH36fW6PArwqykTFzotUxRs6gmTcZDuH", Name: "Oxford", EndorsementKind: ATTESTATIONOP, Tenderbake: true},
+	{Hash: "PtParisBxoLz5gzMmn3d9WBQNoPSZakgnkMC2VNuQ3KXfUtUQeZ", Name: "ParisB", EndorsementKind: ATTESTATIONOP, Tenderbake: true},
+	{Hash: "PsParisCZo7KAh1Z1smVd9ZMZ1HHn5gkzbM94V3PLCpknFWhUAi", Name: "ParisC", EndorsementKind: ATTESTATIONOP, Tenderbake: true},
+	{Hash: "PsQuebecnLByd3JwTiGadoG4nGWi3HYiLXUjkibeFV8dCFeVMUg", Name: "Quebec", EndorsementKind: ATTESTATIONOP, Tenderbake: true},
+	{Hash: "PsRiotumaAMotcRoDWW1bysEhQy2n1M5fy8JgRp8jjRfHGmfeA7", Name: "Rio", EndorsementKind: ATTESTATIONOP, Tenderbake: true},
+}
+
+/*
+ProtocolByHash Function
+Description: Returns the operation schema of a protocol. Unknown protocols (e.g. a protocol newer than
+this library) are assumed to use the schema of the latest known protocol.
+
+Parameters:
+	hash:
+		The protocol hash.
+*/
+func ProtocolByHash(hash string) Protocol {
+	for _, p := range protocols {
+		if p.Hash == hash {
+			return p
+		}
+	}
+
+	latest := protocols[len(protocols)-1]
+	return Protocol{
+		Hash:            hash,
+		EndorsementKind: latest.EndorsementKind,
+		Tenderbake:      latest.Tenderbake,
+	}
+}
+
+/*
+PreendorsementKind Function
+Description: Returns the kind preendorsements are forged and unforged as, preendorsement or preattestation. Protocols
+before Tenderbake have no preendorsements.
+*/
+func (p Protocol) PreendorsementKind() string {
+	if !p.Tenderbake {
+		return ""
+	}
+	if p.EndorsementKind == ATTESTATIONOP {
+		return PREATTESTATIONOP
+	}
+
+	return PREENDORSEMENTOP
+}
+
+/*
+Protocol Function
+Description: Returns the operation schema GoTezos forges and unforges operations with. It is
+selected from the protocol of the head block the library was initialized with, see New and SetProtocol.
+*/
+func (t *GoTezos) Protocol() Protocol {
+	t.stateMu.RLock()
+	defer t.stateMu.RUnlock()
+
+	if t.protocol == nil {
+		return ProtocolByHash("")
+	}
+
+	return *t.protocol
+}
+
+/*
+SetProtocol Func
+Description: Overrides the protocol GoTezos forges and unforges operations for.
+
+Parameters:
+	hash:
+		The protocol hash.
+*/
+func (t *GoTezos) SetProtocol(hash string) {
+	protocol := ProtocolByHash(hash)
+
+	t.stateMu.Lock()
+	defer t.stateMu.Unlock()
+
+	t.protocol = &protocol
+}
+
+// followProtocol refreshes the network constants and the protocol operations are forged for when the head's
+// next protocol differs from the current one, as constants change at activation. Constants only cached by New
+// or SetConstants are refreshed, and on failure they are kept until the next head.
+func (t *GoTezos) followProtocol(head *Block) {
+	next := head.Metadata.NextProtocol
+	t.stateMu.RLock()
+	current := t.networkConstants == nil || t.protocol == nil || next == "" || next == t.protocol.Hash
+	t.stateMu.RUnlock()
+	if current {
+		return
+	}
+
+	constants, err := t.Constants(BlockIDHash(head.Hash))
+	if err != nil {
+		return
+	}
+
+	protocol := ProtocolByHash(next)
+
+	t.stateMu.Lock()
+	defer t.stateMu.Unlock()
+
+	t.networkConstants = constants
+	t.protocol = &protocol
+}
+
+// atLeast returns whether p is the known protocol with the given name or a later one. Unknown protocols
+// are assumed to be later than every known protocol.
+func (p Protocol) atLeast(name string) bool {
+	index, target := len(protocols), len(protocols)
+	for i, known := range protocols {
+		if known.Hash == p.Hash {
+			index = i
+		}
+		if known.Name == name {
+			target = i
+		}
+	}
+
+	return index >= target
+}
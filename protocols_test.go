@@ -0,0 +1,231 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ProtocolByHash(t *testing.T) {
+	cases := []struct {
+		name            string
+		hash            string
+		endorsementKind string
+		tenderbake      bool
+	}{
+		{"babylon", "PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS", ENDORSEMENTOP, false},
+		{"edo", "PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA", ENDORSEMENTWITHSLOTOP, false},
+		{"ithaca", "Psithaca2MLRFYargivpo7YvUr7wUDqyxrdhC5CQq78mRvimz6A", ENDORSEMENTOP, true},
+		{"oxford", "ProxfordYmVfjWnRcgjWH36fW6PArwqykTFzotUxRs6gmTcZDuH", ATTESTATIONOP, true},
+		{"unknown", "PtUnknownProtocol", ATTESTATIONOP, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			protocol := ProtocolByHash(tt.hash)
+			assert.Equal(t, tt.hash, protocol.Hash)
+			assert.Equal(t, tt.endorsementKind, protocol.EndorsementKind)
+			assert.Equal(t, tt.tenderbake, protocol.Tenderbake)
+		})
+	}
+}
+
+func Test_NewSetsProtocol(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(blankHandler))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, "Babylon", gt.Protocol().Name)
+
+	assert.Equal(t, ATTESTATIONOP, (&GoTezos{}).Protocol().EndorsementKind)
+}
+
+func Test_HeadFollowsProtocol(t *testing.T) {
+	nextProtocol := "PsCARTHAGazKbHtnKfLzQg3kms52kSRpgnDY982a9oYsSXRLQEb"
+	constantsRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case regConstants.MatchString(req.URL.String()):
+			constantsRequests++
+			rw.Write([]byte(`{"preserved_cycles":3,"blocks_per_cycle":8192}`))
+		case regBlock.MatchString(req.URL.String()):
+			rw.Write([]byte(`{"hash":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","protocol":"PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS","metadata":{"protocol":"PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS","next_protocol":"` + nextProtocol + `"}}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	gt := &GoTezos{client: http.DefaultClient, host: server.URL}
+	_, err := gt.Head()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, constantsRequests)
+
+	gt.SetConstants(*expectedConstants(t))
+	gt.SetProtocol("PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS")
+
+	_, err = gt.Head()
+	assert.Nil(t, err)
+	assert.Equal(t, "Carthage", gt.Protocol().Name)
+	assert.Equal(t, 3, gt.networkConstants.PreservedCycles)
+	assert.Equal(t, 8192, gt.networkConstants.BlocksPerCycle)
+
+	_, err = gt.Head()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, constantsRequests)
+}
+
+func Test_ForgeEndorsementOperation(t *testing.T) {
+	signature := "sigvU29YNjSN8foVQRgqBYWS1wsqSGmWtnE6WrTiq9zfMxAnyrq7zJdPGVQiLUTTmqEzDjjRKRsdRnDbsnXUgc1afnqRApru"
+	payloadHash := "vh1wp3PKz9qNHuiK9ri8TeC5Du9soVqij779SyuhVdx3STFtrjg7"
+
+	cases := []struct {
+		name     string
+		protocol string
+		input    Contents
+		tag      string
+		unforged Contents
+		json     string
+	}{
+		{
+			"forges an endorsement for babylon",
+			"PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS",
+			Contents{Kind: ENDORSEMENTOP, Level: 656938},
+			"00000a062a",
+			Contents{Kind: ENDORSEMENTOP, Level: 656938},
+			`{"kind":"endorsement","level":656938}`,
+		},
+		{
+			"forges an endorsement_with_slot for edo",
+			"PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA",
+			Contents{Kind: ENDORSEMENTWITHSLOTOP, Slot: 7, Endorsement: &InlinedEndorsement{Branch: mockBlockHash, Operations: Contents{Kind: ENDORSEMENTOP, Level: 1300000}, Signature: signature}},
+			"0a00000065",
+			Contents{Kind: ENDORSEMENTWITHSLOTOP, Slot: 7, Endorsement: &InlinedEndorsement{Branch: mockBlockHash, Operations: Contents{Kind: ENDORSEMENTOP, Level: 1300000}, Signature: signature}},
+			`{"kind":"endorsement_with_slot","endorsement":{"branch":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","operations":{"kind":"endorsement","level":1300000},"signature":"sigvU29YNjSN8foVQRgqBYWS1wsqSGmWtnE6WrTiq9zfMxAnyrq7zJdPGVQiLUTTmqEzDjjRKRsdRnDbsnXUgc1afnqRApru"},"slot":7}`,
+		},
+		{
+			"forges a tenderbake endorsement for ithaca",
+			"Psithaca2MLRFYargivpo7YvUr7wUDqyxrdhC5CQq78mRvimz6A",
+			Contents{Kind: ENDORSEMENTOP, Slot: 0, Level: 2244609, Round: 0, BlockPayloadHash: payloadHash},
+			"150000",
+			Contents{Kind: ENDORSEMENTOP, Slot: 0, Level: 2244609, Round: 0, BlockPayloadHash: payloadHash},
+			`{"kind":"endorsement","slot":0,"level":2244609,"round":0,"block_payload_hash":"vh1wp3PKz9qNHuiK9ri8TeC5Du9soVqij779SyuhVdx3STFtrjg7"}`,
+		},
+		{
+			"forges an endorsement as an attestation for oxford",
+			"ProxfordYmVfjWnRcgjWH36fW6PArwqykTFzotUxRs6gmTcZDuH",
+			Contents{Kind: ENDORSEMENTOP, Slot: 3, Level: 4587521, Round: 1, BlockPayloadHash: payloadHash},
+			"150003",
+			Contents{Kind: ATTESTATIONOP, Slot: 3, Level: 4587521, Round: 1, BlockPayloadHash: payloadHash},
+			`{"kind":"attestation","slot":3,"level":4587521,"round":1,"block_payload_hash":"vh1wp3PKz9qNHuiK9ri8TeC5Du9soVqij779SyuhVdx3STFtrjg7"}`,
+		},
+		{
+			"forges a preendorsement for ithaca",
+			"Psithaca2MLRFYargivpo7YvUr7wUDqyxrdhC5CQq78mRvimz6A",
+			Contents{Kind: PREENDORSEMENTOP, Slot: 2, Level: 2244609, Round: 1, BlockPayloadHash: payloadHash},
+			"140002",
+			Contents{Kind: PREENDORSEMENTOP, Slot: 2, Level: 2244609, Round: 1, BlockPayloadHash: payloadHash},
+			`{"kind":"preendorsement","slot":2,"level":2244609,"round":1,"block_payload_hash":"vh1wp3PKz9qNHuiK9ri8TeC5Du9soVqij779SyuhVdx3STFtrjg7"}`,
+		},
+		{
+			"forges a preendorsement as a preattestation for oxford",
+			"ProxfordYmVfjWnRcgjWH36fW6PArwqykTFzotUxRs6gmTcZDuH",
+			Contents{Kind: PREENDORSEMENTOP, Slot: 3, Level: 4587521, Round: 2, BlockPayloadHash: payloadHash},
+			"140003",
+			Contents{Kind: PREATTESTATIONOP, Slot: 3, Level: 4587521, Round: 2, BlockPayloadHash: payloadHash},
+			`{"kind":"preattestation","slot":3,"level":4587521,"round":2,"block_payload_hash":"vh1wp3PKz9qNHuiK9ri8TeC5Du9soVqij779SyuhVdx3STFtrjg7"}`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			gt := &GoTezos{}
+			gt.SetProtocol(tt.protocol)
+
+			forge, err := gt.ForgeOperation(mockBlockHash, tt.input)
+			assert.Nil(t, err)
+			assert.True(t, strings.HasPrefix((*forge)[64:], tt.tag))
+
+			branch, unforged, err := gt.UnforgeOperation(*forge, false)
+			assert.Nil(t, err)
+			assert.Equal(t, mockBlockHash, *branch)
+			assert.Equal(t, []Contents{tt.unforged}, *unforged)
+
+			v, err := json.Marshal(tt.unforged)
+			assert.Nil(t, err)
+			assert.JSONEq(t, tt.json, string(v))
+		})
+	}
+
+	gt := &GoTezos{}
+	gt.SetProtocol("PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA")
+	_, err := gt.ForgeOperation(mockBlockHash, Contents{Kind: ENDORSEMENTOP, Level: 1})
+	checkErr(t, true, "endorsement is required", err)
+
+	_, err = gt.ForgeOperation(mockBlockHash, Contents{Kind: PREATTESTATIONOP, Level: 1, BlockPayloadHash: payloadHash})
+	checkErr(t, true, "has no preendorsements", err)
+
+	gt.SetProtocol("ProxfordYmVfjWnRcgjWH36fW6PArwqykTFzotUxRs6gmTcZDuH")
+	_, err = gt.ForgeOperation(mockBlockHash, Contents{Kind: ATTESTATIONOP, Level: 1})
+	checkErr(t, true, "invalid block payload hash", err)
+	_, err = gt.ForgeOperation(mockBlockHash, Contents{Kind: PREATTESTATIONOP, Level: 1})
+	checkErr(t, true, "failed to forge preattestation operation: invalid block payload hash", err)
+}
+
+func Test_PreendorsementKind(t *testing.T) {
+	assert.Equal(t, "", ProtocolByHash("PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA").PreendorsementKind())
+	assert.Equal(t, PREENDORSEMENTOP, ProtocolByHash("PtNairobiyssHuh87hEhfVBGCVrK3WnS8Z2FT4ymB5tAa4r1nQf").PreendorsementKind())
+	assert.Equal(t, PREATTESTATIONOP, ProtocolByHash("PsRiotumaAMotcRoDWW1bysEhQy2n1M5fy8JgRp8jjRfHGmfeA7").PreendorsementKind())
+
+	var header Header
+	err := json.Unmarshal([]byte(`{"level":4587521,"payload_hash":"vh1wp3PKz9qNHuiK9ri8TeC5Du9soVqij779SyuhVdx3STFtrjg7","payload_round":1}`), &header)
+	assert.Nil(t, err)
+	assert.Equal(t, "vh1wp3PKz9qNHuiK9ri8TeC5Du9soVqij779SyuhVdx3STFtrjg7", header.PayloadHash)
+	assert.Equal(t, 1, header.PayloadRound)
+}
+
+func Test_FollowProtocolConcurrently(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case regConstants.MatchString(req.URL.String()):
+			rw.Write([]byte(`{"preserved_cycles":3,"blocks_per_cycle":8192}`))
+		case regBlock.MatchString(req.URL.String()):
+			rw.Write([]byte(`{"hash":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","protocol":"PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS","metadata":{"protocol":"PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS","next_protocol":"PsCARTHAGazKbHtnKfLzQg3kms52kSRpgnDY982a9oYsSXRLQEb"}}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	gt := &GoTezos{client: http.DefaultClient, host: server.URL}
+	gt.SetConstants(*expectedConstants(t))
+
+	// Run with -race: heads update the protocol and constants while they are read and set.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			gt.SetProtocol("PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS")
+			_, err := gt.Head()
+			assert.Nil(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NotEmpty(t, gt.Protocol().EndorsementKind)
+			assert.NotNil(t, gt.cachedConstants())
+		}()
+	}
+	wg.Wait()
+
+	_, err := gt.Head()
+	assert.Nil(t, err)
+	assert.Equal(t, "Carthage", gt.Protocol().Name)
+	assert.Equal(t, 8192, gt.cachedConstants().BlocksPerCycle)
+}
@@ -0,0 +1,100 @@
+package gotezos
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+/*
+UnmarshalJSON Function
+Description: Implements the json.Unmarshaler interface for Block, keeping the JSON in Raw.
+
+Parameters:
+	v:
+		The JSON representation of a block.
+*/
+func (b *Block) UnmarshalJSON(v []byte) error {
+	type block Block
+	err := json.Unmarshal(v, (*block)(b))
+	if err != nil {
+		return err
+	}
+	b.Raw = append(json.RawMessage{}, v...)
+
+	return nil
+}
+
+/*
+Get Function
+Description: Unmarshals any field of the block returned by the node, including those without a field in Block.
+Only the block keeps the JSON returned by the node, the fields of its operations are reached through it, e.g.
+with Get("operations", &operations) into a [][]map[string]json.RawMessage.
+
+Parameters:
+	name:
+		The name of the field (e.g. "protocol").
+	v:
+		A pointer to unmarshal the field into.
+*/
+func (b *Block) Get(name string, v interface{}) error {
+	return unmarshalRawField(b.Raw, name, v)
+}
+
+/*
+UnmarshalJSON Function
+Description: Implements the json.Unmarshaler interface for Delegate, keeping the JSON in Raw.
+
+Parameters:
+	v:
+		The JSON representation of a delegate.
+*/
+func (d *Delegate) UnmarshalJSON(v []byte) error {
+	type delegate Delegate
+	err := json.Unmarshal(v, (*delegate)(d))
+	if err != nil {
+		return err
+	}
+	d.Raw = append(json.RawMessage{}, v...)
+
+	return nil
+}
+
+/*
+Get Function
+Description: Unmarshals any field of the delegate returned by the node, e.g. the staking fields of
+protocols from Paris on.
+
+Parameters:
+	name:
+		The name of the field (e.g. "full_balance").
+	v:
+		A pointer to unmarshal the field into.
+*/
+func (d *Delegate) Get(name string, v interface{}) error {
+	return unmarshalRawField(d.Raw, name, v)
+}
+
+func unmarshalRawField(raw json.RawMessage, name string, v interface{}) error {
+	if len(raw) == 0 {
+		return errors.Errorf("field '%s' not found: no raw json", name)
+	}
+
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(raw, &fields)
+	if err != nil {
+		return errors.Wrap(err, "could not unmarshal raw json")
+	}
+
+	field, ok := fields[name]
+	if !ok {
+		return errors.Errorf("field '%s' not found", name)
+	}
+
+	err = json.Unmarshal(field, v)
+	if err != nil {
+		return errors.Wrapf(err, "could not unmarshal field '%s'", name)
+	}
+
+	return nil
+}
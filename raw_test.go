@@ -0,0 +1,69 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Raw(t *testing.T) {
+	var block Block
+	err := json.Unmarshal([]byte(`{
+		"protocol":"PsRiotumaAMotcRoDWW1bysEhQy2n1M5fy8JgRp8jjRfHGmfeA7",
+		"hash":"BLTbZ3U7kHwGqSUdq3e5QMyn2aJu9uZkMdQAtRHGmFf46TKdcfM",
+		"header":{"level":42},
+		"metadata":{},
+		"future_field":{"answer":42},
+		"operations":[[{
+			"hash":"opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A",
+			"contents":[{
+				"kind":"transaction",
+				"fee":"420",
+				"future_content":"content",
+				"metadata":{
+					"operation_result":{"status":"applied","ticket_updates":[{"ticket_token":{}}]},
+					"internal_operation_results":[{"kind":"event","sender":"KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg","nonce":1,"result":{"status":"applied"},"future_internal":true}]
+				}
+			}]
+		}]]
+	}`), &block)
+	assert.Nil(t, err)
+	assert.Equal(t, 42, block.Header.Level)
+
+	var future struct {
+		Answer int `json:"answer"`
+	}
+	assert.Nil(t, block.Get("future_field", &future))
+	assert.Equal(t, 42, future.Answer)
+
+	// Only the block keeps its JSON, the fields of its operations are reached through it.
+	var operations [][]struct {
+		Contents []map[string]json.RawMessage `json:"contents"`
+	}
+	assert.Nil(t, block.Get("operations", &operations))
+	assert.JSONEq(t, `"content"`, string(operations[0][0].Contents[0]["future_content"]))
+
+	contents := block.Operations[0][0].Contents[0]
+	assert.Equal(t, "420", contents.Fee.String())
+	assert.Nil(t, contents.Raw)
+	assert.Equal(t, "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", contents.Metadata.InternalOperationResults[0].Source)
+
+	var hash string
+	err = block.Get("missing", &future)
+	checkErr(t, true, "field 'missing' not found", err)
+
+	err = block.Get("hash", &future)
+	checkErr(t, true, "could not unmarshal field 'hash'", err)
+
+	err = (&Block{}).Get("hash", &hash)
+	checkErr(t, true, "no raw json", err)
+
+	var delegate Delegate
+	assert.Nil(t, json.Unmarshal([]byte(`{"balance":"1000","full_balance":"1500"}`), &delegate))
+	assert.Equal(t, "1000", delegate.Balance)
+
+	var fullBalance string
+	assert.Nil(t, delegate.Get("full_balance", &fullBalance))
+	assert.Equal(t, "1500", fullBalance)
+}
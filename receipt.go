@@ -0,0 +1,127 @@
+package gotezos
+
+import (
+	"math/big"
+)
+
+/*
+OperationReceipt -
+Description: A flat summary of the results of an applied operation group, e.g. to record a payout or a
+withdrawal without walking its contents and internal operations.
+Function: func (o *Operations) Receipt() *OperationReceipt {}
+*/
+type OperationReceipt struct {
+	// The hash of the operation.
+	Hash string
+
+	// The status of the operation. "applied" if every content applied, otherwise the first other status
+	// (e.g. "failed", "backtracked" or "skipped").
+	Status string
+
+	// The errors of any content or internal operation.
+	Errors []Error
+
+	// The sum of the fees of the contents.
+	Fee BigInt
+
+	// The tez (in mutez) burned for storage and allocations.
+	Burn BigInt
+
+	// The gas consumed, internal operations included.
+	ConsumedGas BigInt
+
+	// The storage paid for, in bytes, internal operations included.
+	PaidStorageSizeDiff BigInt
+
+	// The contracts originated, internal operations included.
+	OriginatedContracts []string
+
+	// The keys written to or removed from big maps, e.g. the balances of a token ledger. Allocations, copies,
+	// removals and writes to temporary big maps are left out; see BigMapUpdates for every change.
+	BigMapWrites []BigMapUpdate
+}
+
+/*
+Receipt Function
+Description: Sums the fees, burns, gas and storage of the operation and lists the contracts it originated and
+the big map keys it wrote. Failed, backtracked and skipped results burn nothing and originate nothing, but
+count towards the gas consumed.
+*/
+func (o *Operations) Receipt() *OperationReceipt {
+	receipt := &OperationReceipt{
+		Hash:                o.Hash,
+		Status:              APPLIEDSTATUS,
+		OriginatedContracts: []string{},
+		BigMapWrites:        []BigMapUpdate{},
+	}
+
+	// Protocols before Ithaca record burns as debits of contracts with no matching credit.
+	var burned, debited big.Int
+	var burnedUpdates bool
+	for _, content := range o.Contents {
+		receipt.Fee.Add(&receipt.Fee.Int, &content.Fee.Int)
+		if content.Metadata == nil || content.Metadata.OperationResult == nil {
+			continue
+		}
+
+		results := []OperationResult{*content.Metadata.OperationResult}
+		for _, internal := range content.Metadata.InternalOperationResults {
+			results = append(results, internal.Result)
+		}
+
+		for _, r := range results {
+			if r.Status != APPLIEDSTATUS && receipt.Status == APPLIEDSTATUS {
+				receipt.Status = r.Status
+			}
+			receipt.Errors = append(receipt.Errors, r.Errors...)
+			receipt.ConsumedGas.Add(&receipt.ConsumedGas.Int, r.consumedGas())
+
+			if r.Status != APPLIEDSTATUS {
+				continue
+			}
+
+			receipt.PaidStorageSizeDiff.Add(&receipt.PaidStorageSizeDiff.Int, &r.PaidStorageSizeDiff.Int)
+			receipt.OriginatedContracts = append(receipt.OriginatedContracts, r.OriginatedContracts...)
+
+			for _, update := range r.BalanceUpdates {
+				switch update.Kind {
+				case BalanceUpdateKindBurned:
+					burnedUpdates = true
+					if update.Category == BalanceUpdateCategoryStorageFees {
+						burned.Add(&burned, &update.Change.Int)
+					}
+				case BalanceUpdateKindContract:
+					debited.Sub(&debited, &update.Change.Int)
+				}
+			}
+
+			for _, update := range r.bigMapUpdates(o.Hash) {
+				if update.Action == BigMapActionUpdate && !isTemporaryBigMap(update.BigMap) {
+					receipt.BigMapWrites = append(receipt.BigMapWrites, update)
+				}
+			}
+		}
+	}
+
+	if burnedUpdates {
+		receipt.Burn.Set(&burned)
+	} else {
+		receipt.Burn.Set(&debited)
+	}
+
+	return receipt
+}
+
+// consumedGas returns the gas consumed by the result, rounding up its milligas if the node returned them.
+func (r *OperationResult) consumedGas() *big.Int {
+	if r.ConsumedMilligas.Sign() == 0 {
+		return &r.ConsumedGas.Int
+	}
+
+	gas := new(big.Int).Add(&r.ConsumedMilligas.Int, big.NewInt(999))
+	return gas.Quo(gas, big.NewInt(1000))
+}
+
+func isTemporaryBigMap(id string) bool {
+	return len(id) > 0 && id[0] == '-'
+}
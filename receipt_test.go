@@ -0,0 +1,138 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var mockReceiptOperationResp = []byte(`{
+	"hash": "opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A",
+	"contents": [
+		{
+			"kind": "reveal",
+			"source": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+			"fee": "374",
+			"metadata": {
+				"balance_updates": [],
+				"operation_result": {"status": "applied", "consumed_milligas": "1000"}
+			}
+		},
+		{
+			"kind": "transaction",
+			"source": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+			"fee": "2941",
+			"amount": "0",
+			"destination": "KT1TxqZ8QtKvLu3V3JH7Gx58n7Co8pgtpQU5",
+			"metadata": {
+				"balance_updates": [],
+				"operation_result": {
+					"status": "applied",
+					"balance_updates": [
+						{"kind": "contract", "contract": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "change": "-17000"},
+						{"kind": "burned", "category": "storage fees", "change": "17000"}
+					],
+					"consumed_milligas": "2500500",
+					"paid_storage_size_diff": "68",
+					"lazy_storage_diff": [
+						{"kind": "big_map", "id": "17", "diff": {"action": "update", "updates": [{"key_hash": "exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC", "key": {"int": "1"}, "value": {"int": "100"}}]}},
+						{"kind": "big_map", "id": "-1", "diff": {"action": "alloc", "updates": [{"key_hash": "exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC", "key": {"int": "1"}}], "key_type": {"prim": "nat"}, "value_type": {"prim": "nat"}}}
+					]
+				},
+				"internal_operation_results": [
+					{
+						"kind": "origination",
+						"source": "KT1TxqZ8QtKvLu3V3JH7Gx58n7Co8pgtpQU5",
+						"nonce": 0,
+						"result": {
+							"status": "applied",
+							"balance_updates": [
+								{"kind": "contract", "contract": "KT1TxqZ8QtKvLu3V3JH7Gx58n7Co8pgtpQU5", "change": "-64250"},
+								{"kind": "burned", "category": "storage fees", "change": "64250"}
+							],
+							"originated_contracts": ["KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"],
+							"consumed_milligas": "1400000",
+							"paid_storage_size_diff": "257"
+						}
+					}
+				]
+			}
+		}
+	]
+}`)
+
+func Test_Receipt(t *testing.T) {
+	var operation Operations
+	err := json.Unmarshal(mockReceiptOperationResp, &operation)
+	assert.Nil(t, err)
+
+	receipt := operation.Receipt()
+	assert.Equal(t, "opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A", receipt.Hash)
+	assert.Equal(t, APPLIEDSTATUS, receipt.Status)
+	assert.Empty(t, receipt.Errors)
+	assert.Equal(t, "3315", receipt.Fee.String())
+	assert.Equal(t, "81250", receipt.Burn.String())
+	assert.Equal(t, "3902", receipt.ConsumedGas.String())
+	assert.Equal(t, "325", receipt.PaidStorageSizeDiff.String())
+	assert.Equal(t, []string{"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"}, receipt.OriginatedContracts)
+
+	assert.Len(t, receipt.BigMapWrites, 1)
+	assert.Equal(t, "17", receipt.BigMapWrites[0].BigMap)
+	assert.Equal(t, "100", receipt.BigMapWrites[0].Value.Int.String())
+
+	t.Run("handles protocols before ithaca", func(t *testing.T) {
+		var operation Operations
+		err := json.Unmarshal([]byte(`{
+			"contents": [{
+				"kind": "transaction",
+				"fee": "1420",
+				"metadata": {
+					"balance_updates": [],
+					"operation_result": {
+						"status": "applied",
+						"balance_updates": [
+							{"kind": "contract", "contract": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "change": "-1000000"},
+							{"kind": "contract", "contract": "tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q", "change": "1000000"},
+							{"kind": "contract", "contract": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "change": "-257000"}
+						],
+						"consumed_gas": "10207",
+						"allocated_destination_contract": true
+					}
+				}
+			}]
+		}`), &operation)
+		assert.Nil(t, err)
+
+		receipt := operation.Receipt()
+		assert.Equal(t, "257000", receipt.Burn.String())
+		assert.Equal(t, "10207", receipt.ConsumedGas.String())
+	})
+
+	t.Run("handles failed operations", func(t *testing.T) {
+		var operation Operations
+		err := json.Unmarshal([]byte(`{
+			"contents": [{
+				"kind": "transaction",
+				"fee": "1420",
+				"metadata": {
+					"balance_updates": [],
+					"operation_result": {
+						"status": "failed",
+						"consumed_milligas": "1500",
+						"errors": [{"kind": "temporary", "id": "proto.017-PtNairob.michelson_v1.script_rejected"}]
+					}
+				}
+			}]
+		}`), &operation)
+		assert.Nil(t, err)
+
+		receipt := operation.Receipt()
+		assert.Equal(t, FAILEDSTATUS, receipt.Status)
+		assert.Len(t, receipt.Errors, 1)
+		assert.Equal(t, "1420", receipt.Fee.String())
+		assert.Equal(t, "0", receipt.Burn.String())
+		assert.Equal(t, "2", receipt.ConsumedGas.String())
+		assert.Empty(t, receipt.OriginatedContracts)
+	})
+}
@@ -0,0 +1,136 @@
+package gotezos
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+// DefaultRevealGasLimit is the gas limit of the reveals PrependReveal prepends, enough to reveal an Ed25519,
+// Secp256k1 or P256 key.
+const DefaultRevealGasLimit = 1000
+
+/*
+IsRevealed Function
+Description: Returns whether the public key of an implicit account is revealed, i.e. whether the account can
+sign manager operations without a reveal.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+	address:
+		The implicit account (tz1, tz2, tz3 or tz4).
+*/
+func (t *GoTezos) IsRevealed(blockID BlockID, address string) (bool, error) {
+	key, err := t.ManagerKey(blockID, address)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to check if '%s' is revealed", address)
+	}
+
+	return key != nil, nil
+}
+
+/*
+PrependReveal Function
+Description: Prepends a reveal of the source of manager operation contents if it is not revealed yet, so that the
+first operation of an account does not fail. The reveal takes the counter of the first content, the counters
+set on the contents are shifted by one. The reveal has DefaultRevealGasLimit as gas limit and the minimal fee
+bakers accept for it, so the fees of the contents do not need to account for it. Contents that already start
+with a reveal, or whose source is revealed, are returned unchanged.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) at which the source is checked to be revealed.
+	publicKey:
+		The public key of the source (edpk, sppk or p2pk).
+	contents:
+		The contents of the operation. Their source is required.
+*/
+func (t *GoTezos) PrependReveal(blockID BlockID, publicKey string, contents ...Contents) ([]Contents, error) {
+	if len(contents) == 0 {
+		return nil, errors.New("failed to prepend reveal: no contents")
+	}
+
+	source := contents[0].Source
+	if source == "" {
+		return nil, errors.New("failed to prepend reveal: no source")
+	}
+
+	if contents[0].Kind == REVEALOP {
+		return contents, nil
+	}
+
+	address, err := PublicKeyAddress(publicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to prepend reveal")
+	}
+	if address != source {
+		return nil, errors.Errorf("failed to prepend reveal: '%s' is not the public key of '%s'", publicKey, source)
+	}
+
+	revealed, err := t.IsRevealed(blockID, source)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to prepend reveal")
+	}
+	if revealed {
+		return contents, nil
+	}
+
+	reveal := Contents{Kind: REVEALOP, Source: source, Phk: publicKey}
+	reveal.Counter.Set(&contents[0].Counter.Int)
+	reveal.GasLimit.SetInt64(DefaultRevealGasLimit)
+	reveal.Fee.SetInt64(revealFee(reveal))
+
+	operation := []Contents{reveal}
+	for _, c := range contents {
+		if c.Counter.Sign() > 0 {
+			c.Counter.Int = *new(big.Int).Add(&c.Counter.Int, big.NewInt(1))
+		}
+		operation = append(operation, c)
+	}
+
+	return operation, nil
+}
+
+// revealFee returns the minimal fee of a reveal heading an operation, which pays for the branch and the
+// signature of the operation.
+func revealFee(reveal Contents) int64 {
+	key, _ := publicKeyToBytes(reveal.Phk)
+
+	var fee int64
+	// The size of the fee depends on the fee, a second pass settles it.
+	for i := 0; i < 2; i++ {
+		reveal.Fee.SetInt64(fee)
+		size := 1 + 21 + len(key) + 32 + 64
+		for _, n := range []BigInt{reveal.Fee, reveal.Counter, reveal.GasLimit, reveal.StorageLimit} {
+			size += len(bigNumberToZarith(n)) / 2
+		}
+		fee = minimalFee + minimalFeePerGas*reveal.GasLimit.Int64()/100 + minimalFeePerByte*int64(size) + 1
+	}
+
+	return fee
+}
+
+/*
+PublicKeyAddress Function
+Description: Returns the address of the implicit account of a public key, e.g. the tz1 address of an edpk key.
+
+Parameters:
+	publicKey:
+		The public key.
+*/
+func PublicKeyAddress(publicKey string) (string, error) {
+	key, err := publicKeyToBytes(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := blake2b.New(20, nil)
+	if err != nil {
+		return "", err
+	}
+	hash.Write(key[1:])
+
+	return bytesToKeyHash(append(key[:1:1], hash.Sum(nil)...))
+}
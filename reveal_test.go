@@ -0,0 +1,149 @@
+package gotezos
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PrependReveal(t *testing.T) {
+	source := "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"
+	key := "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G"
+
+	transfer := func(counter int64) Contents {
+		c := Contents{Kind: TRANSACTIONOP, Source: source, Destination: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}
+		c.Counter.SetInt64(counter)
+		return c
+	}
+
+	type want struct {
+		err         bool
+		containsErr string
+		kinds       []string
+		counters    []int64
+	}
+
+	cases := []struct {
+		name        string
+		inputHanler http.Handler
+		key         string
+		contents    []Contents
+		want
+	}{
+		{
+			"prepends a reveal",
+			gtGoldenHTTPMock(managerKeyHandlerMock([]byte(`null`), blankHandler)),
+			key,
+			[]Contents{transfer(10), transfer(11)},
+			want{false, "", []string{REVEALOP, TRANSACTIONOP, TRANSACTIONOP}, []int64{10, 11, 12}},
+		},
+		{
+			"leaves unset counters",
+			gtGoldenHTTPMock(managerKeyHandlerMock([]byte(`null`), blankHandler)),
+			key,
+			[]Contents{transfer(0)},
+			want{false, "", []string{REVEALOP, TRANSACTIONOP}, []int64{0, 0}},
+		},
+		{
+			"does not prepend a reveal when revealed",
+			gtGoldenHTTPMock(managerKeyHandlerMock([]byte(`"`+key+`"`), blankHandler)),
+			key,
+			[]Contents{transfer(10)},
+			want{false, "", []string{TRANSACTIONOP}, []int64{10}},
+		},
+		{
+			"does not prepend a reveal to a reveal",
+			gtGoldenHTTPMock(blankHandler),
+			key,
+			[]Contents{{Kind: REVEALOP, Source: source, Phk: key}, transfer(11)},
+			want{false, "", []string{REVEALOP, TRANSACTIONOP}, []int64{0, 11}},
+		},
+		{
+			"handles failure to get manager key",
+			gtGoldenHTTPMock(managerKeyHandlerMock(mockRPCErrorResp, blankHandler)),
+			key,
+			[]Contents{transfer(10)},
+			want{true, "failed to check if 'tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK' is revealed", nil, nil},
+		},
+		{
+			"handles key of another account",
+			gtGoldenHTTPMock(blankHandler),
+			"edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav",
+			[]Contents{transfer(10)},
+			want{true, "'edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav' is not the public key of 'tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK'", nil, nil},
+		},
+		{
+			"handles invalid key",
+			gtGoldenHTTPMock(blankHandler),
+			"edpk",
+			[]Contents{transfer(10)},
+			want{true, "invalid public key", nil, nil},
+		},
+		{
+			"handles missing source",
+			gtGoldenHTTPMock(blankHandler),
+			key,
+			[]Contents{{Kind: TRANSACTIONOP}},
+			want{true, "no source", nil, nil},
+		},
+		{
+			"handles no contents",
+			gtGoldenHTTPMock(blankHandler),
+			key,
+			nil,
+			want{true, "no contents", nil, nil},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.inputHanler)
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			operation, err := gt.PrependReveal(BlockIDHead{}, tt.key, tt.contents...)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+
+			var kinds []string
+			var counters []int64
+			for _, c := range operation {
+				kinds = append(kinds, c.Kind)
+				counters = append(counters, c.Counter.Int64())
+			}
+			assert.Equal(t, tt.want.kinds, kinds)
+			assert.Equal(t, tt.want.counters, counters)
+			// The contents given are left as they are.
+			if len(tt.contents) > 1 {
+				assert.Equal(t, int64(11), tt.contents[1].Counter.Int64())
+			}
+		})
+	}
+}
+
+func Test_RevealFee(t *testing.T) {
+	reveal := Contents{Kind: REVEALOP, Source: "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", Phk: "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G"}
+	reveal.Counter.SetInt64(10)
+	reveal.GasLimit.SetInt64(DefaultRevealGasLimit)
+
+	// 100 + 1000 gas * 0.1 + (61 bytes of contents + 96 bytes of branch and signature) + 1
+	assert.Equal(t, int64(358), revealFee(reveal))
+
+	gt := &GoTezos{}
+	reveal.Fee.SetInt64(revealFee(reveal))
+	forge, err := gt.forgeRevealOperation(reveal)
+	assert.Nil(t, err)
+	assert.Equal(t, 61, len(forge)/2)
+}
+
+func Test_PublicKeyAddress(t *testing.T) {
+	address, err := PublicKeyAddress("edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G")
+	assert.Nil(t, err)
+	assert.Equal(t, "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", address)
+
+	_, err = PublicKeyAddress("edpk")
+	assert.NotNil(t, err)
+}
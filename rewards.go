@@ -0,0 +1,133 @@
+package gotezos
+
+import "math/big"
+
+/*
+Rewards -
+Description: The rewards a delegate earned and lost, in mutez, as recorded by balance updates (Ithaca and later),
+rather than computed from the constants of the protocol. Rewards credited to the stake of the stakers of the
+delegate are included.
+*/
+type Rewards struct {
+	// Baking rewards and bonuses.
+	Baking BigInt
+	// Attesting rewards (endorsing rewards before Oxford), credited at the end of a cycle.
+	Attesting BigInt
+	// The fees of the operations of the blocks baked.
+	Fees BigInt
+	// Seed nonce and VDF revelation rewards.
+	Revelation BigInt
+	// Rewards for denouncing double signings.
+	Denunciation BigInt
+	// The attesting rewards lost for missing too many slots, burned instead of credited.
+	LostAttesting BigInt
+	// The attesting rewards lost for not revealing a seed nonce, burned instead of credited.
+	LostRevelation BigInt
+	// The deposits burned for double signing.
+	Slashed BigInt
+}
+
+/*
+Earned Function
+Description: Returns the rewards credited to the delegate: its baking, attesting, revelation and denunciation
+rewards and fees.
+*/
+func (r *Rewards) Earned() *BigInt {
+	earned := &BigInt{}
+	for _, reward := range []*BigInt{&r.Baking, &r.Attesting, &r.Fees, &r.Revelation, &r.Denunciation} {
+		earned.Add(&earned.Int, &reward.Int)
+	}
+
+	return earned
+}
+
+/*
+Net Function
+Description: Returns the rewards credited to the delegate less its slashed deposits, e.g. the amount to split
+between its delegators. Lost rewards were never credited and are not subtracted.
+*/
+func (r *Rewards) Net() *BigInt {
+	net := r.Earned()
+	net.Sub(&net.Int, &r.Slashed.Int)
+
+	return net
+}
+
+// rewardSources are the categories of the minted and accumulated tez credited as rewards, by reward.
+var rewardSources = map[string]func(*Rewards) *BigInt{
+	BalanceUpdateCategoryBakingRewards:                func(r *Rewards) *BigInt { return &r.Baking },
+	BalanceUpdateCategoryBakingBonuses:                func(r *Rewards) *BigInt { return &r.Baking },
+	BalanceUpdateCategoryEndorsingRewards:             func(r *Rewards) *BigInt { return &r.Attesting },
+	BalanceUpdateCategoryAttestingRewards:             func(r *Rewards) *BigInt { return &r.Attesting },
+	BalanceUpdateCategoryBlockFees:                    func(r *Rewards) *BigInt { return &r.Fees },
+	BalanceUpdateCategoryNonceRevelationRewards:       func(r *Rewards) *BigInt { return &r.Revelation },
+	BalanceUpdateCategoryVDFRevelationRewards:         func(r *Rewards) *BigInt { return &r.Revelation },
+	BalanceUpdateCategoryDoubleSigningEvidenceRewards: func(r *Rewards) *BigInt { return &r.Denunciation },
+}
+
+/*
+RewardChanges Function
+Description: Sums the rewards, lost rewards and slashed deposits in balance updates by delegate, keyed by the
+address of the delegate. A reward is a debit of minted tez (or of the fees of a block) followed by the credits
+of the delegate and its stakers. Lost rewards are burned for missed slots or a missed nonce revelation, and
+slashed deposits are debits of deposits followed by a burned punishment.
+
+Parameters:
+	balanceUpdates:
+		The balance updates to sum, in the order they were applied, e.g. those of the blocks of a cycle (see
+		Block.BalanceUpdates). Rewards are only counted from Ithaca on.
+*/
+func RewardChanges(balanceUpdates []BalanceUpdates) map[string]*Rewards {
+	changes := map[string]*Rewards{}
+	rewards := func(delegate string) *Rewards {
+		if _, ok := changes[delegate]; !ok {
+			changes[delegate] = &Rewards{}
+		}
+		return changes[delegate]
+	}
+	add := func(sum *BigInt, change *BigInt) {
+		var amount big.Int
+		sum.Add(&sum.Int, amount.Abs(&change.Int))
+	}
+
+	// The reward the credits are paid from, nil if they are not rewards (e.g. a transfer).
+	var source func(*Rewards) *BigInt
+	for i, update := range balanceUpdates {
+		switch {
+		case update.Kind == BalanceUpdateKindMinted || update.Kind == BalanceUpdateKindAccumulator:
+			source = nil
+			if update.Change.Sign() < 0 {
+				source = rewardSources[update.Category]
+			}
+		case update.Kind == BalanceUpdateKindBurned:
+			source = nil
+			switch update.Category {
+			case BalanceUpdateCategoryLostEndorsingRewards, BalanceUpdateCategoryLostAttestingRewards:
+				if update.Participation || !update.Revelation {
+					add(&rewards(update.Delegate).LostAttesting, &balanceUpdates[i].Change)
+				} else {
+					add(&rewards(update.Delegate).LostRevelation, &balanceUpdates[i].Change)
+				}
+			case BalanceUpdateCategoryPunishments, BalanceUpdateCategoryDoubleSigningPunishments:
+				if i > 0 && balanceUpdates[i-1].owner() != "" {
+					add(&rewards(balanceUpdates[i-1].delegate()).Slashed, &balanceUpdates[i].Change)
+				}
+			}
+		case update.Change.Sign() < 0:
+			source = nil
+		case source != nil && update.owner() != "":
+			add(source(rewards(update.delegate())), &balanceUpdates[i].Change)
+		}
+	}
+
+	return changes
+}
+
+// delegate returns the delegate of an update of a delegate or of the stake of its stakers, its owner otherwise.
+func (b BalanceUpdates) delegate() string {
+	if b.Staker != nil && b.Staker.Delegate != "" {
+		return b.Staker.Delegate
+	}
+
+	return b.owner()
+}
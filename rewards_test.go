@@ -0,0 +1,65 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RewardChanges(t *testing.T) {
+	baker := "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc"
+	other := "tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH"
+
+	// The balance updates of a block baked by baker and of the end of a cycle, as returned by a Paris node.
+	var balanceUpdates []BalanceUpdates
+	err := json.Unmarshal([]byte(`[
+		{"kind":"accumulator","category":"block fees","change":"-3000","origin":"block"},
+		{"kind":"contract","contract":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","change":"3000","origin":"block"},
+		{"kind":"minted","category":"baking rewards","change":"-5000000","origin":"block"},
+		{"kind":"contract","contract":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","change":"4000000","origin":"block"},
+		{"kind":"freezer","category":"deposits","staker":{"baker_own_stake":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc"},"change":"600000","origin":"block"},
+		{"kind":"freezer","category":"deposits","staker":{"delegate":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc"},"change":"400000","origin":"block"},
+		{"kind":"minted","category":"baking bonuses","change":"-1000","origin":"block"},
+		{"kind":"contract","contract":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","change":"1000","origin":"block"},
+		{"kind":"contract","contract":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","change":"-1000","origin":"block"},
+		{"kind":"accumulator","category":"block fees","change":"1000","origin":"block"},
+		{"kind":"contract","contract":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","change":"-100","origin":"block"},
+		{"kind":"contract","contract":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","change":"100","origin":"block"},
+		{"kind":"minted","category":"nonce revelation rewards","change":"-250","origin":"block"},
+		{"kind":"contract","contract":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","change":"250","origin":"block"},
+		{"kind":"minted","category":"attesting rewards","change":"-20000","origin":"block"},
+		{"kind":"contract","contract":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","change":"20000","origin":"block"},
+		{"kind":"minted","category":"attesting rewards","change":"-7000","origin":"block"},
+		{"kind":"burned","category":"lost attesting rewards","delegate":"tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH","participation":true,"revelation":false,"change":"7000","origin":"block"},
+		{"kind":"minted","category":"attesting rewards","change":"-3000","origin":"block"},
+		{"kind":"burned","category":"lost attesting rewards","delegate":"tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH","participation":false,"revelation":true,"change":"3000","origin":"block"},
+		{"kind":"freezer","category":"deposits","staker":{"baker_own_stake":"tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH"},"change":"-50000","origin":"delayed_operation"},
+		{"kind":"burned","category":"double signing punishments","change":"50000","origin":"delayed_operation"},
+		{"kind":"freezer","category":"deposits","staker":{"contract":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","delegate":"tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH"},"change":"-5000","origin":"delayed_operation"},
+		{"kind":"burned","category":"double signing punishments","change":"5000","origin":"delayed_operation"}
+	]`), &balanceUpdates)
+	assert.Nil(t, err)
+
+	changes := RewardChanges(balanceUpdates)
+	assert.Len(t, changes, 2)
+
+	rewards := changes[baker]
+	assert.Equal(t, 0, rewards.Baking.Cmp(big.NewInt(5001000)))
+	assert.Equal(t, 0, rewards.Attesting.Cmp(big.NewInt(20000)))
+	assert.Equal(t, 0, rewards.Fees.Cmp(big.NewInt(3000)))
+	assert.Equal(t, 0, rewards.Revelation.Cmp(big.NewInt(250)))
+	assert.Equal(t, 0, rewards.Slashed.Sign())
+	assert.Equal(t, "5024250", rewards.Earned().String())
+	assert.Equal(t, "5024250", rewards.Net().String())
+
+	rewards = changes[other]
+	assert.Equal(t, 0, rewards.Earned().Sign())
+	assert.Equal(t, 0, rewards.LostAttesting.Cmp(big.NewInt(7000)))
+	assert.Equal(t, 0, rewards.LostRevelation.Cmp(big.NewInt(3000)))
+	assert.Equal(t, 0, rewards.Slashed.Cmp(big.NewInt(55000)))
+	assert.Equal(t, "-55000", rewards.Net().String())
+
+	assert.Empty(t, RewardChanges(nil))
+}
@@ -0,0 +1,210 @@
+package gotezos
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+const (
+	// UpcomingRightBaking is the right to bake a block.
+	UpcomingRightBaking = "baking"
+	// UpcomingRightEndorsing is the right to endorse a block.
+	UpcomingRightEndorsing = "endorsing"
+)
+
+/*
+UpcomingRightsInput -
+Description: The input for the upcoming rights of a delegate.
+Function: func (t *GoTezos) UpcomingRights(input *UpcomingRightsInput) ([]UpcomingRight, error) {}
+*/
+type UpcomingRightsInput struct {
+	// The delegate of which you want the rights.
+	// Required.
+	Delegate string `validate:"required"`
+
+	// The number of cycles to return the rights of, starting with the current cycle. Defaults to 1. Rights are
+	// only known for the preserved cycles after the current one.
+	Cycles int
+
+	// The max priority of the baking rights to return, the max round on Tenderbake. Defaults to the node's
+	// default (64 before Tenderbake).
+	MaxPriority *int
+}
+
+/*
+UpcomingRight -
+Description: A baking or endorsing right of a delegate at a future level.
+*/
+type UpcomingRight struct {
+	// The kind of the right, see the UpcomingRight constants.
+	Kind  string
+	Level int
+	Cycle int
+	// The priority of a baking right before Tenderbake.
+	Priority int
+	// The round of a baking right on Tenderbake.
+	Round int
+	// The slots of an endorsing right before Tenderbake.
+	Slots []int
+	// The endorsing power of an endorsing right on Tenderbake.
+	EndorsingPower int
+	// When the level is expected to be baked, estimated from the head and the block delays of the constants,
+	// assuming every block from the head is baked at priority (or round) zero.
+	EstimatedTime time.Time
+}
+
+/*
+UpcomingRights Function
+Description: Returns the baking and endorsing rights of a delegate for the levels after the head, over the
+next cycles, with the time each is expected at. Rights are sorted by level, baking rights first.
+
+Parameters:
+	input:
+		The delegate and the number of cycles. Delegate is required.
+*/
+func (t *GoTezos) UpcomingRights(input *UpcomingRightsInput) ([]UpcomingRight, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	cycles := input.Cycles
+	if cycles <= 0 {
+		cycles = 1
+	}
+
+	head, err := t.Head()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get upcoming rights")
+	}
+
+	constants := t.cachedConstants()
+	if constants == nil {
+		constants, err = t.Constants(BlockIDHash(head.Hash))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get upcoming rights")
+		}
+	}
+
+	if cycles-1 > constants.PreservedCycles {
+		return nil, errors.Errorf("failed to get upcoming rights: rights are only known for %d cycles after the current cycle", constants.PreservedCycles)
+	}
+
+	delay, err := blockDelays(constants)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get upcoming rights")
+	}
+
+	estimate := func(level, priority int) time.Time {
+		return head.Header.Timestamp.Time.Add(time.Duration(level-head.Header.Level)*delay(0) + delay(priority) - delay(0))
+	}
+
+	tenderbake := t.Protocol().Tenderbake
+	rights := []UpcomingRight{}
+	for cycle := head.Metadata.Level.Cycle; cycle < head.Metadata.Level.Cycle+cycles; cycle++ {
+		baking, err := t.BakingRights(&BakingRightsInput{
+			BlockID:     BlockIDHash(head.Hash),
+			Cycle:       &cycle,
+			Delegate:    &input.Delegate,
+			MaxPriority: input.MaxPriority,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get upcoming rights of cycle %d", cycle)
+		}
+
+		for _, right := range *baking {
+			if right.Level <= head.Header.Level || right.Delegate != input.Delegate {
+				continue
+			}
+
+			upcoming := UpcomingRight{
+				Kind:          UpcomingRightBaking,
+				Level:         right.Level,
+				Cycle:         cycle,
+				Priority:      right.Priority,
+				EstimatedTime: estimate(right.Level, right.Priority),
+			}
+			if tenderbake {
+				upcoming.Priority, upcoming.Round = 0, right.Round
+				upcoming.EstimatedTime = estimate(right.Level, right.Round)
+			}
+			rights = append(rights, upcoming)
+		}
+
+		endorsing, err := t.EndorsingRights(&EndorsingRightsInput{
+			BlockID:  BlockIDHash(head.Hash),
+			Cycle:    &cycle,
+			Delegate: &input.Delegate,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get upcoming rights of cycle %d", cycle)
+		}
+
+		for _, right := range *endorsing {
+			if right.Level <= head.Header.Level || right.Delegate != input.Delegate {
+				continue
+			}
+
+			rights = append(rights, UpcomingRight{
+				Kind:           UpcomingRightEndorsing,
+				Level:          right.Level,
+				Cycle:          cycle,
+				Slots:          right.Slots,
+				EndorsingPower: right.EndorsingPower,
+				EstimatedTime:  estimate(right.Level, 0),
+			})
+		}
+	}
+
+	sort.SliceStable(rights, func(i, j int) bool {
+		return rights[i].Level < rights[j].Level
+	})
+
+	return rights, nil
+}
+
+// blockDelays returns the time it takes to bake a block at a priority (or round) from the previous block.
+func blockDelays(constants *Constants) (func(priority int) time.Duration, error) {
+	if constants.Tenderbake != nil {
+		minimal, err := strconv.Atoi(constants.Tenderbake.MinimalBlockDelay)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid minimal_block_delay")
+		}
+
+		increment, err := strconv.Atoi(constants.Tenderbake.DelayIncrementPerRound)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid delay_increment_per_round")
+		}
+
+		// Round 0 starts minimal after the previous block, round r once the rounds before it, each lasting
+		// minimal + i * increment, have passed.
+		return func(round int) time.Duration {
+			return time.Duration(minimal*(round+1)+increment*round*(round-1)/2) * time.Second
+		}, nil
+	}
+
+	var delays []int
+	for _, d := range constants.TimeBetweenBlocks {
+		delay, err := strconv.Atoi(d)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid time_between_blocks")
+		}
+		delays = append(delays, delay)
+	}
+
+	if len(delays) == 0 {
+		return nil, errors.New("invalid time_between_blocks")
+	}
+
+	return func(priority int) time.Duration {
+		delay := delays[0]
+		if len(delays) > 1 {
+			delay += priority * delays[1]
+		}
+		return time.Duration(delay) * time.Second
+	}, nil
+}
@@ -0,0 +1,152 @@
+package gotezos
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UpcomingRights(t *testing.T) {
+	delegate := "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"
+	other := "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+	now := time.Date(2021, 12, 24, 10, 0, 0, 0, time.UTC)
+
+	head := mockChainBlock(1000, "BL1000")
+	head.Header.Timestamp.Time = now
+	head.Metadata.Level.Cycle = 3
+
+	baking := map[string]string{
+		"3": fmt.Sprintf(`[{"level":1000,"delegate":"%[1]s","priority":0},{"level":1001,"delegate":"%[2]s","priority":0},{"level":1002,"delegate":"%[1]s","priority":1}]`, delegate, other),
+		"4": fmt.Sprintf(`[{"level":1010,"delegate":"%s","priority":0}]`, delegate),
+	}
+	endorsing := map[string]string{
+		"3": fmt.Sprintf(`[{"level":1001,"delegate":"%s","slots":[4,9]}]`, delegate),
+	}
+
+	type want struct {
+		err         bool
+		containsErr string
+		rights      []UpcomingRight
+	}
+
+	cases := []struct {
+		name    string
+		handler http.Handler
+		input   UpcomingRightsInput
+		want
+	}{
+		{
+			"returns invalid input",
+			rightsHandlerMock("cycle", baking, endorsing, blankHandler),
+			UpcomingRightsInput{},
+			want{true, "invalid input", nil},
+		},
+		{
+			"returns unknown cycles",
+			rightsHandlerMock("cycle", baking, endorsing, blankHandler),
+			UpcomingRightsInput{Delegate: delegate, Cycles: 7},
+			want{true, "rights are only known for 5 cycles", nil},
+		},
+		{
+			"handles failure to get rights",
+			rightsHandlerMock("cycle", nil, nil, blankHandler),
+			UpcomingRightsInput{Delegate: delegate},
+			want{true, "failed to get upcoming rights of cycle 3", nil},
+		},
+		{
+			"is successful",
+			rightsHandlerMock("cycle", baking, endorsing, blankHandler),
+			UpcomingRightsInput{Delegate: delegate, Cycles: 2},
+			want{
+				false,
+				"",
+				[]UpcomingRight{
+					{Kind: UpcomingRightEndorsing, Level: 1001, Cycle: 3, Slots: []int{4, 9}, EstimatedTime: now.Add(60 * time.Second)},
+					{Kind: UpcomingRightBaking, Level: 1002, Cycle: 3, Priority: 1, EstimatedTime: now.Add(160 * time.Second)},
+					{Kind: UpcomingRightBaking, Level: 1010, Cycle: 4, EstimatedTime: now.Add(600 * time.Second)},
+				},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := &chainHandlerMock{heads: []int{1000}, chains: []map[int]Block{{1000: head}}}
+			server := httptest.NewServer(gtGoldenHTTPMock(chain.handler(tt.handler)))
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			rights, err := gt.UpcomingRights(&tt.input)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			assert.Equal(t, tt.want.rights, rights)
+		})
+	}
+}
+
+func Test_UpcomingRightsTenderbake(t *testing.T) {
+	delegate := "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"
+	now := time.Date(2022, 4, 1, 10, 0, 0, 0, time.UTC)
+
+	head := mockChainBlock(1000, "BL1000")
+	head.Header.Timestamp.Time = now
+	head.Metadata.Level.Cycle = 3
+
+	baking := map[string]string{
+		"3": fmt.Sprintf(`[{"level":1002,"delegate":"%s","round":2}]`, delegate),
+	}
+	endorsing := map[string]string{
+		"3": fmt.Sprintf(`[{"level":1001,"delegates":[{"delegate":"%s","first_slot":4,"endorsing_power":12}]}]`, delegate),
+	}
+
+	var queries []string
+	rights := rightsHandlerMock("cycle", baking, endorsing, blankHandler)
+	chain := &chainHandlerMock{heads: []int{1000}, chains: []map[int]Block{{1000: head}}}
+	server := httptest.NewServer(gtGoldenHTTPMock(chain.handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if regBakingRights.MatchString(r.URL.Path) {
+			queries = append(queries, r.URL.Query().Get("max_round"))
+		}
+		rights.ServeHTTP(w, r)
+	}))))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	constants := *expectedConstants(t)
+	constants.Tenderbake = &TenderbakeConstants{MinimalBlockDelay: "15", DelayIncrementPerRound: "8"}
+	gt.SetConstants(constants)
+	gt.SetProtocol("Psithaca2MLRFYargivpo7YvUr7wUDqyxrdhC5CQq78mRvimz6A")
+
+	maxRound := 2
+	upcoming, err := gt.UpcomingRights(&UpcomingRightsInput{Delegate: delegate, MaxPriority: &maxRound})
+	assert.Nil(t, err)
+	assert.Equal(t, []UpcomingRight{
+		{Kind: UpcomingRightEndorsing, Level: 1001, Cycle: 3, EndorsingPower: 12, EstimatedTime: now.Add(15 * time.Second)},
+		{Kind: UpcomingRightBaking, Level: 1002, Cycle: 3, Round: 2, EstimatedTime: now.Add((15 + 53) * time.Second)},
+	}, upcoming)
+	assert.Equal(t, []string{"2"}, queries)
+}
+
+func Test_blockDelays(t *testing.T) {
+	emmy := &Constants{TimeBetweenBlocks: []string{"60", "40"}}
+	delay, err := blockDelays(emmy)
+	assert.Nil(t, err)
+	assert.Equal(t, 60*time.Second, delay(0))
+	assert.Equal(t, 140*time.Second, delay(2))
+
+	tenderbake := &Constants{Tenderbake: &TenderbakeConstants{MinimalBlockDelay: "15", DelayIncrementPerRound: "8"}}
+	delay, err = blockDelays(tenderbake)
+	assert.Nil(t, err)
+	assert.Equal(t, 15*time.Second, delay(0))
+	assert.Equal(t, 30*time.Second, delay(1))
+	assert.Equal(t, 53*time.Second, delay(2))
+
+	_, err = blockDelays(&Constants{})
+	checkErr(t, true, "invalid time_between_blocks", err)
+}
@@ -0,0 +1,225 @@
+package gotezos
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+/*
+RPCOption -
+Description: A query parameter of an RPC. Build it with the With functions of the parameter (e.g. WithCycle),
+which check its value, and pass it in the Options of the input of the RPC (or to Block), which checks the RPC
+supports it before querying the node.
+*/
+type RPCOption struct {
+	Key   string
+	Value string
+
+	// Why the value is invalid, returned by the RPC instead of querying the node.
+	err error
+}
+
+/*
+WithLevel Function
+Description: Restricts the BakingRights and EndorsingRights RPCs to a level.
+
+Parameters:
+	level:
+		The level, not negative.
+*/
+func WithLevel(level int) RPCOption {
+	return intRPCOption("level", level, 0)
+}
+
+/*
+WithCycle Function
+Description: Restricts the BakingRights and EndorsingRights RPCs to the levels of a cycle.
+
+Parameters:
+	cycle:
+		The cycle, not negative.
+*/
+func WithCycle(cycle int) RPCOption {
+	return intRPCOption("cycle", cycle, 0)
+}
+
+/*
+WithDelegate Function
+Description: Restricts the BakingRights and EndorsingRights RPCs to the rights of a delegate. Repeat it to
+query the rights of several delegates.
+
+Parameters:
+	delegate:
+		The tz1, tz2 or tz3 address of the delegate.
+*/
+func WithDelegate(delegate string) RPCOption {
+	return keyHashRPCOption("delegate", delegate)
+}
+
+/*
+WithConsensusKey Function
+Description: Restricts the BakingRights and EndorsingRights RPCs to the rights of the delegates with a
+consensus key (Lima and later), see WalletClient.UpdateConsensusKey.
+
+Parameters:
+	consensusKey:
+		The tz1, tz2 or tz3 address of the consensus key.
+*/
+func WithConsensusKey(consensusKey string) RPCOption {
+	return keyHashRPCOption("consensus_key", consensusKey)
+}
+
+/*
+WithMaxRound Function
+Description: Restricts the BakingRights RPC to the rounds up to a max (Ithaca and later, see
+BakingRightsInput.MaxPriority before).
+
+Parameters:
+	round:
+		The max round, not negative.
+*/
+func WithMaxRound(round int) RPCOption {
+	return intRPCOption("max_round", round, 0)
+}
+
+/*
+WithAll Function
+Description: Makes the BakingRights RPC return every round of each delegate at each level, instead of the
+first one only.
+*/
+func WithAll() RPCOption {
+	return RPCOption{Key: "all", Value: "true"}
+}
+
+/*
+WithLength Function
+Description: Makes the Blocks RPC return the predecessors of each head, see BlocksInput.Length.
+
+Parameters:
+	length:
+		The number of predecessors per head, positive.
+*/
+func WithLength(length int) RPCOption {
+	return intRPCOption("length", length, 1)
+}
+
+/*
+WithHead Function
+Description: Makes the Blocks RPC return the predecessors of a block instead of the current heads, see
+BlocksInput.Head. Repeat it to query several blocks.
+
+Parameters:
+	hash:
+		The hash of the block.
+*/
+func WithHead(hash string) RPCOption {
+	opt := RPCOption{Key: "head", Value: hash}
+	if _, err := b58cdecodeChecked(hash, prefix_branch, 32); err != nil {
+		opt.err = errors.Wrapf(err, "invalid head '%s'", hash)
+	}
+
+	return opt
+}
+
+/*
+WithMinDate Function
+Description: Makes the Blocks RPC filter out the heads older than a date, see BlocksInput.MinDate.
+
+Parameters:
+	date:
+		The date, not before the epoch.
+*/
+func WithMinDate(date time.Time) RPCOption {
+	opt := RPCOption{Key: "min_date", Value: strconv.FormatInt(date.Unix(), 10)}
+	if date.Unix() < 0 {
+		opt.err = errors.Errorf("invalid min_date '%s': before the epoch", date)
+	}
+
+	return opt
+}
+
+/*
+WithForceMetadata Function
+Description: Makes the Block RPC return the metadata of the block even if the node considers it too large to be
+computed by default.
+
+Parameters:
+	force:
+		Whether to force the metadata.
+*/
+func WithForceMetadata(force bool) RPCOption {
+	return RPCOption{Key: "force_metadata", Value: strconv.FormatBool(force)}
+}
+
+/*
+WithMetadata Function
+Description: Sets whether the Block RPC returns the metadata of the block (e.g. the results of its operations).
+Blocks are smaller without, see WithMaxResponseSize.
+
+Parameters:
+	metadata:
+		"always" or "never".
+*/
+func WithMetadata(metadata string) RPCOption {
+	opt := RPCOption{Key: "metadata", Value: metadata}
+	if metadata != "always" && metadata != "never" {
+		opt.err = errors.Errorf("invalid metadata '%s': expected always or never", metadata)
+	}
+
+	return opt
+}
+
+/*
+WithMinimalStake Function
+Description: Restricts the Delegates RPC to the delegates with at least the minimal stake, those getting rights.
+*/
+func WithMinimalStake() RPCOption {
+	return RPCOption{Key: "with_minimal_stake", Value: "true"}
+}
+
+/*
+WithoutMinimalStake Function
+Description: Restricts the Delegates RPC to the delegates without the minimal stake.
+*/
+func WithoutMinimalStake() RPCOption {
+	return RPCOption{Key: "without_minimal_stake", Value: "true"}
+}
+
+func intRPCOption(key string, value, min int) RPCOption {
+	opt := RPCOption{Key: key, Value: strconv.Itoa(value)}
+	if value < min {
+		opt.err = errors.Errorf("invalid %s '%d': less than %d", key, value, min)
+	}
+
+	return opt
+}
+
+func keyHashRPCOption(key, keyHash string) RPCOption {
+	opt := RPCOption{Key: key, Value: keyHash}
+	if _, err := keyHashToBytes(keyHash); err != nil {
+		opt.err = errors.Wrapf(err, "invalid %s", key)
+	}
+
+	return opt
+}
+
+// checkRPCOptions returns why the options are invalid or not supported by an RPC, given its supported keys.
+func checkRPCOptions(opts []RPCOption, keys ...string) error {
+	for _, opt := range opts {
+		if opt.err != nil {
+			return opt.err
+		}
+
+		supported := false
+		for _, key := range keys {
+			supported = supported || opt.Key == key
+		}
+		if !supported {
+			return errors.Errorf("option '%s' is not supported", opt.Key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package gotezos
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RPCOptions(t *testing.T) {
+	cases := []struct {
+		name    string
+		opt     RPCOption
+		want    RPCOption
+		wantErr string
+	}{
+		{"builds level", WithLevel(100), RPCOption{Key: "level", Value: "100"}, ""},
+		{"builds cycle", WithCycle(0), RPCOption{Key: "cycle", Value: "0"}, ""},
+		{"builds delegate", WithDelegate(mockAddressTz1), RPCOption{Key: "delegate", Value: mockAddressTz1}, ""},
+		{"builds consensus key", WithConsensusKey(mockAddressTz1), RPCOption{Key: "consensus_key", Value: mockAddressTz1}, ""},
+		{"builds max round", WithMaxRound(2), RPCOption{Key: "max_round", Value: "2"}, ""},
+		{"builds all", WithAll(), RPCOption{Key: "all", Value: "true"}, ""},
+		{"builds length", WithLength(5), RPCOption{Key: "length", Value: "5"}, ""},
+		{"builds head", WithHead(mockBlockHash), RPCOption{Key: "head", Value: mockBlockHash}, ""},
+		{"builds min date", WithMinDate(time.Unix(1600000000, 0)), RPCOption{Key: "min_date", Value: "1600000000"}, ""},
+		{"builds force metadata", WithForceMetadata(true), RPCOption{Key: "force_metadata", Value: "true"}, ""},
+		{"builds metadata", WithMetadata("never"), RPCOption{Key: "metadata", Value: "never"}, ""},
+		{"handles negative level", WithLevel(-1), RPCOption{}, "invalid level '-1': less than 0"},
+		{"handles negative cycle", WithCycle(-1), RPCOption{}, "invalid cycle '-1': less than 0"},
+		{"handles invalid delegate", WithDelegate("KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg"), RPCOption{}, "invalid delegate"},
+		{"handles invalid consensus key", WithConsensusKey("tz1"), RPCOption{}, "invalid consensus_key"},
+		{"handles zero length", WithLength(0), RPCOption{}, "invalid length '0': less than 1"},
+		{"handles invalid head", WithHead(mockAddressTz1), RPCOption{}, "invalid head"},
+		{"handles min date before the epoch", WithMinDate(time.Unix(-1, 0)), RPCOption{}, "before the epoch"},
+		{"handles invalid metadata", WithMetadata("sometimes"), RPCOption{}, "invalid metadata 'sometimes'"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkRPCOptions([]RPCOption{tt.opt}, tt.opt.Key)
+			checkErr(t, tt.wantErr != "", tt.wantErr, err)
+			if tt.wantErr == "" {
+				assert.Equal(t, tt.want, tt.opt)
+			}
+		})
+	}
+
+	err := checkRPCOptions([]RPCOption{WithLevel(1), WithAll()}, "level")
+	checkErr(t, true, "option 'all' is not supported", err)
+}
+
+func Test_RPCOptionsQuery(t *testing.T) {
+	var queries []url.Values
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		switch {
+		case strings.HasSuffix(r.URL.Path, "_rights"), r.URL.Path == "/chains/main/blocks":
+			w.Write([]byte(`[]`))
+		default:
+			w.Write(mockBlockResp)
+		}
+	})))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	cycle := 100
+	_, err = gt.BakingRights(&BakingRightsInput{
+		BlockID: BlockIDHead{},
+		Cycle:   &cycle,
+		Options: []RPCOption{WithDelegate(mockAddressTz1), WithMaxRound(2), WithAll()},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, url.Values{
+		"cycle":     {"100"},
+		"delegate":  {mockAddressTz1},
+		"max_round": {"2"},
+		"all":       {"true"},
+	}, queries[0])
+
+	_, err = gt.EndorsingRights(&EndorsingRightsInput{
+		BlockID: BlockIDHead{},
+		Options: []RPCOption{WithLevel(10), WithConsensusKey(mockAddressTz1)},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, url.Values{"level": {"10"}, "consensus_key": {mockAddressTz1}}, queries[1])
+
+	_, err = gt.Blocks(&BlocksInput{Options: []RPCOption{WithLength(2), WithHead(mockBlockHash)}})
+	assert.Nil(t, err)
+	assert.Equal(t, url.Values{"length": {"2"}, "head": {mockBlockHash}}, queries[2])
+
+	_, err = gt.Block(BlockIDHead{}, WithMetadata("never"))
+	assert.Nil(t, err)
+	assert.Equal(t, url.Values{"metadata": {"never"}}, queries[3])
+
+	// Invalid and unsupported options fail before querying the node.
+	_, err = gt.EndorsingRights(&EndorsingRightsInput{BlockID: BlockIDHead{}, Options: []RPCOption{WithAll()}})
+	checkErr(t, true, "invalid input: option 'all' is not supported", err)
+	_, err = gt.BakingRights(&BakingRightsInput{BlockID: BlockIDHead{}, Options: []RPCOption{WithCycle(-1)}})
+	checkErr(t, true, "invalid input: invalid cycle '-1'", err)
+	_, err = gt.Blocks(&BlocksInput{Options: []RPCOption{WithLevel(1)}})
+	checkErr(t, true, "invalid input: option 'level' is not supported", err)
+	_, err = gt.Block(BlockIDHead{}, WithMetadata("sometimes"))
+	checkErr(t, true, "invalid input: invalid metadata 'sometimes'", err)
+	assert.Len(t, queries, 4)
+}
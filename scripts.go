@@ -0,0 +1,658 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+/*
+NormalizeDataInput -
+Description: The input for the NormalizeData rpc query.
+Function: func (t *GoTezos) NormalizeData(input *NormalizeDataInput) (*Micheline, error) {}
+*/
+type NormalizeDataInput struct {
+	// The block (hash, level, head or head~<n>) of which you want to make the query.
+	// Required.
+	BlockID BlockID `validate:"required"`
+
+	// The data to normalize.
+	Data Micheline
+
+	// The type of the data.
+	Type Micheline
+
+	// The unparsing mode to normalize the data to.
+	// Required.
+	UnparsingMode UnparsingMode `validate:"required"`
+
+	// Accept deprecated instructions and types.
+	Legacy bool
+}
+
+/*
+RunCodeInput -
+Description: The input for the RunCode and TraceCode rpc queries.
+Function: func (t *GoTezos) RunCode(input *RunCodeInput) (*RunCodeResult, error) {}
+*/
+type RunCodeInput struct {
+	// The block (hash, level, head or head~<n>) of which you want to make the query.
+	// Required.
+	BlockID BlockID `validate:"required"`
+
+	// The code of the contract (parameter, storage and code sections).
+	Script Micheline
+
+	// The storage to run the code with.
+	Storage Micheline
+
+	// The parameter to call the contract with.
+	Input Micheline
+
+	// The amount (mutez) transferred to the contract.
+	Amount BigInt
+
+	// The chain id. If empty, the chain id of the node is used.
+	ChainID string
+
+	// The SOURCE of the call (optional).
+	Source string
+
+	// The SENDER of the call (optional).
+	Payer string
+
+	// The gas limit (optional).
+	Gas *BigInt
+
+	// The entrypoint called (optional). Defaults to default.
+	Entrypoint string
+}
+
+/*
+RunCodeResult -
+RPC: ../<block_id>/helpers/scripts/run_code (POST)
+Link: https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-scripts-run-code
+*/
+type RunCodeResult struct {
+	Storage    Micheline                  `json:"storage"`
+	Operations []InternalOperationResults `json:"operations"`
+	BigMapDiff []BigMapDiff               `json:"big_map_diff,omitempty"`
+}
+
+/*
+TraceCodeResult -
+RPC: ../<block_id>/helpers/scripts/trace_code (POST)
+Link: https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-scripts-trace-code
+*/
+type TraceCodeResult struct {
+	RunCodeResult
+	Trace []TraceStep `json:"trace"`
+}
+
+/*
+TraceStep -
+RPC: ../<block_id>/helpers/scripts/trace_code (POST)
+Link: https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-scripts-trace-code
+*/
+type TraceStep struct {
+	Location int              `json:"location"`
+	Gas      string           `json:"gas"`
+	Stack    []TraceStackItem `json:"stack"`
+}
+
+/*
+TraceStackItem -
+RPC: ../<block_id>/helpers/scripts/trace_code (POST)
+Link: https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-scripts-trace-code
+*/
+type TraceStackItem struct {
+	Item  Micheline `json:"item"`
+	Annot string    `json:"annot,omitempty"`
+}
+
+/*
+BigMapDiff -
+RPC: ../<block_id>/helpers/scripts/run_code (POST)
+Link: https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-scripts-run-code
+*/
+type BigMapDiff struct {
+	Action            string     `json:"action"`
+	BigMap            string     `json:"big_map,omitempty"`
+	KeyHash           string     `json:"key_hash,omitempty"`
+	Key               *Micheline `json:"key,omitempty"`
+	Value             *Micheline `json:"value,omitempty"`
+	SourceBigMap      string     `json:"source_big_map,omitempty"`
+	DestinationBigMap string     `json:"destination_big_map,omitempty"`
+	KeyType           *Micheline `json:"key_type,omitempty"`
+	ValueType         *Micheline `json:"value_type,omitempty"`
+}
+
+/*
+UnmarshalJSON Function
+Description: Implements the json.Unmarshaler interface for TraceStackItem. Older protocols trace the stack
+as {"item": <data>, "annot": <annot>} objects, newer protocols as plain data; both are accepted.
+
+Parameters:
+	b:
+		The JSON representation of a stack item.
+*/
+func (s *TraceStackItem) UnmarshalJSON(b []byte) error {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(b, &fields) == nil {
+		if _, ok := fields["item"]; ok {
+			type traceStackItem TraceStackItem
+			return json.Unmarshal(b, (*traceStackItem)(s))
+		}
+	}
+
+	s.Annot = ""
+	return json.Unmarshal(b, &s.Item)
+}
+
+/*
+RunCode RPC
+Path: ../<block_id>/helpers/scripts/run_code (POST)
+Link: https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-scripts-run-code
+Description: Runs a piece of code in the current context. Nothing is injected.
+
+Parameters:
+	input:
+		The script, storage, parameter and context to run the code with. BlockID is required.
+*/
+func (t *GoTezos) RunCode(input *RunCodeInput) (*RunCodeResult, error) {
+	resp, err := t.runCode("run_code", input)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to run code")
+	}
+
+	var result RunCodeResult
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal run code result")
+	}
+
+	return &result, nil
+}
+
+/*
+TraceCode RPC
+Path: ../<block_id>/helpers/scripts/trace_code (POST)
+Link: https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-scripts-trace-code
+Description: Runs a piece of code in the current context, keeping a trace of the stack after every instruction.
+Nothing is injected.
+
+Parameters:
+	input:
+		The script, storage, parameter and context to run the code with. BlockID is required.
+*/
+func (t *GoTezos) TraceCode(input *RunCodeInput) (*TraceCodeResult, error) {
+	resp, err := t.runCode("trace_code", input)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to trace code")
+	}
+
+	var result TraceCodeResult
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal trace code result")
+	}
+
+	return &result, nil
+}
+
+func (t *GoTezos) runCode(rpc string, input *RunCodeInput) ([]byte, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	chainID := input.ChainID
+	if chainID == "" {
+		id, err := t.ChainID()
+		if err != nil {
+			return nil, err
+		}
+		chainID = *id
+	}
+
+	v, err := json.Marshal(struct {
+		Script     Micheline `json:"script"`
+		Storage    Micheline `json:"storage"`
+		Input      Micheline `json:"input"`
+		Amount     string    `json:"amount"`
+		ChainID    string    `json:"chain_id"`
+		Source     string    `json:"source,omitempty"`
+		Payer      string    `json:"payer,omitempty"`
+		Gas        string    `json:"gas,omitempty"`
+		Entrypoint string    `json:"entrypoint,omitempty"`
+	}{
+		Script:     input.Script,
+		Storage:    input.Storage,
+		Input:      input.Input,
+		Amount:     input.Amount.String(),
+		ChainID:    chainID,
+		Source:     input.Source,
+		Payer:      input.Payer,
+		Gas:        gasToString(input.Gas),
+		Entrypoint: input.Entrypoint,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return t.post(fmt.Sprintf("/chains/main/blocks/%s/helpers/scripts/%s", input.BlockID.ID(), rpc), v)
+}
+
+/*
+RunViewInput -
+Description: The input for the RunView and RunScriptView rpc queries.
+Function: func (t *GoTezos) RunView(input *RunViewInput) (*Micheline, error) {}
+*/
+type RunViewInput struct {
+	// The block (hash, level, head or head~<n>) of which you want to make the query.
+	// Required.
+	BlockID BlockID `validate:"required"`
+
+	// The contract exposing the view.
+	// Required.
+	Contract string `validate:"required"`
+
+	// The name of the view. For RunView, the TZIP-4 entrypoint (e.g. getBalance).
+	// Required.
+	View string `validate:"required"`
+
+	// The input of the view.
+	Input Micheline
+
+	// The chain id. If empty, the chain id of the node is used.
+	ChainID string
+
+	// The SOURCE of the call (optional).
+	Source string
+
+	// The SENDER of the call (optional).
+	Payer string
+
+	// The gas limit (optional).
+	Gas *BigInt
+
+	// The unparsing mode of the result. Defaults to Readable.
+	UnparsingMode UnparsingMode
+}
+
+/*
+TypecheckCodeInput -
+Description: The input for the TypecheckCode rpc query.
+Function: func (t *GoTezos) TypecheckCode(input *TypecheckCodeInput) (*TypecheckCodeResult, error) {}
+*/
+type TypecheckCodeInput struct {
+	// The block (hash, level, head or head~<n>) of which you want to make the query.
+	// Required.
+	BlockID BlockID `validate:"required"`
+
+	// The code of the contract (parameter, storage and code sections).
+	Program Micheline
+
+	// The gas limit (optional).
+	Gas *BigInt
+
+	// Accept deprecated instructions and types.
+	Legacy bool
+}
+
+/*
+TypecheckDataInput -
+Description: The input for the TypecheckData rpc query.
+Function: func (t *GoTezos) TypecheckData(input *TypecheckDataInput) (*TypecheckDataResult, error) {}
+*/
+type TypecheckDataInput struct {
+	// The block (hash, level, head or head~<n>) of which you want to make the query.
+	// Required.
+	BlockID BlockID `validate:"required"`
+
+	// The data to typecheck.
+	Data Micheline
+
+	// The expected type of the data.
+	Type Micheline
+
+	// The gas limit (optional).
+	Gas *BigInt
+
+	// Accept deprecated instructions and types.
+	Legacy bool
+}
+
+/*
+TypecheckCodeResult -
+RPC: ../<block_id>/helpers/scripts/typecheck_code (POST)
+Link: https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-scripts-typecheck-code
+*/
+type TypecheckCodeResult struct {
+	TypeMap []TypeMap `json:"type_map"`
+	Gas     string    `json:"gas"`
+}
+
+/*
+TypeMap -
+RPC: ../<block_id>/helpers/scripts/typecheck_code (POST)
+Link: https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-scripts-typecheck-code
+*/
+type TypeMap struct {
+	Location    int         `json:"location"`
+	StackBefore []Micheline `json:"stack_before"`
+	StackAfter  []Micheline `json:"stack_after"`
+}
+
+/*
+TypecheckDataResult -
+RPC: ../<block_id>/helpers/scripts/typecheck_data (POST)
+Link: https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-scripts-typecheck-data
+*/
+type TypecheckDataResult struct {
+	Gas string `json:"gas"`
+}
+
+/*
+MichelsonError -
+Description: An error returned by the node when a script or data does not typecheck. Which fields are set
+depends on the error ID, e.g. a bad_stack error has the Location and PrimitiveName of the failing instruction
+and the WrongStackType, an inconsistent_types error has the FirstType and OtherType.
+*/
+type MichelsonError struct {
+	Kind                 string      `json:"kind"`
+	ID                   string      `json:"id"`
+	Location             *int        `json:"location,omitempty"`
+	PrimitiveName        string      `json:"primitive_name,omitempty"`
+	RelevantStackPortion *int        `json:"relevant_stack_portion,omitempty"`
+	WrongStackType       []Micheline `json:"wrong_stack_type,omitempty"`
+	FirstType            *Micheline  `json:"first_type,omitempty"`
+	OtherType            *Micheline  `json:"other_type,omitempty"`
+	ExpectedType         *Micheline  `json:"expected_type,omitempty"`
+	WrongExpression      *Micheline  `json:"wrong_expression,omitempty"`
+	IllTypedCode         *Micheline  `json:"ill_typed_code,omitempty"`
+	IllTypedData         *Micheline  `json:"ill_typed_data,omitempty"`
+	TypeMap              []TypeMap   `json:"type_map,omitempty"`
+}
+
+/*
+TypecheckError -
+Description: The error returned by TypecheckCode and TypecheckData when the node rejects the script or data.
+Use errors.Cause to get it from the returned error.
+*/
+type TypecheckError struct {
+	Errors []MichelsonError
+}
+
+// Error satisfies the error interface.
+func (e *TypecheckError) Error() string {
+	var msgs []string
+	for _, err := range e.Errors {
+		msg := err.ID
+		if err.Location != nil {
+			msg = fmt.Sprintf("%s at location %d", msg, *err.Location)
+		}
+		if err.PrimitiveName != "" {
+			msg = fmt.Sprintf("%s (%s)", msg, err.PrimitiveName)
+		}
+		msgs = append(msgs, msg)
+	}
+
+	return fmt.Sprintf("typecheck failed: %s", strings.Join(msgs, ", "))
+}
+
+/*
+RunView RPC
+Path: ../<block_id>/helpers/scripts/run_view (POST)
+Link: https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-scripts-run-view
+Description: Simulates a call to a TZIP-4 view, i.e. an entrypoint taking a (pair input (contract callback)),
+and returns the data the view sends to its callback.
+
+Parameters:
+	input:
+		The contract, view and input of the call. BlockID, Contract and View are required.
+*/
+func (t *GoTezos) RunView(input *RunViewInput) (*Micheline, error) {
+	data, err := t.runView("run_view", "entrypoint", input)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to run view")
+	}
+
+	return data, nil
+}
+
+/*
+RunScriptView RPC
+Path: ../<block_id>/helpers/scripts/run_script_view (POST)
+Link: https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-scripts-run-script-view
+Description: Simulates a call to an on-chain view (declared with the view keyword in the script) and returns its result.
+
+Parameters:
+	input:
+		The contract, view and input of the call. BlockID, Contract and View are required.
+*/
+func (t *GoTezos) RunScriptView(input *RunViewInput) (*Micheline, error) {
+	data, err := t.runView("run_script_view", "view", input)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to run script view")
+	}
+
+	return data, nil
+}
+
+func (t *GoTezos) runView(rpc, viewField string, input *RunViewInput) (*Micheline, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	chainID := input.ChainID
+	if chainID == "" {
+		id, err := t.ChainID()
+		if err != nil {
+			return nil, err
+		}
+		chainID = *id
+	}
+
+	mode := input.UnparsingMode
+	if mode == "" {
+		mode = UnparsingModeReadable
+	}
+
+	body := map[string]interface{}{
+		"contract":       input.Contract,
+		viewField:        input.View,
+		"input":          input.Input,
+		"chain_id":       chainID,
+		"unparsing_mode": mode,
+	}
+	if input.Source != "" {
+		body["source"] = input.Source
+	}
+	if input.Payer != "" {
+		body["payer"] = input.Payer
+	}
+	if input.Gas != nil {
+		body["gas"] = input.Gas.String()
+	}
+
+	v, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.post(fmt.Sprintf("/chains/main/blocks/%s/helpers/scripts/%s", input.BlockID.ID(), rpc), v)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data Micheline `json:"data"`
+	}
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal view result")
+	}
+
+	return &result.Data, nil
+}
+
+/*
+TypecheckCode RPC
+Path: ../<block_id>/helpers/scripts/typecheck_code (POST)
+Link: https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-scripts-typecheck-code
+Description: Typechecks a piece of code in the current context. If the code is ill typed the cause of the
+returned error is a *TypecheckError.
+
+Parameters:
+	input:
+		The code to typecheck. BlockID is required.
+*/
+func (t *GoTezos) TypecheckCode(input *TypecheckCodeInput) (*TypecheckCodeResult, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	v, err := json.Marshal(struct {
+		Program Micheline `json:"program"`
+		Gas     string    `json:"gas,omitempty"`
+		Legacy  bool      `json:"legacy,omitempty"`
+	}{
+		Program: input.Program,
+		Gas:     gasToString(input.Gas),
+		Legacy:  input.Legacy,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to typecheck code")
+	}
+
+	resp, err := t.post(fmt.Sprintf("/chains/main/blocks/%s/helpers/scripts/typecheck_code", input.BlockID.ID()), v)
+	if err != nil {
+		return nil, errors.Wrap(typecheckError(resp, err), "failed to typecheck code")
+	}
+
+	var result TypecheckCodeResult
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal typecheck code result")
+	}
+
+	return &result, nil
+}
+
+/*
+TypecheckData RPC
+Path: ../<block_id>/helpers/scripts/typecheck_data (POST)
+Link: https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-scripts-typecheck-data
+Description: Checks that some data expression is well formed and of a given type in the current context.
+If it is not the cause of the returned error is a *TypecheckError.
+
+Parameters:
+	input:
+		The data and type to typecheck. BlockID is required.
+*/
+func (t *GoTezos) TypecheckData(input *TypecheckDataInput) (*TypecheckDataResult, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	v, err := json.Marshal(struct {
+		Data   Micheline `json:"data"`
+		Type   Micheline `json:"type"`
+		Gas    string    `json:"gas,omitempty"`
+		Legacy bool      `json:"legacy,omitempty"`
+	}{
+		Data:   input.Data,
+		Type:   input.Type,
+		Gas:    gasToString(input.Gas),
+		Legacy: input.Legacy,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to typecheck data")
+	}
+
+	resp, err := t.post(fmt.Sprintf("/chains/main/blocks/%s/helpers/scripts/typecheck_data", input.BlockID.ID()), v)
+	if err != nil {
+		return nil, errors.Wrap(typecheckError(resp, err), "failed to typecheck data")
+	}
+
+	var result TypecheckDataResult
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal typecheck data result")
+	}
+
+	return &result, nil
+}
+
+// typecheckError decodes the michelson errors of a failed typecheck response, or returns err if there are none.
+func typecheckError(resp []byte, err error) error {
+	var michelsonErrors []MichelsonError
+	if json.Unmarshal(resp, &michelsonErrors) != nil || len(michelsonErrors) == 0 {
+		return err
+	}
+
+	return &TypecheckError{Errors: michelsonErrors}
+}
+
+func gasToString(gas *BigInt) string {
+	if gas == nil {
+		return ""
+	}
+
+	return gas.String()
+}
+
+/*
+NormalizeData RPC
+Path: ../<block_id>/helpers/scripts/normalize_data (POST)
+Link: https://tezos.gitlab.io/api/rpc.html#post-block-id-helpers-scripts-normalize-data
+Description: Normalizes some data expression using the requested unparsing mode. Use it to get data
+(e.g. comb pairs) in a canonical form regardless of how the protocol or the author wrote it.
+
+Parameters:
+	input:
+		Modifies the NormalizeData RPC query. BlockID and UnparsingMode are required.
+*/
+func (t *GoTezos) NormalizeData(input *NormalizeDataInput) (*Micheline, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	v, err := json.Marshal(struct {
+		Data          Micheline     `json:"data"`
+		Type          Micheline     `json:"type"`
+		UnparsingMode UnparsingMode `json:"unparsing_mode"`
+		Legacy        bool          `json:"legacy,omitempty"`
+	}{
+		Data:          input.Data,
+		Type:          input.Type,
+		UnparsingMode: input.UnparsingMode,
+		Legacy:        input.Legacy,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to normalize data")
+	}
+
+	resp, err := t.post(fmt.Sprintf("/chains/main/blocks/%s/helpers/scripts/normalize_data", input.BlockID.ID()), v)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to normalize data")
+	}
+
+	var normalized struct {
+		Normalized Micheline `json:"normalized"`
+	}
+	err = json.Unmarshal(resp, &normalized)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal normalized data")
+	}
+
+	return &normalized.Normalized, nil
+}
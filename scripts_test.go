@@ -0,0 +1,568 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	mockTypecheckCodeResp  = []byte(`{"type_map":[{"location":7,"stack_before":[{"prim":"pair","args":[{"prim":"string"},{"prim":"string"}]}],"stack_after":[{"prim":"string"}]}],"gas":"799880"}`)
+	mockTypecheckErrorResp = []byte(`[{"kind":"permanent","id":"proto.006-PsCARTHA.michelson_v1.ill_typed_contract","ill_typed_code":[{"prim":"code","args":[[{"prim":"ADD"}]]}],"type_map":[]},{"kind":"permanent","id":"proto.006-PsCARTHA.michelson_v1.bad_stack","location":7,"primitive_name":"ADD","relevant_stack_portion":2,"wrong_stack_type":[{"prim":"pair","args":[{"prim":"string"},{"prim":"string"}]}]}]`)
+)
+
+func badRequestHandlerMock(resp []byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write(resp)
+	})
+}
+
+func Test_NormalizeData(t *testing.T) {
+	comb := NewMichelinePrim("Pair", NewMichelineInt(1), NewMichelineInt(2), NewMichelineInt(3))
+	input := NormalizeDataInput{
+		BlockID:       BlockIDHead{},
+		Data:          NewMichelinePrim("Pair", NewMichelineInt(1), NewMichelinePrim("Pair", NewMichelineInt(2), NewMichelineInt(3))),
+		Type:          NewMichelinePrim("pair", NewMichelinePrim("nat"), NewMichelinePrim("nat"), NewMichelinePrim("nat")),
+		UnparsingMode: UnparsingModeOptimized,
+	}
+
+	type want struct {
+		err         bool
+		containsErr string
+		normalized  *Micheline
+	}
+
+	cases := []struct {
+		name        string
+		inputHanler http.Handler
+		input       NormalizeDataInput
+		want
+	}{
+		{
+			"handles invalid input",
+			gtGoldenHTTPMock(blankHandler),
+			NormalizeDataInput{BlockID: BlockIDHead{}},
+			want{
+				true,
+				"invalid input",
+				nil,
+			},
+		},
+		{
+			"returns rpc error",
+			gtGoldenHTTPMock(normalizeDataHandlerMock(mockRPCErrorResp, blankHandler)),
+			input,
+			want{
+				true,
+				"failed to normalize data",
+				nil,
+			},
+		},
+		{
+			"fails to unmarshal",
+			gtGoldenHTTPMock(normalizeDataHandlerMock([]byte(`junk`), blankHandler)),
+			input,
+			want{
+				true,
+				"failed to unmarshal normalized data",
+				nil,
+			},
+		},
+		{
+			"is successful",
+			gtGoldenHTTPMock(normalizeDataHandlerMock([]byte(`{"normalized":{"prim":"Pair","args":[{"int":"1"},{"int":"2"},{"int":"3"}]}}`), blankHandler)),
+			input,
+			want{
+				false,
+				"",
+				&comb,
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.inputHanler)
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			normalized, err := gt.NormalizeData(&tt.input)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			assert.Equal(t, tt.want.normalized, normalized)
+		})
+	}
+}
+
+func Test_RunCode(t *testing.T) {
+	input := RunCodeInput{
+		BlockID: BlockIDHead{},
+		Script:  NewMichelineSeq(),
+		Storage: NewMichelineString("Hello"),
+		Input:   NewMichelineString("Tezos"),
+		ChainID: "NetXdQprcVkpaWU",
+	}
+
+	type want struct {
+		err         bool
+		containsErr string
+		storage     Micheline
+		operations  int
+	}
+
+	cases := []struct {
+		name        string
+		inputHanler http.Handler
+		input       RunCodeInput
+		want
+	}{
+		{
+			"handles invalid input",
+			gtGoldenHTTPMock(blankHandler),
+			RunCodeInput{},
+			want{
+				true,
+				"invalid input",
+				Micheline{},
+				0,
+			},
+		},
+		{
+			"handles failure to get chain id",
+			gtGoldenHTTPMock(chainIDHandlerMock(mockRPCErrorResp, blankHandler)),
+			RunCodeInput{BlockID: BlockIDHead{}},
+			want{
+				true,
+				"failed to get chain id",
+				Micheline{},
+				0,
+			},
+		},
+		{
+			"returns rpc error",
+			gtGoldenHTTPMock(runCodeHandlerMock(mockRPCErrorResp, blankHandler)),
+			input,
+			want{
+				true,
+				"failed to run code",
+				Micheline{},
+				0,
+			},
+		},
+		{
+			"is successful",
+			gtGoldenHTTPMock(runCodeHandlerMock([]byte(`{"storage":{"string":"Tezos"},"operations":[{"kind":"transaction","source":"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn","nonce":0,"amount":"10","destination":"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"}]}`), blankHandler)),
+			input,
+			want{
+				false,
+				"",
+				NewMichelineString("Tezos"),
+				1,
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.inputHanler)
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			result, err := gt.RunCode(&tt.input)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			if tt.want.err {
+				return
+			}
+
+			assert.Equal(t, tt.want.storage, result.Storage)
+			assert.Len(t, result.Operations, tt.want.operations)
+		})
+	}
+}
+
+func Test_TraceCode(t *testing.T) {
+	input := RunCodeInput{
+		BlockID: BlockIDHead{},
+		Script:  NewMichelineSeq(),
+		Storage: NewMichelineString("Hello"),
+		Input:   NewMichelineString("Tezos"),
+		ChainID: "NetXdQprcVkpaWU",
+	}
+
+	type want struct {
+		err         bool
+		containsErr string
+		trace       []TraceStep
+	}
+
+	cases := []struct {
+		name        string
+		inputHanler http.Handler
+		want
+	}{
+		{
+			"returns rpc error",
+			gtGoldenHTTPMock(traceCodeHandlerMock(mockRPCErrorResp, blankHandler)),
+			want{
+				true,
+				"failed to trace code",
+				nil,
+			},
+		},
+		{
+			"fails to unmarshal",
+			gtGoldenHTTPMock(traceCodeHandlerMock([]byte(`junk`), blankHandler)),
+			want{
+				true,
+				"failed to unmarshal trace code result",
+				nil,
+			},
+		},
+		{
+			"is successful with annotated stack items",
+			gtGoldenHTTPMock(traceCodeHandlerMock([]byte(`{"storage":{"string":"Tezos"},"operations":[],"trace":[{"location":7,"gas":"799814","stack":[{"item":{"prim":"Pair","args":[{"string":"Tezos"},{"string":"Hello"}]},"annot":"@parameter"}]}]}`), blankHandler)),
+			want{
+				false,
+				"",
+				[]TraceStep{
+					{
+						Location: 7,
+						Gas:      "799814",
+						Stack: []TraceStackItem{
+							{
+								Item:  NewMichelinePrim("Pair", NewMichelineString("Tezos"), NewMichelineString("Hello")),
+								Annot: "@parameter",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"is successful with plain stack items",
+			gtGoldenHTTPMock(traceCodeHandlerMock([]byte(`{"storage":{"string":"Tezos"},"operations":[],"trace":[{"location":8,"gas":"799813.5","stack":[{"string":"Tezos"}]}]}`), blankHandler)),
+			want{
+				false,
+				"",
+				[]TraceStep{
+					{
+						Location: 8,
+						Gas:      "799813.5",
+						Stack: []TraceStackItem{
+							{
+								Item: NewMichelineString("Tezos"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.inputHanler)
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			result, err := gt.TraceCode(&input)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			if tt.want.err {
+				return
+			}
+
+			assert.Equal(t, NewMichelineString("Tezos"), result.Storage)
+			assert.Equal(t, tt.want.trace, result.Trace)
+		})
+	}
+}
+
+func Test_TypecheckCode(t *testing.T) {
+	location := 7
+	input := TypecheckCodeInput{
+		BlockID: BlockIDHead{},
+		Program: NewMichelineSeq(),
+	}
+
+	type want struct {
+		err         bool
+		containsErr string
+		typecheck   *TypecheckError
+		result      *TypecheckCodeResult
+	}
+
+	cases := []struct {
+		name        string
+		inputHanler http.Handler
+		input       TypecheckCodeInput
+		want
+	}{
+		{
+			"handles invalid input",
+			gtGoldenHTTPMock(blankHandler),
+			TypecheckCodeInput{},
+			want{
+				true,
+				"invalid input",
+				nil,
+				nil,
+			},
+		},
+		{
+			"returns rpc error",
+			gtGoldenHTTPMock(typecheckCodeHandlerMock(mockRPCErrorResp, blankHandler)),
+			input,
+			want{
+				true,
+				"failed to typecheck code",
+				nil,
+				nil,
+			},
+		},
+		{
+			"is ill typed",
+			gtGoldenHTTPMock(badRequestHandlerMock(mockTypecheckErrorResp)),
+			input,
+			want{
+				true,
+				"typecheck failed: proto.006-PsCARTHA.michelson_v1.ill_typed_contract, proto.006-PsCARTHA.michelson_v1.bad_stack at location 7 (ADD)",
+				&TypecheckError{},
+				nil,
+			},
+		},
+		{
+			"is successful",
+			gtGoldenHTTPMock(typecheckCodeHandlerMock(mockTypecheckCodeResp, blankHandler)),
+			input,
+			want{
+				false,
+				"",
+				nil,
+				&TypecheckCodeResult{
+					TypeMap: []TypeMap{
+						{
+							Location:    7,
+							StackBefore: []Micheline{NewMichelinePrim("pair", NewMichelinePrim("string"), NewMichelinePrim("string"))},
+							StackAfter:  []Micheline{NewMichelinePrim("string")},
+						},
+					},
+					Gas: "799880",
+				},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.inputHanler)
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			result, err := gt.TypecheckCode(&tt.input)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			assert.Equal(t, tt.want.result, result)
+
+			if tt.want.typecheck != nil {
+				typecheck, ok := errors.Cause(err).(*TypecheckError)
+				assert.True(t, ok)
+				assert.Len(t, typecheck.Errors, 2)
+				assert.Equal(t, &location, typecheck.Errors[1].Location)
+				assert.Equal(t, []Micheline{NewMichelinePrim("pair", NewMichelinePrim("string"), NewMichelinePrim("string"))}, typecheck.Errors[1].WrongStackType)
+			}
+		})
+	}
+}
+
+func Test_TypecheckData(t *testing.T) {
+	input := TypecheckDataInput{
+		BlockID: BlockIDHead{},
+		Data:    NewMichelineString("Hello"),
+		Type:    NewMichelinePrim("nat"),
+	}
+
+	type want struct {
+		err         bool
+		containsErr string
+		result      *TypecheckDataResult
+	}
+
+	cases := []struct {
+		name        string
+		inputHanler http.Handler
+		want
+	}{
+		{
+			"is ill typed",
+			gtGoldenHTTPMock(badRequestHandlerMock([]byte(`[{"kind":"permanent","id":"proto.006-PsCARTHA.michelson_v1.invalid_constant","expected_type":{"prim":"nat"},"wrong_expression":{"string":"Hello"}}]`))),
+			want{
+				true,
+				"failed to typecheck data: typecheck failed: proto.006-PsCARTHA.michelson_v1.invalid_constant",
+				nil,
+			},
+		},
+		{
+			"returns http error",
+			gtGoldenHTTPMock(badRequestHandlerMock([]byte(`not json`))),
+			want{
+				true,
+				"response returned code 400",
+				nil,
+			},
+		},
+		{
+			"is successful",
+			gtGoldenHTTPMock(typecheckDataHandlerMock([]byte(`{"gas":"799990"}`), blankHandler)),
+			want{
+				false,
+				"",
+				&TypecheckDataResult{Gas: "799990"},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.inputHanler)
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			result, err := gt.TypecheckData(&input)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			assert.Equal(t, tt.want.result, result)
+		})
+	}
+}
+
+func viewHandlerMock(t *testing.T, rpc, viewField string, resp []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chains/main/blocks/head/helpers/scripts/"+rpc {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var body map[string]interface{}
+		v, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(v, &body)
+		assert.Equal(t, "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", body["contract"])
+		assert.Equal(t, "getBalance", body[viewField])
+		assert.Equal(t, "NetXdQprcVkpaWU", body["chain_id"])
+		assert.Equal(t, "Readable", body["unparsing_mode"])
+
+		w.Write(resp)
+	})
+}
+
+func Test_RunView(t *testing.T) {
+	input := RunViewInput{
+		BlockID:  BlockIDHead{},
+		Contract: "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn",
+		View:     "getBalance",
+		Input:    NewMichelineString("tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"),
+		ChainID:  "NetXdQprcVkpaWU",
+	}
+	balance := NewMichelineInt(1000)
+
+	type want struct {
+		err         bool
+		containsErr string
+		data        *Micheline
+	}
+
+	cases := []struct {
+		name        string
+		inputHanler http.Handler
+		input       RunViewInput
+		script      bool
+		want
+	}{
+		{
+			"handles invalid input",
+			gtGoldenHTTPMock(blankHandler),
+			RunViewInput{BlockID: BlockIDHead{}, View: "getBalance"},
+			false,
+			want{
+				true,
+				"invalid input",
+				nil,
+			},
+		},
+		{
+			"returns rpc error",
+			gtGoldenHTTPMock(viewHandlerMock(t, "run_view", "entrypoint", mockRPCErrorResp, blankHandler)),
+			input,
+			false,
+			want{
+				true,
+				"failed to run view",
+				nil,
+			},
+		},
+		{
+			"fails to unmarshal",
+			gtGoldenHTTPMock(viewHandlerMock(t, "run_script_view", "view", []byte(`junk`), blankHandler)),
+			input,
+			true,
+			want{
+				true,
+				"failed to unmarshal view result",
+				nil,
+			},
+		},
+		{
+			"is successful",
+			gtGoldenHTTPMock(viewHandlerMock(t, "run_view", "entrypoint", []byte(`{"data":{"int":"1000"}}`), blankHandler)),
+			input,
+			false,
+			want{
+				false,
+				"",
+				&balance,
+			},
+		},
+		{
+			"is successful with on-chain view",
+			gtGoldenHTTPMock(viewHandlerMock(t, "run_script_view", "view", []byte(`{"data":{"int":"1000"}}`), blankHandler)),
+			input,
+			true,
+			want{
+				false,
+				"",
+				&balance,
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.inputHanler)
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			var data *Micheline
+			if tt.script {
+				data, err = gt.RunScriptView(&tt.input)
+			} else {
+				data, err = gt.RunView(&tt.input)
+			}
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			assert.Equal(t, tt.want.data, data)
+		})
+	}
+}
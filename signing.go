@@ -0,0 +1,222 @@
+package gotezos
+
+import (
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// WatermarkBlock is the watermark of block headers (Emmy).
+	WatermarkBlock byte = 0x01
+	// WatermarkEndorsement is the watermark of endorsements (Emmy).
+	WatermarkEndorsement byte = 0x02
+	// WatermarkGeneric is the watermark of manager operations and any other operation.
+	WatermarkGeneric byte = 0x03
+	// WatermarkTenderbakeBlock is the watermark of block headers (Tenderbake).
+	WatermarkTenderbakeBlock byte = 0x11
+	// WatermarkPreendorsement is the watermark of preendorsements, or preattestations (Tenderbake).
+	WatermarkPreendorsement byte = 0x12
+	// WatermarkTenderbakeEndorsement is the watermark of endorsements, or attestations (Tenderbake).
+	WatermarkTenderbakeEndorsement byte = 0x13
+)
+
+/*
+Signer -
+Description: Signs messages with a secret key following the Tezos signature scheme (the blake2b-256 digest of
+the message is signed). Implemented by Wallet, by AWSKMSSigner and GoogleKMSSigner which keep the key in a
+cloud KMS, and by PKCS11Signer which keeps it in an HSM. See SignOperationWith to sign forged operations with
+any Signer.
+*/
+type Signer interface {
+	// Sign returns the signature of a message, encoded for the curve of the key (edsig, spsig1 or p2sig).
+	Sign(message []byte) (string, error)
+}
+
+var _ Signer = &Wallet{}
+
+/*
+SignedOperation -
+Description: An operation signed by Wallet.SignOperation.
+*/
+type SignedOperation struct {
+	// The forged operation that was signed.
+	Operation string
+	// The signature (edsig).
+	Signature string
+	// The forged operation followed by the signature, as expected by InjectionOperation.
+	SignedOperation string
+}
+
+/*
+Watermark Function
+Description: Returns the watermark prefixed to the bytes signed for a kind of operation. Block and consensus
+watermarks are followed by the chain id, so that the signature can not be replayed on another chain.
+
+Parameters:
+	watermark:
+		The kind of watermark, see the Watermark constants.
+	chainID:
+		The chain id (e.g. NetXdQprcVkpaWU), required for every watermark but WatermarkGeneric.
+*/
+func Watermark(watermark byte, chainID string) ([]byte, error) {
+	switch watermark {
+	case WatermarkGeneric:
+		return []byte{watermark}, nil
+	case WatermarkBlock, WatermarkEndorsement, WatermarkTenderbakeBlock, WatermarkPreendorsement, WatermarkTenderbakeEndorsement:
+		chain, err := chainIDToBytes(chainID)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid watermark")
+		}
+		return append([]byte{watermark}, chain...), nil
+	default:
+		return nil, errors.Errorf("invalid watermark: unknown watermark %#x", watermark)
+	}
+}
+
+/*
+SignWithWatermark Function
+Description: Signs forged bytes prefixed with a watermark. Returns the edsig encoded signature.
+
+Parameters:
+	watermark:
+		The watermark, see Watermark.
+	forged:
+		The hex encoded bytes to sign (e.g. a forged operation or block header).
+*/
+func (w *Wallet) SignWithWatermark(watermark []byte, forged string) (string, error) {
+	return signWithWatermark(w, watermark, forged)
+}
+
+/*
+SignOperation Function
+Description: Signs a forged manager operation (transaction, reveal, origination, delegation, ...) with the
+generic operation watermark. The forged bytes are signed as they are and returned with the signature, so the
+signed operation is exactly the one forged; check it encodes the intended contents with VerifyForgedOperation.
+
+Parameters:
+	operation:
+		The forged operation, see ForgeOperation.
+*/
+func (w *Wallet) SignOperation(operation string) (*SignedOperation, error) {
+	return signOperation(w, []byte{WatermarkGeneric}, operation)
+}
+
+/*
+SignOperationWith Function
+Description: Signs a forged manager operation with the generic operation watermark, as Wallet.SignOperation
+does, with any Signer (e.g. a key in a KMS).
+
+Parameters:
+	signer:
+		The signer of the operation, the manager of its source.
+	operation:
+		The forged operation, see ForgeOperation.
+*/
+func SignOperationWith(signer Signer, operation string) (*SignedOperation, error) {
+	return signOperation(signer, []byte{WatermarkGeneric}, operation)
+}
+
+/*
+SignEndorsement Function
+Description: Signs a forged endorsement (or attestation) with the endorsement watermark of the protocol
+it was forged for.
+
+Parameters:
+	protocol:
+		The protocol of the endorsement, see GoTezos.Protocol.
+	chainID:
+		The chain the endorsement is for.
+	operation:
+		The forged endorsement, see ForgeOperation.
+*/
+func (w *Wallet) SignEndorsement(protocol Protocol, chainID, operation string) (*SignedOperation, error) {
+	kind := WatermarkEndorsement
+	if protocol.Tenderbake {
+		kind = WatermarkTenderbakeEndorsement
+	}
+
+	return w.signConsensusOperation(kind, chainID, operation)
+}
+
+/*
+SignPreendorsement Function
+Description: Signs a forged preendorsement (or preattestation), Tenderbake protocols only.
+
+Parameters:
+	chainID:
+		The chain the preendorsement is for.
+	operation:
+		The forged preendorsement.
+*/
+func (w *Wallet) SignPreendorsement(chainID, operation string) (*SignedOperation, error) {
+	return w.signConsensusOperation(WatermarkPreendorsement, chainID, operation)
+}
+
+/*
+SignBlock Function
+Description: Signs a forged block header with the block watermark of its protocol. Returns the edsig
+encoded signature.
+
+Parameters:
+	protocol:
+		The protocol of the block, see GoTezos.Protocol.
+	chainID:
+		The chain the block is for.
+	header:
+		The forged (unsigned) block header.
+*/
+func (w *Wallet) SignBlock(protocol Protocol, chainID, header string) (string, error) {
+	kind := WatermarkBlock
+	if protocol.Tenderbake {
+		kind = WatermarkTenderbakeBlock
+	}
+
+	watermark, err := Watermark(kind, chainID)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign block")
+	}
+
+	signature, err := w.SignWithWatermark(watermark, header)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign block")
+	}
+
+	return signature, nil
+}
+
+func (w *Wallet) signConsensusOperation(kind byte, chainID, operation string) (*SignedOperation, error) {
+	watermark, err := Watermark(kind, chainID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign operation")
+	}
+
+	return signOperation(w, watermark, operation)
+}
+
+func signWithWatermark(signer Signer, watermark []byte, forged string) (string, error) {
+	message, err := hex.DecodeString(forged)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign: invalid hex")
+	}
+
+	return signer.Sign(append(append([]byte{}, watermark...), message...))
+}
+
+func signOperation(signer Signer, watermark []byte, operation string) (*SignedOperation, error) {
+	signature, err := signWithWatermark(signer, watermark, operation)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign operation")
+	}
+
+	signatureBytes, err := signatureToBytes(signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign operation")
+	}
+
+	return &SignedOperation{
+		Operation:       operation,
+		Signature:       signature,
+		SignedOperation: operation + hex.EncodeToString(signatureBytes),
+	}, nil
+}
@@ -0,0 +1,102 @@
+package gotezos
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/ed25519"
+)
+
+func Test_Watermark(t *testing.T) {
+	cases := []struct {
+		name        string
+		watermark   byte
+		chainID     string
+		want        string
+		containsErr string
+	}{
+		{"generic", WatermarkGeneric, "", "03", ""},
+		{"block", WatermarkBlock, "NetXdQprcVkpaWU", "017a06a770", ""},
+		{"endorsement", WatermarkEndorsement, "NetXdQprcVkpaWU", "027a06a770", ""},
+		{"tenderbake block", WatermarkTenderbakeBlock, "NetXdQprcVkpaWU", "117a06a770", ""},
+		{"preendorsement", WatermarkPreendorsement, "NetXdQprcVkpaWU", "127a06a770", ""},
+		{"tenderbake endorsement", WatermarkTenderbakeEndorsement, "NetXdQprcVkpaWU", "137a06a770", ""},
+		{"missing chain id", WatermarkTenderbakeEndorsement, "", "", "invalid chain id"},
+		{"unknown watermark", 0x04, "NetXdQprcVkpaWU", "", "unknown watermark 0x4"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			watermark, err := Watermark(tt.watermark, tt.chainID)
+			checkErr(t, tt.containsErr != "", tt.containsErr, err)
+			assert.Equal(t, tt.want, hex.EncodeToString(watermark))
+		})
+	}
+}
+
+func Test_SignWithWatermark(t *testing.T) {
+	wallet, err := ImportWallet("tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G", "edskSA4oADtx6DTT6eXdBc6Pv5MoVBGXUzy8bBryi6D96RQNQYcRfVEXd2nuE2ZZPxs4YLZeM7KazUULFT1SfMDNyKFCUgk6vR")
+	assert.Nil(t, err)
+
+	forged := hex.EncodeToString(b58cdecode(mockBlockHash, prefix_branch)) + "6e00"
+
+	verify := func(t *testing.T, watermark string, signature string) {
+		message, err := hex.DecodeString(watermark + forged)
+		assert.Nil(t, err)
+		digest := blake2b.Sum256(message)
+
+		sig, err := signatureToBytes(signature)
+		assert.Nil(t, err)
+		assert.True(t, ed25519.Verify(ed25519.PublicKey(wallet.Kp.PubKey), digest[:], sig))
+	}
+
+	t.Run("signs operations with the generic watermark", func(t *testing.T) {
+		signed, err := wallet.SignOperation(forged)
+		assert.Nil(t, err)
+		verify(t, "03", signed.Signature)
+
+		sig, _ := signatureToBytes(signed.Signature)
+		assert.Equal(t, forged, signed.Operation)
+		assert.Equal(t, forged+hex.EncodeToString(sig), signed.SignedOperation)
+	})
+
+	t.Run("signs endorsements with the watermark of the protocol", func(t *testing.T) {
+		signed, err := wallet.SignEndorsement(ProtocolByHash("PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS"), "NetXdQprcVkpaWU", forged)
+		assert.Nil(t, err)
+		verify(t, "027a06a770", signed.Signature)
+
+		signed, err = wallet.SignEndorsement(ProtocolByHash("ProxfordYmVfjWnRcgjWH36fW6PArwqykTFzotUxRs6gmTcZDuH"), "NetXdQprcVkpaWU", forged)
+		assert.Nil(t, err)
+		verify(t, "137a06a770", signed.Signature)
+
+		signed, err = wallet.SignPreendorsement("NetXdQprcVkpaWU", forged)
+		assert.Nil(t, err)
+		verify(t, "127a06a770", signed.Signature)
+	})
+
+	t.Run("signs blocks with the watermark of the protocol", func(t *testing.T) {
+		signature, err := wallet.SignBlock(ProtocolByHash("PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS"), "NetXdQprcVkpaWU", forged)
+		assert.Nil(t, err)
+		verify(t, "017a06a770", signature)
+
+		signature, err = wallet.SignBlock(ProtocolByHash("Psithaca2MLRFYargivpo7YvUr7wUDqyxrdhC5CQq78mRvimz6A"), "NetXdQprcVkpaWU", forged)
+		assert.Nil(t, err)
+		verify(t, "117a06a770", signature)
+	})
+
+	t.Run("handles invalid input", func(t *testing.T) {
+		_, err := wallet.SignOperation("zz")
+		checkErr(t, true, "invalid hex", err)
+
+		_, err = wallet.SignEndorsement(Protocol{}, "junk", forged)
+		checkErr(t, true, "invalid chain id", err)
+
+		_, err = wallet.SignBlock(Protocol{}, "junk", forged)
+		checkErr(t, true, "failed to sign block", err)
+
+		_, err = (&Wallet{}).SignOperation(forged)
+		checkErr(t, true, "valid ed25519 secret key", err)
+	})
+}
@@ -0,0 +1,246 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// PVMKindArith is the arithmetic PVM used for testing rollups.
+	PVMKindArith = "arith"
+	// PVMKindWasm is the WebAssembly PVM.
+	PVMKindWasm = "wasm_2_0_0"
+	// PVMKindRiscv is the RISC-V PVM.
+	PVMKindRiscv = "riscv"
+)
+
+var pvmKinds = []string{PVMKindArith, PVMKindWasm, PVMKindRiscv}
+
+/*
+SmartRollupCommitment -
+RPC: ../<block_id>/context/smart_rollups/smart_rollup/<address>/commitment/<commitment_hash> (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-smart-rollups-smart-rollup-smart-rollup-address-commitment-smart-rollup-commitment-hash
+*/
+type SmartRollupCommitment struct {
+	CompressedState string `json:"compressed_state"`
+	InboxLevel      int    `json:"inbox_level"`
+	Predecessor     string `json:"predecessor"`
+	NumberOfTicks   BigInt `json:"number_of_ticks"`
+}
+
+/*
+SmartRollupGenesisInfo -
+RPC: ../<block_id>/context/smart_rollups/smart_rollup/<address>/genesis_info (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-smart-rollups-smart-rollup-smart-rollup-address-genesis-info
+*/
+type SmartRollupGenesisInfo struct {
+	Level          int    `json:"level"`
+	CommitmentHash string `json:"commitment_hash"`
+}
+
+/*
+SmartRollupCementedCommitment -
+RPC: ../<block_id>/context/smart_rollups/smart_rollup/<address>/last_cemented_commitment_hash_with_level (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-smart-rollups-smart-rollup-smart-rollup-address-last-cemented-commitment-hash-with-level
+*/
+type SmartRollupCementedCommitment struct {
+	Hash  string `json:"hash"`
+	Level int    `json:"level"`
+}
+
+/*
+SmartRollupStakedOnCommitment -
+RPC: ../<block_id>/context/smart_rollups/smart_rollup/<address>/staker/<staker>/staked_on_commitment (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-smart-rollups-smart-rollup-smart-rollup-address-staker-signature-public-key-hash-staked-on-commitment
+*/
+type SmartRollupStakedOnCommitment struct {
+	Hash       string                `json:"hash"`
+	Commitment SmartRollupCommitment `json:"commitment"`
+}
+
+/*
+SmartRollupInbox -
+RPC: ../<block_id>/context/smart_rollups/all/inbox (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-smart-rollups-all-inbox
+*/
+type SmartRollupInbox struct {
+	Level             int                     `json:"level"`
+	OldLevelsMessages SmartRollupInboxHistory `json:"old_levels_messages"`
+}
+
+// SmartRollupInboxHistory is the skip list cell of the messages of the previous inbox levels.
+type SmartRollupInboxHistory struct {
+	Index        BigInt                  `json:"index"`
+	Content      SmartRollupInboxContent `json:"content"`
+	BackPointers []string                `json:"back_pointers"`
+}
+
+// SmartRollupInboxContent is the hash of the messages of an inbox level.
+type SmartRollupInboxContent struct {
+	Hash  string `json:"hash"`
+	Level int    `json:"level"`
+}
+
+/*
+SmartRollups RPC
+Path: ../<block_id>/context/smart_rollups/all (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-smart-rollups-all
+Description: Lists the addresses of all originated smart rollups.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+*/
+func (t *GoTezos) SmartRollups(blockID BlockID) ([]string, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/smart_rollups/all", blockID.ID()))
+	if err != nil {
+		return []string{}, errors.Wrap(err, "could not get smart rollups")
+	}
+
+	var rollups []string
+	err = json.Unmarshal(resp, &rollups)
+	if err != nil {
+		return []string{}, errors.Wrap(err, "could not unmarshal smart rollups")
+	}
+
+	return rollups, nil
+}
+
+/*
+SmartRollupGenesisInfo RPC
+Path: ../<block_id>/context/smart_rollups/smart_rollup/<address>/genesis_info (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-smart-rollups-smart-rollup-smart-rollup-address-genesis-info
+Description: Returns the level at which the smart rollup was originated and its genesis commitment hash.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+	rollup:
+		The sr1 address of the smart rollup.
+*/
+func (t *GoTezos) SmartRollupGenesisInfo(blockID BlockID, rollup string) (*SmartRollupGenesisInfo, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/smart_rollups/smart_rollup/%s/genesis_info", blockID.ID(), rollup))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get genesis info of smart rollup '%s'", rollup)
+	}
+
+	var info SmartRollupGenesisInfo
+	err = json.Unmarshal(resp, &info)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal genesis info of smart rollup '%s'", rollup)
+	}
+
+	return &info, nil
+}
+
+/*
+SmartRollupCommitment RPC
+Path: ../<block_id>/context/smart_rollups/smart_rollup/<address>/commitment/<commitment_hash> (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-smart-rollups-smart-rollup-smart-rollup-address-commitment-smart-rollup-commitment-hash
+Description: Returns a commitment of a smart rollup.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+	rollup:
+		The sr1 address of the smart rollup.
+	hash:
+		The src1 hash of the commitment.
+*/
+func (t *GoTezos) SmartRollupCommitment(blockID BlockID, rollup, hash string) (*SmartRollupCommitment, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/smart_rollups/smart_rollup/%s/commitment/%s", blockID.ID(), rollup, hash))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get commitment '%s' of smart rollup '%s'", hash, rollup)
+	}
+
+	var commitment SmartRollupCommitment
+	err = json.Unmarshal(resp, &commitment)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal commitment '%s' of smart rollup '%s'", hash, rollup)
+	}
+
+	return &commitment, nil
+}
+
+/*
+SmartRollupLastCementedCommitment RPC
+Path: ../<block_id>/context/smart_rollups/smart_rollup/<address>/last_cemented_commitment_hash_with_level (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-smart-rollups-smart-rollup-smart-rollup-address-last-cemented-commitment-hash-with-level
+Description: Returns the hash and level of the last cemented commitment of a smart rollup.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+	rollup:
+		The sr1 address of the smart rollup.
+*/
+func (t *GoTezos) SmartRollupLastCementedCommitment(blockID BlockID, rollup string) (*SmartRollupCementedCommitment, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/smart_rollups/smart_rollup/%s/last_cemented_commitment_hash_with_level", blockID.ID(), rollup))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get last cemented commitment of smart rollup '%s'", rollup)
+	}
+
+	var commitment SmartRollupCementedCommitment
+	err = json.Unmarshal(resp, &commitment)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal last cemented commitment of smart rollup '%s'", rollup)
+	}
+
+	return &commitment, nil
+}
+
+/*
+SmartRollupStakedOnCommitment RPC
+Path: ../<block_id>/context/smart_rollups/smart_rollup/<address>/staker/<staker>/staked_on_commitment (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-smart-rollups-smart-rollup-smart-rollup-address-staker-signature-public-key-hash-staked-on-commitment
+Description: Returns the newest commitment a staker is staked on, or nil if the staker is not staked.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+	rollup:
+		The sr1 address of the smart rollup.
+	staker:
+		The public key hash of the staker.
+*/
+func (t *GoTezos) SmartRollupStakedOnCommitment(blockID BlockID, rollup, staker string) (*SmartRollupStakedOnCommitment, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/smart_rollups/smart_rollup/%s/staker/%s/staked_on_commitment", blockID.ID(), rollup, staker))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get staked on commitment of '%s' on smart rollup '%s'", staker, rollup)
+	}
+
+	var commitment *SmartRollupStakedOnCommitment
+	err = json.Unmarshal(resp, &commitment)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal staked on commitment of '%s' on smart rollup '%s'", staker, rollup)
+	}
+
+	return commitment, nil
+}
+
+/*
+SmartRollupInbox RPC
+Path: ../<block_id>/context/smart_rollups/all/inbox (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-block-id-context-smart-rollups-all-inbox
+Description: Returns the current state of the smart rollups inbox.
+
+Parameters:
+	blockID:
+		The block (hash, level, head or head~<n>) of which you want to make the query.
+*/
+func (t *GoTezos) SmartRollupInbox(blockID BlockID) (*SmartRollupInbox, error) {
+	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/smart_rollups/all/inbox", blockID.ID()))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get smart rollups inbox")
+	}
+
+	var inbox SmartRollupInbox
+	err = json.Unmarshal(resp, &inbox)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal smart rollups inbox")
+	}
+
+	return &inbox, nil
+}
@@ -0,0 +1,165 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	mockSmartRollup           = "sr1CRNsrB6okDY5ivfTsSPEYdgpoNP43b3Fc"
+	mockSmartRollupCommitment = "src12UJzB8mg7yU6nWPzicH7ofJbFjyJEbHvwtZdfRXi8DQHNp1LY8"
+	mockSmartRollupState      = "srs11y1ZCJfeWnHzoX3rAjcTXiphwg8NvqQhvishP3PU68jgSREuk6"
+)
+
+var (
+	mockSmartRollupsResp           = []byte(`["sr1CRNsrB6okDY5ivfTsSPEYdgpoNP43b3Fc"]`)
+	mockSmartRollupGenesisInfoResp = []byte(`{"level":1024,"commitment_hash":"src12UJzB8mg7yU6nWPzicH7ofJbFjyJEbHvwtZdfRXi8DQHNp1LY8"}`)
+	mockSmartRollupCommitmentResp  = []byte(`{"compressed_state":"srs11y1ZCJfeWnHzoX3rAjcTXiphwg8NvqQhvishP3PU68jgSREuk6","inbox_level":1084,"predecessor":"src12UJzB8mg7yU6nWPzicH7ofJbFjyJEbHvwtZdfRXi8DQHNp1LY8","number_of_ticks":"6600000"}`)
+	mockSmartRollupCementedResp    = []byte(`{"hash":"src12UJzB8mg7yU6nWPzicH7ofJbFjyJEbHvwtZdfRXi8DQHNp1LY8","level":1024}`)
+	mockSmartRollupStakedResp      = []byte(`{"hash":"src13sucE2b6TVNATNABGp7yqBF95jMaAzWF32GJE1YbJqr62C1gNx","commitment":{"compressed_state":"srs11y1ZCJfeWnHzoX3rAjcTXiphwg8NvqQhvishP3PU68jgSREuk6","inbox_level":1084,"predecessor":"src12UJzB8mg7yU6nWPzicH7ofJbFjyJEbHvwtZdfRXi8DQHNp1LY8","number_of_ticks":"6600000"}}`)
+	mockSmartRollupInboxResp       = []byte(`{"level":1090,"old_levels_messages":{"index":"66","content":{"hash":"scib1ugSqbNuMdaqdPMVNm7BvPZ1n4SwSdy5eH5U6YYePCDQbX4CkD","level":1089},"back_pointers":["scib1ugSqbNuMdaqdPMVNm7BvPZ1n4SwSdy5eH5U6YYePCDQbX4CkD"]}}`)
+)
+
+func smartRollupsHandlerMock(routes map[string][]byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/context/smart_rollups/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		for suffix, resp := range routes {
+			if strings.HasSuffix(r.URL.Path, suffix) {
+				w.Write(resp)
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	})
+}
+
+func Test_SmartRollups(t *testing.T) {
+	commitment := SmartRollupCommitment{
+		CompressedState: mockSmartRollupState,
+		InboxLevel:      1084,
+		Predecessor:     mockSmartRollupCommitment,
+		NumberOfTicks:   BigInt{*big.NewInt(6600000)},
+	}
+
+	unstaked := "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"
+	routes := map[string][]byte{
+		"/smart_rollups/all": mockSmartRollupsResp,
+		"/smart_rollup/" + mockSmartRollup + "/genesis_info":                                       mockSmartRollupGenesisInfoResp,
+		"/smart_rollup/" + mockSmartRollup + "/commitment/" + mockSmartRollupCommitment:            mockSmartRollupCommitmentResp,
+		"/smart_rollup/" + mockSmartRollup + "/last_cemented_commitment_hash_with_level":           mockSmartRollupCementedResp,
+		"/smart_rollup/" + mockSmartRollup + "/staker/" + mockAddressTz1 + "/staked_on_commitment": mockSmartRollupStakedResp,
+		"/smart_rollup/" + mockSmartRollup + "/staker/" + unstaked + "/staked_on_commitment":       []byte(`null`),
+		"/smart_rollups/all/inbox": mockSmartRollupInboxResp,
+	}
+
+	server := httptest.NewServer(gtGoldenHTTPMock(smartRollupsHandlerMock(routes, blankHandler)))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	rollups, err := gt.SmartRollups(BlockIDHead{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{mockSmartRollup}, rollups)
+
+	info, err := gt.SmartRollupGenesisInfo(BlockIDHead{}, mockSmartRollup)
+	assert.Nil(t, err)
+	assert.Equal(t, &SmartRollupGenesisInfo{Level: 1024, CommitmentHash: mockSmartRollupCommitment}, info)
+
+	c, err := gt.SmartRollupCommitment(BlockIDHead{}, mockSmartRollup, mockSmartRollupCommitment)
+	assert.Nil(t, err)
+	assert.Equal(t, &commitment, c)
+
+	cemented, err := gt.SmartRollupLastCementedCommitment(BlockIDHead{}, mockSmartRollup)
+	assert.Nil(t, err)
+	assert.Equal(t, &SmartRollupCementedCommitment{Hash: mockSmartRollupCommitment, Level: 1024}, cemented)
+
+	staked, err := gt.SmartRollupStakedOnCommitment(BlockIDHead{}, mockSmartRollup, mockAddressTz1)
+	assert.Nil(t, err)
+	assert.Equal(t, &SmartRollupStakedOnCommitment{Hash: "src13sucE2b6TVNATNABGp7yqBF95jMaAzWF32GJE1YbJqr62C1gNx", Commitment: commitment}, staked)
+
+	staked, err = gt.SmartRollupStakedOnCommitment(BlockIDHead{}, mockSmartRollup, unstaked)
+	assert.Nil(t, err)
+	assert.Nil(t, staked)
+
+	inbox, err := gt.SmartRollupInbox(BlockIDHead{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1090, inbox.Level)
+	assert.Equal(t, int64(66), inbox.OldLevelsMessages.Index.Int64())
+	assert.Equal(t, 1089, inbox.OldLevelsMessages.Content.Level)
+	assert.Len(t, inbox.OldLevelsMessages.BackPointers, 1)
+
+	_, err = gt.SmartRollupGenesisInfo(BlockIDHead{}, "sr1NotARollup")
+	checkErr(t, true, "could not get genesis info of smart rollup 'sr1NotARollup'", err)
+}
+
+func Test_ForgeSmartRollupOperations(t *testing.T) {
+	parametersTy := NewMichelinePrim("bytes")
+	common := func(kind string, counter int64) Contents {
+		return Contents{
+			Kind:         kind,
+			Source:       mockAddressTz1,
+			Fee:          BigInt{*big.NewInt(1000)},
+			Counter:      BigInt{*big.NewInt(counter)},
+			GasLimit:     BigInt{*big.NewInt(2500)},
+			StorageLimit: BigInt{*big.NewInt(6552)},
+		}
+	}
+
+	originate := common(SMARTROLLUPORIGINATEOP, 1)
+	originate.PvmKind = PVMKindWasm
+	originate.Kernel = "0061736d01000000"
+	originate.ParametersTy = &parametersTy
+	originate.Whitelist = []string{mockAddressTz1}
+
+	addMessages := common(SMARTROLLUPADDMESSAGESOP, 2)
+	addMessages.Message = []string{"0102", "", "deadbeef"}
+
+	cement := common(SMARTROLLUPCEMENTOP, 3)
+	cement.Rollup = mockSmartRollup
+
+	publish := common(SMARTROLLUPPUBLISHOP, 4)
+	publish.Rollup = mockSmartRollup
+	publish.Commitment = &SmartRollupCommitment{
+		CompressedState: mockSmartRollupState,
+		InboxLevel:      1084,
+		Predecessor:     mockSmartRollupCommitment,
+		NumberOfTicks:   BigInt{*big.NewInt(6600000)},
+	}
+
+	contents := []Contents{originate, addMessages, cement, publish}
+
+	gt := &GoTezos{}
+	forge, err := gt.ForgeOperation(mockBlockHash, contents...)
+	assert.Nil(t, err)
+
+	branch, unforged, err := gt.UnforgeOperation(*forge, false)
+	assert.Nil(t, err)
+	assert.Equal(t, mockBlockHash, *branch)
+	assert.Equal(t, contents, *unforged)
+
+	v, err := json.Marshal(publish)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"kind":"smart_rollup_publish","source":"tz1YGLnq1Ls4W3rPanAvCvmcuQ1H5rffnc2V","fee":"1000","counter":"4","gas_limit":"2500","storage_limit":"6552","rollup":"sr1CRNsrB6okDY5ivfTsSPEYdgpoNP43b3Fc","commitment":{"compressed_state":"srs11y1ZCJfeWnHzoX3rAjcTXiphwg8NvqQhvishP3PU68jgSREuk6","inbox_level":1084,"predecessor":"src12UJzB8mg7yU6nWPzicH7ofJbFjyJEbHvwtZdfRXi8DQHNp1LY8","number_of_ticks":"6600000"}}`, string(v))
+
+	invalid := originate
+	invalid.PvmKind = "not_a_pvm"
+	_, err = gt.ForgeOperation(mockBlockHash, invalid)
+	checkErr(t, true, "unsupported pvm kind 'not_a_pvm'", err)
+
+	invalid = cement
+	invalid.Rollup = mockAddressTz1
+	_, err = gt.ForgeOperation(mockBlockHash, invalid)
+	checkErr(t, true, "invalid rollup", err)
+}
@@ -0,0 +1,226 @@
+package gotezos
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+/*
+SplitOperation Function
+Description: Splits manager operation contents into groups that each fit in an operation: the forged operation is
+at most max_operation_data_length bytes and its gas limits sum to at most hard_gas_limit_per_block. The gas
+of each group is estimated by running it, so every content of a group is checked to apply. Contents with a gas
+or storage limit above hard_gas_limit_per_operation or hard_storage_limit_per_operation can not be injected
+and fail the split. Contents are kept in order, see SendAll to send the groups.
+
+Parameters:
+	contents:
+		The contents to split, e.g. the transactions of a large batch.
+*/
+func (w *WalletClient) SplitOperation(contents ...Contents) ([][]Contents, error) {
+	groups, err := w.splitOperation(contents, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to split operation")
+	}
+
+	return groups, nil
+}
+
+/*
+SendAll Function
+Description: Splits manager operation contents into operations (see SplitOperation) and sends them in order,
+each waited for until it is included (or confirmed, see WalletClient.Confirmations) before the next one is
+prepared. Returns the hashes of the operations injected, along with the error of the first that failed.
+
+Parameters:
+	ctx:
+		Cancels the operations.
+	contents:
+		The contents to send.
+*/
+func (w *WalletClient) SendAll(ctx context.Context, contents ...Contents) ([]string, error) {
+	groups, err := w.SplitOperation(contents...)
+	if err != nil {
+		return nil, err
+	}
+
+	client := *w
+	client.Wait = true
+
+	hashes := []string{}
+	for i, group := range groups {
+		if err := ctx.Err(); err != nil {
+			return hashes, errors.Wrapf(err, "failed to send operation %d of %d", i+1, len(groups))
+		}
+
+		hash, err := client.Send(ctx, group...)
+		if hash != nil {
+			hashes = append(hashes, *hash)
+		}
+		if err != nil {
+			return hashes, errors.Wrapf(err, "failed to send operation %d of %d", i+1, len(groups))
+		}
+	}
+
+	return hashes, nil
+}
+
+// splitOperation splits contents into groups fitting in an operation, of at most maxContents contents if
+// maxContents is positive.
+func (w *WalletClient) splitOperation(contents []Contents, maxContents int) ([][]Contents, error) {
+	if len(contents) == 0 {
+		return nil, errors.New("no contents")
+	}
+
+	constants, err := w.Client.Constants(BlockIDHead{})
+	if err != nil {
+		return nil, err
+	}
+
+	maxGas, err := strconv.ParseInt(constants.HardGasLimitPerBlock, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid hard_gas_limit_per_block")
+	}
+
+	if err := checkHardLimits(contents, constants); err != nil {
+		return nil, err
+	}
+
+	head, err := w.Client.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	sizes, overhead, err := w.contentsSizes(head.Hash, contents, constants)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := [][]Contents{}
+	for start := 0; start < len(contents); {
+		// The largest group that fits in an operation by size, then trimmed to the contents that fit by gas.
+		end, size := start, overhead
+		for end < len(contents) && size+sizes[end] <= constants.MaxOperationDataLength &&
+			(maxContents <= 0 || end-start < maxContents) {
+			size += sizes[end]
+			end++
+		}
+
+		if end == start {
+			return nil, errors.Errorf("contents %d do not fit in an operation", start)
+		}
+
+		for {
+			fit, err := w.contentsFittingGas(contents[start:end], maxGas)
+			if err != nil {
+				return nil, errors.Wrapf(err, "contents %d to %d", start, end-1)
+			}
+
+			if fit == 0 {
+				return nil, errors.Errorf("contents %d do not fit in a block", start)
+			}
+
+			if fit == end-start {
+				break
+			}
+			end = start + fit
+		}
+
+		groups = append(groups, contents[start:end])
+		start = end
+	}
+
+	return groups, nil
+}
+
+// checkHardLimits checks that the gas and storage limits set on contents do not exceed the limits of an
+// operation, which no split can bring them under.
+func checkHardLimits(contents []Contents, constants *Constants) error {
+	for i, c := range contents {
+		for _, limit := range []struct {
+			name  string
+			value BigInt
+			hard  string
+		}{
+			{"gas", c.GasLimit, constants.HardGasLimitPerOperation},
+			{"storage", c.StorageLimit, constants.HardStorageLimitPerOperation},
+		} {
+			hard, err := strconv.ParseInt(limit.hard, 10, 64)
+			if err != nil || limit.value.Sign() == 0 {
+				continue
+			}
+			if !limit.value.IsInt64() || limit.value.Int64() > hard {
+				return errors.Errorf("contents %d: %s limit %s exceeds the hard limit of an operation %d", i, limit.name, limit.value.String(), hard)
+			}
+		}
+	}
+
+	return nil
+}
+
+// contentsSizes returns the max forged size of each content, and of the rest of an operation: its branch,
+// signature and a reveal.
+func (w *WalletClient) contentsSizes(branch string, contents []Contents, constants *Constants) ([]int, int, error) {
+	// The fields set when the contents are prepared are forged at their max values.
+	prepared := func(contents Contents) Contents {
+		contents.Source = w.Address
+		if contents.Fee.Sign() == 0 {
+			contents.Fee.SetInt64(MUTEZ * 10)
+		}
+		contents.Counter.SetInt64(1 << 40)
+		contents.GasLimit.SetString(constants.HardGasLimitPerOperation, 10)
+		contents.StorageLimit.SetString(constants.HardStorageLimitPerOperation, 10)
+		return contents
+	}
+
+	size := func(contents Contents) (int, error) {
+		forge, err := w.Client.ForgeOperation(branch, contents)
+		if err != nil {
+			return 0, err
+		}
+		return len(*forge)/2 - 32, nil
+	}
+
+	reveal, err := size(prepared(Contents{Kind: REVEALOP, Phk: w.Pk}))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var sizes []int
+	for _, c := range contents {
+		s, err := size(prepared(c))
+		if err != nil {
+			return nil, 0, err
+		}
+		sizes = append(sizes, s)
+	}
+
+	return sizes, 32 + 64 + reveal, nil
+}
+
+// contentsFittingGas prepares the contents and returns the number of them, from the first, whose gas limits
+// fit in a block. The gas of the reveal Prepare may prepend counts towards the block.
+func (w *WalletClient) contentsFittingGas(contents []Contents, maxGas int64) (int, error) {
+	operation, err := w.Prepare(contents...)
+	if err != nil {
+		return 0, err
+	}
+
+	reveals := len(operation) - len(contents)
+
+	var gas int64
+	fit := 0
+	for i, c := range operation {
+		gas += c.GasLimit.Int64()
+		if gas > maxGas {
+			break
+		}
+		if i >= reveals {
+			fit++
+		}
+	}
+
+	return fit, nil
+}
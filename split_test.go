@@ -0,0 +1,109 @@
+package gotezos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SplitOperation(t *testing.T) {
+	origination := Contents{Kind: ORIGINATIONOP}
+	origination.StorageLimit.SetInt64(60001)
+
+	type want struct {
+		err         bool
+		containsErr string
+		sizes       []int
+	}
+
+	cases := []struct {
+		name        string
+		contents    []Contents
+		consumedGas int
+		want
+	}{
+		{
+			"fits contents in an operation",
+			payoutContents(testPayouts(25)),
+			1000,
+			want{false, "", []int{25}},
+		},
+		{
+			"splits contents by size",
+			payoutContents(testPayouts(500)),
+			1000,
+			want{false, "", []int{253, 247}},
+		},
+		{
+			"splits contents by gas",
+			payoutContents(testPayouts(25)),
+			700000,
+			want{false, "", []int{11, 11, 3}},
+		},
+		{
+			"handles storage limit above the hard limit",
+			append(payoutContents(testPayouts(2)), origination),
+			1000,
+			want{true, "contents 2: storage limit 60001 exceeds the hard limit of an operation 60000", nil},
+		},
+		{
+			"handles no contents",
+			nil,
+			1000,
+			want{true, "failed to split operation: no contents", nil},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := testWalletClient(t)
+			revealed := "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G"
+			mock.managerKey = &revealed
+			client.Client = &payoutsMock{walletClientMock: mock, consumedGas: tt.consumedGas}
+
+			groups, err := client.SplitOperation(tt.contents...)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+
+			var sizes []int
+			var contents []Contents
+			for _, group := range groups {
+				sizes = append(sizes, len(group))
+				contents = append(contents, group...)
+			}
+			assert.Equal(t, tt.want.sizes, sizes)
+			if !tt.want.err {
+				assert.Equal(t, tt.contents, contents)
+			}
+		})
+	}
+}
+
+func Test_SplitOperationReveal(t *testing.T) {
+	client, mock := testWalletClient(t)
+	client.Client = &payoutsMock{walletClientMock: mock, consumedGas: 700000}
+
+	// The reveal prepended to the first operation takes the gas of a transaction.
+	groups, err := client.SplitOperation(payoutContents(testPayouts(12))...)
+	assert.Nil(t, err)
+	assert.Len(t, groups, 2)
+	assert.Len(t, groups[0], 10)
+}
+
+func Test_SendAll(t *testing.T) {
+	client, mock := testWalletClient(t)
+	revealed := "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G"
+	mock.managerKey = &revealed
+	mock.confirmations = []Confirmation{{Confirmations: 0}}
+	client.Client = &payoutsMock{walletClientMock: mock, consumedGas: 700000, failOn: 3}
+
+	hashes, err := client.SendAll(context.Background(), payoutContents(testPayouts(25))...)
+	checkErr(t, true, "failed to send operation 3 of 3: injection failed", err)
+	assert.Equal(t, []string{mock.hash, mock.hash}, hashes)
+	assert.Len(t, mock.injected, 2)
+
+	hashes, err = client.SendAll(context.Background(), payoutContents(testPayouts(3))...)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{mock.hash}, hashes)
+	assert.Len(t, mock.injected, 3)
+}
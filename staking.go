@@ -0,0 +1,189 @@
+package gotezos
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// EntrypointStake is the pseudo-entrypoint staking tez with the delegate of the source (Oxford and later).
+	EntrypointStake = "stake"
+	// EntrypointUnstake is the pseudo-entrypoint unstaking tez (Oxford and later).
+	EntrypointUnstake = "unstake"
+	// EntrypointFinalizeUnstake is the pseudo-entrypoint returning the finalizable unstaked tez to the spendable
+	// balance (Oxford and later).
+	EntrypointFinalizeUnstake = "finalize_unstake"
+
+	// BalanceUpdateCategoryDelegatorNumerator is a staking balance update of the pseudo-tokens of a staker.
+	BalanceUpdateCategoryDelegatorNumerator = "delegator_numerator"
+	// BalanceUpdateCategoryDelegateDenominator is a staking balance update of the pseudo-tokens of a delegate.
+	BalanceUpdateCategoryDelegateDenominator = "delegate_denominator"
+)
+
+/*
+StakeContents Function
+Description: Builds the transaction staking tez: a transaction of the amount to the source itself, calling
+the stake pseudo-entrypoint. The source must have set a delegate that accepts stakers.
+
+Parameters:
+	address:
+		The tz1 address staking.
+	amount:
+		The amount to stake, in mutez.
+*/
+func StakeContents(address string, amount int64) Contents {
+	return stakingContents(address, EntrypointStake, amount)
+}
+
+/*
+UnstakeContents Function
+Description: Builds the transaction unstaking tez: a transaction of the amount to the source itself, calling
+the unstake pseudo-entrypoint. Unstaked tez become finalizable after consensus_rights_delay + max_slashing_period
+cycles, see FinalizeUnstakeContents.
+
+Parameters:
+	address:
+		The tz1 address unstaking.
+	amount:
+		The amount to unstake, in mutez. Unstakes everything if greater than the staked balance.
+*/
+func UnstakeContents(address string, amount int64) Contents {
+	return stakingContents(address, EntrypointUnstake, amount)
+}
+
+/*
+FinalizeUnstakeContents Function
+Description: Builds the transaction returning the finalizable unstaked tez to the spendable balance: a
+transaction of 0 tez to the source itself, calling the finalize_unstake pseudo-entrypoint.
+
+Parameters:
+	address:
+		The tz1 address finalizing.
+*/
+func FinalizeUnstakeContents(address string) Contents {
+	return stakingContents(address, EntrypointFinalizeUnstake, 0)
+}
+
+func stakingContents(address, entrypoint string, amount int64) Contents {
+	var contents Contents
+	contents.Kind = TRANSACTIONOP
+	contents.Source = address
+	contents.Destination = address
+	contents.Amount.SetInt64(amount)
+	contents.Parameters = &Parameters{
+		Entrypoint: entrypoint,
+		Value:      NewMichelinePrim("Unit"),
+	}
+
+	return contents
+}
+
+/*
+Stake Function
+Description: Stakes tez of the wallet with its delegate and returns the hash of the operation.
+
+Parameters:
+	ctx:
+		Cancels waiting for the operation, see WalletClient.Wait.
+	amount:
+		The amount in mutez.
+*/
+func (w *WalletClient) Stake(ctx context.Context, amount int64) (*string, error) {
+	hash, err := w.Send(ctx, StakeContents(w.Address, amount))
+	if err != nil {
+		return hash, errors.Wrap(err, "failed to stake")
+	}
+
+	return hash, nil
+}
+
+/*
+Unstake Function
+Description: Unstakes tez of the wallet and returns the hash of the operation.
+
+Parameters:
+	ctx:
+		Cancels waiting for the operation, see WalletClient.Wait.
+	amount:
+		The amount in mutez.
+*/
+func (w *WalletClient) Unstake(ctx context.Context, amount int64) (*string, error) {
+	hash, err := w.Send(ctx, UnstakeContents(w.Address, amount))
+	if err != nil {
+		return hash, errors.Wrap(err, "failed to unstake")
+	}
+
+	return hash, nil
+}
+
+/*
+FinalizeUnstake Function
+Description: Returns the finalizable unstaked tez of the wallet to its spendable balance and returns the hash
+of the operation.
+
+Parameters:
+	ctx:
+		Cancels waiting for the operation, see WalletClient.Wait.
+*/
+func (w *WalletClient) FinalizeUnstake(ctx context.Context) (*string, error) {
+	hash, err := w.Send(ctx, FinalizeUnstakeContents(w.Address))
+	if err != nil {
+		return hash, errors.Wrap(err, "failed to finalize unstake")
+	}
+
+	return hash, nil
+}
+
+/*
+StakingChange -
+Description: The change of the staked and unstaked tez of a staker, in mutez, e.g. after a stake (Staked
+increases), an unstake (Staked decreases and Unstaked increases) or a finalize_unstake (Unstaked decreases).
+*/
+type StakingChange struct {
+	// The deposits of the staker, frozen with its delegate.
+	Staked BigInt
+	// The unstaked deposits of the staker, not yet finalizable or finalized.
+	Unstaked BigInt
+	// The staking pseudo-tokens of the staker, its share of the stake of its delegate.
+	PseudoTokens BigInt
+}
+
+/*
+StakingChanges Function
+Description: Sums the deposits, unstaked deposits and staking pseudo-token balance updates by staker, keyed by
+the address of the staker (the delegate for its own stake). Other balance updates are skipped.
+
+Parameters:
+	balanceUpdates:
+		The balance updates to sum, e.g. those of a stake operation (see Operations.BalanceUpdates).
+*/
+func StakingChanges(balanceUpdates []BalanceUpdates) map[string]*StakingChange {
+	changes := map[string]*StakingChange{}
+	change := func(staker string) *StakingChange {
+		// Updates without a staker are summed into a discarded change.
+		if staker == "" {
+			return &StakingChange{}
+		}
+		if _, ok := changes[staker]; !ok {
+			changes[staker] = &StakingChange{}
+		}
+		return changes[staker]
+	}
+
+	for _, update := range balanceUpdates {
+		switch {
+		case update.Kind == BalanceUpdateKindFreezer && update.Category == BalanceUpdateCategoryDeposits:
+			c := change(update.owner())
+			c.Staked.Add(&c.Staked.Int, &update.Change.Int)
+		case update.Kind == BalanceUpdateKindFreezer && update.Category == BalanceUpdateCategoryUnstakedDeposits:
+			c := change(update.owner())
+			c.Unstaked.Add(&c.Unstaked.Int, &update.Change.Int)
+		case update.Kind == BalanceUpdateKindStaking && update.Category == BalanceUpdateCategoryDelegatorNumerator:
+			c := change(update.Delegator)
+			c.PseudoTokens.Add(&c.PseudoTokens.Int, &update.Change.Int)
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,97 @@
+package gotezos
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StakingContents(t *testing.T) {
+	cases := []struct {
+		name       string
+		contents   Contents
+		entrypoint string
+		tag        string
+		amount     int64
+	}{
+		{"stake", StakeContents(mockAddressTz1, 5000000), EntrypointStake, "06", 5000000},
+		{"unstake", UnstakeContents(mockAddressTz1, 2000000), EntrypointUnstake, "07", 2000000},
+		{"finalize_unstake", FinalizeUnstakeContents(mockAddressTz1), EntrypointFinalizeUnstake, "08", 0},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, TRANSACTIONOP, tt.contents.Kind)
+			assert.Equal(t, mockAddressTz1, tt.contents.Source)
+			assert.Equal(t, mockAddressTz1, tt.contents.Destination)
+			assert.Equal(t, tt.amount, tt.contents.Amount.Int64())
+			assert.Equal(t, tt.entrypoint, tt.contents.Parameters.Entrypoint)
+
+			parameters, err := forgeParameters(tt.contents.Parameters)
+			assert.Nil(t, err)
+			assert.Equal(t, "ff"+tt.tag+"00000002030b", parameters)
+
+			unforged, rest, err := unforgeParameters(parameters)
+			assert.Nil(t, err)
+			assert.Empty(t, rest)
+			assert.Equal(t, tt.entrypoint, unforged.Entrypoint)
+		})
+	}
+}
+
+func Test_WalletClientStaking(t *testing.T) {
+	client, mock := testWalletClient(t)
+
+	_, err := client.Stake(context.Background(), 5000000)
+	assert.Nil(t, err)
+	_, err = client.Unstake(context.Background(), 2000000)
+	assert.Nil(t, err)
+	_, err = client.FinalizeUnstake(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, mock.injected, 3)
+
+	for i, entrypoint := range []string{EntrypointStake, EntrypointUnstake, EntrypointFinalizeUnstake} {
+		_, unforged, err := client.Client.UnforgeOperation(mock.injected[i], true)
+		assert.Nil(t, err)
+
+		last := (*unforged)[len(*unforged)-1]
+		assert.Equal(t, client.Address, last.Destination)
+		assert.Equal(t, entrypoint, last.Parameters.Entrypoint)
+	}
+}
+
+func Test_StakingChanges(t *testing.T) {
+	staker := "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+	baker := "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc"
+
+	// A stake and an unstake by a staker, and a stake by its baker, as returned by a Paris node.
+	var balanceUpdates []BalanceUpdates
+	err := json.Unmarshal([]byte(`[
+		{"kind":"contract","contract":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","change":"-5000000","origin":"block"},
+		{"kind":"freezer","category":"deposits","staker":{"contract":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","delegate":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc"},"change":"5000000","origin":"block"},
+		{"kind":"staking","category":"delegate_denominator","delegate":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","change":"4999000","origin":"block"},
+		{"kind":"staking","category":"delegator_numerator","delegator":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","change":"4999000","origin":"block"},
+		{"kind":"freezer","category":"deposits","staker":{"contract":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","delegate":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc"},"change":"-2000000","origin":"block"},
+		{"kind":"freezer","category":"unstaked_deposits","staker":{"contract":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","delegate":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc"},"cycle":750,"change":"2000000","origin":"block"},
+		{"kind":"staking","category":"delegator_numerator","delegator":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx","change":"-1999600","origin":"block"},
+		{"kind":"contract","contract":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","change":"-10000000","origin":"block"},
+		{"kind":"freezer","category":"deposits","staker":{"baker_own_stake":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc"},"change":"10000000","origin":"block"}
+	]`), &balanceUpdates)
+	assert.Nil(t, err)
+
+	changes := StakingChanges(balanceUpdates)
+	assert.Len(t, changes, 2)
+
+	assert.Equal(t, 0, changes[staker].Staked.Cmp(big.NewInt(3000000)))
+	assert.Equal(t, 0, changes[staker].Unstaked.Cmp(big.NewInt(2000000)))
+	assert.Equal(t, 0, changes[staker].PseudoTokens.Cmp(big.NewInt(2999400)))
+
+	assert.Equal(t, 0, changes[baker].Staked.Cmp(big.NewInt(10000000)))
+	assert.Equal(t, 0, changes[baker].Unstaked.Sign())
+
+	balances := BalanceChanges(balanceUpdates)
+	assert.Equal(t, 0, balances[staker].Sign())
+}
@@ -0,0 +1,78 @@
+package gotezos
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+/*
+Timestamp Wrapper
+Description: Timestamp wraps go's time.Time for the timestamps of RPC responses, which are RFC3339 strings
+for most endpoints and integers (seconds since the epoch) for others. Timestamps are in UTC.
+*/
+type Timestamp struct {
+	time.Time
+}
+
+/*
+UnmarshalJSON Function
+Description: Implements the json.Unmarshaler interface for Timestamp. RFC3339 strings, integers and integer
+strings are supported, null is the zero time.
+
+Parameters:
+	b:
+		The JSON representation of a timestamp.
+*/
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if bytes.Equal(b, []byte("null")) {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	var val string
+	if len(b) > 0 && b[0] == '"' {
+		err := json.Unmarshal(b, &val)
+		if err != nil {
+			return err
+		}
+	} else {
+		val = string(b)
+	}
+
+	timestamp, err := ParseTimestamp(val)
+	if err != nil {
+		return err
+	}
+	t.Time = timestamp
+
+	return nil
+}
+
+/*
+ParseTimestamp Function
+Description: Parses a timestamp as returned by the RPC or found in Michelson values: an RFC3339 date
+(e.g. 2020-01-02T15:04:05Z) or the number of seconds since the epoch.
+
+Parameters:
+	timestamp:
+		The timestamp to parse.
+*/
+func ParseTimestamp(timestamp string) (time.Time, error) {
+	timestamp = strings.TrimSpace(timestamp)
+	if seconds, err := strconv.ParseInt(timestamp, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "invalid timestamp '%s'", timestamp)
+	}
+
+	return t.UTC(), nil
+}
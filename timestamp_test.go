@@ -0,0 +1,68 @@
+package gotezos
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Timestamp(t *testing.T) {
+	type want struct {
+		err         bool
+		containsErr string
+		timestamp   time.Time
+	}
+
+	cases := []struct {
+		name  string
+		input string
+		want
+	}{
+		{
+			"parses rfc3339",
+			`"2020-01-02T15:04:05Z"`,
+			want{false, "", time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC)},
+		},
+		{
+			"parses rfc3339 with offset",
+			`"2020-01-02T17:04:05+02:00"`,
+			want{false, "", time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC)},
+		},
+		{
+			"parses integers",
+			`1577977445`,
+			want{false, "", time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC)},
+		},
+		{
+			"parses integer strings",
+			`"1577977445"`,
+			want{false, "", time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC)},
+		},
+		{
+			"parses null",
+			`null`,
+			want{false, "", time.Time{}},
+		},
+		{
+			"returns invalid timestamps",
+			`"yesterday"`,
+			want{true, "invalid timestamp 'yesterday'", time.Time{}},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var bootstrap Bootstrap
+			err := json.Unmarshal([]byte(`{"block":"BLTbZ3U7kHwGqSUdq3e5QMyn2aJu9uZkMdQAtRHGmFf46TKdcfM","timestamp":`+tt.input+`}`), &bootstrap)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			assert.True(t, tt.want.timestamp.Equal(bootstrap.Timestamp.Time))
+			assert.Equal(t, time.UTC, bootstrap.Timestamp.Location())
+		})
+	}
+
+	v, err := json.Marshal(Timestamp{time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC)})
+	assert.Nil(t, err)
+	assert.Equal(t, `"2020-01-02T15:04:05Z"`, string(v))
+}
@@ -0,0 +1,392 @@
+package gotezos
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+// tokenLedgerAnnots are the storage annotations FA1.2 and FA2 contracts commonly name their ledger with, in
+// order of preference.
+var tokenLedgerAnnots = []string{"%ledger", "%balances", "%tokens"}
+
+/*
+TokenSnapshotInput -
+Description: The input for the TokenSnapshot function.
+Function: func (t *GoTezos) TokenSnapshot(input *TokenSnapshotInput) (*TokenSnapshot, error) {}
+*/
+type TokenSnapshotInput struct {
+	// The block (hash, level, head or head~<n>) of which you want the balances.
+	// Required.
+	BlockID BlockID `validate:"required"`
+
+	// The FA1.2 or FA2 contract.
+	// Required.
+	Contract string `validate:"required"`
+
+	// The ID of the ledger big map. If empty, the big map of the storage annotated %ledger, %balances or
+	// %tokens is used.
+	BigMap string
+
+	// The token to snapshot, for ledgers keyed by token ID. If empty, the balances of all the tokens are returned.
+	TokenID string
+
+	// The backend listing the entries of the ledger, e.g. indexer.TzKT. Indexers list the ledger at their own
+	// head, so a Lister can only be used when BlockID is head. If nil, the ledger is rebuilt from the big map
+	// changes of the blocks from FromLevel to BlockID instead.
+	Lister BigMapLister
+
+	// The level the ledger was allocated at (or any level before), e.g. the origination of the contract.
+	// Required if Lister is nil.
+	FromLevel int
+}
+
+/*
+TokenSnapshot -
+Description: The balances of the holders of a token contract at a block, e.g. for an airdrop or a governance snapshot.
+Function: func (t *GoTezos) TokenSnapshot(input *TokenSnapshotInput) (*TokenSnapshot, error) {}
+*/
+type TokenSnapshot struct {
+	Contract string
+	// The ID of the ledger big map.
+	BigMap string
+	// The non zero balances, sorted by holder then token ID.
+	Balances []TokenBalance
+}
+
+/*
+TokenBalance -
+Description: The balance of a holder in a token, see TokenSnapshot.
+*/
+type TokenBalance struct {
+	Holder string
+	// The ID of the token, empty for ledgers not keyed by token ID (FA1.2 and single asset FA2 contracts).
+	TokenID string
+	Balance BigInt
+}
+
+/*
+Totals Function
+Description: Returns the sum of the balances of each token ID, i.e. the supply held at the snapshot.
+*/
+func (s *TokenSnapshot) Totals() map[string]*big.Int {
+	totals := map[string]*big.Int{}
+	for _, balance := range s.Balances {
+		if _, ok := totals[balance.TokenID]; !ok {
+			totals[balance.TokenID] = big.NewInt(0)
+		}
+		totals[balance.TokenID].Add(totals[balance.TokenID], &balance.Balance.Int)
+	}
+
+	return totals
+}
+
+/*
+TokenSnapshot Function
+Description: Reconstructs the balances of all the holders of an FA1.2 or FA2 contract at a block from its ledger
+big map. The ledger is decoded from its type, the layouts supported are address -> nat (FA1.2 and single asset
+FA2), address -> pair with a nat balance (e.g. the FA1.2 reference implementation), pair address nat -> nat
+(multi asset FA2) and nat -> address (NFTs, balance 1).
+
+Parameters:
+	input:
+		The contract and block to snapshot. BlockID and Contract are required, and FromLevel if Lister is nil or
+		BlockID is not head.
+*/
+func (t *GoTezos) TokenSnapshot(input *TokenSnapshotInput) (*TokenSnapshot, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+	if input.Lister == nil && input.FromLevel <= 0 {
+		return nil, errors.New("invalid input: FromLevel is required without a Lister")
+	}
+	if input.Lister != nil && input.BlockID.ID() != (BlockIDHead{}).ID() {
+		return nil, errors.Errorf("invalid input: a Lister lists the ledger at its own head, not at block '%s', use FromLevel instead", input.BlockID.ID())
+	}
+
+	script, err := t.ContractScript(&ContractScriptInput{BlockID: input.BlockID, Contract: input.Contract})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to snapshot token '%s'", input.Contract)
+	}
+
+	ledger, err := findTokenLedger(*script, input.BigMap)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to snapshot token '%s'", input.Contract)
+	}
+
+	var entries []BigMapEntry
+	if input.Lister != nil {
+		entries, err = listBigMap(input.Lister, ledger.id)
+	} else {
+		entries, err = t.replayBigMap(ledger.id, input.FromLevel, input.BlockID)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to snapshot token '%s'", input.Contract)
+	}
+
+	snapshot := &TokenSnapshot{Contract: input.Contract, BigMap: ledger.id, Balances: []TokenBalance{}}
+	for _, entry := range entries {
+		if entry.Key == nil {
+			return nil, errors.Errorf("failed to snapshot token '%s': no key listed for ledger entry", input.Contract)
+		}
+
+		balance, err := ledger.decode(*entry.Key, entry.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to snapshot token '%s'", input.Contract)
+		}
+		if balance.Balance.Sign() == 0 || (input.TokenID != "" && balance.TokenID != "" && balance.TokenID != input.TokenID) {
+			continue
+		}
+		snapshot.Balances = append(snapshot.Balances, balance)
+	}
+
+	sort.Slice(snapshot.Balances, func(i, j int) bool {
+		if snapshot.Balances[i].Holder != snapshot.Balances[j].Holder {
+			return snapshot.Balances[i].Holder < snapshot.Balances[j].Holder
+		}
+		return snapshot.Balances[i].TokenID < snapshot.Balances[j].TokenID
+	})
+
+	return snapshot, nil
+}
+
+// tokenLedger is the ledger big map of a token contract and its key and value types.
+type tokenLedger struct {
+	id        string
+	keyType   Micheline
+	valueType Micheline
+}
+
+// findTokenLedger returns the big map of the storage with the ID bigMap, or the one annotated as a ledger if
+// bigMap is empty.
+func findTokenLedger(script Script, bigMap string) (*tokenLedger, error) {
+	var storageType *Micheline
+	for _, section := range script.Code.Seq {
+		if isPrim(section, "storage", 1) {
+			storageType = &section.Args[0]
+		}
+	}
+	if storageType == nil {
+		return nil, errors.New("no storage type in script")
+	}
+
+	types, values, err := flattenTypedPair(*storageType, script.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgers := map[string]*tokenLedger{}
+	for i := range types {
+		if !isPrim(types[i], "big_map", 2) || values[i].Int == nil {
+			continue
+		}
+
+		ledger := &tokenLedger{id: values[i].Int.String(), keyType: types[i].Args[0], valueType: types[i].Args[1]}
+		if bigMap != "" && ledger.id == bigMap {
+			return ledger, nil
+		}
+		for _, annot := range types[i].Annots {
+			if _, ok := ledgers[annot]; !ok {
+				ledgers[annot] = ledger
+			}
+		}
+	}
+
+	if bigMap != "" {
+		return nil, errors.Errorf("no big map '%s' in storage", bigMap)
+	}
+	for _, annot := range tokenLedgerAnnots {
+		if ledger, ok := ledgers[annot]; ok {
+			return ledger, nil
+		}
+	}
+
+	return nil, errors.Errorf("no big map annotated %s in storage", strings.Join(tokenLedgerAnnots, ", "))
+}
+
+// decode returns the balance a ledger entry records.
+func (l *tokenLedger) decode(key, value Micheline) (TokenBalance, error) {
+	var balance TokenBalance
+
+	key, err := UnparseData(l.keyType, key, UnparsingModeReadable)
+	if err != nil {
+		return balance, err
+	}
+	value, err = UnparseData(l.valueType, value, UnparsingModeReadable)
+	if err != nil {
+		return balance, err
+	}
+
+	switch {
+	case isPrim(l.keyType, "nat", 0) && isPrim(l.valueType, "address", 0) && key.Int != nil:
+		balance.Holder, balance.TokenID = value.String, key.Int.String()
+		balance.Balance.SetInt64(1)
+		return balance, nil
+	case isPrim(l.keyType, "address", 0):
+		balance.Holder = key.String
+	case isPrim(l.keyType, "pair", -1):
+		types, values, err := flattenTypedPair(l.keyType, key)
+		if err != nil {
+			return balance, err
+		}
+		for i := range types {
+			switch {
+			case isPrim(types[i], "address", 0) && balance.Holder == "":
+				balance.Holder = values[i].String
+			case isPrim(types[i], "nat", 0) && values[i].Int != nil && balance.TokenID == "":
+				balance.TokenID = values[i].Int.String()
+			}
+		}
+		if balance.Holder == "" || balance.TokenID == "" {
+			return balance, errors.Errorf("unsupported ledger key type %s", l.keyType.CompactMichelson())
+		}
+	default:
+		return balance, errors.Errorf("unsupported ledger key type %s", l.keyType.CompactMichelson())
+	}
+
+	amount, err := tokenLedgerAmount(l.valueType, value)
+	if err != nil {
+		return balance, err
+	}
+	balance.Balance.Set(amount)
+
+	return balance, nil
+}
+
+// tokenLedgerAmount returns the nat of a ledger value annotated %balance, or else its first nat.
+func tokenLedgerAmount(typ, value Micheline) (*big.Int, error) {
+	types, values, err := flattenTypedPair(typ, value)
+	if err != nil {
+		return nil, err
+	}
+
+	var amount *big.Int
+	for i := range types {
+		if !isPrim(types[i], "nat", 0) || values[i].Int == nil {
+			continue
+		}
+		for _, annot := range types[i].Annots {
+			if annot == "%balance" {
+				return values[i].Int, nil
+			}
+		}
+		if amount == nil {
+			amount = values[i].Int
+		}
+	}
+	if amount == nil {
+		return nil, errors.Errorf("unsupported ledger value type %s", typ.CompactMichelson())
+	}
+
+	return amount, nil
+}
+
+// flattenTypedPair is FlattenPair returning the type of each leaf along with it.
+func flattenTypedPair(typ, value Micheline) ([]Micheline, []Micheline, error) {
+	if !isPrim(typ, "pair", -1) || len(typ.Args) < 2 {
+		return []Micheline{typ}, []Micheline{value}, nil
+	}
+
+	types, values, err := splitPair(typ, value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var leafTypes, leaves []Micheline
+	for i := range types {
+		lt, l, err := flattenTypedPair(types[i], values[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		leafTypes, leaves = append(leafTypes, lt...), append(leaves, l...)
+	}
+
+	return leafTypes, leaves, nil
+}
+
+// listBigMap returns all the entries of a big map the lister lists.
+func listBigMap(lister BigMapLister, bigMap string) ([]BigMapEntry, error) {
+	it := &BigMapIterator{
+		list: func(offset, limit int) ([]BigMapEntry, error) {
+			return lister.BigMapEntries(bigMap, offset, limit)
+		},
+		bigMap:   bigMap,
+		pageSize: DefaultBigMapPageSize,
+	}
+
+	entries := []BigMapEntry{}
+	for it.Next() {
+		entries = append(entries, it.Value())
+	}
+
+	return entries, it.Err()
+}
+
+// replayBigMap rebuilds the entries of a big map at a block by applying the big map changes of the blocks from
+// the level from to the block.
+func (t *GoTezos) replayBigMap(bigMap string, from int, blockID BlockID) ([]BigMapEntry, error) {
+	block, err := t.Block(blockID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get block")
+	}
+	if from > block.Header.Level {
+		return nil, errors.Errorf("invalid block range: %d is after block %d", from, block.Header.Level)
+	}
+
+	updates := []BigMapUpdate{}
+	if from < block.Header.Level {
+		updates, err = t.BigMapUpdates(from, block.Header.Level-1)
+		if err != nil {
+			return nil, err
+		}
+	}
+	updates = append(updates, block.BigMapUpdates()...)
+
+	// Temporary big maps are tracked as well, as the ledger may be copied from one, and only live during their operation.
+	maps := map[string]map[string]BigMapEntry{bigMap: {}}
+	var operation string
+	for _, update := range updates {
+		if update.OperationHash != operation {
+			for id := range maps {
+				if id != bigMap {
+					delete(maps, id)
+				}
+			}
+			operation = update.OperationHash
+		}
+		if update.BigMap != bigMap && !isTemporaryBigMap(update.BigMap) {
+			continue
+		}
+
+		switch update.Action {
+		case BigMapActionAlloc, BigMapActionRemove:
+			maps[update.BigMap] = map[string]BigMapEntry{}
+		case BigMapActionCopy:
+			entries := map[string]BigMapEntry{}
+			for hash, entry := range maps[update.SourceBigMap] {
+				entries[hash] = entry
+			}
+			maps[update.BigMap] = entries
+		case BigMapActionUpdate:
+			if maps[update.BigMap] == nil {
+				maps[update.BigMap] = map[string]BigMapEntry{}
+			}
+			if update.Value == nil {
+				delete(maps[update.BigMap], update.KeyHash)
+				continue
+			}
+			maps[update.BigMap][update.KeyHash] = BigMapEntry{KeyHash: update.KeyHash, Key: update.Key, Value: *update.Value}
+		}
+	}
+
+	entries := []BigMapEntry{}
+	for _, entry := range maps[bigMap] {
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
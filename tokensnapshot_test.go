@@ -0,0 +1,281 @@
+package gotezos
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	mockFA12ScriptResp = []byte(`{
+		"code": [
+			{"prim": "parameter", "args": [{"prim": "unit"}]},
+			{"prim": "storage", "args": [{"prim": "pair", "args": [
+				{"prim": "big_map", "args": [{"prim": "address"}, {"prim": "pair", "args": [{"prim": "map", "args": [{"prim": "address"}, {"prim": "nat"}], "annots": ["%allowances"]}, {"prim": "nat", "annots": ["%balance"]}]}], "annots": ["%ledger"]},
+				{"prim": "nat", "annots": ["%totalSupply"]}
+			]}]},
+			{"prim": "code", "args": [[]]}
+		],
+		"storage": {"prim": "Pair", "args": [{"int": "55"}, {"int": "100"}]}
+	}`)
+
+	mockFA2ScriptResp = []byte(`{
+		"code": [
+			{"prim": "parameter", "args": [{"prim": "unit"}]},
+			{"prim": "storage", "args": [{"prim": "pair", "args": [
+				{"prim": "big_map", "args": [{"prim": "pair", "args": [{"prim": "address"}, {"prim": "nat"}]}, {"prim": "nat"}], "annots": ["%ledger"]},
+				{"prim": "big_map", "args": [{"prim": "nat"}, {"prim": "address"}], "annots": ["%owners"]},
+				{"prim": "big_map", "args": [{"prim": "string"}, {"prim": "nat"}], "annots": ["%names"]}
+			]}]},
+			{"prim": "code", "args": [[]]}
+		],
+		"storage": {"prim": "Pair", "args": [{"int": "42"}, {"int": "43"}, {"int": "44"}]}
+	}`)
+
+	mockFA12OriginationBlockResp = []byte(`{
+		"hash": "BMWVEwEYw9m5iaHzqxDfkPzZTV4rhkSouRh3DkVMVGkxZ3EVaNs",
+		"header": {"level": 100},
+		"operations": [[], [], [], [{
+			"hash": "oo1Tqbv3sLdH4MUnrCmWxn8DqMSqBYpWaDhbULcf6eWRnNLnxgi",
+			"contents": [{
+				"kind": "origination",
+				"metadata": {
+					"operation_result": {
+						"status": "applied",
+						"lazy_storage_diff": [
+							{"kind": "big_map", "id": "-1", "diff": {"action": "alloc", "key_type": {"prim": "address"}, "value_type": {"prim": "nat"}, "updates": [
+								{"key_hash": "exprvDq5tNnR8xrEAhbbB3SmJTaRRvG28cGM9S1XUW2kgERwHbXaAC", "key": {"bytes": "000002298c03ed7d454a101eb7022bc95f7e5f41ac78"}, "value": {"prim": "Pair", "args": [[], {"int": "90"}]}},
+								{"key_hash": "exprtvHH13RnopPtf4XAkgdxR2u3VFzdWJvxq9nV9nn8H2M5P6SCqB", "key": {"bytes": "0000da6b4273731e9a26903c3fba93a8004ac0a12565"}, "value": {"prim": "Pair", "args": [[], {"int": "10"}]}}
+							]}},
+							{"kind": "big_map", "id": "55", "diff": {"action": "copy", "source": "-1", "updates": []}}
+						]
+					}
+				}
+			}]
+		}]]
+	}`)
+
+	mockFA12TransferBlockResp = []byte(`{
+		"hash": "BLTbZ3U7kHwGqSUdq3e5QMyn2aJu9uZkMdQAtRHGmFf46TKdcfM",
+		"header": {"level": 101},
+		"operations": [[], [], [], [{
+			"hash": "opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A",
+			"contents": [{
+				"kind": "transaction",
+				"metadata": {
+					"operation_result": {
+						"status": "applied",
+						"lazy_storage_diff": [
+							{"kind": "big_map", "id": "55", "diff": {"action": "update", "updates": [
+								{"key_hash": "exprtvHH13RnopPtf4XAkgdxR2u3VFzdWJvxq9nV9nn8H2M5P6SCqB", "key": {"bytes": "0000da6b4273731e9a26903c3fba93a8004ac0a12565"}},
+								{"key_hash": "exprvDq5tNnR8xrEAhbbB3SmJTaRRvG28cGM9S1XUW2kgERwHbXaAC", "key": {"string": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}, "value": {"prim": "Pair", "args": [[], {"int": "60"}]}},
+								{"key_hash": "exprtvHH13RnopPtf4XAkgdxR2u3VFzdWJvxq9nV9nn8H2M5P6SCqB", "key": {"bytes": "0000da6b4273731e9a26903c3fba93a8004ac0a12565"}, "value": {"prim": "Pair", "args": [[], {"int": "40"}]}}
+							]}},
+							{"kind": "big_map", "id": "56", "diff": {"action": "update", "updates": [
+								{"key_hash": "exprtvHH13RnopPtf4XAkgdxR2u3VFzdWJvxq9nV9nn8H2M5P6SCqB", "key": {"string": "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"}, "value": {"prim": "Pair", "args": [[], {"int": "1"}]}}
+							]}}
+						]
+					}
+				}
+			}]
+		}]]
+	}`)
+)
+
+// tokenBlocksHandlerMock serves the script of a token contract and blocks by their ID.
+func tokenBlocksHandlerMock(script []byte, blocks map[string][]byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if regScript.MatchString(r.URL.Path) {
+			w.Write(script)
+			return
+		}
+
+		for id, block := range blocks {
+			if r.URL.Path == "/chains/main/blocks/"+id {
+				w.Write(block)
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+		w.Write(mockRPCErrorResp)
+	})
+}
+
+// tokenListerMock lists the entries of a ledger page by page.
+type tokenListerMock struct {
+	entries []BigMapEntry
+}
+
+func (l *tokenListerMock) BigMapEntries(bigMap string, offset, limit int) ([]BigMapEntry, error) {
+	if offset >= len(l.entries) {
+		return []BigMapEntry{}, nil
+	}
+	if offset+limit > len(l.entries) {
+		limit = len(l.entries) - offset
+	}
+	return l.entries[offset : offset+limit], nil
+}
+
+func Test_TokenSnapshot(t *testing.T) {
+	fa2Key := func(holder string, tokenID int64) *Micheline {
+		key := NewMichelinePrim("Pair", NewMichelineString(holder), NewMichelineInt(tokenID))
+		return &key
+	}
+	nftKey := NewMichelineInt(7)
+	nameKey := NewMichelineString("tez")
+
+	fa2Ledger := &tokenListerMock{entries: []BigMapEntry{
+		{Key: fa2Key("tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", 1), Value: NewMichelineInt(5)},
+		{Key: fa2Key("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", 0), Value: NewMichelineInt(10)},
+		{Key: fa2Key("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", 1), Value: NewMichelineInt(0)},
+		{Key: fa2Key("KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", 1), Value: NewMichelineInt(3)},
+	}}
+
+	balance := func(holder, tokenID string, amount int64) TokenBalance {
+		b := TokenBalance{Holder: holder, TokenID: tokenID}
+		b.Balance.SetInt64(amount)
+		return b
+	}
+
+	type want struct {
+		err         bool
+		containsErr string
+		snapshot    *TokenSnapshot
+	}
+
+	cases := []struct {
+		name        string
+		inputHanler http.Handler
+		input       TokenSnapshotInput
+		want
+	}{
+		{
+			"returns invalid input",
+			tokenBlocksHandlerMock(mockFA2ScriptResp, nil),
+			TokenSnapshotInput{BlockID: BlockIDHead{}},
+			want{true, "invalid input", nil},
+		},
+		{
+			"returns invalid input without lister or level",
+			tokenBlocksHandlerMock(mockFA2ScriptResp, nil),
+			TokenSnapshotInput{BlockID: BlockIDHead{}, Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg"},
+			want{true, "FromLevel is required without a Lister", nil},
+		},
+		{
+			"handles lister at past block",
+			tokenBlocksHandlerMock(mockFA2ScriptResp, nil),
+			TokenSnapshotInput{BlockID: BlockIDLevel(100), Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", Lister: fa2Ledger},
+			want{true, "a Lister lists the ledger at its own head, not at block '100', use FromLevel instead", nil},
+		},
+		{
+			"handles failure to get script",
+			tokenBlocksHandlerMock(mockRPCErrorResp, nil),
+			TokenSnapshotInput{BlockID: BlockIDHead{}, Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", Lister: fa2Ledger},
+			want{true, "failed to snapshot token 'KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg'", nil},
+		},
+		{
+			"lists an FA2 ledger",
+			tokenBlocksHandlerMock(mockFA2ScriptResp, nil),
+			TokenSnapshotInput{BlockID: BlockIDHead{}, Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", Lister: fa2Ledger},
+			want{false, "", &TokenSnapshot{Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", BigMap: "42", Balances: []TokenBalance{
+				balance("KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", "1", 3),
+				balance("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "0", 10),
+				balance("tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", "1", 5),
+			}}},
+		},
+		{
+			"filters an FA2 ledger by token",
+			tokenBlocksHandlerMock(mockFA2ScriptResp, nil),
+			TokenSnapshotInput{BlockID: BlockIDHead{}, Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", Lister: fa2Ledger, TokenID: "1"},
+			want{false, "", &TokenSnapshot{Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", BigMap: "42", Balances: []TokenBalance{
+				balance("KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", "1", 3),
+				balance("tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", "1", 5),
+			}}},
+		},
+		{
+			"lists an NFT ledger",
+			tokenBlocksHandlerMock(mockFA2ScriptResp, nil),
+			TokenSnapshotInput{BlockID: BlockIDHead{}, Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", BigMap: "43", Lister: &tokenListerMock{entries: []BigMapEntry{
+				{Key: &nftKey, Value: NewMichelineString("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")},
+			}}},
+			want{false, "", &TokenSnapshot{Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", BigMap: "43", Balances: []TokenBalance{
+				balance("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "7", 1),
+			}}},
+		},
+		{
+			"handles unsupported ledger",
+			tokenBlocksHandlerMock(mockFA2ScriptResp, nil),
+			TokenSnapshotInput{BlockID: BlockIDHead{}, Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", BigMap: "44", Lister: &tokenListerMock{entries: []BigMapEntry{
+				{Key: &nameKey, Value: NewMichelineInt(1)},
+			}}},
+			want{true, "unsupported ledger key type string", nil},
+		},
+		{
+			"handles missing big map",
+			tokenBlocksHandlerMock(mockFA2ScriptResp, nil),
+			TokenSnapshotInput{BlockID: BlockIDHead{}, Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", BigMap: "45", Lister: fa2Ledger},
+			want{true, "no big map '45' in storage", nil},
+		},
+		{
+			"handles missing key",
+			tokenBlocksHandlerMock(mockFA2ScriptResp, nil),
+			TokenSnapshotInput{BlockID: BlockIDHead{}, Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", Lister: &tokenListerMock{entries: []BigMapEntry{
+				{Value: NewMichelineInt(1)},
+			}}},
+			want{true, "no key listed for ledger entry", nil},
+		},
+		{
+			"replays an FA1.2 ledger",
+			tokenBlocksHandlerMock(mockFA12ScriptResp, map[string][]byte{"head": mockFA12TransferBlockResp, "100": mockFA12OriginationBlockResp}),
+			TokenSnapshotInput{BlockID: BlockIDHead{}, Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", FromLevel: 100},
+			want{false, "", &TokenSnapshot{Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", BigMap: "55", Balances: []TokenBalance{
+				balance("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "", 60),
+				balance("tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", "", 40),
+			}}},
+		},
+		{
+			"replays an FA1.2 ledger at its origination",
+			tokenBlocksHandlerMock(mockFA12ScriptResp, map[string][]byte{"100": mockFA12OriginationBlockResp}),
+			TokenSnapshotInput{BlockID: BlockIDLevel(100), Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", FromLevel: 100},
+			want{false, "", &TokenSnapshot{Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", BigMap: "55", Balances: []TokenBalance{
+				balance("tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "", 90),
+				balance("tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", "", 10),
+			}}},
+		},
+		{
+			"handles failure to get blocks",
+			tokenBlocksHandlerMock(mockFA12ScriptResp, map[string][]byte{"head": mockFA12TransferBlockResp}),
+			TokenSnapshotInput{BlockID: BlockIDHead{}, Contract: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg", FromLevel: 100},
+			want{true, "failed to get big map updates of block 100", nil},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(gtGoldenHTTPMock(tt.inputHanler))
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			snapshot, err := gt.TokenSnapshot(&tt.input)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			assert.Equal(t, tt.want.snapshot, snapshot)
+		})
+	}
+}
+
+func Test_TokenSnapshotTotals(t *testing.T) {
+	snapshot := TokenSnapshot{Balances: []TokenBalance{{TokenID: "0"}, {TokenID: "1"}, {TokenID: "0"}}}
+	snapshot.Balances[0].Balance.SetInt64(10)
+	snapshot.Balances[1].Balance.SetInt64(3)
+	snapshot.Balances[2].Balance.SetInt64(5)
+
+	totals := snapshot.Totals()
+	assert.Len(t, totals, 2)
+	assert.Equal(t, "15", totals["0"].String())
+	assert.Equal(t, "3", totals["1"].String())
+}
@@ -0,0 +1,342 @@
+package gotezos
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+/*
+UnparseData Function
+Description: Converts a value between its readable and optimized representations offline, as the node does
+when unparsing data. In readable form addresses, key hashes, keys, signatures and chain ids are base58 strings
+and timestamps RFC3339 strings; in optimized form they are bytes and timestamps are ints. The value is walked
+along its type (pairs, options, ors, lists, sets and maps included), values already in the requested form and
+values of other types are returned unchanged.
+
+Parameters:
+	typ:
+		The Michelson type of the value, e.g. the key type of a big map.
+	value:
+		The value to convert.
+	mode:
+		UnparsingModeReadable or UnparsingModeOptimized. UnparsingModeOptimizedLegacy converts as
+		UnparsingModeOptimized, pair combs are left as is.
+*/
+func UnparseData(typ, value Micheline, mode UnparsingMode) (Micheline, error) {
+	var u unparser
+	switch mode {
+	case UnparsingModeReadable:
+	case UnparsingModeOptimized, UnparsingModeOptimizedLegacy:
+		u.optimized = true
+	default:
+		return Micheline{}, errors.Errorf("failed to unparse data: unknown unparsing mode '%s'", mode)
+	}
+
+	v, err := u.unparse(typ, value)
+	if err != nil {
+		return Micheline{}, errors.Wrap(err, "failed to unparse data")
+	}
+
+	return v, nil
+}
+
+/*
+BigMapKeyHash Function
+Description: Returns the expr hash a big map key is stored under: the blake2b hash of the key packed in its
+optimized form. Use it to query the value of a key with the big map RPC.
+
+Parameters:
+	keyType:
+		The key type of the big map.
+	key:
+		The key, in readable or optimized form.
+*/
+func BigMapKeyHash(keyType, key Micheline) (string, error) {
+	optimized, err := UnparseData(keyType, key, UnparsingModeOptimized)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to hash big map key")
+	}
+
+	packed, err := optimized.Pack()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to hash big map key")
+	}
+
+	hash := blake2b.Sum256(packed)
+	return b58cencode(hash[:], prefix_expr), nil
+}
+
+type unparser struct {
+	optimized bool
+}
+
+func (u unparser) unparse(typ, value Micheline) (Micheline, error) {
+	if typ.Kind != MichelineKindPrim {
+		return Micheline{}, errors.Errorf("invalid type %s", typ.CompactMichelson())
+	}
+
+	switch typ.Prim {
+	case "address", "contract":
+		return u.convert(typ, value, addressToOptimized, addressToReadable)
+	case "key_hash":
+		return u.convert(typ, value, keyHashToBytes, bytesToKeyHash)
+	case "key":
+		return u.convert(typ, value, publicKeyToBytes, bytesToPublicKey)
+	case "signature":
+		return u.convert(typ, value, signatureToBytes, func(sig []byte) (string, error) {
+			if len(sig) != 64 {
+				return "", errors.New("invalid signature bytes")
+			}
+			return b58cencode(sig, prefix_sig), nil
+		})
+	case "chain_id":
+		return u.convert(typ, value, chainIDToBytes, func(chainID []byte) (string, error) {
+			if len(chainID) != 4 {
+				return "", errors.New("invalid chain id bytes")
+			}
+			return b58cencode(chainID, prefix_chain_id), nil
+		})
+	case "timestamp":
+		return u.timestamp(value)
+	case "pair":
+		return u.pair(typ, value)
+	case "option":
+		if isPrim(value, "Some", 1) && len(typ.Args) == 1 {
+			return u.unparseArgs(value, typ.Args[0])
+		}
+	case "or":
+		if len(typ.Args) == 2 && isPrim(value, "Left", 1) {
+			return u.unparseArgs(value, typ.Args[0])
+		}
+		if len(typ.Args) == 2 && isPrim(value, "Right", 1) {
+			return u.unparseArgs(value, typ.Args[1])
+		}
+	case "list", "set":
+		if value.Kind == MichelineKindSeq && len(typ.Args) == 1 {
+			return u.unparseSeq(value, func(node Micheline) (Micheline, error) {
+				return u.unparse(typ.Args[0], node)
+			})
+		}
+	case "map", "big_map":
+		if value.Kind == MichelineKindSeq && len(typ.Args) == 2 {
+			return u.unparseSeq(value, func(node Micheline) (Micheline, error) {
+				if !isPrim(node, "Elt", 2) {
+					return Micheline{}, errors.Errorf("invalid map element %s", node.CompactMichelson())
+				}
+				return u.unparseArgs(node, typ.Args...)
+			})
+		}
+	}
+
+	return value, nil
+}
+
+// convert converts a value held as a base58 string in readable form and as bytes in optimized form.
+func (u unparser) convert(typ, value Micheline, toBytes func(string) ([]byte, error), toString func([]byte) (string, error)) (Micheline, error) {
+	switch {
+	case value.Kind == MichelineKindString && u.optimized:
+		b, err := toBytes(value.String)
+		if err != nil {
+			return Micheline{}, errors.Wrapf(err, "invalid %s", typ.Prim)
+		}
+		return NewMichelineBytes(b), nil
+	case value.Kind == MichelineKindBytes && !u.optimized:
+		s, err := toString(value.Bytes)
+		if err != nil {
+			return Micheline{}, errors.Wrapf(err, "invalid %s", typ.Prim)
+		}
+		return NewMichelineString(s), nil
+	case value.Kind == MichelineKindString || value.Kind == MichelineKindBytes:
+		return value, nil
+	default:
+		return Micheline{}, errors.Errorf("invalid %s %s", typ.Prim, value.CompactMichelson())
+	}
+}
+
+func (u unparser) timestamp(value Micheline) (Micheline, error) {
+	switch {
+	case value.Kind == MichelineKindString && u.optimized:
+		t, err := ParseTimestamp(value.String)
+		if err != nil {
+			return Micheline{}, err
+		}
+		return NewMichelineInt(t.Unix()), nil
+	case value.Kind == MichelineKindInt && !u.optimized:
+		if !value.Int.IsInt64() {
+			return value, nil
+		}
+		return NewMichelineString(time.Unix(value.Int.Int64(), 0).UTC().Format(time.RFC3339)), nil
+	case value.Kind == MichelineKindString || value.Kind == MichelineKindInt:
+		return value, nil
+	default:
+		return Micheline{}, errors.Errorf("invalid timestamp %s", value.CompactMichelson())
+	}
+}
+
+// pair converts the elements of a pair, written as nested pairs, as a comb (Pair a b c) or as a sequence.
+// The value keeps the form it is written in.
+func (u unparser) pair(typ, value Micheline) (Micheline, error) {
+	if len(typ.Args) < 2 {
+		return value, nil
+	}
+
+	types, values, err := splitPair(typ, value)
+	if err != nil {
+		return Micheline{}, err
+	}
+
+	first, err := u.unparse(types[0], values[0])
+	if err != nil {
+		return Micheline{}, err
+	}
+
+	second, err := u.unparse(types[1], values[1])
+	if err != nil {
+		return Micheline{}, err
+	}
+
+	converted := []Micheline{first, second}
+	if elements := pairElements(value); len(elements) > 2 {
+		converted = append([]Micheline{first}, second.Args...)
+	}
+
+	if value.Kind == MichelineKindSeq {
+		return NewMichelineSeq(converted...), nil
+	}
+
+	pair := value
+	pair.Args = converted
+	return pair, nil
+}
+
+/*
+FlattenPair Function
+Description: Returns the leaves of a value of a pair type, in order. Nested pairs are flattened whether the
+value is written as nested pairs, as a comb (Pair a b c) or as a sequence. A value of another type is its
+only leaf.
+
+Parameters:
+	typ:
+		The Michelson type of the value, e.g. the storage type of a contract.
+	value:
+		The value to flatten.
+*/
+func FlattenPair(typ, value Micheline) ([]Micheline, error) {
+	if !isPrim(typ, "pair", -1) || len(typ.Args) < 2 {
+		return []Micheline{value}, nil
+	}
+
+	types, values, err := splitPair(typ, value)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to flatten pair")
+	}
+
+	var leaves []Micheline
+	for i := range types {
+		l, err := FlattenPair(types[i], values[i])
+		if err != nil {
+			return nil, err
+		}
+		leaves = append(leaves, l...)
+	}
+
+	return leaves, nil
+}
+
+// splitPair splits both a pair type and its value into their first element and the comb of the others.
+func splitPair(typ, value Micheline) ([2]Micheline, [2]Micheline, error) {
+	elements := pairElements(value)
+	if elements == nil {
+		return [2]Micheline{}, [2]Micheline{}, errors.Errorf("invalid pair %s", value.CompactMichelson())
+	}
+
+	types := [2]Micheline{typ.Args[0], typ.Args[1]}
+	if len(typ.Args) > 2 {
+		types[1] = NewMichelinePrim("pair", typ.Args[1:]...)
+	}
+
+	values := [2]Micheline{elements[0], elements[1]}
+	if len(elements) > 2 {
+		values[1] = NewMichelinePrim("Pair", elements[1:]...)
+	}
+
+	return types, values, nil
+}
+
+// pairElements returns the elements of a pair value written as Pair or as a sequence, or nil if it is not a pair.
+func pairElements(value Micheline) []Micheline {
+	switch {
+	case isPrim(value, "Pair", -1) && len(value.Args) >= 2:
+		return value.Args
+	case value.Kind == MichelineKindSeq && len(value.Seq) >= 2:
+		return value.Seq
+	default:
+		return nil
+	}
+}
+
+// unparseArgs converts the arguments of a primitive value with their types.
+func (u unparser) unparseArgs(value Micheline, types ...Micheline) (Micheline, error) {
+	args := make([]Micheline, len(value.Args))
+	for i := range value.Args {
+		arg, err := u.unparse(types[i], value.Args[i])
+		if err != nil {
+			return Micheline{}, err
+		}
+		args[i] = arg
+	}
+
+	value.Args = args
+	return value, nil
+}
+
+func (u unparser) unparseSeq(value Micheline, unparse func(Micheline) (Micheline, error)) (Micheline, error) {
+	nodes := make([]Micheline, len(value.Seq))
+	for i := range value.Seq {
+		node, err := unparse(value.Seq[i])
+		if err != nil {
+			return Micheline{}, err
+		}
+		nodes[i] = node
+	}
+
+	return NewMichelineSeq(nodes...), nil
+}
+
+// addressToOptimized returns the binary form of an address, followed by its entrypoint if any (KT1...%mint).
+func addressToOptimized(address string) ([]byte, error) {
+	entrypoint := ""
+	if i := strings.Index(address, "%"); i >= 0 {
+		address, entrypoint = address[:i], address[i+1:]
+	}
+
+	b, err := addressToBytes(address)
+	if err != nil {
+		return nil, err
+	}
+	return append(b, entrypoint...), nil
+}
+
+// addressToReadable is the inverse of addressToOptimized.
+func addressToReadable(address []byte) (string, error) {
+	if len(address) < 22 {
+		return "", errors.New("invalid address bytes")
+	}
+
+	readable, err := bytesToAddress(address[:22])
+	if err != nil {
+		return "", err
+	}
+	if len(address) > 22 {
+		readable += "%" + string(address[22:])
+	}
+	return readable, nil
+}
+
+// isPrim returns whether the node is the primitive prim with argc arguments, or any number of them if argc < 0.
+func isPrim(node Micheline, prim string, argc int) bool {
+	return node.Kind == MichelineKindPrim && node.Prim == prim && (argc < 0 || len(node.Args) == argc)
+}
@@ -0,0 +1,171 @@
+package gotezos
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnparseData(t *testing.T) {
+	address, _ := hex.DecodeString("0000da6b4273731e9a26903c3fba93a8004ac0a12565")
+	contract, _ := hex.DecodeString("01a3d0f58d8964bd1b37fb0a0c197b38cf46608d49006d696e74")
+	key, _ := hex.DecodeString("00d74ede6b262c49ff0d6fc05f6196a36ccae6be70bb577ab89702953cec833ee0")
+
+	cases := []struct {
+		name      string
+		typ       string
+		readable  string
+		optimized Micheline
+		wantErr   string
+	}{
+		{
+			"address",
+			"address",
+			`"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"`,
+			NewMichelineBytes(address),
+			"",
+		},
+		{
+			"contract with entrypoint",
+			"contract nat",
+			`"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn%mint"`,
+			NewMichelineBytes(contract),
+			"",
+		},
+		{
+			"key hash",
+			"key_hash",
+			`"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"`,
+			NewMichelineBytes(address[1:]),
+			"",
+		},
+		{
+			"key",
+			"key",
+			`"edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G"`,
+			NewMichelineBytes(key),
+			"",
+		},
+		{
+			"timestamp",
+			"timestamp",
+			`"2021-12-24T10:00:00Z"`,
+			NewMichelineInt(1640340000),
+			"",
+		},
+		{
+			"chain id",
+			"chain_id",
+			`"NetXdQprcVkpaWU"`,
+			NewMichelineBytes([]byte{0x7a, 0x06, 0xa7, 0x70}),
+			"",
+		},
+		{
+			"nested values",
+			"pair (address %owner) (option timestamp) (map key_hash (list address))",
+			`Pair "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK" (Some "2021-12-24T10:00:00Z") { Elt "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK" { "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK" } }`,
+			NewMichelinePrim("Pair",
+				NewMichelineBytes(address),
+				NewMichelinePrim("Some", NewMichelineInt(1640340000)),
+				NewMichelineSeq(NewMichelinePrim("Elt", NewMichelineBytes(address[1:]), NewMichelineSeq(NewMichelineBytes(address)))),
+			),
+			"",
+		},
+		{
+			"nested pairs and ors",
+			"pair (or address nat) (pair nat timestamp)",
+			`Pair (Left "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK") (Pair 7 "2021-12-24T10:00:00Z")`,
+			NewMichelinePrim("Pair",
+				NewMichelinePrim("Left", NewMichelineBytes(address)),
+				NewMichelinePrim("Pair", NewMichelineInt(7), NewMichelineInt(1640340000)),
+			),
+			"",
+		},
+		{
+			"comb sequence",
+			"pair nat address timestamp",
+			`{ 7 ; "tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK" ; "2021-12-24T10:00:00Z" }`,
+			NewMichelineSeq(NewMichelineInt(7), NewMichelineBytes(address), NewMichelineInt(1640340000)),
+			"",
+		},
+		{
+			"returns invalid address",
+			"address",
+			`"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKL"`,
+			Micheline{},
+			"invalid address",
+		},
+		{
+			"returns invalid pair",
+			"pair nat address",
+			`7`,
+			Micheline{},
+			"invalid pair",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			typ := mustParseMichelson(t, tt.typ)
+			readable := mustParseMichelson(t, tt.readable)
+
+			optimized, err := UnparseData(typ, readable, UnparsingModeOptimized)
+			checkErr(t, tt.wantErr != "", tt.wantErr, err)
+			if tt.wantErr != "" {
+				return
+			}
+			assert.Equal(t, tt.optimized.CompactMichelson(), optimized.CompactMichelson())
+
+			back, err := UnparseData(typ, optimized, UnparsingModeReadable)
+			assert.Nil(t, err)
+			assert.Equal(t, readable.CompactMichelson(), back.CompactMichelson())
+
+			// Values already in the requested form are unchanged.
+			same, err := UnparseData(typ, readable, UnparsingModeReadable)
+			assert.Nil(t, err)
+			assert.Equal(t, readable, same)
+		})
+	}
+
+	_, err := UnparseData(NewMichelinePrim("nat"), NewMichelineInt(1), UnparsingMode("Pretty"))
+	checkErr(t, true, "unknown unparsing mode", err)
+}
+
+func Test_BigMapKeyHash(t *testing.T) {
+	hash, err := BigMapKeyHash(NewMichelinePrim("nat"), NewMichelineInt(0))
+	assert.Nil(t, err)
+	assert.Equal(t, "exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC", hash)
+
+	readable, err := BigMapKeyHash(NewMichelinePrim("address"), NewMichelineString("tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK"))
+	assert.Nil(t, err)
+	address, _ := hex.DecodeString("0000da6b4273731e9a26903c3fba93a8004ac0a12565")
+	optimized, err := BigMapKeyHash(NewMichelinePrim("address"), NewMichelineBytes(address))
+	assert.Nil(t, err)
+	assert.Equal(t, optimized, readable)
+
+	_, err = BigMapKeyHash(NewMichelinePrim("address"), NewMichelineInt(0))
+	checkErr(t, true, "failed to hash big map key", err)
+}
+
+func Test_FlattenPair(t *testing.T) {
+	typ := mustParseMichelson(t, "pair (nat %a) (pair (string %b) (option nat)) bool")
+	want := []Micheline{NewMichelineInt(1), NewMichelineString("b"), NewMichelinePrim("None"), NewMichelineBool(true)}
+
+	for _, value := range []string{
+		`Pair 1 (Pair "b" None) True`,
+		`Pair 1 (Pair (Pair "b" None) True)`,
+		`{ 1 ; { "b" ; None } ; True }`,
+	} {
+		leaves, err := FlattenPair(typ, mustParseMichelson(t, value))
+		assert.Nil(t, err)
+		assert.Equal(t, want, leaves)
+	}
+
+	leaves, err := FlattenPair(NewMichelinePrim("nat"), NewMichelineInt(1))
+	assert.Nil(t, err)
+	assert.Equal(t, []Micheline{NewMichelineInt(1)}, leaves)
+
+	_, err = FlattenPair(typ, NewMichelineInt(1))
+	checkErr(t, true, "failed to flatten pair", err)
+}
@@ -0,0 +1,159 @@
+package gotezos
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+/*
+ValidBlocksMonitorInput -
+Description: The filters of the blocks streamed by MonitorValidBlocks. If none are set, every valid block of the
+main chain is streamed.
+Function: func (t *GoTezos) MonitorValidBlocks(ctx context.Context, input *ValidBlocksMonitorInput) (<-chan ValidBlock, <-chan error, error) {}
+*/
+type ValidBlocksMonitorInput struct {
+	// Only stream the blocks of these protocols.
+	Protocols []string
+
+	// Only stream the blocks of which the next protocol is one of these, e.g. to watch the blocks activating
+	// a protocol.
+	NextProtocols []string
+
+	// How long the node can send nothing before the stream is considered dead (e.g. its connection silently
+	// dropped) and reopened. Defaults to DefaultStreamHeartbeat.
+	Heartbeat time.Duration
+}
+
+func (v *ValidBlocksMonitorInput) contructRPCOptions() []RPCOption {
+	var opts []RPCOption
+	for _, protocol := range v.Protocols {
+		opts = append(opts, RPCOption{Key: "protocol", Value: protocol})
+	}
+	for _, protocol := range v.NextProtocols {
+		opts = append(opts, RPCOption{Key: "next_protocol", Value: protocol})
+	}
+
+	return opts
+}
+
+/*
+ValidBlock -
+RPC: /monitor/valid_blocks (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-monitor-valid-blocks
+Description: The header of a block validated by the node, whether or not it is on the main branch.
+*/
+type ValidBlock struct {
+	ChainID        string    `json:"chain_id"`
+	Hash           string    `json:"hash"`
+	Level          int       `json:"level"`
+	Proto          int       `json:"proto"`
+	Predecessor    string    `json:"predecessor"`
+	Timestamp      Timestamp `json:"timestamp"`
+	ValidationPass int       `json:"validation_pass"`
+	OperationsHash string    `json:"operations_hash"`
+	Fitness        []string  `json:"fitness"`
+	Context        string    `json:"context"`
+	// The protocol specific part of the header (e.g. its payload hash, round and signature), hex encoded.
+	ProtocolData string `json:"protocol_data"`
+}
+
+/*
+MonitorValidBlocks RPC
+Path: /monitor/valid_blocks (GET)
+Link: https://tezos.gitlab.io/active/rpc.html#get-monitor-valid-blocks
+Description: Streams the blocks validated by the node. Unlike the heads of the chain, blocks of branches that
+were not (or not yet) selected are streamed too, e.g. to analyze forks and reorganizations. The stream is
+reopened when nothing was received for the heartbeat of the input, or when the node ends it. Both channels are
+closed when monitoring stops: once an RPC fails or the context is done. The error channel receives the reason
+monitoring stopped, if any.
+
+Parameters:
+	ctx:
+		Cancels monitoring.
+	input:
+		Filters the blocks streamed by protocol.
+*/
+func (t *GoTezos) MonitorValidBlocks(ctx context.Context, input *ValidBlocksMonitorInput) (<-chan ValidBlock, <-chan error, error) {
+	if input == nil {
+		input = &ValidBlocksMonitorInput{}
+	}
+	opts := input.contructRPCOptions()
+	ctx, cancel := t.untilClosed(ctx)
+
+	subscribe := func() (io.ReadCloser, error) {
+		body, err := t.streamContext(ctx, "/monitor/valid_blocks", opts...)
+		if err != nil {
+			return nil, err
+		}
+		return withHeartbeat(body, input.Heartbeat), nil
+	}
+
+	body, err := subscribe()
+	if err != nil {
+		cancel()
+		return nil, nil, errors.Wrap(err, "failed to monitor valid blocks")
+	}
+
+	blocks := make(chan ValidBlock)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer cancel()
+		defer close(errs)
+		defer close(blocks)
+
+		for {
+			err := monitorValidBlocksStream(ctx, body, blocks)
+			body.Close()
+			if ctx.Err() != nil {
+				errs <- ctx.Err()
+				return
+			}
+			if err != nil {
+				errs <- errors.Wrap(err, "failed to monitor valid blocks")
+				return
+			}
+
+			body, err = subscribe()
+			if err != nil {
+				if ctx.Err() != nil {
+					err = ctx.Err()
+				}
+				errs <- errors.Wrap(err, "failed to monitor valid blocks")
+				return
+			}
+		}
+	}()
+
+	return blocks, errs, nil
+}
+
+// monitorValidBlocksStream sends the blocks of a valid_blocks stream, a sequence of JSON objects, until it ends.
+// A stream that ends or receives nothing for the heartbeat while waiting for the next block is not an error.
+func monitorValidBlocksStream(ctx context.Context, body io.Reader, blocks chan<- ValidBlock) error {
+	decoder := json.NewDecoder(body)
+	for {
+		var block ValidBlock
+		err := decoder.Decode(&block)
+		if err == io.EOF {
+			return nil
+		}
+		// Only a silent stream is reopened, other timeouts (e.g. of the client) are errors.
+		if _, ok := errors.Cause(err).(heartbeatTimeout); ok {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "could not unmarshal valid block")
+		}
+
+		select {
+		case blocks <- block:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
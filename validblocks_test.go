@@ -0,0 +1,130 @@
+package gotezos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validBlocksMonitorHandlerMock(chunks [][]string, queries *[]string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/monitor/valid_blocks") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		*queries = append(*queries, r.URL.RawQuery)
+		if len(chunks) == 0 {
+			<-r.Context().Done()
+			return
+		}
+
+		for _, chunk := range chunks[0] {
+			w.Write([]byte(chunk))
+			w.(http.Flusher).Flush()
+		}
+		chunks = chunks[1:]
+	})
+}
+
+func Test_MonitorValidBlocks(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(gtGoldenHTTPMock(validBlocksMonitorHandlerMock([][]string{
+		{
+			`{"chain_id":"NetXdQprcVkpaWU","hash":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","level":1300000,"proto":16,"predecessor":"BLc7tKfzia9hnaY1YTMS6RkDniQBoApM4EjKFRLucsuHbiy3eqt","timestamp":"2023-06-14T08:11:25Z","validation_pass":4,"operations_hash":"LLoaR5ZtHkAhkGfV4dVNbFmLFRpr2yhYvf9H4HWTuUwhVEfdpgsA5","fitness":["02","0013d620","","ffffffff","00000000"],"context":"CoVBYdAGWBoDTkiVXJEGX6FQUTmXH8Wj5zK9B4wgBxEo7uNRPgRF","protocol_data":"dd9fb6d6"}`,
+			`{"chain_id":"NetXdQprcVkpaWU","hash":"BLc7tKfzia9hnaY1YTMS6RkDniQBoApM4EjKFRLucsuHbiy3eqt","level":1300000,"proto":16,"predecessor":"BLc7tKfzia9hnaY1YTMS6RkDniQBoApM4EjKFRLucsuHbiy3eqt","timestamp":"2023-06-14T08:11:30Z","validation_pass":4,"operations_hash":"LLoaR5ZtHkAhkGfV4dVNbFmLFRpr2yhYvf9H4HWTuUwhVEfdpgsA5","fitness":["02","0013d620","","fffffffe","00000001"],"context":"CoVBYdAGWBoDTkiVXJEGX6FQUTmXH8Wj5zK9B4wgBxEo7uNRPgRF","protocol_data":"dd9fb6d7"}`,
+		},
+		{
+			`{"chain_id":"NetXdQprcVkpaWU","hash":"BMeaiFq5S6EuPVR3ctvNGWT7dufc35SfxsUCvHSVvkhShd6ZBEh","level":1300001}`,
+		},
+	}, &queries, blankHandler)))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	blocks, errs, err := gt.MonitorValidBlocks(ctx, &ValidBlocksMonitorInput{
+		Protocols:     []string{"PtMumbai2TmsJHNGRkD8v8YDbtao7BLUC3wjASn1inAKLFCjaH1"},
+		NextProtocols: []string{"PtMumbai2TmsJHNGRkD8v8YDbtao7BLUC3wjASn1inAKLFCjaH1", "PtNairobiyssHuh87hEhfVBGCVrK3WnS8Z2FT4ymB5tAa4r1nQf"},
+	})
+	assert.Nil(t, err)
+
+	var hashes []string
+	for block := range blocks {
+		hashes = append(hashes, block.Hash)
+		if len(hashes) == 2 {
+			assert.Equal(t, 1300000, block.Level)
+			assert.Equal(t, "BLc7tKfzia9hnaY1YTMS6RkDniQBoApM4EjKFRLucsuHbiy3eqt", block.Predecessor)
+			assert.Equal(t, "fffffffe", block.Fitness[3])
+			assert.Equal(t, "dd9fb6d7", block.ProtocolData)
+		}
+		if len(hashes) == 3 {
+			cancel()
+		}
+	}
+	checkErr(t, true, "context canceled", <-errs)
+
+	assert.Equal(t, []string{
+		"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1",
+		"BLc7tKfzia9hnaY1YTMS6RkDniQBoApM4EjKFRLucsuHbiy3eqt",
+		"BMeaiFq5S6EuPVR3ctvNGWT7dufc35SfxsUCvHSVvkhShd6ZBEh",
+	}, hashes)
+	assert.Equal(t, "next_protocol=PtMumbai2TmsJHNGRkD8v8YDbtao7BLUC3wjASn1inAKLFCjaH1&next_protocol=PtNairobiyssHuh87hEhfVBGCVrK3WnS8Z2FT4ymB5tAa4r1nQf&protocol=PtMumbai2TmsJHNGRkD8v8YDbtao7BLUC3wjASn1inAKLFCjaH1", queries[0])
+	assert.Nil(t, (&ValidBlocksMonitorInput{}).contructRPCOptions())
+}
+
+func Test_MonitorValidBlocksClientTimeout(t *testing.T) {
+	var subscriptions int
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subscriptions++
+		for level := 1300000; ; level++ {
+			w.Write([]byte(fmt.Sprintf(`{"hash":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","level":%d}`, level)))
+			w.(http.Flusher).Flush()
+
+			select {
+			case <-time.After(20 * time.Millisecond):
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+	gt.SetClient(&http.Client{Timeout: 100 * time.Millisecond})
+
+	blocks, errs, err := gt.MonitorValidBlocks(context.Background(), nil)
+	assert.Nil(t, err)
+
+	var levels []int
+	for block := range blocks {
+		levels = append(levels, block.Level)
+	}
+	checkErr(t, true, "failed to monitor valid blocks", <-errs)
+	assert.NotEmpty(t, levels)
+	assert.Equal(t, 1, subscriptions)
+}
+
+func Test_MonitorValidBlocksFailure(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write(mockRPCErrorResp)
+	})))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	_, _, err = gt.MonitorValidBlocks(context.Background(), nil)
+	checkErr(t, true, "failed to monitor valid blocks", err)
+}
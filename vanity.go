@@ -0,0 +1,142 @@
+package gotezos
+
+import (
+	"context"
+	"crypto/rand"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ed25519"
+)
+
+// tz1FirstCharacters are the characters a tz1 address can have after "tz1".
+const tz1FirstCharacters = "KLMNPQRSTUVWXYZabcdefghi"
+
+/*
+VanityInput -
+Description: The pattern of a vanity address. Every base58 character of the pattern divides the odds of
+a key matching by 58 (by about 34 if case insensitive), so patterns longer than 5 or 6 characters take hours
+or more to find.
+Function: func VanityWallet(ctx context.Context, input *VanityInput) (*Wallet, error) {}
+*/
+type VanityInput struct {
+	// The characters the address starts with after "tz1". Not every character can follow "tz1": the first
+	// character is one of tz1FirstCharacters.
+	Prefix string
+
+	// The characters the address ends with.
+	Suffix string
+
+	// Whether the prefix and suffix match regardless of case.
+	CaseInsensitive bool
+
+	// The number of keys generated in parallel. Defaults to the number of CPUs.
+	Workers int
+}
+
+/*
+VanityWallet Function
+Description: Generates random ed25519 keys until the tz1 address of one matches the pattern, and returns
+its wallet. Generating stops when the context is done.
+
+Parameters:
+	ctx:
+		Cancels generating, e.g. after a timeout.
+	input:
+		The pattern of the address.
+*/
+func VanityWallet(ctx context.Context, input *VanityInput) (*Wallet, error) {
+	if input == nil {
+		return nil, errors.New("invalid input: nil input")
+	}
+
+	prefix, suffix := input.Prefix, input.Suffix
+	if input.CaseInsensitive {
+		prefix, suffix = strings.ToLower(prefix), strings.ToLower(suffix)
+	}
+
+	// With a case insensitive pattern, a character is valid if either of its cases is.
+	valid := func(c string, characters string) bool {
+		return strings.Contains(characters, c) || (input.CaseInsensitive && strings.Contains(characters, strings.ToUpper(c)))
+	}
+
+	for _, c := range prefix + suffix {
+		if !valid(string(c), alphabet) {
+			return nil, errors.Errorf("invalid input: '%c' is not a base58 character", c)
+		}
+	}
+
+	if prefix != "" && !valid(prefix[:1], tz1FirstCharacters) {
+		return nil, errors.Errorf("invalid input: no tz1 address starts with 'tz1%s'", input.Prefix[:1])
+	}
+
+	workers := input.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	found := make(chan *Wallet, 1)
+	errs := make(chan error, 1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for ctx.Err() == nil {
+				pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+				if err != nil {
+					select {
+					case errs <- errors.Wrap(err, "failed to generate key"):
+					default:
+					}
+					cancel()
+					return
+				}
+
+				address, err := generatePublicHash(pubKey)
+				if err != nil {
+					continue
+				}
+
+				body := address[3:]
+				if input.CaseInsensitive {
+					body = strings.ToLower(body)
+				}
+				if !strings.HasPrefix(body, prefix) || !strings.HasSuffix(body, suffix) {
+					continue
+				}
+
+				select {
+				case found <- &Wallet{
+					Address: address,
+					Kp:      keyPair{PrivKey: privKey, PubKey: pubKey},
+					Seed:    privKey.Seed(),
+					Sk:      b58cencode(privKey, prefix_edsk),
+					Pk:      b58cencode(pubKey, prefix_edpk),
+				}:
+				default:
+				}
+				cancel()
+				return
+			}
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case wallet := <-found:
+		return wallet, nil
+	case err := <-errs:
+		return nil, err
+	default:
+		return nil, errors.Wrap(parent.Err(), "failed to find vanity address")
+	}
+}
@@ -0,0 +1,82 @@
+package gotezos
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_VanityWallet(t *testing.T) {
+	type want struct {
+		err         bool
+		containsErr string
+	}
+
+	cases := []struct {
+		name    string
+		input   *VanityInput
+		timeout time.Duration
+		want
+	}{
+		{
+			"is successful with a suffix",
+			&VanityInput{Suffix: "a"},
+			time.Minute,
+			want{false, ""},
+		},
+		{
+			"is successful with a case insensitive prefix",
+			&VanityInput{Prefix: "x", CaseInsensitive: true, Workers: 2},
+			time.Minute,
+			want{false, ""},
+		},
+		{
+			"handles non base58 characters",
+			&VanityInput{Suffix: "0"},
+			time.Minute,
+			want{true, "'0' is not a base58 character"},
+		},
+		{
+			"handles impossible prefix",
+			&VanityInput{Prefix: "z"},
+			time.Minute,
+			want{true, "no tz1 address starts with 'tz1z'"},
+		},
+		{
+			"handles context done",
+			&VanityInput{Suffix: "zzzzzzzzzz"},
+			10 * time.Millisecond,
+			want{true, "context deadline exceeded"},
+		},
+		{
+			"handles nil input",
+			nil,
+			time.Minute,
+			want{true, "invalid input"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+
+			wallet, err := VanityWallet(ctx, tt.input)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			if tt.want.err {
+				return
+			}
+
+			address := strings.ToLower(wallet.Address)
+			assert.True(t, strings.HasPrefix(address, "tz1"+strings.ToLower(tt.input.Prefix)))
+			assert.True(t, strings.HasSuffix(address, strings.ToLower(tt.input.Suffix)))
+
+			imported, err := ImportWallet(wallet.Address, wallet.Pk, wallet.Sk)
+			assert.Nil(t, err)
+			assert.Equal(t, wallet.Address, imported.Address)
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package gotezos
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/ed25519"
+)
+
+/*
+VerifySignature Function
+Description: Checks a signature of a message by the secret key of a public key, e.g. to prove a user owns an
+account from a message they signed with their wallet. Following the Tezos signature scheme the blake2b-256
+digest of the message is verified, as signed by Wallet.Sign. Returns false if the signature does not match, and
+an error if the public key or signature is malformed. Signatures of operations and blocks are of their forged
+bytes prefixed with a watermark, see Watermark.
+
+Parameters:
+	publicKey:
+		The public key (edpk, sppk or p2pk) of the signer. An address only commits to the hash of its public key,
+		so check the public key is the one of the account (e.g. with ManagerKey) before trusting a signature.
+	message:
+		The bytes signed (e.g. a packed Michelson value).
+	signature:
+		The signature (edsig, spsig1, p2sig or a generic sig).
+*/
+func VerifySignature(publicKey string, message []byte, signature string) (bool, error) {
+	key, err := publicKeyToBytes(publicKey)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to verify signature")
+	}
+
+	sig, err := signatureToBytes(signature)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to verify signature")
+	}
+
+	// A signature of a specific curve must match the curve of the key, a generic one may be of any.
+	for tag, p := range []prefix{prefix_edsig, prefix_spsig, prefix_p2sig} {
+		if _, err := b58cdecodeChecked(signature, p, 64); err == nil && int(key[0]) != tag {
+			return false, errors.Errorf("failed to verify signature: signature '%s' is not of the curve of public key '%s'", signature, publicKey)
+		}
+	}
+
+	digest := blake2b.Sum256(message)
+	switch key[0] {
+	case 0:
+		return ed25519.Verify(ed25519.PublicKey(key[1:]), digest[:], sig), nil
+	case 1:
+		return secp256k1.verify(key[1:], digest[:], sig)
+	default:
+		x, y, err := decompressPoint(p256, key[1:])
+		if err != nil {
+			return false, errors.Wrap(err, "failed to verify signature")
+		}
+		r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+		return ecdsa.Verify(&ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, digest[:], r, s), nil
+	}
+}
+
+/*
+CheckAddressChecksum Function
+Description: Checks an address is well formed: a base58check encoded tz1, tz2, tz3, KT1 or sr1 address with a
+valid checksum, e.g. before paying out to an address typed by a user. A typo in an address fails the checksum.
+
+Parameters:
+	address:
+		The address to check.
+*/
+func CheckAddressChecksum(address string) error {
+	decoded, err := decode(address)
+	if err != nil {
+		return errors.Errorf("invalid address '%s': invalid base58 checksum", address)
+	}
+
+	for _, p := range []prefix{prefix_tz1, prefix_tz2, prefix_tz3, prefix_kt, prefix_sr1} {
+		if bytes.HasPrefix(decoded, p) && len(decoded) == len(p)+20 {
+			return nil
+		}
+	}
+
+	return errors.Errorf("invalid address '%s': unknown prefix or length", address)
+}
+
+// weierstrassCurve is a curve y² = x³ + ax + b over the integers modulo p, with a base point of order n.
+type weierstrassCurve struct {
+	p, a, b, n *big.Int
+	gx, gy     *big.Int
+	// lowS rejects signatures of which s is above n / 2, as libsecp256k1 does since (r, n - s) is also valid.
+	lowS bool
+}
+
+var (
+	secp256k1 = weierstrassCurve{
+		p:    hexInt("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f"),
+		a:    big.NewInt(0),
+		b:    big.NewInt(7),
+		n:    hexInt("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"),
+		gx:   hexInt("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"),
+		gy:   hexInt("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"),
+		lowS: true,
+	}
+	p256 = weierstrassCurve{
+		p: elliptic.P256().Params().P,
+		a: big.NewInt(-3),
+		b: elliptic.P256().Params().B,
+		n: elliptic.P256().Params().N,
+	}
+)
+
+func hexInt(s string) *big.Int {
+	i, _ := new(big.Int).SetString(s, 16)
+	return i
+}
+
+// decompressPoint returns the coordinates of a point compressed as its x coordinate, prefixed by the parity of y.
+func decompressPoint(c weierstrassCurve, key []byte) (*big.Int, *big.Int, error) {
+	if len(key) != 33 || (key[0] != 2 && key[0] != 3) {
+		return nil, nil, errors.New("invalid compressed public key")
+	}
+
+	x := new(big.Int).SetBytes(key[1:])
+	if x.Cmp(c.p) >= 0 {
+		return nil, nil, errors.New("invalid compressed public key")
+	}
+
+	y2 := new(big.Int).Mul(x, x)
+	y2.Add(y2, c.a).Mul(y2, x).Add(y2, c.b).Mod(y2, c.p)
+	y := new(big.Int).ModSqrt(y2, c.p)
+	if y == nil {
+		return nil, nil, errors.New("invalid compressed public key: not on the curve")
+	}
+	if y.Bit(0) != uint(key[0]&1) {
+		y.Sub(c.p, y)
+	}
+
+	return x, y, nil
+}
+
+// verify checks an ECDSA signature (r || s) of a digest by a compressed public key. The curve arithmetic is not
+// constant time, which is fine as it only handles public data.
+func (c weierstrassCurve) verify(key, digest, sig []byte) (bool, error) {
+	x, y, err := decompressPoint(c, key)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to verify signature")
+	}
+
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+	if r.Sign() == 0 || s.Sign() == 0 || r.Cmp(c.n) >= 0 || s.Cmp(c.n) >= 0 {
+		return false, nil
+	}
+	if c.lowS && s.Cmp(new(big.Int).Rsh(c.n, 1)) > 0 {
+		return false, nil
+	}
+
+	e := new(big.Int).SetBytes(digest)
+	w := new(big.Int).ModInverse(s, c.n)
+	u1 := new(big.Int).Mul(e, w)
+	u1.Mod(u1, c.n)
+	u2 := new(big.Int).Mul(r, w)
+	u2.Mod(u2, c.n)
+
+	q := c.add(c.multiply(point{c.gx, c.gy}, u1), c.multiply(point{x, y}, u2))
+	if q[0] == nil {
+		return false, nil
+	}
+
+	return new(big.Int).Mod(q[0], c.n).Cmp(r) == 0, nil
+}
+
+// point is a point of a curve in affine coordinates, nil at infinity.
+type point [2]*big.Int
+
+func (c weierstrassCurve) multiply(p point, k *big.Int) point {
+	result := point{}
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		result = c.add(result, result)
+		if k.Bit(i) == 1 {
+			result = c.add(result, p)
+		}
+	}
+
+	return result
+}
+
+func (c weierstrassCurve) add(p1, p2 point) point {
+	if p1[0] == nil {
+		return p2
+	}
+	if p2[0] == nil {
+		return p1
+	}
+
+	var slope *big.Int
+	if p1[0].Cmp(p2[0]) == 0 {
+		sum := new(big.Int).Add(p1[1], p2[1])
+		if sum.Mod(sum, c.p).Sign() == 0 {
+			return point{}
+		}
+		// The tangent: (3x² + a) / 2y.
+		slope = new(big.Int).Mul(p1[0], p1[0])
+		slope.Mul(slope, big.NewInt(3)).Add(slope, c.a)
+		slope.Mul(slope, new(big.Int).ModInverse(new(big.Int).Lsh(p1[1], 1), c.p))
+	} else {
+		// The chord: (y2 - y1) / (x2 - x1).
+		dx := new(big.Int).Sub(p2[0], p1[0])
+		dx.Mod(dx, c.p)
+		slope = new(big.Int).Sub(p2[1], p1[1])
+		slope.Mul(slope, dx.ModInverse(dx, c.p))
+	}
+	slope.Mod(slope, c.p)
+
+	x := new(big.Int).Mul(slope, slope)
+	x.Sub(x, p1[0]).Sub(x, p2[0]).Mod(x, c.p)
+	y := new(big.Int).Sub(p1[0], x)
+	y.Mul(y, slope).Sub(y, p1[1]).Mod(y, c.p)
+
+	return point{x, y}
+}
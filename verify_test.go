@@ -0,0 +1,102 @@
+package gotezos
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+func Test_VerifySignature(t *testing.T) {
+	message := []byte("tezos ownership proof")
+
+	wallet, err := ImportWallet("tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK", "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G", "edskSA4oADtx6DTT6eXdBc6Pv5MoVBGXUzy8bBryi6D96RQNQYcRfVEXd2nuE2ZZPxs4YLZeM7KazUULFT1SfMDNyKFCUgk6vR")
+	assert.Nil(t, err)
+	edsig, err := wallet.Sign(message)
+	assert.Nil(t, err)
+	edsigBytes, err := signatureToBytes(edsig)
+	assert.Nil(t, err)
+
+	// Signed by the secret key 0x1234567890abcdef... of the message.
+	sppk := "sppk7aj8bC26meHTtfixTudrNdCEUfTnXnqQBYbqVAJM4o96fzcEixK"
+	spsig := "spsig1Q7yuqm8ydStKv6G9mV4GfhbsvTJNHoi3UwYZoQK728nRY5hqXjf2gWmzzCqPmaAmtxHt31Dc3F1f9S63drAu9JbPP3NbP"
+
+	// The same signature with s replaced by n - s, valid ECDSA but rejected by Tezos.
+	spsigBytes, err := signatureToBytes(spsig)
+	assert.Nil(t, err)
+	highS := new(big.Int).Sub(secp256k1.n, new(big.Int).SetBytes(spsigBytes[32:]))
+	highSBytes := make([]byte, 64)
+	copy(highSBytes, spsigBytes[:32])
+	copy(highSBytes[64-len(highS.Bytes()):], highS.Bytes())
+	highSpsig := b58cencode(highSBytes, prefix_spsig)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	compressed := make([]byte, 33)
+	compressed[0] = byte(2 + key.Y.Bit(0))
+	copy(compressed[33-len(key.X.Bytes()):], key.X.Bytes())
+	p2pk := b58cencode(compressed, prefix_p2pk)
+	digest := blake2b.Sum256(message)
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	assert.Nil(t, err)
+	p2sigBytes := make([]byte, 64)
+	copy(p2sigBytes[32-len(r.Bytes()):], r.Bytes())
+	copy(p2sigBytes[64-len(s.Bytes()):], s.Bytes())
+	p2sig := b58cencode(p2sigBytes, prefix_p2sig)
+
+	cases := []struct {
+		name        string
+		publicKey   string
+		message     []byte
+		signature   string
+		want        bool
+		errContains string
+	}{
+		{"verifies ed25519", wallet.Pk, message, edsig, true, ""},
+		{"verifies ed25519 generic signature", wallet.Pk, message, b58cencode(edsigBytes, prefix_sig), true, ""},
+		{"verifies secp256k1", sppk, message, spsig, true, ""},
+		{"verifies secp256k1 generic signature", sppk, message, "siggJJan9tWHUjwk6WBcvucm2Dy6fjJEB1ZupDo1F1J8D4zURtexfH5Z2vJcdkLNWJhBD9959bAu6HZriyXmAfyvmUqrVfAv", true, ""},
+		{"verifies p256", p2pk, message, p2sig, true, ""},
+		{"rejects ed25519 signature of other message", wallet.Pk, []byte("other"), edsig, false, ""},
+		{"rejects secp256k1 signature of other message", sppk, []byte("other"), spsig, false, ""},
+		{"rejects secp256k1 signature with high s", sppk, message, highSpsig, false, ""},
+		{"rejects p256 signature of other message", p2pk, []byte("other"), p2sig, false, ""},
+		{"rejects signature of other key", wallet.Pk, message, b58cencode(p2sigBytes, prefix_sig), false, ""},
+		{"handles signature of other curve", sppk, message, edsig, false, "is not of the curve of public key"},
+		{"handles invalid public key", mockAddressTz1, message, edsig, false, "failed to verify signature: invalid public key"},
+		{"handles invalid signature", wallet.Pk, message, "edsig", false, "failed to verify signature: invalid signature"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := VerifySignature(tt.publicKey, tt.message, tt.signature)
+			checkErr(t, tt.errContains != "", tt.errContains, err)
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+}
+
+func Test_CheckAddressChecksum(t *testing.T) {
+	cases := []struct {
+		name        string
+		address     string
+		errContains string
+	}{
+		{"accepts tz1", "tz1LSAycAVcNdYnXCy18bwVksXci8gUC2YpA", ""},
+		{"accepts KT1", "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", ""},
+		{"handles typo", "tz1LSAycAVcNdYnXCy18bwVksXci8gUC2YpB", "invalid address 'tz1LSAycAVcNdYnXCy18bwVksXci8gUC2YpB': invalid base58 checksum"},
+		{"handles public key", "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G", "unknown prefix or length"},
+		{"handles empty address", "", "invalid base58 checksum"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckAddressChecksum(tt.address)
+			checkErr(t, tt.errContains != "", tt.errContains, err)
+		})
+	}
+}
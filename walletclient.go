@@ -0,0 +1,687 @@
+package gotezos
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// The minimal fee a baker accepts: 100 mutez, plus 100 nanotez per unit of gas and 1000 nanotez per byte.
+const (
+	minimalFee        = 100
+	minimalFeePerGas  = 10
+	minimalFeePerByte = 1
+)
+
+// DefaultGasMargin is the margin added to estimated gas limits by default.
+var DefaultGasMargin = SafetyMargin{Absolute: 100}
+
+/*
+SafetyMargin -
+Description: A margin added to an estimated gas or storage limit, as the state an operation is run on when
+its limits are estimated may differ from the state it is included on (e.g. a counter or big map that grew).
+Both the absolute and the percentage margins are added. Limits are capped by the hard limits of the network.
+*/
+type SafetyMargin struct {
+	// Added to the estimate, in gas units or bytes.
+	Absolute int64
+	// Added to the estimate, in percent of the estimate.
+	Percent int64
+}
+
+func (m SafetyMargin) add(estimate, hardLimit int64) int64 {
+	limit := estimate + m.Absolute + (estimate*m.Percent+99)/100
+	if hardLimit > 0 && limit > hardLimit {
+		limit = hardLimit
+	}
+
+	return limit
+}
+
+/*
+WalletClient -
+Description: A Wallet bound to a node, for the common manager operations. Counters, reveals, gas and
+storage limits and fees are handled by the client, and operations can be waited for until they are
+included or confirmed.
+Function: func NewWalletClient(gt IFace, wallet *Wallet) *WalletClient {}
+*/
+type WalletClient struct {
+	*Wallet
+
+	// The node operations are estimated on and injected to.
+	Client IFace
+
+	// Whether operations are waited for until they are included in a block before they are returned.
+	Wait bool
+
+	// The number of blocks baked on top of the inclusion block to wait for, if Wait is set.
+	Confirmations int
+
+	// How often the head is polled while waiting. Defaults to 10 seconds.
+	Interval time.Duration
+
+	// The number of blocks to wait for the inclusion of an operation before giving up. If zero, wait
+	// until the context is done.
+	MaxBlocks int
+
+	// The fees paid and the limits of the operations injected. If nil, the minimal fees are paid without limits.
+	FeePolicy *FeePolicy
+
+	// The margins added to the estimated gas and storage limits. If nil, DefaultGasMargin is added to gas
+	// limits and nothing to storage limits.
+	GasMargin     *SafetyMargin
+	StorageMargin *SafetyMargin
+}
+
+/*
+NewWalletClient Function
+Description: Returns a WalletClient signing with wallet and injecting to gt. Operations are not
+waited for, see WalletClient.Wait. If gt is a *GoTezos, the client uses its fee policy, see SetFeePolicy.
+
+Parameters:
+	gt:
+		The node to use, e.g. a *GoTezos.
+	wallet:
+		The wallet signing operations.
+*/
+func NewWalletClient(gt IFace, wallet *Wallet) *WalletClient {
+	client := &WalletClient{
+		Wallet: wallet,
+		Client: gt,
+	}
+
+	if t, ok := gt.(*GoTezos); ok {
+		client.FeePolicy = t.feePolicy
+	}
+
+	return client
+}
+
+/*
+Balance Function
+Description: Returns the balance of the wallet at the head, in mutez.
+*/
+func (w *WalletClient) Balance() (*string, error) {
+	return w.Client.Balance(BlockIDHead{}, w.Address)
+}
+
+/*
+Transfer Function
+Description: Transfers tez from the wallet and returns the hash of the operation.
+
+Parameters:
+	ctx:
+		Cancels waiting for the operation, see WalletClient.Wait.
+	to:
+		The destination.
+	amount:
+		The amount in mutez.
+*/
+func (w *WalletClient) Transfer(ctx context.Context, to string, amount int64) (*string, error) {
+	var contents Contents
+	contents.Kind = TRANSACTIONOP
+	contents.Destination = to
+	contents.Amount.SetInt64(amount)
+
+	hash, err := w.Send(ctx, contents)
+	if err != nil {
+		return hash, errors.Wrap(err, "failed to transfer")
+	}
+
+	return hash, nil
+}
+
+/*
+Delegate Function
+Description: Sets the delegate of the wallet and returns the hash of the operation.
+
+Parameters:
+	ctx:
+		Cancels waiting for the operation, see WalletClient.Wait.
+	baker:
+		The delegate. If empty, the delegation is withdrawn.
+*/
+func (w *WalletClient) Delegate(ctx context.Context, baker string) (*string, error) {
+	hash, err := w.Send(ctx, Contents{Kind: DELEGATIONOP, Delegate: baker})
+	if err != nil {
+		return hash, errors.Wrap(err, "failed to delegate")
+	}
+
+	return hash, nil
+}
+
+/*
+SetDelegate Function
+Description: Sets the delegate of the wallet, or of a manager.tz contract (KT1) managed by the wallet through
+its do entrypoint, and returns the hash of the operation.
+
+Parameters:
+	ctx:
+		Cancels waiting for the operation, see WalletClient.Wait.
+	source:
+		The account to delegate: the wallet if empty or its address, otherwise a manager.tz contract.
+	delegate:
+		The delegate. If empty, the delegation is withdrawn.
+*/
+func (w *WalletClient) SetDelegate(ctx context.Context, source, delegate string) (*string, error) {
+	if source == "" || source == w.Address {
+		return w.Delegate(ctx, delegate)
+	}
+
+	if !strings.HasPrefix(source, "KT1") {
+		return nil, errors.Errorf("failed to delegate: '%s' is not managed by '%s'", source, w.Address)
+	}
+
+	// { DROP ; NIL operation ; (PUSH key_hash <delegate> ; SOME | NONE key_hash) ; SET_DELEGATE ; CONS }
+	lambda := []Micheline{
+		NewMichelinePrim("DROP"),
+		NewMichelinePrim("NIL", NewMichelinePrim("operation")),
+	}
+	if delegate != "" {
+		lambda = append(lambda,
+			NewMichelinePrim("PUSH", NewMichelinePrim("key_hash"), NewMichelineString(delegate)),
+			NewMichelinePrim("SOME"),
+		)
+	} else {
+		lambda = append(lambda, NewMichelinePrim("NONE", NewMichelinePrim("key_hash")))
+	}
+	lambda = append(lambda, NewMichelinePrim("SET_DELEGATE"), NewMichelinePrim("CONS"))
+
+	hash, err := w.Send(ctx, Contents{
+		Kind:        TRANSACTIONOP,
+		Destination: source,
+		Parameters: &Parameters{
+			Entrypoint: "do",
+			Value:      NewMichelineSeq(lambda...),
+		},
+	})
+	if err != nil {
+		return hash, errors.Wrapf(err, "failed to delegate '%s'", source)
+	}
+
+	return hash, nil
+}
+
+/*
+SetDelegate Function
+Description: Sets the delegate of an account in one call: the signer is revealed if needed, the counter,
+limits and fee are filled in, and the operation is signed, injected and waited for until it is included.
+Returns the hash of the operation. See WalletClient for more control.
+
+Parameters:
+	ctx:
+		Cancels waiting for the operation.
+	signer:
+		The wallet signing the operation.
+	source:
+		The account to delegate: the signer if empty or its address, otherwise a manager.tz contract of the signer.
+	delegate:
+		The delegate. If empty, the delegation is withdrawn.
+*/
+func (t *GoTezos) SetDelegate(ctx context.Context, signer *Wallet, source, delegate string) (*string, error) {
+	client := NewWalletClient(t, signer)
+	client.Wait = true
+
+	return client.SetDelegate(ctx, source, delegate)
+}
+
+/*
+Origination -
+Description: An originated contract and the operation that originated it.
+Function: func (w *WalletClient) Originate(ctx context.Context, code, storage Micheline, balance int64) (*Origination, error) {}
+*/
+type Origination struct {
+	OperationHash string
+	// The KT1 address of the contract, as listed in the originated contracts of the operation result.
+	Contract string
+}
+
+/*
+Originate Function
+Description: Originates a smart contract and returns its address along with the hash of the operation. The
+address is derived from the operation hash the way the protocol does, so it is known before the operation is
+included. Code and storage written in Micheline JSON are decoded with json.Unmarshal, Michelson source
+(e.g. a .tz file) with ParseMichelson.
+
+Parameters:
+	ctx:
+		Cancels waiting for the operation, see WalletClient.Wait.
+	code:
+		The code of the contract: the parameter, storage and code sections.
+	storage:
+		The initial storage.
+	balance:
+		The initial balance of the contract in mutez.
+*/
+func (w *WalletClient) Originate(ctx context.Context, code, storage Micheline, balance int64) (*Origination, error) {
+	var contents Contents
+	contents.Kind = ORIGINATIONOP
+	contents.Balance.SetInt64(balance)
+	contents.Script = &Script{Code: code, Storage: storage}
+
+	hash, err := w.Send(ctx, contents)
+	if hash == nil {
+		return nil, errors.Wrap(err, "failed to originate contract")
+	}
+
+	contract, cerr := OriginatedContract(*hash, 0)
+	if cerr != nil {
+		return nil, errors.Wrap(cerr, "failed to originate contract")
+	}
+
+	origination := &Origination{OperationHash: *hash, Contract: contract}
+	if err != nil {
+		return origination, errors.Wrap(err, "failed to originate contract")
+	}
+
+	return origination, nil
+}
+
+/*
+Originate Function
+Description: Originates a smart contract in one call: the signer is revealed if needed, the counter, limits
+and fee are filled in, and the operation is signed, injected and waited for until it is included. Returns the
+address of the contract and the hash of the operation. See WalletClient for more control.
+
+Parameters:
+	ctx:
+		Cancels waiting for the operation.
+	signer:
+		The wallet signing the operation and paying for the origination.
+	code:
+		The code of the contract.
+	storage:
+		The initial storage.
+	balance:
+		The initial balance of the contract in mutez.
+*/
+func (t *GoTezos) Originate(ctx context.Context, signer *Wallet, code, storage Micheline, balance int64) (*Origination, error) {
+	client := NewWalletClient(t, signer)
+	client.Wait = true
+
+	return client.Originate(ctx, code, storage, balance)
+}
+
+/*
+Reveal Function
+Description: Reveals the public key of the wallet and returns the hash of the operation. Other operations
+reveal the wallet when needed, so Reveal is only required to reveal it ahead of time.
+
+Parameters:
+	ctx:
+		Cancels waiting for the operation, see WalletClient.Wait.
+*/
+func (w *WalletClient) Reveal(ctx context.Context) (*string, error) {
+	key, err := w.Client.ManagerKey(BlockIDHead{}, w.Address)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to reveal")
+	}
+
+	if key != nil {
+		return nil, errors.Errorf("failed to reveal: '%s' is already revealed", w.Address)
+	}
+
+	hash, err := w.Send(ctx, Contents{Kind: REVEALOP, Phk: w.Pk})
+	if err != nil {
+		return hash, errors.Wrap(err, "failed to reveal")
+	}
+
+	return hash, nil
+}
+
+/*
+Send Function
+Description: Prepares, signs and injects manager operation contents, see Prepare and Inject. Returns the
+hash of the operation.
+
+Parameters:
+	ctx:
+		Cancels waiting for the operation, see WalletClient.Wait.
+	contents:
+		The contents of the operation.
+*/
+func (w *WalletClient) Send(ctx context.Context, contents ...Contents) (*string, error) {
+	operation, err := w.Prepare(contents...)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.Inject(ctx, operation...)
+}
+
+/*
+Prepare Function
+Description: Completes manager operation contents so that they can be injected from the wallet. A reveal
+is prepended if the wallet is not revealed yet. The source and counter of every content are set. Contents
+with a zero gas limit have their gas and storage limits estimated by running the operation, and contents
+with a zero fee get the minimal fee bakers accept, or the fixed fee of the fee policy.
+
+Parameters:
+	contents:
+		The contents of the operation.
+*/
+func (w *WalletClient) Prepare(contents ...Contents) ([]Contents, error) {
+	if len(contents) == 0 {
+		return nil, errors.New("failed to prepare operation: no contents")
+	}
+
+	key, err := w.Client.ManagerKey(BlockIDHead{}, w.Address)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to prepare operation")
+	}
+
+	operation := []Contents{}
+	if key == nil && contents[0].Kind != REVEALOP {
+		operation = append(operation, Contents{Kind: REVEALOP, Phk: w.Pk})
+	}
+	operation = append(operation, contents...)
+
+	counter, err := w.Client.Counter(BlockIDHead{}, w.Address)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to prepare operation")
+	}
+
+	constants, err := w.Client.Constants(BlockIDHead{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to prepare operation")
+	}
+
+	// The gas limits of an operation must fit in a block, so large batches are run with a share of the block.
+	gasLimit, _ := strconv.ParseInt(constants.HardGasLimitPerOperation, 10, 64)
+	if blockGasLimit, err := strconv.ParseInt(constants.HardGasLimitPerBlock, 10, 64); err == nil && blockGasLimit/int64(len(operation)) < gasLimit {
+		gasLimit = blockGasLimit / int64(len(operation))
+	}
+
+	var estimate []bool
+	for i := range operation {
+		*counter++
+		operation[i].Source = w.Address
+		operation[i].Counter.SetInt64(int64(*counter))
+
+		estimate = append(estimate, operation[i].GasLimit.Sign() == 0)
+		if estimate[i] {
+			operation[i].GasLimit.SetInt64(gasLimit)
+			operation[i].StorageLimit.SetString(constants.HardStorageLimitPerOperation, 10)
+		}
+	}
+
+	if err := w.estimateLimits(operation, estimate, constants); err != nil {
+		return nil, errors.Wrap(err, "failed to prepare operation")
+	}
+
+	if err := w.estimateFees(operation); err != nil {
+		return nil, errors.Wrap(err, "failed to prepare operation")
+	}
+
+	return operation, nil
+}
+
+/*
+Inject Function
+Description: Forges the contents on the head, verifies the forged bytes decode back to the contents (see
+VerifyForgedOperation), signs those bytes with the wallet and injects them. Returns the hash
+of the operation, once it is included or confirmed if WalletClient.Wait is set. If waiting fails, the hash
+is returned along with the error. Contents exceeding the limits of the fee policy are not injected.
+
+Parameters:
+	ctx:
+		Cancels waiting for the operation.
+	contents:
+		The complete contents of the operation, see Prepare.
+*/
+func (w *WalletClient) Inject(ctx context.Context, contents ...Contents) (*string, error) {
+	signed, err := w.sign(contents)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to inject operation")
+	}
+
+	return w.injectSigned(ctx, signed.SignedOperation)
+}
+
+// sign forges the contents on the head, verifies the forged bytes and signs them.
+func (w *WalletClient) sign(contents []Contents) (*SignedOperation, error) {
+	err := w.FeePolicy.check(contents, func() (*Constants, error) {
+		return w.Client.Constants(BlockIDHead{})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := w.Client.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	forge, err := w.Client.ForgeOperation(head.Hash, contents...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The forged bytes are signed and injected as they are, so they are checked to encode the contents first.
+	err = w.Client.VerifyForgedOperation(*forge, head.Hash, contents...)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.SignOperation(*forge)
+}
+
+// injectSigned injects a signed operation and waits for it if WalletClient.Wait is set.
+func (w *WalletClient) injectSigned(ctx context.Context, signed string) (*string, error) {
+	resp, err := w.Client.InjectionOperation(&InjectionOperationInput{Operation: &signed})
+	if err != nil {
+		return nil, err
+	}
+
+	var hash string
+	err = json.Unmarshal(*resp, &hash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal operation hash")
+	}
+
+	if w.Wait {
+		err = w.wait(ctx, hash)
+		if err != nil {
+			return &hash, err
+		}
+	}
+
+	return &hash, nil
+}
+
+func (w *WalletClient) wait(ctx context.Context, hash string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	confirmations, errs, err := w.Client.TrackConfirmations(ctx, &ConfirmationInput{
+		OperationHash: hash,
+		Confirmations: w.Confirmations,
+		Interval:      w.Interval,
+		MaxBlocks:     w.MaxBlocks,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to wait for operation %s", hash)
+	}
+
+	for confirmation := range confirmations {
+		if confirmation.Confirmations >= w.Confirmations {
+			return nil
+		}
+	}
+
+	if err := <-errs; err != nil {
+		return errors.Wrapf(err, "failed to wait for operation %s", hash)
+	}
+
+	return errors.Errorf("failed to wait for operation %s: tracking stopped", hash)
+}
+
+// estimateLimits runs the operation and sets the gas and storage limits of the contents to estimate, margins
+// included.
+func (w *WalletClient) estimateLimits(operation []Contents, estimate []bool, constants *Constants) error {
+	run := false
+	for _, e := range estimate {
+		run = run || e
+	}
+
+	if !run {
+		return nil
+	}
+
+	result, err := w.Client.DryRun(operation...)
+	if err != nil {
+		return err
+	}
+
+	if result.Status != APPLIEDSTATUS {
+		return errors.Errorf("operation %s: %v", result.Status, result.Errors)
+	}
+
+	gasMargin, storageMargin := DefaultGasMargin, SafetyMargin{}
+	if w.GasMargin != nil {
+		gasMargin = *w.GasMargin
+	}
+	if w.StorageMargin != nil {
+		storageMargin = *w.StorageMargin
+	}
+
+	hardGasLimit, _ := strconv.ParseInt(constants.HardGasLimitPerOperation, 10, 64)
+	hardStorageLimit, _ := strconv.ParseInt(constants.HardStorageLimitPerOperation, 10, 64)
+
+	for i, content := range result.Contents {
+		if i < len(operation) && estimate[i] {
+			operation[i].GasLimit.SetInt64(gasMargin.add(consumedGas(content), hardGasLimit))
+			operation[i].StorageLimit.SetInt64(storageMargin.add(paidStorage(content, constants.OriginationSize), hardStorageLimit))
+		}
+	}
+
+	return nil
+}
+
+// estimateFees sets the minimal fee, or the fixed fee of the fee policy, of the contents with a zero fee. The
+// minimal fee for the size of the operation is paid by the first content.
+func (w *WalletClient) estimateFees(operation []Contents) error {
+	var zero []int
+	for i := range operation {
+		if operation[i].Fee.Sign() == 0 {
+			zero = append(zero, i)
+		}
+	}
+
+	if len(zero) == 0 {
+		return nil
+	}
+
+	if fixed := w.FeePolicy.fixedFee(); fixed > 0 {
+		for _, i := range zero {
+			operation[i].Fee.SetInt64(fixed)
+		}
+		return nil
+	}
+
+	head, err := w.Client.Head()
+	if err != nil {
+		return err
+	}
+
+	forge, err := w.Client.ForgeOperation(head.Hash, operation...)
+	if err != nil {
+		return err
+	}
+
+	fees := minimalFees(*forge, operation)
+	for _, i := range zero {
+		operation[i].Fee.SetInt64(fees[i])
+	}
+
+	return nil
+}
+
+/*
+MinimalFees Function
+Description: Returns the fee of each of the contents of an operation that passes the minimal fees of bakers: the
+gas limit of each content, plus the base fee and the size of the operation for the first one. The operation is
+forged locally, so that the fees of an operation signed offline (see NewOffline) are known before signing.
+
+Parameters:
+	contents:
+		The contents of the operation, with their gas limits set.
+*/
+func (t *GoTezos) MinimalFees(contents ...Contents) ([]int64, error) {
+	// Any branch will do, only the size of the operation matters.
+	forge, err := t.ForgeOperation(sizingBranch, contents...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute minimal fees")
+	}
+
+	return minimalFees(*forge, contents), nil
+}
+
+// sizingBranch is the branch operations are forged on to be measured.
+const sizingBranch = "BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1"
+
+// minimalFees returns the minimal fees of the contents of a forged operation.
+func minimalFees(forge string, operation []Contents) []int64 {
+	// Each fee takes at most a few bytes once forged, account for them and the 64 byte signature.
+	size := int64(len(forge)/2 + 64 + 4*len(operation))
+	fees := make([]int64, len(operation))
+	for i := range operation {
+		fee := minimalFeePerGas * operation[i].GasLimit.Int64() / 100
+		if i == 0 {
+			fee += minimalFee + minimalFeePerByte*size
+		}
+		fees[i] = fee + 1
+	}
+
+	return fees
+}
+
+func consumedGas(content Contents) int64 {
+	if content.Metadata == nil || content.Metadata.OperationResult == nil {
+		return 0
+	}
+
+	results := []OperationResult{*content.Metadata.OperationResult}
+	for _, internal := range content.Metadata.InternalOperationResults {
+		results = append(results, internal.Result)
+	}
+
+	var gas int64
+	for _, result := range results {
+		if result.ConsumedMilligas.Sign() > 0 {
+			milligas := new(big.Int).Add(&result.ConsumedMilligas.Int, big.NewInt(999))
+			gas += milligas.Div(milligas, big.NewInt(1000)).Int64()
+		} else {
+			gas += result.ConsumedGas.Int64()
+		}
+	}
+
+	return gas
+}
+
+func paidStorage(content Contents, originationSize int) int64 {
+	if content.Metadata == nil || content.Metadata.OperationResult == nil {
+		return 0
+	}
+
+	results := []OperationResult{*content.Metadata.OperationResult}
+	for _, internal := range content.Metadata.InternalOperationResults {
+		results = append(results, internal.Result)
+	}
+
+	var storage int64
+	for _, result := range results {
+		storage += result.PaidStorageSizeDiff.Int64()
+		storage += int64(len(result.OriginatedContracts) * originationSize)
+		if result.AllocatedDestinationContract {
+			storage += int64(originationSize)
+		}
+	}
+
+	return storage
+}
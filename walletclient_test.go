@@ -0,0 +1,505 @@
+package gotezos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type walletClientMock struct {
+	IFace
+	t             *testing.T
+	managerKey    *string
+	counter       int
+	dryRun        *DryRunResult
+	dryRuns       int
+	ran           []Contents
+	hash          string
+	injected      []string
+	confirmations []Confirmation
+	trackErr      error
+	injectErr     error
+}
+
+func newWalletClientMock(t *testing.T) *walletClientMock {
+	return &walletClientMock{
+		IFace:   testGoTezos(t, gtGoldenHTTPMock(blankHandler)),
+		t:       t,
+		counter: 10,
+		hash:    "opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A",
+		dryRun: &DryRunResult{
+			Status: APPLIEDSTATUS,
+			Contents: []Contents{
+				dryRunContents(`{"status":"applied","consumed_milligas":"1000001"}`),
+				dryRunContents(`{"status":"applied","consumed_gas":"1000","paid_storage_size_diff":"67","allocated_destination_contract":true}`),
+			},
+		},
+	}
+}
+
+func dryRunContents(result string) Contents {
+	var contents Contents
+	contents.Metadata = &ContentsMetadata{}
+	if err := json.Unmarshal([]byte(result), &contents.Metadata.OperationResult); err != nil {
+		panic(err)
+	}
+	return contents
+}
+
+func (w *walletClientMock) Head() (*Block, error) {
+	return &Block{Hash: mockBlockHash}, nil
+}
+
+func (w *walletClientMock) Constants(blockID BlockID) (*Constants, error) {
+	return expectedConstants(w.t), nil
+}
+
+func (w *walletClientMock) ManagerKey(blockID BlockID, address string) (*string, error) {
+	return w.managerKey, nil
+}
+
+func (w *walletClientMock) Counter(blockID BlockID, pkh string) (*int, error) {
+	counter := w.counter
+	return &counter, nil
+}
+
+func (w *walletClientMock) Balance(blockID BlockID, address string) (*string, error) {
+	balance := "1000000"
+	return &balance, nil
+}
+
+func (w *walletClientMock) DryRun(contents ...Contents) (*DryRunResult, error) {
+	w.dryRuns++
+	w.ran = contents
+	return w.dryRun, nil
+}
+
+func (w *walletClientMock) InjectionOperation(input *InjectionOperationInput) (*[]byte, error) {
+	if w.injectErr != nil {
+		return nil, w.injectErr
+	}
+	w.injected = append(w.injected, *input.Operation)
+	resp, err := json.Marshal(w.hash)
+	return &resp, err
+}
+
+func (w *walletClientMock) TrackConfirmations(ctx context.Context, input *ConfirmationInput) (<-chan Confirmation, <-chan error, error) {
+	confirmations := make(chan Confirmation, len(w.confirmations))
+	errs := make(chan error, 1)
+	for _, confirmation := range w.confirmations {
+		confirmations <- confirmation
+	}
+	if w.trackErr != nil {
+		errs <- w.trackErr
+	}
+	close(confirmations)
+	close(errs)
+
+	return confirmations, errs, nil
+}
+
+func testWalletClient(t *testing.T) (*WalletClient, *walletClientMock) {
+	wallet, err := ImportWallet(
+		"tz1fYvVTsSQWkt63P5V8nMjW764cSTrKoQKK",
+		"edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G",
+		"edskSA4oADtx6DTT6eXdBc6Pv5MoVBGXUzy8bBryi6D96RQNQYcRfVEXd2nuE2ZZPxs4YLZeM7KazUULFT1SfMDNyKFCUgk6vR",
+	)
+	assert.Nil(t, err)
+
+	mock := newWalletClientMock(t)
+	return NewWalletClient(mock, wallet), mock
+}
+
+func Test_WalletClientPrepare(t *testing.T) {
+	revealed := "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G"
+
+	var preset Contents
+	preset.Kind = TRANSACTIONOP
+	preset.Destination = "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+	preset.Fee.SetInt64(1500)
+	preset.GasLimit.SetInt64(2000)
+	preset.StorageLimit.SetInt64(257)
+
+	type want struct {
+		err         bool
+		containsErr string
+		kinds       []string
+		gasLimits   []string
+		storage     []string
+		dryRuns     int
+	}
+
+	cases := []struct {
+		name       string
+		managerKey *string
+		status     string
+		contents   []Contents
+		want
+	}{
+		{
+			"prepends a reveal",
+			nil,
+			APPLIEDSTATUS,
+			[]Contents{{Kind: TRANSACTIONOP, Destination: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}},
+			want{
+				false,
+				"",
+				[]string{REVEALOP, TRANSACTIONOP},
+				[]string{"1101", "1100"},
+				[]string{"0", "324"},
+				1,
+			},
+		},
+		{
+			"does not reveal twice",
+			&revealed,
+			APPLIEDSTATUS,
+			[]Contents{{Kind: TRANSACTIONOP, Destination: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}},
+			want{
+				false,
+				"",
+				[]string{TRANSACTIONOP},
+				[]string{"1101"},
+				[]string{"0"},
+				1,
+			},
+		},
+		{
+			"keeps preset limits",
+			&revealed,
+			APPLIEDSTATUS,
+			[]Contents{preset},
+			want{
+				false,
+				"",
+				[]string{TRANSACTIONOP},
+				[]string{"2000"},
+				[]string{"257"},
+				0,
+			},
+		},
+		{
+			"returns failed operations",
+			&revealed,
+			FAILEDSTATUS,
+			[]Contents{{Kind: TRANSACTIONOP, Destination: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}},
+			want{
+				true,
+				"operation failed",
+				nil,
+				nil,
+				nil,
+				1,
+			},
+		},
+		{
+			"returns no contents",
+			&revealed,
+			APPLIEDSTATUS,
+			nil,
+			want{
+				true,
+				"no contents",
+				nil,
+				nil,
+				nil,
+				0,
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := testWalletClient(t)
+			mock.managerKey = tt.managerKey
+			mock.dryRun.Status = tt.status
+
+			operation, err := client.Prepare(tt.contents...)
+			checkErr(t, tt.want.err, tt.want.containsErr, err)
+			assert.Equal(t, tt.want.dryRuns, mock.dryRuns)
+			if tt.want.err {
+				return
+			}
+
+			var kinds, gasLimits, storage []string
+			for i, content := range operation {
+				kinds = append(kinds, content.Kind)
+				gasLimits = append(gasLimits, content.GasLimit.String())
+				storage = append(storage, content.StorageLimit.String())
+				assert.Equal(t, client.Address, content.Source)
+				assert.Equal(t, int64(11+i), content.Counter.Int64())
+				assert.True(t, content.Fee.Sign() > 0)
+			}
+			assert.Equal(t, tt.want.kinds, kinds)
+			assert.Equal(t, tt.want.gasLimits, gasLimits)
+			assert.Equal(t, tt.want.storage, storage)
+		})
+	}
+}
+
+func Test_WalletClientMargins(t *testing.T) {
+	cases := []struct {
+		name          string
+		gasMargin     *SafetyMargin
+		storageMargin *SafetyMargin
+		gasLimits     []string
+		storage       []string
+	}{
+		{
+			"adds the default margins",
+			nil,
+			nil,
+			[]string{"1101", "1100"},
+			[]string{"0", "324"},
+		},
+		{
+			"adds absolute and percentage margins",
+			&SafetyMargin{Percent: 10},
+			&SafetyMargin{Absolute: 10, Percent: 50},
+			[]string{"1102", "1100"},
+			[]string{"10", "496"},
+		},
+		{
+			"caps limits at the hard limits",
+			&SafetyMargin{Percent: 100000},
+			&SafetyMargin{Absolute: 100000},
+			[]string{"800000", "800000"},
+			[]string{"60000", "60000"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := testWalletClient(t)
+			client.GasMargin = tt.gasMargin
+			client.StorageMargin = tt.storageMargin
+
+			operation, err := client.Prepare(Contents{Kind: TRANSACTIONOP, Destination: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"})
+			assert.Nil(t, err)
+
+			var gasLimits, storage []string
+			for _, content := range operation {
+				gasLimits = append(gasLimits, content.GasLimit.String())
+				storage = append(storage, content.StorageLimit.String())
+			}
+			assert.Equal(t, tt.gasLimits, gasLimits)
+			assert.Equal(t, tt.storage, storage)
+		})
+	}
+}
+
+func Test_WalletClient(t *testing.T) {
+	client, mock := testWalletClient(t)
+
+	balance, err := client.Balance()
+	assert.Nil(t, err)
+	assert.Equal(t, "1000000", *balance)
+
+	hash, err := client.Transfer(context.Background(), "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", 1500)
+	assert.Nil(t, err)
+	assert.Equal(t, "opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A", *hash)
+	assert.Len(t, mock.injected, 1)
+
+	_, err = client.Delegate(context.Background(), "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx")
+	assert.Nil(t, err)
+	assert.Len(t, mock.injected, 2)
+
+	_, err = client.Reveal(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, mock.injected, 3)
+
+	revealed := "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G"
+	mock.managerKey = &revealed
+	_, err = client.Reveal(context.Background())
+	checkErr(t, true, "is already revealed", err)
+	assert.Len(t, mock.injected, 3)
+}
+
+func Test_WalletClientSetDelegate(t *testing.T) {
+	revealed := "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G"
+
+	cases := []struct {
+		name            string
+		source          string
+		delegate        string
+		wantErr         string
+		wantKind        string
+		wantLambda      string
+		wantDestination string
+	}{
+		{
+			"delegates the wallet",
+			"",
+			"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+			"",
+			DELEGATIONOP,
+			"",
+			"",
+		},
+		{
+			"delegates a manager contract",
+			"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn",
+			"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+			"",
+			TRANSACTIONOP,
+			`[{"prim":"DROP"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PUSH","args":[{"prim":"key_hash"},{"string":"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"}]},{"prim":"SOME"},{"prim":"SET_DELEGATE"},{"prim":"CONS"}]`,
+			"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn",
+		},
+		{
+			"withdraws the delegate of a manager contract",
+			"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn",
+			"",
+			"",
+			TRANSACTIONOP,
+			`[{"prim":"DROP"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"NONE","args":[{"prim":"key_hash"}]},{"prim":"SET_DELEGATE"},{"prim":"CONS"}]`,
+			"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn",
+		},
+		{
+			"returns unmanaged sources",
+			"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+			"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+			"is not managed by",
+			"",
+			"",
+			"",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := testWalletClient(t)
+			mock.managerKey = &revealed
+
+			hash, err := client.SetDelegate(context.Background(), tt.source, tt.delegate)
+			checkErr(t, tt.wantErr != "", tt.wantErr, err)
+			if tt.wantErr != "" {
+				assert.Len(t, mock.injected, 0)
+				return
+			}
+			assert.Equal(t, "opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A", *hash)
+			assert.Len(t, mock.injected, 1)
+
+			assert.Len(t, mock.ran, 1)
+			assert.Equal(t, tt.wantKind, mock.ran[0].Kind)
+			if tt.wantKind == DELEGATIONOP {
+				assert.Equal(t, tt.delegate, mock.ran[0].Delegate)
+				return
+			}
+
+			assert.Equal(t, tt.wantDestination, mock.ran[0].Destination)
+			assert.Equal(t, "do", mock.ran[0].Parameters.Entrypoint)
+			lambda, err := json.Marshal(mock.ran[0].Parameters.Value)
+			assert.Nil(t, err)
+			assert.JSONEq(t, tt.wantLambda, string(lambda))
+		})
+	}
+}
+
+func Test_WalletClientOriginate(t *testing.T) {
+	code := NewMichelineSeq(
+		NewMichelinePrim("parameter", NewMichelinePrim("unit")),
+		NewMichelinePrim("storage", NewMichelinePrim("unit")),
+		NewMichelinePrim("code", NewMichelineSeq(
+			NewMichelinePrim("CDR"),
+			NewMichelinePrim("NIL", NewMichelinePrim("operation")),
+			NewMichelinePrim("PAIR"),
+		)),
+	)
+	storage := NewMichelinePrim("Unit")
+
+	cases := []struct {
+		name     string
+		revealed bool
+		trackErr error
+		wantErr  string
+		want     *Origination
+	}{
+		{
+			"originates a contract",
+			true,
+			nil,
+			"",
+			&Origination{OperationHash: "ooAD9LiA6B2WkPL1TerMKmE9yRS88iLJ6aLu36KYeW7AiMWeFLo", Contract: "KT1TjrEBttTzFDHjHk5BtGforkjnC5GpvcWf"},
+		},
+		{
+			"reveals the wallet first",
+			false,
+			nil,
+			"",
+			&Origination{OperationHash: "ooAD9LiA6B2WkPL1TerMKmE9yRS88iLJ6aLu36KYeW7AiMWeFLo", Contract: "KT1TjrEBttTzFDHjHk5BtGforkjnC5GpvcWf"},
+		},
+		{
+			"returns the contract when waiting fails",
+			true,
+			errors.New("operation was not included"),
+			"failed to originate contract",
+			&Origination{OperationHash: "ooAD9LiA6B2WkPL1TerMKmE9yRS88iLJ6aLu36KYeW7AiMWeFLo", Contract: "KT1TjrEBttTzFDHjHk5BtGforkjnC5GpvcWf"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := testWalletClient(t)
+			mock.hash = "ooAD9LiA6B2WkPL1TerMKmE9yRS88iLJ6aLu36KYeW7AiMWeFLo"
+			mock.trackErr = tt.trackErr
+			if tt.revealed {
+				revealed := "edpkvH3h91QHjKtuR45X9BJRWJJmK7s8rWxiEPnNXmHK67EJYZF75G"
+				mock.managerKey = &revealed
+			}
+			client.Wait = tt.trackErr != nil
+
+			origination, err := client.Originate(context.Background(), code, storage, 1000000)
+			checkErr(t, tt.wantErr != "", tt.wantErr, err)
+			assert.Equal(t, tt.want, origination)
+
+			contents := mock.ran[len(mock.ran)-1]
+			assert.Equal(t, ORIGINATIONOP, contents.Kind)
+			assert.Equal(t, "1000000", contents.Balance.String())
+			assert.Equal(t, &Script{Code: code, Storage: storage}, contents.Script)
+		})
+	}
+}
+
+func Test_WalletClientWait(t *testing.T) {
+	cases := []struct {
+		name          string
+		confirmations []Confirmation
+		trackErr      error
+		wantErr       string
+	}{
+		{
+			"is successful",
+			[]Confirmation{{Confirmations: 0}, {Confirmations: 1}, {Confirmations: 2}},
+			nil,
+			"",
+		},
+		{
+			"returns tracking errors",
+			[]Confirmation{{Confirmations: 0}},
+			errors.New("operation not included within 10 blocks"),
+			"not included within 10 blocks",
+		},
+		{
+			"returns stopped tracking",
+			nil,
+			nil,
+			"tracking stopped",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := testWalletClient(t)
+			client.Wait, client.Confirmations = true, 2
+			mock.confirmations, mock.trackErr = tt.confirmations, tt.trackErr
+
+			hash, err := client.Transfer(context.Background(), "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", 1500)
+			checkErr(t, tt.wantErr != "", tt.wantErr, err)
+			assert.Len(t, mock.injected, 1)
+			assert.Equal(t, "opNVVmrFNRfZBzN8EHa8nWjfLM2rmk7fiTs3WoYjbbPy8HY7B5A", *hash)
+		})
+	}
+}